@@ -0,0 +1,243 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"fmt"
+)
+
+// The parameters of the workload tracking of AdaptiveHeap.
+const (
+	// adaptiveWindow is the number of mutations after which the mix of the operations is evaluated.
+	adaptiveWindow = 1024
+	// adaptiveToFibonacci is the share of DecreaseKey in a window above which a DaryHeap migrates to a FibHeap.
+	adaptiveToFibonacci = 0.5
+	// adaptiveToDary is the share of DecreaseKey in a window below which a FibHeap migrates to a DaryHeap.
+	adaptiveToDary = 0.2
+)
+
+// AdaptiveStats reports the workload seen by an AdaptiveHeap and its current backend.
+type AdaptiveStats struct {
+	// Backend is the kind of the current backend, either Fibonacci or Dary.
+	Backend Kind
+	// Migrations is the number of times the values have been moved to another backend.
+	Migrations uint64
+	// Mutations is the total number of inserts, extractions, deletions and key updates.
+	Mutations uint64
+	// DecreaseKeys is the total number of DecreaseKey and DecreaseKeyValue calls.
+	DecreaseKeys uint64
+	// LastRatio is the share of DecreaseKey in the last evaluated window.
+	LastRatio float64
+}
+
+// AdaptiveHeap is a priority queue which tracks the mix of its operations and migrates between a FibHeap and a DaryHeap at runtime.
+// A DaryHeap is faster for the workloads of mostly inserts and extractions, while a FibHeap wins when DecreaseKey dominates.
+// Every 1024 mutations, the share of DecreaseKey is evaluated: above 50% the values move to a FibHeap, and below 20% back to a DaryHeap.
+// The gap between the two thresholds is the hysteresis which keeps a mixed workload from migrating back and forth.
+// Every migration is O(n), and Stats reports the current backend and the workload.
+// AdaptiveHeap provides exactly the same methods as FibHeap so the two implementations can be swapped by changing the constructor only.
+// Please note that all methods of AdaptiveHeap are not concurrent safe.
+type AdaptiveHeap struct {
+	backend PriorityQueue
+	stats   AdaptiveStats
+	// window and decreases count the mutations and the DecreaseKey calls of the current window.
+	window    int
+	decreases int
+}
+
+// NewAdaptiveHeap creates an initialized AdaptiveHeap backed by a DaryHeap with DefaultArity.
+func NewAdaptiveHeap() *AdaptiveHeap {
+	heap := new(AdaptiveHeap)
+	heap.backend = NewDaryHeap(DefaultArity)
+	heap.stats.Backend = Dary
+
+	return heap
+}
+
+// Stats returns the current backend and the workload seen by the heap.
+func (heap *AdaptiveHeap) Stats() AdaptiveStats {
+	return heap.stats
+}
+
+// Num returns the total number of values in the heap.
+func (heap *AdaptiveHeap) Num() uint {
+	return heap.backend.Num()
+}
+
+// Insert pushes the input tag and key into the heap.
+// Try to insert a nil tag, a duplicate tag or a -inf key will cause an error return.
+func (heap *AdaptiveHeap) Insert(tag interface{}, key float64) error {
+	defer heap.track(false)
+
+	return heap.backend.Insert(tag, key)
+}
+
+// InsertValue pushes the input value into the heap.
+// Try to insert a nil value, a value with a duplicate tag or a -inf key will cause an error return.
+func (heap *AdaptiveHeap) InsertValue(value Value) error {
+	defer heap.track(false)
+
+	return heap.backend.InsertValue(value)
+}
+
+// Minimum returns the current minimum tag and key in the heap sorted by the key.
+// An empty heap will return nil and -inf.
+func (heap *AdaptiveHeap) Minimum() (interface{}, float64) {
+	return heap.backend.Minimum()
+}
+
+// MinimumValue returns the current minimum value in the heap sorted by the key.
+// An empty heap will return nil.
+func (heap *AdaptiveHeap) MinimumValue() Value {
+	return heap.backend.MinimumValue()
+}
+
+// ExtractMin returns the current minimum tag and key in the heap and then extracts them from the heap.
+// An empty heap will return nil/-inf and extracts nothing.
+func (heap *AdaptiveHeap) ExtractMin() (interface{}, float64) {
+	defer heap.track(false)
+
+	return heap.backend.ExtractMin()
+}
+
+// ExtractMinValue returns the current minimum value in the heap and then extracts it from the heap.
+// An empty heap will return nil and extracts nothing.
+func (heap *AdaptiveHeap) ExtractMinValue() Value {
+	defer heap.track(false)
+
+	return heap.backend.ExtractMinValue()
+}
+
+// Union moves all values of the input heap into the heap.
+// The input heap can be any implementation of PriorityQueue, and it is emptied afterwards so that no value is reachable from both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned and both heaps are left untouched.
+func (heap *AdaptiveHeap) Union(anotherHeap PriorityQueue) error {
+	return heap.backend.Union(anotherHeap)
+}
+
+// UnionInto merges copies of all values of the input heap into the heap.
+// The input heap can be any implementation of PriorityQueue, and it is left untouched, so the values are shared by both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned.
+func (heap *AdaptiveHeap) UnionInto(anotherHeap PriorityQueue) error {
+	return heap.backend.UnionInto(anotherHeap)
+}
+
+// DecreaseKey updates the tag in the heap by the input key.
+// If the input key is not smaller than the current key or is -inf, or the tag does not exist, an error will be returned.
+func (heap *AdaptiveHeap) DecreaseKey(tag interface{}, key float64) error {
+	defer heap.track(true)
+
+	return heap.backend.DecreaseKey(tag, key)
+}
+
+// DecreaseKeyValue updates the value in the heap by the input value.
+// If the input value's key is not smaller than the current key or is -inf, or the tag does not exist, an error will be returned.
+func (heap *AdaptiveHeap) DecreaseKeyValue(value Value) error {
+	defer heap.track(true)
+
+	return heap.backend.DecreaseKeyValue(value)
+}
+
+// IncreaseKey updates the tag in the heap by the input key.
+// If the input key is not larger than the current key, or the tag does not exist, an error will be returned.
+func (heap *AdaptiveHeap) IncreaseKey(tag interface{}, key float64) error {
+	defer heap.track(false)
+
+	return heap.backend.IncreaseKey(tag, key)
+}
+
+// IncreaseKeyValue updates the value in the heap by the input value.
+// If the input value's key is not larger than the current key, or the tag does not exist, an error will be returned.
+func (heap *AdaptiveHeap) IncreaseKeyValue(value Value) error {
+	defer heap.track(false)
+
+	return heap.backend.IncreaseKeyValue(value)
+}
+
+// Delete deletes the input tag in the heap.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *AdaptiveHeap) Delete(tag interface{}) error {
+	defer heap.track(false)
+
+	return heap.backend.Delete(tag)
+}
+
+// DeleteValue deletes the value in the heap by the input value.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *AdaptiveHeap) DeleteValue(value Value) error {
+	defer heap.track(false)
+
+	return heap.backend.DeleteValue(value)
+}
+
+// GetTag searches and returns the key in the heap by the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *AdaptiveHeap) GetTag(tag interface{}) float64 {
+	return heap.backend.GetTag(tag)
+}
+
+// GetValue searches and returns the value in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *AdaptiveHeap) GetValue(tag interface{}) Value {
+	return heap.backend.GetValue(tag)
+}
+
+// ExtractTag searches and extracts the tag/key in the heap by the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *AdaptiveHeap) ExtractTag(tag interface{}) float64 {
+	defer heap.track(false)
+
+	return heap.backend.ExtractTag(tag)
+}
+
+// ExtractValue searches and extracts the value in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *AdaptiveHeap) ExtractValue(tag interface{}) Value {
+	defer heap.track(false)
+
+	return heap.backend.ExtractValue(tag)
+}
+
+// String provides some basic debug information of the heap.
+// It returns the current backend, the workload and the debug information of the backend.
+func (heap *AdaptiveHeap) String() string {
+	return fmt.Sprintf("Adaptive heap backed by %v, migrations: %d, last ratio of DecreaseKey: %f,\n%s",
+		heap.stats.Backend, heap.stats.Migrations, heap.stats.LastRatio, heap.backend.String())
+}
+
+func (heap *AdaptiveHeap) reset() {
+	heap.backend.reset()
+}
+
+func (heap *AdaptiveHeap) each(fn func(tag interface{}, key float64, value Value)) {
+	heap.backend.each(fn)
+}
+
+// track counts a mutation, and migrates the values to the other backend at the end of a window if the mix of the operations requires.
+func (heap *AdaptiveHeap) track(decrease bool) {
+	heap.stats.Mutations++
+	heap.window++
+	if decrease {
+		heap.stats.DecreaseKeys++
+		heap.decreases++
+	}
+	if heap.window < adaptiveWindow {
+		return
+	}
+
+	heap.stats.LastRatio = float64(heap.decreases) / float64(heap.window)
+	heap.window, heap.decreases = 0, 0
+	switch {
+	case heap.stats.Backend == Dary && heap.stats.LastRatio > adaptiveToFibonacci:
+		heap.migrate(NewFibHeap(), Fibonacci)
+	case heap.stats.Backend == Fibonacci && heap.stats.LastRatio < adaptiveToDary:
+		heap.migrate(NewDaryHeap(DefaultArity), Dary)
+	}
+}
+
+func (heap *AdaptiveHeap) migrate(backend PriorityQueue, kind Kind) {
+	backend.Union(heap.backend)
+	heap.backend = backend
+	heap.stats.Backend = kind
+	heap.stats.Migrations++
+}
@@ -0,0 +1,82 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/starwander/GoFibonacciHeap/heaptest"
+	"math/rand"
+)
+
+var _ = Describe("Tests of adaptiveHeap", func() {
+	var (
+		heap *AdaptiveHeap
+	)
+
+	BeforeEach(func() {
+		heap = NewAdaptiveHeap()
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given an adaptiveHeap, when the workload turns from inserts and extractions to decrease-keys and back, it should migrate the backend with hysteresis.", func() {
+		random := rand.New(rand.NewSource(1))
+		for i := 0; i < 1000; i++ {
+			heap.Insert(i, random.Float64()*1e6)
+		}
+		for i := 0; i < 1000; i++ {
+			heap.ExtractMin()
+			heap.Insert(1000+i, random.Float64()*1e6)
+		}
+		Expect(heap.Stats().Backend).Should(Equal(Dary))
+		Expect(heap.Stats().Migrations).Should(BeZero())
+
+		decrease := func() {
+			tag := 1000 + random.Intn(1000)
+			heap.DecreaseKey(tag, heap.GetTag(tag)-1)
+		}
+		for i := 0; i < 3000; i++ {
+			decrease()
+		}
+		stats := heap.Stats()
+		Expect(stats.Backend).Should(Equal(Fibonacci))
+		Expect(stats.Migrations).Should(BeEquivalentTo(1))
+		Expect(stats.DecreaseKeys).Should(BeEquivalentTo(3000))
+		Expect(stats.LastRatio).Should(BeEquivalentTo(1))
+		Expect(heap.String()).Should(ContainSubstring("backed by Fibonacci"))
+
+		for i := 0; i < 3000; i++ {
+			if i%3 == 0 {
+				heap.ExtractMin()
+				heap.Insert(3000+i, random.Float64()*1e6)
+			} else {
+				decrease()
+			}
+		}
+		Expect(heap.Stats().Backend).Should(Equal(Fibonacci))
+
+		for i := 0; i < 3000; i++ {
+			heap.ExtractMin()
+			heap.Insert(10000+i, random.Float64()*1e6)
+		}
+		Expect(heap.Stats().Backend).Should(Equal(Dary))
+		Expect(heap.Stats().Migrations).Should(BeEquivalentTo(2))
+
+		Expect(heap.Num()).Should(BeEquivalentTo(1000))
+		last := -1e300
+		for heap.Num() != 0 {
+			_, key := heap.ExtractMin()
+			Expect(key).Should(BeNumerically(">=", last))
+			last = key
+		}
+	})
+
+	It("Given adaptiveHeaps, when run the differential tester, it should never diverge from the reference model.", func() {
+		for seed := int64(0); seed < 20; seed++ {
+			Expect(heaptest.Run(NewAdaptiveHeap(), seed)).ShouldNot(HaveOccurred())
+		}
+	})
+})
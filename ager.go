@@ -0,0 +1,178 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// AgeingCurve describes how the priorities in an Ager change while they are waiting.
+// A priority is mapped by Transform to a scale on which it decreases by Rate every second, and mapped back by Inverse.
+// Both functions must be increasing, and nil functions mean the identity.
+// Since all priorities age on the same curve, their order only depends on their values and times of insertion,
+// which allows the ageing of all priorities to be applied in O(1) by AddToAllKeys.
+type AgeingCurve struct {
+	// Rate is the decrease per second on the transformed scale. A negative rate increases the priorities instead.
+	Rate float64
+	// Transform maps a priority to the scale on which it ages linearly.
+	Transform func(key float64) float64
+	// Inverse maps a transformed priority back.
+	Inverse func(key float64) float64
+}
+
+// LinearAgeing returns an AgeingCurve which decreases every priority by perSecond every second.
+func LinearAgeing(perSecond float64) AgeingCurve {
+	return AgeingCurve{Rate: perSecond}
+}
+
+// ExponentialAgeing returns an AgeingCurve which halves every priority in every halfLife.
+// It only accepts positive priorities.
+func ExponentialAgeing(halfLife time.Duration) AgeingCurve {
+	return AgeingCurve{Rate: 1 / halfLife.Seconds(), Transform: math.Log2, Inverse: math.Exp2}
+}
+
+// Ager is a priority queue of tags whose priorities decay or grow over the wall-clock time along an AgeingCurve,
+// so that the long-waiting tags eventually reach the front, e.g. to prevent starvation in a scheduler.
+// The smaller priority is still extracted first, so a positive Rate moves the waiting tags forward.
+// Please note that all methods of Ager are not concurrent safe.
+type Ager struct {
+	heap  *FibHeap
+	curve AgeingCurve
+	last  time.Time
+	now   func() time.Time
+}
+
+// NewAger creates an initialized empty Ager with the input curve.
+// A Rate of inf or NaN will cause a panic.
+func NewAger(curve AgeingCurve) *Ager {
+	if math.IsInf(curve.Rate, 0) || math.IsNaN(curve.Rate) {
+		panic("fibHeap: rate of AgeingCurve must be finite")
+	}
+
+	ager := new(Ager)
+	ager.heap = NewFibHeap()
+	ager.curve = curve
+	ager.now = time.Now
+	ager.last = ager.now()
+
+	return ager
+}
+
+// Num returns the total number of tags in the Ager.
+func (ager *Ager) Num() uint {
+	return ager.heap.Num()
+}
+
+// Insert pushes the input tag with its current priority into the Ager, from which the priority starts ageing.
+// Try to insert a nil or duplicate tag, or a priority which the curve cannot transform, will cause an error return.
+func (ager *Ager) Insert(tag interface{}, key float64) error {
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	transformed, err := ager.transform(key)
+	if err != nil {
+		return err
+	}
+
+	ager.age()
+
+	return ager.heap.insert(tag, transformed, nil)
+}
+
+// Update replaces the priority of the input tag by the input one, from which the priority starts ageing again.
+// If the input tag does not exist in the Ager or the curve cannot transform the priority, an error will be returned.
+func (ager *Ager) Update(tag interface{}, key float64) error {
+	transformed, err := ager.transform(key)
+	if err != nil {
+		return err
+	}
+
+	node, exists := ager.heap.index[tag]
+	if !exists {
+		return errors.New("Tag is not found ")
+	}
+
+	ager.age()
+	ager.heap.deleteNode(node)
+
+	return ager.heap.insert(tag, transformed, nil)
+}
+
+// Delete deletes the input tag in the Ager.
+// If the input tag does not exist in the Ager, an error will be returned.
+func (ager *Ager) Delete(tag interface{}) error {
+	return ager.heap.Delete(tag)
+}
+
+// Minimum returns the tag with the current minimum aged priority and the priority.
+// An empty Ager will return nil and -inf.
+func (ager *Ager) Minimum() (interface{}, float64) {
+	if ager.heap.Num() == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	ager.age()
+	tag, key := ager.heap.Minimum()
+
+	return tag, ager.inverse(key)
+}
+
+// ExtractMin returns the tag with the current minimum aged priority and the priority, and then extracts them from the Ager.
+// An empty Ager will return nil/-inf and extracts nothing.
+func (ager *Ager) ExtractMin() (interface{}, float64) {
+	if ager.heap.Num() == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	ager.age()
+	tag, key := ager.heap.ExtractMin()
+
+	return tag, ager.inverse(key)
+}
+
+// GetTag returns the current aged priority of the input tag.
+// If the input tag does not exist in the Ager, -inf will be returned.
+func (ager *Ager) GetTag(tag interface{}) float64 {
+	if _, exists := ager.heap.index[tag]; !exists {
+		return math.Inf(-1)
+	}
+
+	ager.age()
+
+	return ager.inverse(ager.heap.GetTag(tag))
+}
+
+// age applies the ageing since the last call to all priorities by the global offset of the heap.
+func (ager *Ager) age() {
+	now := ager.now()
+	elapsed := now.Sub(ager.last)
+	if elapsed <= 0 {
+		return
+	}
+
+	ager.heap.AddToAllKeys(-ager.curve.Rate * elapsed.Seconds())
+	ager.last = now
+}
+
+func (ager *Ager) transform(key float64) (float64, error) {
+	if ager.curve.Transform != nil {
+		key = ager.curve.Transform(key)
+	}
+
+	if math.IsInf(key, -1) || math.IsNaN(key) {
+		return 0, errors.New("Priority cannot be transformed by the ageing curve ")
+	}
+
+	return key, nil
+}
+
+func (ager *Ager) inverse(key float64) float64 {
+	if ager.curve.Inverse != nil {
+		return ager.curve.Inverse(key)
+	}
+
+	return key
+}
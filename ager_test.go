@@ -0,0 +1,79 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"time"
+)
+
+var _ = Describe("Tests of ager", func() {
+	var (
+		now   time.Time
+		clock func() time.Time
+	)
+
+	BeforeEach(func() {
+		now = time.Now()
+		clock = func() time.Time { return now }
+	})
+
+	It("Given an empty ager, when call Minimum and ExtractMin api, it should return nil.", func() {
+		ager := NewAger(LinearAgeing(1))
+		tag, key := ager.Minimum()
+		Expect(tag).Should(BeNil())
+		Expect(key).Should(Equal(math.Inf(-1)))
+		tag, _ = ager.ExtractMin()
+		Expect(tag).Should(BeNil())
+		Expect(ager.GetTag(1)).Should(Equal(math.Inf(-1)))
+		Expect(func() { NewAger(LinearAgeing(math.NaN())) }).Should(Panic())
+	})
+
+	It("Given an ager with linear ageing, when time passes, it should move the long-waiting tags to the front.", func() {
+		ager := NewAger(LinearAgeing(1))
+		ager.now, ager.last = clock, now
+		Expect(ager.Insert(nil, 1)).Should(HaveOccurred())
+		Expect(ager.Insert("old", 100)).ShouldNot(HaveOccurred())
+		Expect(ager.Insert("old", 100)).Should(HaveOccurred())
+
+		now = now.Add(30 * time.Second)
+		Expect(ager.Insert("new", 80)).ShouldNot(HaveOccurred())
+		tag, key := ager.Minimum()
+		Expect(tag).Should(Equal("old"))
+		Expect(key).Should(BeNumerically("~", 70, 1e-9))
+
+		now = now.Add(10 * time.Second)
+		Expect(ager.GetTag("new")).Should(BeNumerically("~", 70, 1e-9))
+		Expect(ager.Update("old", 65)).ShouldNot(HaveOccurred())
+		Expect(ager.Update("none", 65)).Should(HaveOccurred())
+
+		now = now.Add(10 * time.Second)
+		tag, key = ager.ExtractMin()
+		Expect(tag).Should(Equal("old"))
+		Expect(key).Should(BeNumerically("~", 55, 1e-9))
+		Expect(ager.Delete("new")).ShouldNot(HaveOccurred())
+		Expect(ager.Num()).Should(BeEquivalentTo(0))
+	})
+
+	It("Given an ager with exponential ageing, when time passes, it should halve the priorities in every half-life.", func() {
+		ager := NewAger(ExponentialAgeing(time.Minute))
+		ager.now, ager.last = clock, now
+		Expect(ager.Insert(1, 0)).Should(HaveOccurred())
+		Expect(ager.Insert(1, -1)).Should(HaveOccurred())
+		Expect(ager.Insert(1, 1000)).ShouldNot(HaveOccurred())
+
+		now = now.Add(2 * time.Minute)
+		Expect(ager.Insert(2, 300)).ShouldNot(HaveOccurred())
+		Expect(ager.GetTag(1)).Should(BeNumerically("~", 250, 1e-6))
+
+		now = now.Add(time.Minute)
+		tag, key := ager.ExtractMin()
+		Expect(tag).Should(Equal(1))
+		Expect(key).Should(BeNumerically("~", 125, 1e-6))
+		tag, key = ager.ExtractMin()
+		Expect(tag).Should(Equal(2))
+		Expect(key).Should(BeNumerically("~", 150, 1e-6))
+	})
+})
@@ -0,0 +1,19 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+// BytesTag is the form in which a FibHeap keeps a []byte tag, e.g. a content-addressed identifier,
+// as a byte slice cannot be a key of the index map.
+// A []byte tag can be passed to all methods of FibHeap taking a tag, or returned by the Tag method of a value, as it is,
+// while the tags returned by the heap, e.g. by ExtractMin, are BytesTags, which convert back by []byte(tag.(BytesTag)).
+// A BytesTag is a distinct type, so it never equals a string tag of the same content.
+type BytesTag string
+
+// tagKey returns the form in which the tag is kept in the index, i.e. a BytesTag for a []byte tag and the tag itself otherwise.
+func tagKey(tag interface{}) interface{} {
+	if b, ok := tag.([]byte); ok {
+		return BytesTag(b)
+	}
+
+	return tag
+}
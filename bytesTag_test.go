@@ -0,0 +1,191 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"encoding/binary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"sync"
+	"time"
+)
+
+type bytesValue struct {
+	id  []byte
+	key float64
+}
+
+func (value *bytesValue) Tag() interface{} {
+	return value.id
+}
+
+func (value *bytesValue) Key() float64 {
+	return value.key
+}
+
+func (value *bytesValue) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 8, 8+len(value.id))
+	binary.BigEndian.PutUint64(data, math.Float64bits(value.key))
+	return append(data, value.id...), nil
+}
+
+func (value *bytesValue) UnmarshalBinary(data []byte) error {
+	value.key = math.Float64frombits(binary.BigEndian.Uint64(data))
+	value.id = append([]byte(nil), data[8:]...)
+	return nil
+}
+
+func init() {
+	RegisterValue("fibHeap.bytesValue", &bytesValue{})
+}
+
+var _ = Describe("Tests of byte slice tags", func() {
+	var heap *FibHeap
+
+	BeforeEach(func() {
+		heap = NewFibHeap()
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given a fibHeap, when use []byte tags in the tag and value apis, it should find them by their contents.", func() {
+		Expect(heap.Insert([]byte("a"), 1)).Should(Succeed())
+		Expect(heap.Insert([]byte("a"), 2)).Should(HaveOccurred())
+		Expect(heap.Insert("a", 2)).Should(Succeed())
+		Expect(heap.InsertValue(&bytesValue{[]byte("b"), 3})).Should(Succeed())
+		Expect(heap.InsertOrIncrement([]byte("b"), 3)).Should(Succeed())
+
+		Expect(heap.GetTag([]byte("a"))).Should(BeEquivalentTo(1))
+		Expect(heap.Count([]byte("b"))).Should(BeEquivalentTo(2))
+		Expect(heap.DecreaseKey([]byte("b"), 0)).Should(Succeed())
+		Expect(heap.DecreaseKeyValue(&bytesValue{[]byte("b"), -1})).Should(Succeed())
+
+		tag, key := heap.ExtractMin()
+		Expect(tag).Should(Equal(BytesTag("b")))
+		Expect(key).Should(BeEquivalentTo(-1))
+		Expect(heap.Delete([]byte("b"))).Should(Succeed())
+		Expect(heap.Delete([]byte("b"))).Should(HaveOccurred())
+		Expect(heap.Num()).Should(BeEquivalentTo(2))
+
+		tx := heap.Txn(func(tx *HeapTxn) error {
+			tx.Delete([]byte("a"))
+			return ErrFrozen
+		})
+		Expect(tx).Should(Equal(ErrFrozen))
+		Expect(heap.GetTag([]byte("a"))).Should(BeEquivalentTo(1))
+		Expect(heap.Insert(TagOf([]byte("a"), 1), 0)).Should(Succeed())
+		Expect(heap.GetTag(TagOf([]byte("a"), 1))).Should(BeEquivalentTo(0))
+	})
+
+	It("Given a fibHeap of []byte tags, when marshal and unmarshal it, it should restore the tags.", func() {
+		heap.Insert([]byte{0, 1, 2}, 1)
+		heap.Insert(TagOf([]byte("x"), 1), 2)
+
+		var buffer bytes.Buffer
+		Expect(heap.Marshal(&buffer)).Should(Succeed())
+		restored, err := Unmarshal(&buffer)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(restored.Equal(heap)).Should(BeTrue())
+		Expect(restored.GetTag([]byte{0, 1, 2})).Should(BeEquivalentTo(1))
+	})
+
+	It("Given values of []byte tags, when export and import them, or marshal and unmarshal them, it should restore the values.", func() {
+		Expect(heap.InsertValue(&bytesValue{[]byte("a"), 1})).Should(Succeed())
+		Expect(heap.InsertValue(&bytesValue{[]byte("b"), 2})).Should(Succeed())
+
+		entries, err := heap.Export()
+		Expect(err).ShouldNot(HaveOccurred())
+		imported := NewFibHeap()
+		Expect(imported.Import(entries)).Should(Succeed())
+		Expect(imported.GetValue([]byte("b"))).Should(Equal(&bytesValue{[]byte("b"), 2}))
+
+		var buffer bytes.Buffer
+		Expect(heap.Marshal(&buffer)).Should(Succeed())
+		restored, err := Unmarshal(&buffer)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(restored.GetValue([]byte("a"))).Should(Equal(&bytesValue{[]byte("a"), 1}))
+
+		// An entry may carry the raw []byte tag as well.
+		entries = []Entry{{Tag: []byte("c"), Key: 3, Type: entries[0].Type, Value: []byte{}}}
+		entries[0].Value, _ = (&bytesValue{[]byte("c"), 3}).MarshalBinary()
+		Expect(imported.Import(entries)).Should(Succeed())
+		Expect(imported.Import([]Entry{{Tag: []byte("d"), Key: 4}})).Should(Succeed())
+		Expect(imported.Import([]Entry{{Tag: []byte("x"), Key: 3, Type: entries[0].Type, Value: entries[0].Value}})).Should(HaveOccurred())
+		Expect(imported.GetTag([]byte("c"))).Should(BeEquivalentTo(3))
+		Expect(imported.GetTag([]byte("d"))).Should(BeEquivalentTo(4))
+	})
+
+	It("Given the other tag heaps and the priorityCache, when use []byte tags, it should find them by their contents.", func() {
+		for _, tagHeap := range []TagKeyHeap{NewKeyHeap(), NewTagFibHeap()} {
+			Expect(tagHeap.Insert([]byte("a"), 1)).Should(Succeed())
+			Expect(tagHeap.Insert([]byte("a"), 1)).Should(HaveOccurred())
+			Expect(tagHeap.Insert([]byte("b"), 2)).Should(Succeed())
+			Expect(tagHeap.DecreaseKey([]byte("b"), 0)).Should(Succeed())
+			Expect(tagHeap.IncreaseKey([]byte("a"), 3)).Should(Succeed())
+			Expect(tagHeap.GetTag([]byte("a"))).Should(BeEquivalentTo(3))
+			Expect(tagHeap.ExtractTag([]byte("b"))).Should(BeEquivalentTo(0))
+			Expect(tagHeap.Delete([]byte("a"))).Should(Succeed())
+			Expect(tagHeap.Num()).Should(BeEquivalentTo(0))
+		}
+
+		multi := NewMultiHeap()
+		Expect(multi.Insert([]byte("a"), 1)).Should(Succeed())
+		Expect(multi.InsertValue(&bytesValue{[]byte("a"), 2})).Should(Succeed())
+		Expect(multi.Count([]byte("a"))).Should(BeEquivalentTo(2))
+		Expect(multi.GetTag([]byte("a"))).Should(BeEquivalentTo(1))
+		Expect(multi.ExtractTag([]byte("a"))).Should(BeEquivalentTo(1))
+		Expect(multi.GetValue([]byte("a"))).Should(Equal(&bytesValue{[]byte("a"), 2}))
+		Expect(multi.DeleteAll([]byte("a"))).Should(BeEquivalentTo(1))
+
+		var evicted []interface{}
+		cache := NewPriorityCache(1, func(key, value interface{}) { evicted = append(evicted, key) })
+		Expect(cache.Set([]byte("a"), "x", 1)).Should(Succeed())
+		Expect(cache.Set([]byte("a"), "y", 2)).Should(Succeed())
+		value, exists := cache.Get([]byte("a"))
+		Expect([]interface{}{value, exists}).Should(Equal([]interface{}{"y", true}))
+		Expect(cache.SetPriority([]byte("a"), 0)).Should(Succeed())
+		Expect(cache.Priority([]byte("a"))).Should(BeEquivalentTo(0))
+		Expect(cache.Set([]byte("b"), "z", 3)).Should(Succeed())
+		Expect(evicted).Should(Equal([]interface{}{BytesTag("a")}))
+		Expect(cache.Remove([]byte("b"))).Should(Succeed())
+		Expect(cache.Len()).Should(Equal(0))
+	})
+
+	It("Given a snapshot of a fibHeap of []byte tags, when look up the tags, it should find them by their contents.", func() {
+		heap.Insert([]byte("a"), 1)
+		heap.InsertValue(&bytesValue{[]byte("b"), 2})
+
+		view := heap.Snapshot()
+		Expect(view.GetTag([]byte("a"))).Should(BeEquivalentTo(1))
+		Expect(view.GetValue([]byte("b"))).Should(Equal(&bytesValue{[]byte("b"), 2}))
+		Expect(view.GetTag([]byte("c"))).Should(Equal(math.Inf(-1)))
+	})
+
+	It("Given an expirer, when register, remove and expire []byte tags, it should find the callbacks by their contents.", func() {
+		expirer := NewExpirer()
+		defer expirer.Close()
+
+		var mutex sync.Mutex
+		var expired []interface{}
+		onExpire := func(tag interface{}) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			expired = append(expired, tag)
+		}
+		Expect(expirer.Register([]byte("a"), time.Now(), onExpire)).Should(Succeed())
+		Expect(expirer.Register([]byte("b"), time.Now().Add(time.Hour), onExpire)).Should(Succeed())
+		Expect(expirer.Register([]byte("b"), time.Now(), onExpire)).Should(HaveOccurred())
+		Expect(expirer.Remove([]byte("b"))).Should(Succeed())
+
+		Eventually(func() []interface{} {
+			mutex.Lock()
+			defer mutex.Unlock()
+			return append([]interface{}(nil), expired...)
+		}).Should(Equal([]interface{}{BytesTag("a")}))
+		Expect(expirer.Num()).Should(BeEquivalentTo(0))
+	})
+})
@@ -0,0 +1,45 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CloneWithTags returns a new heap with copies of all values of the heap under the tags returned by remap,
+// e.g. to copy a heap into another tenant or namespace whose tags must be rewritten. The heap is left untouched.
+// The keys, the counts of InsertOrIncrement and the weights of ExtractWeightedRandom are kept for the remapped tags.
+// The values themselves are shared rather than copied, so their Tag methods still return the old tags,
+// and they should be looked up by the new tags instead of by the value interfaces in the new heap.
+// If remap returns nil, or returns the same tag for two values, an error will be returned.
+func (heap *FibHeap) CloneWithTags(remap func(old interface{}) interface{}) (*FibHeap, error) {
+	if debugMode {
+		defer heap.guard.enter("CloneWithTags")()
+	}
+
+	clone := NewFibHeap()
+	if len(heap.counts) != 0 {
+		clone.counts = make(map[interface{}]uint, len(heap.counts))
+	}
+	if len(heap.weights) != 0 {
+		clone.weights = make(map[interface{}]float64, len(heap.weights))
+	}
+	for tag, n := range heap.index {
+		remapped := tagKey(remap(tag))
+		if remapped == nil {
+			return nil, errors.New("Remapped tag is nil ")
+		}
+		if err := clone.insert(remapped, heap.keyOf(n), n.value); err != nil {
+			return nil, fmt.Errorf("Remapped tag %v is duplicate ", remapped)
+		}
+		if count, exists := heap.counts[tag]; exists {
+			clone.counts[remapped] = count
+		}
+		if weight, exists := heap.weights[tag]; exists {
+			clone.weights[remapped] = weight
+		}
+	}
+
+	return clone, nil
+}
@@ -0,0 +1,55 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"fmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tests of clone", func() {
+	var heap *FibHeap
+
+	BeforeEach(func() {
+		heap = NewFibHeap()
+		for i := 0; i < 100; i++ {
+			heap.Insert(i, float64(i))
+		}
+		heap.InsertValue(&demoStruct{100, 100, "100"})
+		heap.InsertOrIncrement(3, 3)
+		heap.SetWeight(5, 2.5)
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given a fibHeap, when call CloneWithTags api, it should copy all values under the remapped tags and leave the heap untouched.", func() {
+		clone, err := heap.CloneWithTags(func(old interface{}) interface{} { return fmt.Sprintf("tenant/%v", old) })
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(clone.Num()).Should(BeEquivalentTo(101))
+		Expect(heap.Num()).Should(BeEquivalentTo(101))
+		Expect(clone.GetTag("tenant/42")).Should(BeEquivalentTo(42))
+		Expect(clone.GetValue("tenant/100")).Should(Equal(&demoStruct{100, 100, "100"}))
+		Expect(clone.Count("tenant/3")).Should(BeEquivalentTo(2))
+		Expect(clone.Weight("tenant/5")).Should(BeEquivalentTo(2.5))
+
+		for i := 0; i < 101; i++ {
+			tag, key := clone.ExtractMin()
+			Expect(tag).Should(Equal(fmt.Sprintf("tenant/%v", i)))
+			Expect(key).Should(BeEquivalentTo(i))
+			if i == 3 {
+				clone.ExtractMin()
+			}
+		}
+	})
+
+	It("Given a remap returning nil or duplicate tags, when call CloneWithTags api, it should return an error.", func() {
+		_, err := heap.CloneWithTags(func(old interface{}) interface{} { return nil })
+		Expect(err).Should(HaveOccurred())
+		_, err = heap.CloneWithTags(func(old interface{}) interface{} { return old.(int) / 2 })
+		Expect(err).Should(HaveOccurred())
+		Expect(heap.Num()).Should(BeEquivalentTo(101))
+	})
+})
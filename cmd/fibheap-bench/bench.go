@@ -0,0 +1,230 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	fibHeap "github.com/starwander/GoFibonacciHeap"
+)
+
+// backend is a heap implementation to run the workload against.
+type backend struct {
+	name string
+	new  func() fibHeap.TagKeyHeap
+}
+
+// backends returns all kinds of PriorityQueue, followed by the implementations which only have the tag/key interfaces.
+func backends() []backend {
+	var all []backend
+	for kind := fibHeap.Kind(0); !strings.HasPrefix(kind.String(), "Kind("); kind++ {
+		kind := kind
+		all = append(all, backend{kind.String(), func() fibHeap.TagKeyHeap { return fibHeap.New(kind) }})
+	}
+
+	return append(all, backend{"TagFibonacci", func() fibHeap.TagKeyHeap { return fibHeap.NewTagFibHeap() }})
+}
+
+func backendNames() []string {
+	var names []string
+	for _, b := range backends() {
+		names = append(names, b.name)
+	}
+
+	return names
+}
+
+// selectBackends returns the backends of the comma separated names, or all of them for an empty input.
+func selectBackends(names string) ([]backend, error) {
+	if names == "" {
+		return backends(), nil
+	}
+
+	var selected []backend
+	for _, name := range strings.Split(names, ",") {
+		found := false
+		for _, b := range backends() {
+			if strings.EqualFold(b.name, strings.TrimSpace(name)) {
+				selected, found = append(selected, b), true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown heap %q, the heaps are %s", name, strings.Join(backendNames(), ","))
+		}
+	}
+
+	return selected, nil
+}
+
+// profiles generate the synthetic workloads by calling a recorded heap.
+var profiles = map[string]func(heap fibHeap.TagKeyHeap, random *rand.Rand, n int){
+	"queue": func(heap fibHeap.TagKeyHeap, random *rand.Rand, n int) {
+		for i := 0; i < n; i++ {
+			heap.Insert(i, random.Float64())
+		}
+		for i := 0; i < n; i++ {
+			heap.ExtractMin()
+		}
+	},
+	"dijkstra": func(heap fibHeap.TagKeyHeap, random *rand.Rand, n int) {
+		for i := 0; i < n; i++ {
+			heap.Insert(i, random.Float64())
+		}
+		for heap.Num() != 0 {
+			heap.ExtractMin()
+			for j := 0; j < 4; j++ {
+				tag := random.Intn(n)
+				if key := heap.GetTag(tag); key > 0 {
+					heap.DecreaseKey(tag, key*random.Float64())
+				}
+			}
+		}
+	},
+	"timers": func(heap fibHeap.TagKeyHeap, random *rand.Rand, n int) {
+		for i := 0; i < n; i++ {
+			heap.Insert(i, random.Float64())
+		}
+		for i := n; i < 2*n; i++ {
+			if random.Intn(5) == 0 {
+				heap.ExtractMin()
+			} else {
+				heap.Delete(random.Intn(i))
+			}
+			heap.Insert(i, random.Float64())
+		}
+	},
+	"mixed": func(heap fibHeap.TagKeyHeap, random *rand.Rand, n int) {
+		for i := 0; i < n; i++ {
+			tag := random.Intn(n/4 + 1)
+			switch random.Intn(6) {
+			case 0, 1:
+				heap.Insert(tag, random.Float64())
+			case 2:
+				heap.ExtractMin()
+			case 3:
+				heap.DecreaseKey(tag, heap.GetTag(tag)*random.Float64())
+			case 4:
+				heap.IncreaseKey(tag, heap.GetTag(tag)+random.Float64())
+			case 5:
+				heap.Delete(tag)
+			}
+		}
+	},
+}
+
+func profileNames() []string {
+	var names []string
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// generate runs the profile on a recorded FibHeap and returns the recorded calls, so a synthetic workload is replayed like a recording.
+// The GetTag and Num calls used by the profiles to pick the keys and to stop are left out.
+func generate(profile string, n int, seed int64) ([]fibHeap.Record, error) {
+	run, exists := profiles[profile]
+	if !exists {
+		return nil, fmt.Errorf("unknown profile %q, the profiles are %s", profile, strings.Join(profileNames(), ", "))
+	}
+	if n < 1 {
+		return nil, fmt.Errorf("size of the profile must be positive")
+	}
+
+	var buffer bytes.Buffer
+	recorder := fibHeap.NewRecorder(fibHeap.NewFibHeap(), &buffer)
+	run(recorder, rand.New(rand.NewSource(seed)), n)
+	if err := recorder.Err(); err != nil {
+		return nil, err
+	}
+	records, err := fibHeap.ReadRecording(&buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	calls := records[:0]
+	for _, record := range records {
+		if record.Op != "GetTag" && record.Op != "Num" {
+			calls = append(calls, record)
+		}
+	}
+
+	return calls, nil
+}
+
+// replay calls the heap by the records and returns the latencies of the calls by the methods.
+func replay(heap fibHeap.TagKeyHeap, records []fibHeap.Record) map[string][]time.Duration {
+	latencies := make(map[string][]time.Duration)
+	for _, record := range records {
+		start := time.Now()
+		switch record.Op {
+		case "Insert":
+			heap.Insert(record.Tag, record.Key)
+		case "Minimum":
+			heap.Minimum()
+		case "ExtractMin":
+			heap.ExtractMin()
+		case "DecreaseKey":
+			heap.DecreaseKey(record.Tag, record.Key)
+		case "IncreaseKey":
+			heap.IncreaseKey(record.Tag, record.Key)
+		case "Delete":
+			heap.Delete(record.Tag)
+		case "GetTag":
+			heap.GetTag(record.Tag)
+		case "ExtractTag":
+			heap.ExtractTag(record.Tag)
+		case "Num":
+			heap.Num()
+		}
+		latencies[record.Op] = append(latencies[record.Op], time.Since(start))
+	}
+
+	return latencies
+}
+
+// report writes the number, the percentiles, the maximum and the total of the latencies of every method.
+func report(w io.Writer, latencies map[string][]time.Duration) {
+	ops := make([]string, 0, len(latencies))
+	for op := range latencies {
+		ops = append(ops, op)
+	}
+	sort.Strings(ops)
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(table, "operation\tcalls\tp50\tp90\tp99\tp99.9\tmax\ttotal\t")
+	var all time.Duration
+	for _, op := range ops {
+		samples := latencies[op]
+		sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+		var total time.Duration
+		for _, sample := range samples {
+			total += sample
+		}
+		all += total
+		fmt.Fprintf(table, "%s\t%d\t%v\t%v\t%v\t%v\t%v\t%v\t\n", op, len(samples),
+			percentile(samples, 0.5), percentile(samples, 0.9), percentile(samples, 0.99), percentile(samples, 0.999), samples[len(samples)-1], total)
+	}
+	fmt.Fprintf(table, "all\t\t\t\t\t\t\t%v\t\n", all)
+	table.Flush()
+}
+
+// percentile returns the latency at the quantile of the sorted latencies, by the nearest rank.
+func percentile(sorted []time.Duration, q float64) time.Duration {
+	rank := int(q*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+
+	return sorted[rank]
+}
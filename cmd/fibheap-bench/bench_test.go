@@ -0,0 +1,101 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package main
+
+import (
+	"bytes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	fibHeap "github.com/starwander/GoFibonacciHeap"
+	"strings"
+	"time"
+)
+
+var _ = Describe("Tests of fibheap-bench", func() {
+	It("Given the names of heaps, when select the backends, it should find them case insensitively and reject unknown ones.", func() {
+		all, err := selectBackends("")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(all).Should(HaveLen(len(backendNames())))
+		Expect(backendNames()).Should(ContainElement("Fibonacci"))
+		Expect(backendNames()).Should(ContainElement("TagFibonacci"))
+
+		selected, err := selectBackends("pairing, Fibonacci")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(selected).Should(HaveLen(2))
+		Expect(selected[0].name).Should(Equal("Pairing"))
+		Expect(selected[1].name).Should(Equal("Fibonacci"))
+
+		_, err = selectBackends("Fibonacci,Splay")
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("Given every profile, when generate it, it should return the same valid calls for the same seed.", func() {
+		for _, profile := range profileNames() {
+			records, err := generate(profile, 1000, 7)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(records).ShouldNot(BeEmpty())
+			for _, record := range records {
+				Expect(record.Op).ShouldNot(BeElementOf("GetTag", "Num"))
+			}
+
+			again, err := generate(profile, 1000, 7)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(again).Should(Equal(records))
+		}
+
+		_, err := generate("random", 1000, 7)
+		Expect(err).Should(HaveOccurred())
+		_, err = generate("queue", 0, 7)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("Given a dijkstra profile, when replay it on every heap, it should time every call and empty the heap.", func() {
+		records, err := generate("dijkstra", 500, 1)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		for _, b := range backends() {
+			heap := b.new()
+			latencies := replay(heap, records)
+			Expect(latencies["Insert"]).Should(HaveLen(500))
+			Expect(latencies["ExtractMin"]).Should(HaveLen(500))
+			Expect(len(latencies["Insert"]) + len(latencies["ExtractMin"]) + len(latencies["DecreaseKey"])).Should(Equal(len(records)))
+			Expect(heap.Num()).Should(BeEquivalentTo(0))
+		}
+	})
+
+	It("Given some latencies, when report them, it should print the percentiles of every operation.", func() {
+		var samples []time.Duration
+		for i := 1; i <= 1000; i++ {
+			samples = append(samples, time.Duration(1001-i)*time.Microsecond)
+		}
+		Expect(percentile([]time.Duration{time.Second}, 0.5)).Should(Equal(time.Second))
+
+		var out bytes.Buffer
+		report(&out, map[string][]time.Duration{"Insert": samples, "Delete": {time.Millisecond}})
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		Expect(lines).Should(HaveLen(4))
+		Expect(strings.Fields(lines[0])).Should(Equal([]string{"operation", "calls", "p50", "p90", "p99", "p99.9", "max", "total"}))
+		Expect(strings.Fields(lines[1])).Should(Equal([]string{"Delete", "1", "1ms", "1ms", "1ms", "1ms", "1ms", "1ms"}))
+		Expect(strings.Fields(lines[2])).Should(Equal([]string{"Insert", "1000", "500µs", "900µs", "990µs", "999µs", "1ms", "500.5ms"}))
+		Expect(strings.Fields(lines[3])).Should(Equal([]string{"all", "501.5ms"}))
+	})
+
+	It("Given a recording, when read and replay it, it should call the heap by the recorded calls.", func() {
+		var buffer bytes.Buffer
+		recorder := fibHeap.NewRecorder(fibHeap.NewFibHeap(), &buffer)
+		recorder.Insert("a", 2)
+		recorder.Insert("b", 1)
+		recorder.IncreaseKey("b", 3)
+		recorder.ExtractTag("a")
+		recorder.Minimum()
+		records, err := fibHeap.ReadRecording(&buffer)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		heap := fibHeap.NewFibHeap()
+		latencies := replay(heap, records)
+		Expect(latencies).Should(HaveLen(4))
+		Expect(latencies["Insert"]).Should(HaveLen(2))
+		Expect(heap.Num()).Should(BeEquivalentTo(1))
+		Expect(heap.GetTag("b")).Should(Equal(3.0))
+	})
+})
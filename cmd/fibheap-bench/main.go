@@ -0,0 +1,68 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+// Command fibheap-bench replays a workload against the heap implementations of the package and prints the latency percentiles
+// of every operation, so that the implementation fitting a mix of operations can be picked by measurement.
+//
+// Usage:
+//
+//	fibheap-bench [-heaps names] [-recording file | -profile name -n size -seed seed]
+//
+// The workload is either a recording written by a Recorder, e.g. captured in production, or a synthetic profile:
+//
+//	queue     inserts n random keys and extracts them all
+//	dijkstra  inserts n random keys, and extracts the minimum followed by 4 decreases of random keys until the heap is empty
+//	timers    inserts n random deadlines, and cancels 4 of 5 by Delete while extracting the rest, inserting a new one for each
+//	mixed     n random inserts, extracts, decreases, increases and deletes
+//
+// Every call is timed on its own, so the latencies include the overhead of reading the clock.
+// The results of the calls are not compared, as the implementations may extract the values of equal keys in different orders.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	fibHeap "github.com/starwander/GoFibonacciHeap"
+)
+
+func main() {
+	heaps := flag.String("heaps", "", "comma separated names of the heaps to run, all by default: "+strings.Join(backendNames(), ","))
+	recording := flag.String("recording", "", "file of a recording written by a Recorder to replay, instead of a synthetic profile")
+	profile := flag.String("profile", "mixed", "synthetic profile: "+strings.Join(profileNames(), ", "))
+	n := flag.Int("n", 100000, "size of the synthetic profile")
+	seed := flag.Int64("seed", 1, "random seed of the synthetic profile")
+	flag.Parse()
+
+	selected, err := selectBackends(*heaps)
+	if err != nil {
+		fail(err)
+	}
+
+	var records []fibHeap.Record
+	if *recording != "" {
+		file, err := os.Open(*recording)
+		if err != nil {
+			fail(err)
+		}
+		records, err = fibHeap.ReadRecording(file)
+		file.Close()
+		if err != nil {
+			fail(fmt.Errorf("%s: %v", *recording, err))
+		}
+	} else if records, err = generate(*profile, *n, *seed); err != nil {
+		fail(err)
+	}
+
+	fmt.Printf("%d operations\n", len(records))
+	for _, b := range selected {
+		fmt.Printf("\n%s\n", b.name)
+		report(os.Stdout, replay(b.new(), records))
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
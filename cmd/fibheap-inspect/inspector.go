@@ -0,0 +1,288 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	fibHeap "github.com/starwander/GoFibonacciHeap"
+)
+
+// inspector keeps a loaded heap together with its entries and trees, and runs the commands on them.
+type inspector struct {
+	heap *fibHeap.FibHeap
+	// entries are sorted by the keys, and byTag keeps them by their tags.
+	entries []fibHeap.Entry
+	byTag   map[interface{}]fibHeap.Entry
+	trees   []*tree
+	out     io.Writer
+}
+
+// tree is a node of the trees decoded from TopologyJSON.
+type tree struct {
+	Tag      interface{} `json:"tag"`
+	Key      interface{} `json:"key"`
+	Marked   bool        `json:"marked"`
+	Children []*tree     `json:"children"`
+}
+
+// sentinel is the tag inserted and extracted to consolidate the loaded heap, which no serialized tag can equal.
+type sentinel struct{}
+
+// load reads the entries of a serialized heap and rebuilds the heap of their tags and keys.
+func load(r io.Reader) (*inspector, error) {
+	entries, err := fibHeap.UnmarshalEntries(r)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+
+	in := &inspector{heap: fibHeap.NewFibHeap(), entries: entries, byTag: make(map[interface{}]fibHeap.Entry, len(entries))}
+	for _, entry := range entries {
+		if err := in.heap.Insert(entry.Tag, entry.Key); err != nil {
+			return nil, err
+		}
+		in.byTag[entry.Tag] = entry
+	}
+	if len(entries) != 0 {
+		if below := math.Nextafter(entries[0].Key, math.Inf(-1)); !math.IsInf(below, -1) {
+			in.heap.Insert(sentinel{}, below)
+			in.heap.ExtractMin()
+		}
+	}
+
+	data, err := in.heap.TopologyJSON()
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&in.trees); err != nil {
+		return nil, err
+	}
+
+	return in, nil
+}
+
+// commands lists the commands with their arguments and descriptions for help.
+var commands = [][2]string{
+	{"stats", "shows the number of values, the shape of the trees, the estimated memory and the value types"},
+	{"roots", "lists the roots with their degrees and the sizes of their trees"},
+	{"tree <n> [depth]", "shows the n-th tree of roots, down to the depth if given; * marks the nodes which lost a child"},
+	{"find <tag>", "shows the key, the value and the path from the root of the tags printed as the input"},
+	{"min [k]", "lists the k values of the smallest keys, 10 by default"},
+	{"help", "shows this list"},
+	{"quit", "exits"},
+}
+
+// exec runs the command line, and reports whether to go on.
+func (in *inspector) exec(line string) bool {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return true
+	}
+
+	var err error
+	switch command, args := fields[0], fields[1:]; command {
+	case "stats":
+		in.stats()
+	case "roots":
+		in.roots()
+	case "tree":
+		err = in.tree(args)
+	case "find":
+		err = in.find(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), command)))
+	case "min":
+		err = in.min(args)
+	case "help":
+		for _, c := range commands {
+			fmt.Fprintf(in.out, "  %-18s %s\n", c[0], c[1])
+		}
+	case "quit", "exit":
+		return false
+	default:
+		err = fmt.Errorf("unknown command %q, type help for the list", command)
+	}
+	if err != nil {
+		fmt.Fprintln(in.out, "error:", err)
+	}
+
+	return true
+}
+
+func (in *inspector) stats() {
+	fmt.Fprintf(in.out, "values: %d\n", in.heap.Num())
+	if len(in.entries) == 0 {
+		return
+	}
+
+	fmt.Fprintf(in.out, "minimum: %v key %v\n", in.entries[0].Tag, in.entries[0].Key)
+	fmt.Fprintf(in.out, "maximum: %v key %v\n", in.entries[len(in.entries)-1].Tag, in.entries[len(in.entries)-1].Key)
+	fmt.Fprintf(in.out, "roots: %d, max tree depth: %d, degree bound: %d\n", len(in.trees), in.heap.MaxTreeDepth(), in.heap.MaxDegree())
+	fmt.Fprintf(in.out, "degree histogram: %v\n", in.heap.DegreeHistogram())
+	fmt.Fprintf(in.out, "estimated memory: %d bytes without the values\n", in.heap.SizeBytes())
+
+	types := make(map[string]int)
+	for _, entry := range in.entries {
+		types[entry.Type]++
+	}
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if name == "" {
+			fmt.Fprintf(in.out, "tags without values: %d\n", types[name])
+		} else {
+			fmt.Fprintf(in.out, "values of %s: %d\n", name, types[name])
+		}
+	}
+}
+
+func (in *inspector) roots() {
+	for i, root := range in.trees {
+		fmt.Fprintf(in.out, "%4d: %s key %v degree %d size %d\n", i, formatTag(root.Tag), root.Key, len(root.Children), root.size())
+	}
+}
+
+func (in *inspector) tree(args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return fmt.Errorf("usage: tree <n> [depth]")
+	}
+	i, err := strconv.Atoi(args[0])
+	if err != nil || i < 0 || i >= len(in.trees) {
+		return fmt.Errorf("no tree %s of %d roots", args[0], len(in.trees))
+	}
+	depth := -1
+	if len(args) == 2 {
+		if depth, err = strconv.Atoi(args[1]); err != nil || depth < 1 {
+			return fmt.Errorf("invalid depth %s", args[1])
+		}
+	}
+
+	in.trees[i].print(in.out, "", depth)
+
+	return nil
+}
+
+func (in *inspector) find(tag string) error {
+	if tag == "" {
+		return fmt.Errorf("usage: find <tag>")
+	}
+
+	found := false
+	for _, entry := range in.entries {
+		if fmt.Sprint(entry.Tag) != tag {
+			continue
+		}
+		found = true
+		fmt.Fprintf(in.out, "%v (%T) key %v %s\n", entry.Tag, entry.Tag, entry.Key, formatValue(entry))
+		for i, root := range in.trees {
+			if path := root.path(tag); path != nil {
+				fmt.Fprintf(in.out, "  path: tree %d: %s\n", i, strings.Join(path, " > "))
+			}
+		}
+	}
+	if !found {
+		return fmt.Errorf("tag %s is not found", tag)
+	}
+
+	return nil
+}
+
+func (in *inspector) min(args []string) error {
+	k := 10
+	if len(args) > 1 {
+		return fmt.Errorf("usage: min [k]")
+	}
+	if len(args) == 1 {
+		var err error
+		if k, err = strconv.Atoi(args[0]); err != nil || k < 1 {
+			return fmt.Errorf("invalid k %s", args[0])
+		}
+	}
+
+	for i, entry := range in.entries {
+		if i == k {
+			break
+		}
+		fmt.Fprintf(in.out, "%4d: %v key %v %s\n", i, entry.Tag, entry.Key, formatValue(entry))
+	}
+
+	return nil
+}
+
+// size returns the number of the nodes of the tree.
+func (t *tree) size() int {
+	size := 1
+	for _, child := range t.Children {
+		size += child.size()
+	}
+
+	return size
+}
+
+// print writes the tree indented by its depth, down to the depth if it is positive.
+func (t *tree) print(w io.Writer, indent string, depth int) {
+	marked := ""
+	if t.Marked {
+		marked = " *"
+	}
+	fmt.Fprintf(w, "%s%s key %v%s\n", indent, formatTag(t.Tag), t.Key, marked)
+
+	if depth == 1 {
+		if len(t.Children) != 0 {
+			fmt.Fprintf(w, "%s  ... %d nodes below\n", indent, t.size()-1)
+		}
+		return
+	}
+	for _, child := range t.Children {
+		child.print(w, indent+"  ", depth-1)
+	}
+}
+
+// path returns the tags from the root down to the node of the tag printed as the input, or nil if there is none.
+func (t *tree) path(tag string) []string {
+	if formatTag(t.Tag) == tag {
+		return []string{tag}
+	}
+	for _, child := range t.Children {
+		if path := child.path(tag); path != nil {
+			return append([]string{formatTag(t.Tag)}, path...)
+		}
+	}
+
+	return nil
+}
+
+// formatTag prints a tag decoded from TopologyJSON as fmt prints the original tag, e.g. a composite tag as (a, b).
+func formatTag(tag interface{}) string {
+	parts, ok := tag.([]interface{})
+	if !ok {
+		return fmt.Sprint(tag)
+	}
+
+	formatted := make([]string, len(parts))
+	for i, part := range parts {
+		formatted[i] = formatTag(part)
+	}
+
+	return "(" + strings.Join(formatted, ", ") + ")"
+}
+
+// formatValue describes the undecoded value of the entry.
+func formatValue(entry fibHeap.Entry) string {
+	if entry.Type == "" {
+		return "without value"
+	}
+
+	return fmt.Sprintf("value %s of %d bytes", entry.Type, len(entry.Value))
+}
@@ -0,0 +1,132 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	fibHeap "github.com/starwander/GoFibonacciHeap"
+	"strings"
+)
+
+// job is a value of a registered type, which the inspector shows without decoding.
+type job struct {
+	ID       int
+	Priority float64
+}
+
+func (j *job) Tag() interface{} {
+	return j.ID
+}
+
+func (j *job) Key() float64 {
+	return j.Priority
+}
+
+func (j *job) MarshalBinary() ([]byte, error) {
+	return json.Marshal(*j)
+}
+
+func (j *job) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, j)
+}
+
+func init() {
+	fibHeap.RegisterValue("inspect.job", &job{})
+}
+
+var _ = Describe("Tests of fibheap-inspect", func() {
+	var (
+		in  *inspector
+		out *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		heap := fibHeap.NewFibHeap()
+		for i := 0; i < 16; i++ {
+			heap.InsertValue(&job{i, float64(i)})
+		}
+		heap.Insert("tag", 100)
+		heap.Insert(fibHeap.TagOf("tenant", 1), 50)
+
+		var data bytes.Buffer
+		Expect(heap.Marshal(&data)).Should(Succeed())
+		var err error
+		in, err = load(&data)
+		Expect(err).ShouldNot(HaveOccurred())
+		out = new(bytes.Buffer)
+		in.out = out
+	})
+
+	AfterEach(func() {
+		in = nil
+		out = nil
+	})
+
+	It("Given a loaded heap, when run stats and roots, it should show the consolidated trees.", func() {
+		Expect(in.exec("stats")).Should(BeTrue())
+		Expect(out.String()).Should(ContainSubstring("values: 18\n"))
+		Expect(out.String()).Should(ContainSubstring("minimum: 0 key 0\n"))
+		Expect(out.String()).Should(ContainSubstring("maximum: tag key 100\n"))
+		Expect(out.String()).Should(ContainSubstring("roots: 2, max tree depth: 5"))
+		Expect(out.String()).Should(ContainSubstring("values of inspect.job: 16\n"))
+		Expect(out.String()).Should(ContainSubstring("tags without values: 2\n"))
+
+		out.Reset()
+		in.exec("roots")
+		Expect(out.String()).Should(Equal("   0: 0 key 0 degree 4 size 16\n   1: (tenant, 1) key 50 degree 1 size 2\n"))
+	})
+
+	It("Given a loaded heap, when run tree, find and min, it should browse the trees and the tags.", func() {
+		in.exec("tree 0 2")
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		Expect(lines[0]).Should(Equal("0 key 0"))
+		Expect(lines[1]).Should(Equal("  1 key 1"))
+		Expect(lines).Should(ContainElement("    ... 7 nodes below"))
+
+		out.Reset()
+		in.exec("find (tenant, 1)")
+		Expect(out.String()).Should(ContainSubstring("(tenant, 1) (fibHeap.CompositeTag) key 50 without value\n"))
+		Expect(out.String()).Should(ContainSubstring("  path: tree 1: (tenant, 1)\n"))
+
+		out.Reset()
+		in.exec("find 15")
+		Expect(out.String()).Should(ContainSubstring("15 (int) key 15 value inspect.job of 23 bytes\n"))
+		Expect(out.String()).Should(ContainSubstring("  path: tree 0: 0 > 8 > 12 > 14 > 15\n"))
+
+		out.Reset()
+		in.exec("min 2")
+		Expect(out.String()).Should(Equal("   0: 0 key 0 value inspect.job of 21 bytes\n   1: 1 key 1 value inspect.job of 21 bytes\n"))
+	})
+
+	It("Given a loaded heap, when run invalid commands, it should report errors and go on until quit.", func() {
+		for _, line := range []string{"tree", "tree 9", "tree 0 x", "find", "find nothing", "min 0", "unknown"} {
+			out.Reset()
+			Expect(in.exec(line)).Should(BeTrue())
+			Expect(out.String()).Should(HavePrefix("error: "))
+		}
+		Expect(in.exec("")).Should(BeTrue())
+		Expect(in.exec("quit")).Should(BeFalse())
+
+		out.Reset()
+		in.repl(strings.NewReader("help\nroots\nexit\nroots\n"))
+		Expect(out.String()).Should(ContainSubstring("find <tag>"))
+		Expect(strings.Count(out.String(), "degree 4")).Should(Equal(1))
+	})
+
+	It("Given corrupted or empty input, when load it, it should fail or show an empty heap.", func() {
+		_, err := load(strings.NewReader("corrupted"))
+		Expect(err).Should(Equal(fibHeap.ErrCorrupted))
+
+		var data bytes.Buffer
+		fibHeap.NewFibHeap().Marshal(&data)
+		in, err = load(&data)
+		Expect(err).ShouldNot(HaveOccurred())
+		in.out = out
+		in.exec("stats")
+		in.exec("roots")
+		Expect(out.String()).Should(Equal("values: 0\n"))
+	})
+})
@@ -0,0 +1,74 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+// Command fibheap-inspect loads a heap written by Marshal and browses it interactively,
+// e.g. to debug the persisted state of a scheduler: the trees, the tags and the statistics of the heap.
+//
+// Usage:
+//
+//	fibheap-inspect [-c commands] file
+//
+// The commands are read from the standard input one per line, or from the -c flag separated by semicolons.
+// Type help for the list of the commands.
+//
+// The types of the values do not need to be registered, as the values are shown by their registered names and sizes only.
+// The serialized form keeps no trees, so the loaded heap is consolidated once, as its first ExtractMin would do,
+// and the trees shown are the ones the restored heap would have.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+func main() {
+	commands := flag.String("c", "", "commands to run separated by semicolons, instead of reading them from the standard input")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-c commands] file\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	file, err := os.Open(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	in, err := load(file)
+	file.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", flag.Arg(0), err)
+		os.Exit(1)
+	}
+
+	in.out = os.Stdout
+	if *commands != "" {
+		for _, line := range strings.Split(*commands, ";") {
+			if !in.exec(line) {
+				break
+			}
+		}
+		return
+	}
+	in.repl(os.Stdin)
+}
+
+// repl runs the commands read from the input line by line until quit or the end of the input.
+func (in *inspector) repl(r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	fmt.Fprint(in.out, "> ")
+	for scanner.Scan() {
+		if !in.exec(scanner.Text()) {
+			return
+		}
+		fmt.Fprint(in.out, "> ")
+	}
+	fmt.Fprintln(in.out)
+}
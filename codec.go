@@ -0,0 +1,157 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ValueCodec encodes and decodes the values of a concrete type for all serialization paths,
+// so that applications control exactly how their payloads are persisted and restored.
+type ValueCodec interface {
+	// Encode returns the encoded form of the input value.
+	Encode(value Value) ([]byte, error)
+	// Decode restores a value from the output of Encode.
+	Decode(data []byte) (Value, error)
+}
+
+var codecs = struct {
+	sync.RWMutex
+	names  map[reflect.Type]string
+	byName map[string]ValueCodec
+}{
+	names:  make(map[reflect.Type]string),
+	byName: make(map[string]ValueCodec),
+}
+
+var (
+	binaryMarshaler   = reflect.TypeOf((*encoding.BinaryMarshaler)(nil)).Elem()
+	binaryUnmarshaler = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+)
+
+// RegisterValue records the concrete type of the input sample value under the input name,
+// so that values of the type can be encoded by Export and decoded back by Import.
+// The type must implement encoding.BinaryMarshaler and its pointer must implement encoding.BinaryUnmarshaler.
+// It is a shortcut of RegisterValueCodec with a codec calling MarshalBinary and UnmarshalBinary.
+func RegisterValue(name string, sample Value) {
+	if sample == nil {
+		panic("fibHeap: sample value must not be nil")
+	}
+
+	t := reflect.TypeOf(sample)
+	if !t.Implements(binaryMarshaler) || !reflect.PtrTo(baseType(t)).Implements(binaryUnmarshaler) {
+		panic(fmt.Sprintf("fibHeap: value type %v must implement encoding.BinaryMarshaler and encoding.BinaryUnmarshaler", t))
+	}
+
+	RegisterValueCodec(name, sample, binaryCodec{t})
+}
+
+// RegisterValueCodec records the concrete type of the input sample value under the input name together with its codec,
+// so that values of the type can be encoded and decoded by all serialization paths.
+// As gob.Register, RegisterValueCodec is expected to be called during initialization,
+// and an empty name, a nil sample or codec, or a name or type registered twice will cause a panic.
+func RegisterValueCodec(name string, sample Value, codec ValueCodec) {
+	if name == "" {
+		panic("fibHeap: value name must not be empty")
+	}
+	if sample == nil {
+		panic("fibHeap: sample value must not be nil")
+	}
+	if codec == nil {
+		panic("fibHeap: value codec must not be nil")
+	}
+
+	t := reflect.TypeOf(sample)
+
+	codecs.Lock()
+	defer codecs.Unlock()
+
+	if _, exists := codecs.byName[name]; exists {
+		panic(fmt.Sprintf("fibHeap: value name %q is registered twice", name))
+	}
+	if _, exists := codecs.names[t]; exists {
+		panic(fmt.Sprintf("fibHeap: value type %v is registered twice", t))
+	}
+	codecs.byName[name] = codec
+	codecs.names[t] = name
+}
+
+// encodeValue returns the registered name of the value type and the encoded value.
+// A nil value is encoded as an empty name and nil data.
+func encodeValue(value Value) (string, []byte, error) {
+	if value == nil {
+		return "", nil, nil
+	}
+
+	codecs.RLock()
+	name, exists := codecs.names[reflect.TypeOf(value)]
+	codec := codecs.byName[name]
+	codecs.RUnlock()
+	if !exists {
+		return "", nil, fmt.Errorf("Value type %T is not registered ", value)
+	}
+
+	data, err := codec.Encode(value)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return name, data, nil
+}
+
+// decodeValue is the reverse of encodeValue.
+func decodeValue(name string, data []byte) (Value, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	codecs.RLock()
+	codec, exists := codecs.byName[name]
+	codecs.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("Value name %q is not registered ", name)
+	}
+
+	value, err := codec.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, fmt.Errorf("Codec of value name %q decodes a nil value ", name)
+	}
+
+	return value, nil
+}
+
+// binaryCodec is the ValueCodec of the types registered by RegisterValue.
+type binaryCodec struct {
+	t reflect.Type
+}
+
+func (codec binaryCodec) Encode(value Value) ([]byte, error) {
+	return value.(encoding.BinaryMarshaler).MarshalBinary()
+}
+
+func (codec binaryCodec) Decode(data []byte) (Value, error) {
+	ptr := reflect.New(baseType(codec.t))
+	if err := ptr.Interface().(encoding.BinaryUnmarshaler).UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+
+	if codec.t.Kind() == reflect.Ptr {
+		return ptr.Interface().(Value), nil
+	}
+
+	return ptr.Elem().Interface().(Value), nil
+}
+
+func baseType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+
+	return t
+}
@@ -0,0 +1,552 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// noNode is the link to no node in a CompactFibHeap.
+const noNode int32 = -1
+
+// CompactFibHeap represents a Fibonacci Heap whose nodes are kept in one growable slice and linked by int32 indices instead of pointers.
+// CompactFibHeap provides exactly the same methods as FibHeap so the two implementations can be swapped by changing the constructor only.
+// Every node takes four 4-byte links instead of the pointers of FibHeap plus the elements of container/list,
+// which halves the memory of a node and keeps the nodes close to each other for better cache locality.
+// The nodes freed by extractions are reused by later inserts, and the heap holds at most 2^31-1 values.
+// Please note that all methods of CompactFibHeap are not concurrent safe.
+type CompactFibHeap struct {
+	nodes   []compactNode
+	free    []int32
+	index   map[interface{}]int32
+	min     int32
+	degrees []int32
+}
+
+// compactNode is a node of CompactFibHeap. The children of a node and the roots are kept in circular doubly linked lists by left and right.
+type compactNode struct {
+	parent, child, left, right int32
+	degree                     int32
+	marked                     bool
+	key                        float64
+	tag                        interface{}
+	value                      Value
+}
+
+// NewCompactFibHeap creates an initialized CompactFibHeap.
+func NewCompactFibHeap() *CompactFibHeap {
+	heap := new(CompactFibHeap)
+	heap.index = make(map[interface{}]int32)
+	heap.min = noNode
+
+	return heap
+}
+
+// Num returns the total number of values in the heap.
+func (heap *CompactFibHeap) Num() uint {
+	return uint(len(heap.index))
+}
+
+// Insert pushes the input tag and key into the heap.
+// Try to insert a nil tag, a duplicate tag or a -inf key will cause an error return.
+func (heap *CompactFibHeap) Insert(tag interface{}, key float64) error {
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	return heap.insert(tag, key, nil)
+}
+
+// InsertValue pushes the input value into the heap.
+// Try to insert a value with a duplicate tag or a -inf key will cause an error return.
+func (heap *CompactFibHeap) InsertValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	return heap.insert(value.Tag(), value.Key(), value)
+}
+
+// Minimum returns the current minimum tag and key in the heap sorted by the key.
+// An empty heap will return nil and -inf.
+func (heap *CompactFibHeap) Minimum() (interface{}, float64) {
+	if heap.min == noNode {
+		return nil, math.Inf(-1)
+	}
+
+	return heap.nodes[heap.min].tag, heap.nodes[heap.min].key
+}
+
+// MinimumValue returns the current minimum value in the heap sorted by the key.
+// An empty heap will return nil.
+func (heap *CompactFibHeap) MinimumValue() Value {
+	if heap.min == noNode {
+		return nil
+	}
+
+	return heap.nodes[heap.min].value
+}
+
+// ExtractMin returns the current minimum tag and key in the heap and then extracts them from the heap.
+// An empty heap will return nil/-inf and extracts nothing.
+func (heap *CompactFibHeap) ExtractMin() (interface{}, float64) {
+	if heap.min == noNode {
+		return nil, math.Inf(-1)
+	}
+
+	tag, key, _ := heap.extractMin()
+
+	return tag, key
+}
+
+// ExtractMinValue returns the current minimum value in the heap and then extracts it from the heap.
+// An empty heap will return nil and extracts nothing.
+func (heap *CompactFibHeap) ExtractMinValue() Value {
+	if heap.min == noNode {
+		return nil
+	}
+
+	_, _, value := heap.extractMin()
+
+	return value
+}
+
+// Union moves all values of the input heap into the heap.
+// The input heap can be any implementation of PriorityQueue, and it is emptied afterwards so that no value is reachable from both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned and both heaps are left untouched.
+func (heap *CompactFibHeap) Union(anotherHeap PriorityQueue) error {
+	if err := heap.UnionInto(anotherHeap); err != nil {
+		return err
+	}
+
+	anotherHeap.reset()
+
+	return nil
+}
+
+// UnionInto merges copies of all values of the input heap into the heap.
+// The input heap can be any implementation of PriorityQueue, and it is left untouched, so the values are shared by both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned.
+func (heap *CompactFibHeap) UnionInto(anotherHeap PriorityQueue) error {
+	if err := checkUnion(heap, anotherHeap); err != nil {
+		return err
+	}
+
+	anotherHeap.each(func(tag interface{}, key float64, value Value) {
+		heap.insert(tag, key, value)
+	})
+
+	return nil
+}
+
+// DecreaseKey updates the tag in the heap by the input key.
+// If the input key is not smaller than the current key or is -inf, an error will be returned.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *CompactFibHeap) DecreaseKey(tag interface{}, key float64) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if i, exists := heap.index[tag]; exists {
+		return heap.decreaseKey(i, heap.nodes[i].value, key)
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// DecreaseKeyValue updates the value in the heap by the input value.
+// If the input value's key is not smaller than the current key or is -inf, an error will be returned.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *CompactFibHeap) DecreaseKeyValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	if i, exists := heap.index[tagKey(value.Tag())]; exists {
+		return heap.decreaseKey(i, value, value.Key())
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// IncreaseKey updates the tag in the heap by the input key.
+// If the input key is not larger than the current key, an error will be returned.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *CompactFibHeap) IncreaseKey(tag interface{}, key float64) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if i, exists := heap.index[tag]; exists {
+		return heap.increaseKey(i, heap.nodes[i].value, key)
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// IncreaseKeyValue updates the value in the heap by the input value.
+// If the input value's key is not larger than the current key, an error will be returned.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *CompactFibHeap) IncreaseKeyValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	if i, exists := heap.index[tagKey(value.Tag())]; exists {
+		return heap.increaseKey(i, value, value.Key())
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// Delete deletes the input tag in the heap.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *CompactFibHeap) Delete(tag interface{}) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	i, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Tag is not found ")
+	}
+
+	heap.remove(i)
+
+	return nil
+}
+
+// DeleteValue deletes the value in the heap by the input value.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *CompactFibHeap) DeleteValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	i, exists := heap.index[tagKey(value.Tag())]
+	if !exists {
+		return errors.New("Value is not found ")
+	}
+
+	heap.remove(i)
+
+	return nil
+}
+
+// GetTag searches and returns the key in the heap by the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *CompactFibHeap) GetTag(tag interface{}) (key float64) {
+	tag = tagKey(tag)
+	if i, exists := heap.index[tag]; exists {
+		return heap.nodes[i].key
+	}
+
+	return math.Inf(-1)
+}
+
+// GetValue searches and returns the value in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *CompactFibHeap) GetValue(tag interface{}) (value Value) {
+	tag = tagKey(tag)
+	if i, exists := heap.index[tag]; exists {
+		value = heap.nodes[i].value
+	}
+
+	return
+}
+
+// ExtractTag searches and extracts the tag/key in the heap by the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *CompactFibHeap) ExtractTag(tag interface{}) (key float64) {
+	tag = tagKey(tag)
+	if i, exists := heap.index[tag]; exists {
+		_, key, _ = heap.remove(i)
+		return
+	}
+
+	return math.Inf(-1)
+}
+
+// ExtractValue searches and extracts the value in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *CompactFibHeap) ExtractValue(tag interface{}) (value Value) {
+	tag = tagKey(tag)
+	if i, exists := heap.index[tag]; exists {
+		_, _, value = heap.remove(i)
+		return
+	}
+
+	return nil
+}
+
+// String provides some basic debug information of the heap.
+// It returns the total number, the number of allocated and free nodes and the current minimum value of the heap.
+func (heap *CompactFibHeap) String() string {
+	var buffer bytes.Buffer
+
+	if heap.min != noNode {
+		min := &heap.nodes[heap.min]
+		buffer.WriteString(fmt.Sprintf("Total number: %d, Allocated nodes: %d, Free nodes: %d,\n", len(heap.index), len(heap.nodes), len(heap.free)))
+		buffer.WriteString(fmt.Sprintf("Current minimun: key(%f), tag(%v), value(%v),\n", min.key, min.tag, min.value))
+	} else {
+		buffer.WriteString(fmt.Sprintf("Heap is empty.\n"))
+	}
+
+	return buffer.String()
+}
+
+func (heap *CompactFibHeap) reset() {
+	*heap = *NewCompactFibHeap()
+}
+
+func (heap *CompactFibHeap) each(fn func(tag interface{}, key float64, value Value)) {
+	for _, i := range heap.index {
+		fn(heap.nodes[i].tag, heap.nodes[i].key, heap.nodes[i].value)
+	}
+}
+
+func (heap *CompactFibHeap) insert(tag interface{}, key float64, value Value) error {
+	tag = tagKey(tag)
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if _, exists := heap.index[tag]; exists {
+		return errors.New("Duplicate tag is not allowed ")
+	}
+
+	var i int32
+	if last := len(heap.free) - 1; last >= 0 {
+		i = heap.free[last]
+		heap.free = heap.free[:last]
+	} else {
+		if len(heap.nodes) == math.MaxInt32 {
+			return errors.New("Heap is full ")
+		}
+		i = int32(len(heap.nodes))
+		heap.nodes = append(heap.nodes, compactNode{})
+	}
+	heap.nodes[i] = compactNode{parent: noNode, child: noNode, left: i, right: i, key: key, tag: tag, value: value}
+	heap.index[tag] = i
+	heap.addRoot(i)
+
+	return nil
+}
+
+// extractMin extracts the minimum node, frees it and returns its content.
+func (heap *CompactFibHeap) extractMin() (interface{}, float64, Value) {
+	nodes := heap.nodes
+	min := heap.min
+	for nodes[min].child != noNode {
+		child := nodes[min].child
+		heap.removeChild(min, child)
+		nodes[child].parent = noNode
+		nodes[child].marked = false
+		heap.splice(min, child)
+	}
+
+	if nodes[min].right == min {
+		heap.min = noNode
+	} else {
+		nodes[nodes[min].left].right = nodes[min].right
+		nodes[nodes[min].right].left = nodes[min].left
+		heap.min = nodes[min].right
+		heap.consolidate()
+	}
+
+	tag, key, value := nodes[min].tag, nodes[min].key, nodes[min].value
+	delete(heap.index, tag)
+	nodes[min] = compactNode{}
+	heap.free = append(heap.free, min)
+
+	return tag, key, value
+}
+
+// remove extracts the node at the input index by making it the minimum.
+func (heap *CompactFibHeap) remove(i int32) (interface{}, float64, Value) {
+	if parent := heap.nodes[i].parent; parent != noNode {
+		heap.cut(i)
+		heap.cascadingCut(parent)
+	}
+	heap.min = i
+
+	return heap.extractMin()
+}
+
+func (heap *CompactFibHeap) decreaseKey(i int32, value Value, key float64) error {
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	n := &heap.nodes[i]
+	if key >= n.key {
+		return errors.New("New key is not smaller than current key ")
+	}
+
+	n.key = key
+	n.value = value
+	if parent := n.parent; parent != noNode && key < heap.nodes[parent].key {
+		heap.cut(i)
+		heap.cascadingCut(parent)
+	}
+	if key < heap.nodes[heap.min].key {
+		heap.min = i
+	}
+
+	return nil
+}
+
+func (heap *CompactFibHeap) increaseKey(i int32, value Value, key float64) error {
+	n := &heap.nodes[i]
+	if key <= n.key {
+		return errors.New("New key is not larger than current key ")
+	}
+
+	// The children may be smaller than the new key, so they are moved to the roots before the key changes.
+	for n.child != noNode {
+		heap.cut(n.child)
+	}
+	if parent := n.parent; parent != noNode {
+		heap.cut(i)
+		heap.cascadingCut(parent)
+	}
+	n.key = key
+	n.value = value
+	if heap.min == i {
+		heap.resetMin()
+	}
+
+	return nil
+}
+
+// consolidate links the roots of the same degree until all roots have different degrees, and finds the new minimum.
+func (heap *CompactFibHeap) consolidate() {
+	nodes := heap.nodes
+	degrees := heap.degrees[:0]
+
+	start := heap.min
+	nodes[nodes[start].left].right = noNode
+	for next := start; next != noNode; {
+		i := next
+		next = nodes[i].right
+		nodes[i].left, nodes[i].right = i, i
+		for {
+			degree := int(nodes[i].degree)
+			for len(degrees) <= degree {
+				degrees = append(degrees, noNode)
+			}
+			other := degrees[degree]
+			if other == noNode {
+				break
+			}
+			degrees[degree] = noNode
+			if nodes[other].key < nodes[i].key {
+				i, other = other, i
+			}
+			heap.link(i, other)
+		}
+		degrees[nodes[i].degree] = i
+	}
+
+	heap.min = noNode
+	for _, i := range degrees {
+		if i != noNode {
+			heap.addRoot(i)
+		}
+	}
+	heap.degrees = degrees
+}
+
+// addRoot adds the single node to the roots and updates the minimum.
+func (heap *CompactFibHeap) addRoot(i int32) {
+	nodes := heap.nodes
+	nodes[i].parent = noNode
+	nodes[i].marked = false
+	if heap.min == noNode {
+		nodes[i].left, nodes[i].right = i, i
+		heap.min = i
+		return
+	}
+
+	min := heap.min
+	nodes[i].left, nodes[i].right = min, nodes[min].right
+	nodes[nodes[min].right].left = i
+	nodes[min].right = i
+	if nodes[i].key < nodes[min].key {
+		heap.min = i
+	}
+}
+
+// link makes the child a child of the parent.
+func (heap *CompactFibHeap) link(parent, child int32) {
+	nodes := heap.nodes
+	nodes[child].parent = parent
+	nodes[child].marked = false
+	if first := nodes[parent].child; first == noNode {
+		nodes[child].left, nodes[child].right = child, child
+		nodes[parent].child = child
+	} else {
+		nodes[child].left, nodes[child].right = first, nodes[first].right
+		nodes[nodes[first].right].left = child
+		nodes[first].right = child
+	}
+	nodes[parent].degree++
+}
+
+// removeChild unlinks the child from the children of the parent.
+func (heap *CompactFibHeap) removeChild(parent, child int32) {
+	nodes := heap.nodes
+	if nodes[child].right == child {
+		nodes[parent].child = noNode
+	} else {
+		if nodes[parent].child == child {
+			nodes[parent].child = nodes[child].right
+		}
+		nodes[nodes[child].left].right = nodes[child].right
+		nodes[nodes[child].right].left = nodes[child].left
+	}
+	nodes[child].left, nodes[child].right = child, child
+	nodes[parent].degree--
+}
+
+// cut moves the node from its parent to the roots.
+func (heap *CompactFibHeap) cut(i int32) {
+	heap.removeChild(heap.nodes[i].parent, i)
+	heap.addRoot(i)
+}
+
+func (heap *CompactFibHeap) cascadingCut(i int32) {
+	for heap.nodes[i].parent != noNode {
+		if !heap.nodes[i].marked {
+			heap.nodes[i].marked = true
+			return
+		}
+		parent := heap.nodes[i].parent
+		heap.cut(i)
+		i = parent
+	}
+}
+
+// resetMin scans the roots for the minimum.
+func (heap *CompactFibHeap) resetMin() {
+	start := heap.min
+	for i := heap.nodes[start].right; i != start; i = heap.nodes[i].right {
+		if heap.nodes[i].key < heap.nodes[heap.min].key {
+			heap.min = i
+		}
+	}
+}
+
+// splice joins the circular lists of a and b.
+func (heap *CompactFibHeap) splice(a, b int32) {
+	nodes := heap.nodes
+	aRight, bLeft := nodes[a].right, nodes[b].left
+	nodes[a].right, nodes[b].left = b, a
+	nodes[aRight].left, nodes[bLeft].right = bLeft, aRight
+}
@@ -0,0 +1,120 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/starwander/GoFibonacciHeap/heaptest"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+var _ = Describe("Tests of compactFibHeap", func() {
+	var (
+		heap *CompactFibHeap
+	)
+
+	BeforeEach(func() {
+		heap = NewCompactFibHeap()
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given an empty compactFibHeap, when call Minimum and ExtractMin api, it should return nil.", func() {
+		tag, key := heap.Minimum()
+		Expect(tag).Should(BeNil())
+		Expect(key).Should(BeEquivalentTo(math.Inf(-1)))
+		Expect(heap.MinimumValue()).Should(BeNil())
+		Expect(heap.ExtractMinValue()).Should(BeNil())
+		Expect(heap.ExtractTag(1)).Should(Equal(math.Inf(-1)))
+		Expect(heap.ExtractValue(1)).Should(BeNil())
+		Expect(heap.String()).Should(BeEquivalentTo("Heap is empty.\n"))
+	})
+
+	It("Given a compactFibHeap, when call the apis with invalid input, it should return errors.", func() {
+		Expect(heap.Insert(nil, 0.0)).Should(HaveOccurred())
+		Expect(heap.Insert(1, math.Inf(-1))).Should(HaveOccurred())
+		Expect(heap.Insert(1, 1)).ShouldNot(HaveOccurred())
+		Expect(heap.Insert(1, 2)).Should(HaveOccurred())
+		Expect(heap.DecreaseKey(nil, 0)).Should(HaveOccurred())
+		Expect(heap.DecreaseKey(1, math.Inf(-1))).Should(HaveOccurred())
+		Expect(heap.DecreaseKey(1, 1)).Should(HaveOccurred())
+		Expect(heap.DecreaseKey(2, 0)).Should(HaveOccurred())
+		Expect(heap.IncreaseKey(nil, 0)).Should(HaveOccurred())
+		Expect(heap.IncreaseKey(1, 1)).Should(HaveOccurred())
+		Expect(heap.IncreaseKey(2, 2)).Should(HaveOccurred())
+		Expect(heap.Delete(nil)).Should(HaveOccurred())
+		Expect(heap.Delete(2)).Should(HaveOccurred())
+		Expect(heap.DeleteValue(&demoStruct{2, 2, ""})).Should(HaveOccurred())
+		Expect(heap.DecreaseKeyValue(&demoStruct{2, 2, ""})).Should(HaveOccurred())
+		Expect(heap.IncreaseKeyValue(&demoStruct{2, 2, ""})).Should(HaveOccurred())
+		Expect(heap.String()).Should(ContainSubstring("tag(1)"))
+	})
+
+	It("Given a compactFibHeap under random value operations, when extract all values, it should return them in order and reuse the freed nodes.", func() {
+		random := rand.New(rand.NewSource(1))
+		keys := make(map[int]float64)
+		for i := 0; i < 20000; i++ {
+			tag := random.Intn(1000)
+			key := random.NormFloat64() * 1000
+			current, exists := keys[tag]
+			switch random.Intn(6) {
+			case 0, 1:
+				if !exists {
+					Expect(heap.InsertValue(&demoStruct{tag, key, "inserted"})).ShouldNot(HaveOccurred())
+					keys[tag] = key
+				}
+			case 2:
+				if value := heap.ExtractMinValue(); value != nil {
+					Expect(value.Key()).Should(Equal(keys[value.Tag().(int)]))
+					for _, other := range keys {
+						Expect(value.Key()).Should(BeNumerically("<=", other))
+					}
+					delete(keys, value.Tag().(int))
+				}
+			case 3:
+				if exists && key < current {
+					Expect(heap.DecreaseKeyValue(&demoStruct{tag, key, "decreased"})).ShouldNot(HaveOccurred())
+					keys[tag] = key
+				} else if exists && key > current {
+					Expect(heap.IncreaseKeyValue(&demoStruct{tag, key, "increased"})).ShouldNot(HaveOccurred())
+					keys[tag] = key
+				}
+			case 4:
+				if exists {
+					Expect(heap.ExtractValue(tag).Key()).Should(Equal(current))
+					delete(keys, tag)
+				}
+			case 5:
+				if exists {
+					Expect(heap.DeleteValue(&demoStruct{tag, 0, ""})).ShouldNot(HaveOccurred())
+					delete(keys, tag)
+				}
+			}
+		}
+		Expect(len(heap.nodes)).Should(BeNumerically("<=", 1000))
+
+		expected := make([]float64, 0, len(keys))
+		for tag, key := range keys {
+			Expect(heap.GetTag(tag)).Should(Equal(key))
+			expected = append(expected, key)
+		}
+		sort.Float64s(expected)
+		Expect(heap.Num()).Should(BeEquivalentTo(len(expected)))
+		for _, key := range expected {
+			_, min := heap.ExtractMin()
+			Expect(min).Should(Equal(key))
+		}
+		Expect(heap.free).Should(HaveLen(len(heap.nodes)))
+	})
+
+	It("Given a compactFibHeap, when run the differential tester, it should never diverge from the reference model.", func() {
+		for seed := int64(0); seed < 20; seed++ {
+			Expect(heaptest.Run(NewCompactFibHeap(), seed)).ShouldNot(HaveOccurred())
+		}
+	})
+})
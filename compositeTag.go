@@ -0,0 +1,63 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+)
+
+// CompositeTag is a tag made of multiple parts by TagOf, e.g. a (jobID, attempt) identity.
+// Two composite tags are equal if and only if they have equal parts in the same order, so they can be used as tags
+// and compared by == directly. The parts are chained in nested values rather than kept in a slice, which would not be comparable.
+type CompositeTag struct {
+	size int
+	head interface{}
+	tail interface{}
+}
+
+// TagOf returns a composite tag of the input parts, so the identities of multiple fields do not need to be stringified by hand.
+// The parts can be any comparable values, including other composite tags, and are returned by Parts.
+// A []byte part is kept as a BytesTag, the same as a []byte tag.
+// Composite tags of booleans, strings, numbers and Handles can be marshaled as the tags of these types.
+// A part which is not comparable, e.g. a slice, will cause a panic, as it could not be a tag.
+func TagOf(parts ...interface{}) interface{} {
+	tag := CompositeTag{}
+	for i := len(parts) - 1; i >= 0; i-- {
+		part := tagKey(parts[i])
+		if part != nil && !reflect.TypeOf(part).Comparable() {
+			panic(fmt.Sprintf("fibHeap: part %v of TagOf must be comparable", i))
+		}
+		tag = CompositeTag{len(parts) - i, part, tag}
+	}
+
+	return tag
+}
+
+// Parts returns the parts of the composite tag in order.
+func (tag CompositeTag) Parts() []interface{} {
+	parts := make([]interface{}, 0, tag.size)
+	for tag.size != 0 {
+		parts = append(parts, tag.head)
+		tag = tag.tail.(CompositeTag)
+	}
+
+	return parts
+}
+
+// String returns the parts of the composite tag in parentheses.
+func (tag CompositeTag) String() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("(")
+	for i, part := range tag.Parts() {
+		if i != 0 {
+			buffer.WriteString(", ")
+		}
+		buffer.WriteString(fmt.Sprint(part))
+	}
+	buffer.WriteString(")")
+
+	return buffer.String()
+}
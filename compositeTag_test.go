@@ -0,0 +1,45 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tests of composite tags", func() {
+	It("Given composite tags, when compare them, it should be equal if and only if their parts are equal in order.", func() {
+		Expect(TagOf("job", 1) == TagOf("job", 1)).Should(BeTrue())
+		Expect(TagOf("job", 1) == TagOf("job", 2)).Should(BeFalse())
+		Expect(TagOf("job", 1) == TagOf(1, "job")).Should(BeFalse())
+		Expect(TagOf("job") == TagOf("job", nil)).Should(BeFalse())
+		Expect(TagOf() == TagOf(nil)).Should(BeFalse())
+		Expect(TagOf("job", 1) == interface{}("job")).Should(BeFalse())
+
+		Expect(TagOf("job", TagOf(1, 2)).(CompositeTag).Parts()).Should(Equal([]interface{}{"job", TagOf(1, 2)}))
+		Expect(TagOf().(CompositeTag).Parts()).Should(BeEmpty())
+		Expect(TagOf("job", 1).(CompositeTag).String()).Should(Equal("(job, 1)"))
+		Expect(func() { TagOf("job", []int{1}) }).Should(Panic())
+	})
+
+	It("Given a fibHeap of composite tags, when marshal and unmarshal it, it should find the values by the composite tags.", func() {
+		heap := NewFibHeap()
+		for attempt := 0; attempt < 3; attempt++ {
+			Expect(heap.Insert(TagOf("job", attempt, TagOf(true, 1.5)), float64(attempt))).Should(Succeed())
+		}
+		Expect(heap.Insert(TagOf("job", 0, TagOf(true, 1.5)), 1)).Should(HaveOccurred())
+		Expect(heap.GetTag(TagOf("job", 2, TagOf(true, 1.5)))).Should(BeEquivalentTo(2))
+
+		var buffer bytes.Buffer
+		Expect(heap.Marshal(&buffer)).Should(Succeed())
+		restored, err := Unmarshal(&buffer)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(restored.Equal(heap)).Should(BeTrue())
+		tag, _ := restored.ExtractMin()
+		Expect(tag).Should(Equal(TagOf("job", 0, TagOf(true, 1.5))))
+
+		heap.Insert(TagOf("job", nil), 0)
+		Expect(heap.Marshal(&buffer)).Should(HaveOccurred())
+	})
+})
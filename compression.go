@@ -0,0 +1,116 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+)
+
+// Compressor injects a compression algorithm into MarshalCompressed and Unmarshal by wrapping their streams.
+// For example, snappy or zstd can be plugged in by wrapping the writers and readers of their packages.
+type Compressor struct {
+	// NewWriter returns a writer which compresses everything written to it into w, and flushes on Close.
+	NewWriter func(w io.Writer) (io.WriteCloser, error)
+	// NewReader returns a reader which decompresses everything read from r.
+	NewReader func(r io.Reader) (io.ReadCloser, error)
+}
+
+var compressors = struct {
+	sync.RWMutex
+	byName map[string]Compressor
+}{
+	byName: map[string]Compressor{
+		"gzip": {
+			NewWriter: func(w io.Writer) (io.WriteCloser, error) { return gzip.NewWriter(w), nil },
+			NewReader: func(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) },
+		},
+	},
+}
+
+// RegisterCompressor records the input compressor under the input name for MarshalCompressed and Unmarshal.
+// The gzip compressor of the standard library is registered as "gzip" by default.
+// An empty name, a compressor without NewWriter or NewReader, or a name registered twice will cause a panic.
+func RegisterCompressor(name string, compressor Compressor) {
+	if name == "" {
+		panic("fibHeap: compressor name must not be empty")
+	}
+	if compressor.NewWriter == nil || compressor.NewReader == nil {
+		panic(fmt.Sprintf("fibHeap: compressor %q must have both NewWriter and NewReader", name))
+	}
+
+	compressors.Lock()
+	defer compressors.Unlock()
+
+	if _, exists := compressors.byName[name]; exists {
+		panic(fmt.Sprintf("fibHeap: compressor %q is registered twice", name))
+	}
+	compressors.byName[name] = compressor
+}
+
+func lookupCompressor(name string) (Compressor, error) {
+	compressors.RLock()
+	compressor, exists := compressors.byName[name]
+	compressors.RUnlock()
+	if !exists {
+		return Compressor{}, fmt.Errorf("Compressor %q is not registered ", name)
+	}
+
+	return compressor, nil
+}
+
+// compress returns the input data compressed by the named compressor, or the data itself for an empty name.
+func compress(name string, data []byte) ([]byte, error) {
+	if name == "" {
+		return data, nil
+	}
+
+	compressor, err := lookupCompressor(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	w, err := compressor.NewWriter(&buffer)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// decompress is the reverse of compress. Any failure of decompression is reported as ErrCorrupted.
+func decompress(name string, data []byte) ([]byte, error) {
+	if name == "" {
+		return data, nil
+	}
+
+	compressor, err := lookupCompressor(name)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := compressor.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrCorrupted
+	}
+	defer r.Close()
+
+	decompressed, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, ErrCorrupted
+	}
+
+	return decompressed, nil
+}
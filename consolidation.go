@@ -0,0 +1,108 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"container/list"
+)
+
+// SetConsolidationBudget turns on the incremental consolidation of the heap, for the soft real-time workloads which cannot afford the pause
+// of ExtractMin consolidating a long list of roots at once, e.g. after many inserts.
+// Instead of consolidating all roots by ExtractMin, every Insert, ExtractMin, DecreaseKey and IncreaseKey links at most the input number of trees,
+// continuing from where the previous operation stopped, so the work of the consolidation is spread across the operations.
+// ExtractMin still scans the roots to find the new minimum, which stays short as long as the budget keeps up with the new roots,
+// i.e. a budget well above log2 of the number of values.
+// A budget of 0 turns off the incremental consolidation, which is the default. A negative budget will cause a panic.
+func (heap *FibHeap) SetConsolidationBudget(links int) {
+	if debugMode {
+		defer heap.guard.enter("SetConsolidationBudget")()
+	}
+
+	if links < 0 {
+		panic("fibHeap: budget of SetConsolidationBudget must not be negative")
+	}
+
+	heap.budget = links
+}
+
+// ConsolidationBudget returns the maximum number of links per operation of the incremental consolidation, or 0 if it is turned off.
+func (heap *FibHeap) ConsolidationBudget() int {
+	if debugMode {
+		defer heap.guard.enter("ConsolidationBudget")()
+	}
+
+	return heap.budget
+}
+
+// step consolidates the roots after the last consolidated one until the budget of links is used up.
+// The consolidated roots are kept in treeDegrees by their degrees, and a tree left unregistered by the budget is moved back to the unconsolidated roots.
+func (heap *FibHeap) step() {
+	if heap.observing() {
+		heap.observe("consolidate", heap.step)
+		return
+	}
+
+	heap.fitDegrees()
+	links := 0
+	for links < heap.budget {
+		e := heap.roots.Front()
+		if heap.done != nil {
+			e = heap.done.Next()
+		}
+		if e == nil {
+			return
+		}
+		if e.Value.(*node).dead {
+			heap.purge(e)
+			continue
+		}
+
+		tree := e
+		for links < heap.budget && heap.treeDegrees[tree.Value.(*node).degree] != nil {
+			anotherTree := heap.treeDegrees[tree.Value.(*node).degree]
+			heap.treeDegrees[tree.Value.(*node).degree] = nil
+			// A registered root may have died since, and no live tree is linked under a dead one.
+			if anotherTree.Value.(*node).dead {
+				heap.purge(anotherTree)
+				continue
+			}
+			// The minimum always stays a root, so that ExtractMin can remove it from the roots.
+			if anotherTree.Value.(*node) != heap.min &&
+				(tree.Value.(*node) == heap.min || tree.Value.(*node).key <= anotherTree.Value.(*node).key) {
+				heap.removeRoot(anotherTree)
+				heap.link(tree.Value.(*node), anotherTree.Value.(*node))
+			} else {
+				heap.removeRoot(tree)
+				heap.link(anotherTree.Value.(*node), tree.Value.(*node))
+				tree = anotherTree
+			}
+			links++
+		}
+
+		if heap.treeDegrees[tree.Value.(*node).degree] != nil {
+			if tree != e {
+				if heap.done == tree {
+					heap.done = tree.Prev()
+				}
+				heap.roots.MoveToBack(tree)
+			}
+			return
+		}
+		heap.treeDegrees[tree.Value.(*node).degree] = tree
+		tree.Value.(*node).position = tree.Value.(*node).degree
+		if tree == e {
+			heap.done = e
+		}
+	}
+}
+
+// removeRoot removes the root from the roots and from treeDegrees, keeping the last consolidated root valid.
+func (heap *FibHeap) removeRoot(e *list.Element) {
+	if heap.done == e {
+		heap.done = e.Prev()
+	}
+	if heap.registered(e) {
+		heap.treeDegrees[e.Value.(*node).position] = nil
+	}
+	heap.roots.Remove(e)
+}
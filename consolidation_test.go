@@ -0,0 +1,129 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"math/rand"
+)
+
+var _ = Describe("Tests of incremental consolidation", func() {
+	var heap, reference *FibHeap
+
+	BeforeEach(func() {
+		heap = NewFibHeap()
+		heap.SetConsolidationBudget(4)
+		reference = NewFibHeap()
+	})
+
+	AfterEach(func() {
+		heap = nil
+		reference = nil
+	})
+
+	It("Given a fibHeap with a consolidation budget, when extract the minimum after many inserts, it should only link the budget of trees.", func() {
+		Expect(heap.ConsolidationBudget()).Should(Equal(4))
+		heap.SetConsolidationBudget(0)
+		for i := 0; i < 1000; i++ {
+			heap.Insert(i, float64(i))
+		}
+		Expect(heap.roots.Len()).Should(Equal(1000))
+
+		heap.SetConsolidationBudget(4)
+		min, _ := heap.ExtractMin()
+		Expect(min).Should(BeEquivalentTo(0))
+		Expect(heap.roots.Len()).Should(Equal(999 - 4))
+
+		for i := 1; i < 1000; i++ {
+			min, _ := heap.ExtractMin()
+			Expect(min).Should(BeEquivalentTo(i))
+		}
+		Expect(heap.Num()).Should(BeEquivalentTo(0))
+		Expect(func() { heap.SetConsolidationBudget(-1) }).Should(Panic())
+	})
+
+	It("Given a fibHeap with a consolidation budget under random operations, it should behave the same as a normal fibHeap.", func() {
+		heap.SetLazyDelete(true)
+		random := rand.New(rand.NewSource(1880))
+		for i := 0; i < 20000; i++ {
+			tag := random.Intn(500)
+			// The keys are unique so that both heaps extract the same tags.
+			key := float64(random.Intn(1000)*500 + tag)
+			switch random.Intn(6) {
+			case 0, 1:
+				Expect(heap.Insert(tag, key) == nil).Should(Equal(reference.Insert(tag, key) == nil))
+			case 2:
+				Expect(heap.Delete(tag) == nil).Should(Equal(reference.Delete(tag) == nil))
+			case 3:
+				Expect(heap.DecreaseKey(tag, key) == nil).Should(Equal(reference.DecreaseKey(tag, key) == nil))
+			case 4:
+				Expect(heap.IncreaseKey(tag, key) == nil).Should(Equal(reference.IncreaseKey(tag, key) == nil))
+			case 5:
+				min, _ := heap.ExtractMin()
+				referenceMin, _ := reference.ExtractMin()
+				Expect(min).Should(Equal(referenceMin))
+			}
+			Expect(heap.Num()).Should(Equal(reference.Num()))
+		}
+
+		Expect(heap.Equal(reference)).Should(BeTrue())
+		heap.SetConsolidationBudget(0)
+		for reference.Num() != 0 {
+			_, key := heap.ExtractMin()
+			_, referenceKey := reference.ExtractMin()
+			Expect(key).Should(Equal(referenceKey))
+		}
+	})
+
+	It("Given a fibHeap with a consolidation budget in the lazy deletion mode under random bulk operations, it should keep the same values as a model.", func() {
+		heap.SetLazyDelete(true)
+		heap.SetConsolidationBudget(2)
+		random := rand.New(rand.NewSource(1880))
+		model := make(map[int]float64)
+		for i := 0; i < 20000; i++ {
+			tag := random.Intn(1000)
+			// The keys are unique so that the extracted tags are known.
+			key := float64(random.Intn(1000)*1000 + tag)
+			switch random.Intn(8) {
+			case 0, 1, 2:
+				if heap.Insert(tag, key) == nil {
+					model[tag] = key
+				}
+			case 3:
+				if heap.Delete(tag) == nil {
+					delete(model, tag)
+				}
+			case 4:
+				if heap.DecreaseKey(tag, key) == nil {
+					model[tag] = key
+				}
+			case 5:
+				for _, value := range heap.ExtractMinK(random.Intn(3) + 1) {
+					Expect(value).Should(BeNil())
+				}
+			case 6:
+				heap.DeleteWhere(func(tag interface{}, key float64, value Value) bool { return tag.(int)%97 == i%97 })
+			case 7:
+				heap.ExtractMax()
+			}
+			Expect(heap.Validate(false)).ShouldNot(HaveOccurred())
+
+			// The bulk operations are applied to the model by what is left in the heap.
+			min := math.Inf(1)
+			for tag, key := range model {
+				if heap.GetTag(tag) != key {
+					delete(model, tag)
+				} else if key < min {
+					min = key
+				}
+			}
+			Expect(heap.Num()).Should(BeEquivalentTo(len(model)))
+			if len(model) != 0 {
+				_, key := heap.Minimum()
+				Expect(key).Should(Equal(min))
+			}
+		}
+	})
+})
@@ -0,0 +1,60 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"context"
+)
+
+// contextCheckInterval is the number of values a bulk operation processes between two checks of its context.
+const contextCheckInterval = 1024
+
+// ImportContext is Import which gives up when the context is cancelled while the entries are being decoded,
+// and returns the error of the context. No entry is imported in that case.
+func (heap *FibHeap) ImportContext(ctx context.Context, entries []Entry) error {
+	if debugMode {
+		defer heap.guard.enter("ImportContext")()
+	}
+
+	return heap.importEntries(ctx, entries)
+}
+
+// DeleteWhereContext is DeleteWhere which gives up when the context is cancelled while the predicate is being called,
+// and returns the error of the context. No value is deleted in that case.
+func (heap *FibHeap) DeleteWhereContext(ctx context.Context, pred func(tag interface{}, key float64, value Value) bool) (int, error) {
+	if debugMode {
+		defer heap.guard.enter("DeleteWhereContext")()
+	}
+
+	return heap.deleteWhere(ctx, pred)
+}
+
+// UnionContext is Union which gives up when the context is cancelled, and returns the error of the context.
+// Both heaps are left as they were in that case: the values copied so far are deleted again, with the OnDelete hook called for them.
+// If the input heap is a FibHeap, the context is only checked while looking for duplicate tags, as moving the trees cannot be interrupted.
+func (heap *FibHeap) UnionContext(ctx context.Context, anotherHeap PriorityQueue) error {
+	if debugMode {
+		defer heap.guard.enter("UnionContext")()
+	}
+
+	return heap.union(ctx, anotherHeap)
+}
+
+// UnionIntoContext is UnionInto which gives up when the context is cancelled, and returns the error of the context.
+// The values copied so far are deleted again in that case, with the OnDelete hook called for them.
+func (heap *FibHeap) UnionIntoContext(ctx context.Context, anotherHeap PriorityQueue) error {
+	if debugMode {
+		defer heap.guard.enter("UnionIntoContext")()
+	}
+
+	return heap.unionInto(ctx, anotherHeap)
+}
+
+// cancelled returns the error of the context at every contextCheckInterval steps of a bulk operation, including the first one, and nil otherwise.
+func cancelled(ctx context.Context, step int) error {
+	if step%contextCheckInterval != 0 {
+		return nil
+	}
+
+	return ctx.Err()
+}
@@ -0,0 +1,95 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tests of context-aware bulk operations", func() {
+	var heap, another *FibHeap
+	var ctx context.Context
+	var cancel context.CancelFunc
+
+	BeforeEach(func() {
+		heap = NewFibHeap()
+		another = NewFibHeap()
+		for i := 0; i < 5000; i++ {
+			heap.Insert(i, float64(i))
+			another.Insert(-i-1, float64(i))
+		}
+		ctx, cancel = context.WithCancel(context.Background())
+	})
+
+	AfterEach(func() {
+		cancel()
+		heap = nil
+		another = nil
+	})
+
+	It("Given a context cancelled by the predicate, when call DeleteWhereContext api, it should delete nothing.", func() {
+		calls := 0
+		deleted, err := heap.DeleteWhereContext(ctx, func(tag interface{}, key float64, value Value) bool {
+			if calls++; calls == 2000 {
+				cancel()
+			}
+			return true
+		})
+		Expect(err).Should(Equal(context.Canceled))
+		Expect(deleted).Should(BeZero())
+		Expect(heap.Num()).Should(BeEquivalentTo(5000))
+
+		deleted, err = heap.DeleteWhereContext(context.Background(), func(tag interface{}, key float64, value Value) bool { return key < 100 })
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(deleted).Should(Equal(100))
+	})
+
+	It("Given a cancelled context, when call UnionContext and UnionIntoContext api, it should leave both heaps untouched.", func() {
+		cancel()
+		Expect(heap.UnionContext(ctx, another)).Should(Equal(context.Canceled))
+		Expect(heap.Num()).Should(BeEquivalentTo(5000))
+		Expect(another.Num()).Should(BeEquivalentTo(5000))
+
+		pairing := NewPairingHeap()
+		for i := 0; i < 5000; i++ {
+			pairing.Insert(-i-1, float64(i))
+		}
+		Expect(heap.UnionIntoContext(ctx, pairing)).Should(Equal(context.Canceled))
+		Expect(heap.UnionContext(ctx, pairing)).Should(Equal(context.Canceled))
+		Expect(heap.Num()).Should(BeEquivalentTo(5000))
+		Expect(pairing.Num()).Should(BeEquivalentTo(5000))
+
+		Expect(heap.UnionContext(context.Background(), pairing)).Should(Succeed())
+		Expect(heap.Num()).Should(BeEquivalentTo(10000))
+		Expect(pairing.Num()).Should(BeEquivalentTo(0))
+	})
+
+	It("Given a context cancelled in the middle of UnionIntoContext, it should delete the copied values again.", func() {
+		inserted := 0
+		heap = NewFibHeapWithHooks(Hooks{
+			OnInsert: func(tag interface{}, key float64, value Value) {
+				if inserted++; inserted == 2000 {
+					cancel()
+				}
+			},
+			OnDelete: func(tag interface{}, key float64, value Value) { inserted-- },
+		})
+		Expect(heap.UnionIntoContext(ctx, another)).Should(Equal(context.Canceled))
+		Expect(heap.Num()).Should(BeEquivalentTo(0))
+		Expect(inserted).Should(BeZero())
+		Expect(another.Num()).Should(BeEquivalentTo(5000))
+	})
+
+	It("Given a cancelled context, when call ImportContext api, it should import nothing.", func() {
+		entries, err := another.Export()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		cancel()
+		Expect(heap.ImportContext(ctx, entries)).Should(Equal(context.Canceled))
+		Expect(heap.Num()).Should(BeEquivalentTo(5000))
+		Expect(heap.ImportContext(context.Background(), entries)).Should(Succeed())
+		Expect(heap.Num()).Should(BeEquivalentTo(10000))
+	})
+})
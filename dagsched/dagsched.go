@@ -0,0 +1,170 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+// Package dagsched schedules the tasks of a dependency graph, e.g. the jobs of a build, in a topological order driven by priorities.
+// The tasks whose dependencies are all done are kept in a FibHeap keyed by their priorities, so the highest-priority ready task
+// is released in O(log n) amortized, while the numbers of unfinished dependencies of the waiting tasks are kept internally.
+package dagsched
+
+import (
+	"errors"
+	"math"
+
+	fibHeap "github.com/starwander/GoFibonacciHeap"
+)
+
+// State is the state of a task in a Scheduler.
+type State int
+
+const (
+	// Unknown is the state of a task which has not been added.
+	Unknown State = iota
+	// Waiting is the state of a task with unfinished dependencies.
+	Waiting
+	// Ready is the state of a task whose dependencies are all done, waiting to be released by Next.
+	Ready
+	// Running is the state of a task released by Next until it is marked by Done.
+	Running
+	// Finished is the state of a task marked by Done.
+	Finished
+)
+
+// Scheduler releases the added tasks one by one once all their dependencies are done, the highest priority first.
+// As the keys of FibHeap, a smaller priority is a higher one. Ready tasks with the same priority are released in no particular order.
+// Please note that all methods of Scheduler are not concurrent safe.
+type Scheduler struct {
+	ready *fibHeap.FibHeap
+	tasks map[interface{}]*task
+	left  int
+}
+
+type task struct {
+	priority   float64
+	state      State
+	pending    int
+	dependents []interface{}
+}
+
+// New creates an initialized Scheduler without any task.
+func New() *Scheduler {
+	scheduler := new(Scheduler)
+	scheduler.ready = fibHeap.NewFibHeap()
+	scheduler.tasks = make(map[interface{}]*task)
+
+	return scheduler
+}
+
+// Add declares the task of the input id with the priority, which depends on the tasks of the input dependencies.
+// The dependencies must have been added before, which guarantees the graph has no cycle, and the finished ones are ignored.
+// The task is ready at once if it has no unfinished dependency. The valid range of the priority is (-inf, +inf].
+// If the id is nil or already added, the priority is invalid, or any dependency is not added, an error will be returned.
+func (scheduler *Scheduler) Add(id interface{}, priority float64, dependencies ...interface{}) error {
+	if id == nil {
+		return errors.New("Input id is nil ")
+	}
+	if math.IsNaN(priority) || math.IsInf(priority, -1) {
+		return errors.New("Input priority is invalid ")
+	}
+	if _, exists := scheduler.tasks[id]; exists {
+		return errors.New("Duplicate task is not allowed ")
+	}
+	for _, dependency := range dependencies {
+		if _, exists := scheduler.tasks[dependency]; !exists {
+			return errors.New("Dependency is not found ")
+		}
+	}
+
+	t := &task{priority: priority, state: Waiting}
+	for _, dependency := range dependencies {
+		if d := scheduler.tasks[dependency]; d.state != Finished {
+			d.dependents = append(d.dependents, id)
+			t.pending++
+		}
+	}
+	scheduler.tasks[id] = t
+	scheduler.left++
+	if t.pending == 0 {
+		scheduler.release(id, t)
+	}
+
+	return nil
+}
+
+// Next releases the ready task of the highest priority, whose state becomes Running until it is marked by Done.
+// If no task is ready, nil and false will be returned.
+func (scheduler *Scheduler) Next() (interface{}, bool) {
+	id, _ := scheduler.ready.ExtractMin()
+	if id == nil {
+		return nil, false
+	}
+	scheduler.tasks[id].state = Running
+
+	return id, true
+}
+
+// Done marks the running task of the input id finished, so the tasks depending on it may become ready.
+// If the task is not running, an error will be returned.
+func (scheduler *Scheduler) Done(id interface{}) error {
+	t, exists := scheduler.tasks[id]
+	if !exists || t.state != Running {
+		return errors.New("Task is not running ")
+	}
+
+	t.state = Finished
+	scheduler.left--
+	for _, dependent := range t.dependents {
+		d := scheduler.tasks[dependent]
+		d.pending--
+		if d.pending == 0 {
+			scheduler.release(dependent, d)
+		}
+	}
+	t.dependents = nil
+
+	return nil
+}
+
+// SetPriority changes the priority of the waiting or ready task of the input id.
+// If the task is neither waiting nor ready, or the priority is invalid, an error will be returned.
+func (scheduler *Scheduler) SetPriority(id interface{}, priority float64) error {
+	if math.IsNaN(priority) || math.IsInf(priority, -1) {
+		return errors.New("Input priority is invalid ")
+	}
+	t, exists := scheduler.tasks[id]
+	if !exists || (t.state != Waiting && t.state != Ready) {
+		return errors.New("Task is neither waiting nor ready ")
+	}
+
+	if t.state == Ready && priority < t.priority {
+		scheduler.ready.DecreaseKey(id, priority)
+	} else if t.state == Ready && priority > t.priority {
+		scheduler.ready.IncreaseKey(id, priority)
+	}
+	t.priority = priority
+
+	return nil
+}
+
+// State returns the state of the task of the input id, or Unknown if it has not been added.
+func (scheduler *Scheduler) State(id interface{}) State {
+	if t, exists := scheduler.tasks[id]; exists {
+		return t.state
+	}
+
+	return Unknown
+}
+
+// Ready returns the number of ready tasks.
+func (scheduler *Scheduler) Ready() uint {
+	return scheduler.ready.Num()
+}
+
+// Remaining returns the number of tasks which are not finished, including the running ones.
+func (scheduler *Scheduler) Remaining() int {
+	return scheduler.left
+}
+
+// release makes the task ready.
+func (scheduler *Scheduler) release(id interface{}, t *task) {
+	t.state = Ready
+	scheduler.ready.Insert(id, t.priority)
+}
@@ -0,0 +1,112 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package dagsched
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"math/rand"
+)
+
+var _ = Describe("Tests of dagsched", func() {
+	var scheduler *Scheduler
+
+	BeforeEach(func() {
+		scheduler = New()
+	})
+
+	AfterEach(func() {
+		scheduler = nil
+	})
+
+	It("Given invalid inputs, when call Add, Done and SetPriority api, it should return errors.", func() {
+		Expect(scheduler.Add(nil, 1)).Should(HaveOccurred())
+		Expect(scheduler.Add("a", math.NaN())).Should(HaveOccurred())
+		Expect(scheduler.Add("a", math.Inf(-1))).Should(HaveOccurred())
+		Expect(scheduler.Add("a", 1, "b")).Should(HaveOccurred())
+		Expect(scheduler.State("a")).Should(Equal(Unknown))
+
+		Expect(scheduler.Add("a", 1)).ShouldNot(HaveOccurred())
+		Expect(scheduler.Add("a", 2)).Should(HaveOccurred())
+		Expect(scheduler.Done("a")).Should(HaveOccurred())
+		Expect(scheduler.Done("b")).Should(HaveOccurred())
+		Expect(scheduler.SetPriority("a", math.NaN())).Should(HaveOccurred())
+		Expect(scheduler.SetPriority("b", 1)).Should(HaveOccurred())
+
+		id, ok := scheduler.Next()
+		Expect([]interface{}{id, ok}).Should(Equal([]interface{}{"a", true}))
+		Expect(scheduler.SetPriority("a", 0)).Should(HaveOccurred())
+		Expect(scheduler.Done("a")).ShouldNot(HaveOccurred())
+		Expect(scheduler.Done("a")).Should(HaveOccurred())
+		id, ok = scheduler.Next()
+		Expect([]interface{}{id, ok}).Should(Equal([]interface{}{nil, false}))
+	})
+
+	It("Given a build graph, when release and finish the tasks, it should follow the dependencies and the priorities.", func() {
+		Expect(scheduler.Add("fetch", 5)).ShouldNot(HaveOccurred())
+		Expect(scheduler.Add("lint", 9)).ShouldNot(HaveOccurred())
+		Expect(scheduler.Add("generate", 3, "fetch")).ShouldNot(HaveOccurred())
+		Expect(scheduler.Add("compile", 1, "fetch", "generate")).ShouldNot(HaveOccurred())
+		Expect(scheduler.Add("docs", 8, "fetch")).ShouldNot(HaveOccurred())
+		Expect(scheduler.Add("test", 2, "compile")).ShouldNot(HaveOccurred())
+		Expect(scheduler.Ready()).Should(BeEquivalentTo(2))
+		Expect(scheduler.Remaining()).Should(Equal(6))
+		Expect(scheduler.State("compile")).Should(Equal(Waiting))
+
+		id, _ := scheduler.Next()
+		Expect(id).Should(Equal("fetch"))
+		Expect(scheduler.State("fetch")).Should(Equal(Running))
+		id, _ = scheduler.Next()
+		Expect(id).Should(Equal("lint"))
+		_, ok := scheduler.Next()
+		Expect(ok).Should(BeFalse())
+
+		Expect(scheduler.Done("fetch")).ShouldNot(HaveOccurred())
+		Expect(scheduler.State("fetch")).Should(Equal(Finished))
+		Expect(scheduler.State("generate")).Should(Equal(Ready))
+		Expect(scheduler.State("compile")).Should(Equal(Waiting))
+		Expect(scheduler.SetPriority("docs", 0)).ShouldNot(HaveOccurred())
+		Expect(scheduler.SetPriority("test", 10)).ShouldNot(HaveOccurred())
+
+		var order []interface{}
+		for {
+			id, ok := scheduler.Next()
+			if !ok {
+				break
+			}
+			order = append(order, id)
+			Expect(scheduler.Done(id)).ShouldNot(HaveOccurred())
+		}
+		Expect(order).Should(Equal([]interface{}{"docs", "generate", "compile", "test"}))
+		Expect(scheduler.Done("lint")).ShouldNot(HaveOccurred())
+		Expect(scheduler.Remaining()).Should(Equal(0))
+
+		// A task added later ignores its finished dependencies.
+		Expect(scheduler.Add("deploy", 1, "test", "lint")).ShouldNot(HaveOccurred())
+		Expect(scheduler.State("deploy")).Should(Equal(Ready))
+	})
+
+	It("Given a random graph, when run all tasks one at a time, it should release every task after its dependencies.", func() {
+		random := rand.New(rand.NewSource(1922))
+		dependencies := make(map[int][]interface{})
+		for i := 0; i < 1000; i++ {
+			for j := 0; j < 3 && i > 0; j++ {
+				dependencies[i] = append(dependencies[i], random.Intn(i))
+			}
+			Expect(scheduler.Add(i, random.Float64(), dependencies[i]...)).ShouldNot(HaveOccurred())
+		}
+
+		finished := make(map[interface{}]bool)
+		for scheduler.Remaining() != 0 {
+			id, ok := scheduler.Next()
+			Expect(ok).Should(BeTrue())
+			for _, dependency := range dependencies[id.(int)] {
+				Expect(finished[dependency]).Should(BeTrue())
+			}
+			finished[id] = true
+			Expect(scheduler.Done(id)).ShouldNot(HaveOccurred())
+		}
+		Expect(finished).Should(HaveLen(1000))
+	})
+})
@@ -0,0 +1,449 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// DaryHeap represents an array based d-ary Heap.
+// DaryHeap provides exactly the same methods as FibHeap so the two implementations can be swapped by changing the constructor only.
+// All keys are kept in one contiguous slice, which makes DaryHeap faster than the pointer based heaps for small to medium sizes.
+// Please note that all methods of DaryHeap are not concurrent safe.
+type DaryHeap struct {
+	arity int
+	items []daryItem
+	index map[interface{}]*daryNode
+}
+
+// daryItem keeps the key inline in the array so that sifting only touches contiguous memory.
+type daryItem struct {
+	key  float64
+	node *daryNode
+}
+
+type daryNode struct {
+	position int
+	tag      interface{}
+	value    Value
+}
+
+// NewDaryHeap creates an initialized d-ary Heap in which every node has at most arity children.
+// An arity smaller than 2 will cause a panic.
+func NewDaryHeap(arity int) *DaryHeap {
+	if arity < 2 {
+		panic("fibHeap: arity of DaryHeap must be at least 2")
+	}
+
+	heap := new(DaryHeap)
+	heap.arity = arity
+	heap.index = make(map[interface{}]*daryNode)
+
+	return heap
+}
+
+// Arity returns the maximum number of children of every node in the heap.
+func (heap *DaryHeap) Arity() int {
+	return heap.arity
+}
+
+// Num returns the total number of values in the heap.
+func (heap *DaryHeap) Num() uint {
+	return uint(len(heap.items))
+}
+
+// Insert pushes the input tag and key into the heap.
+// Try to insert a duplicate tag value will cause an error return.
+// The valid range of the key is (-inf, +inf].
+// Try to insert a -inf key value will cause an error return.
+func (heap *DaryHeap) Insert(tag interface{}, key float64) error {
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	return heap.insert(tag, key, nil)
+}
+
+// InsertValue pushes the input value into the heap.
+// The input value must implements the Value interface.
+// Try to insert a duplicate tag value will cause an error return.
+// The valid range of the value's key is (-inf, +inf].
+// Try to insert a -inf key value will cause an error return.
+func (heap *DaryHeap) InsertValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	return heap.insert(value.Tag(), value.Key(), value)
+}
+
+// Minimum returns the current minimum tag and key in the heap sorted by the key.
+// An empty heap will return nil and -inf.
+func (heap *DaryHeap) Minimum() (interface{}, float64) {
+	if len(heap.items) == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	return heap.items[0].node.tag, heap.items[0].key
+}
+
+// MinimumValue returns the current minimum value in the heap sorted by the key.
+// An empty heap will return nil.
+func (heap *DaryHeap) MinimumValue() Value {
+	if len(heap.items) == 0 {
+		return nil
+	}
+
+	return heap.items[0].node.value
+}
+
+// ExtractMin returns the current minimum tag and key in the heap and then extracts them from the heap.
+// An empty heap will return nil/-inf and extracts nothing.
+func (heap *DaryHeap) ExtractMin() (interface{}, float64) {
+	if len(heap.items) == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	key := heap.items[0].key
+	min := heap.remove(0)
+
+	return min.tag, key
+}
+
+// ExtractMinValue returns the current minimum value in the heap and then extracts it from the heap.
+// An empty heap will return nil and extracts nothing.
+func (heap *DaryHeap) ExtractMinValue() Value {
+	if len(heap.items) == 0 {
+		return nil
+	}
+
+	min := heap.remove(0)
+
+	return min.value
+}
+
+// Union moves all values of the input heap into the heap.
+// The input heap can be any implementation of PriorityQueue, and it is emptied afterwards so that no value is reachable from both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned and both heaps are left untouched.
+func (heap *DaryHeap) Union(anotherHeap PriorityQueue) error {
+	if err := heap.UnionInto(anotherHeap); err != nil {
+		return err
+	}
+
+	anotherHeap.reset()
+
+	return nil
+}
+
+// UnionInto merges copies of all values of the input heap into the heap.
+// The input heap can be any implementation of PriorityQueue, and it is left untouched, so the values are shared by both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned.
+func (heap *DaryHeap) UnionInto(anotherHeap PriorityQueue) error {
+	if err := checkUnion(heap, anotherHeap); err != nil {
+		return err
+	}
+
+	anotherHeap.each(func(tag interface{}, key float64, value Value) {
+		heap.insert(tag, key, value)
+	})
+
+	return nil
+}
+
+// DecreaseKey updates the tag in the heap by the input key.
+// If the input key has a larger key or -inf key, an error will be returned.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *DaryHeap) DecreaseKey(tag interface{}, key float64) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tag]; exists {
+		return heap.decreaseKey(node, node.value, key)
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// DecreaseKeyValue updates the value in the heap by the input value.
+// If the input value has a larger key or -inf key, an error will be returned.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *DaryHeap) DecreaseKeyValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	if math.IsInf(value.Key(), -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tagKey(value.Tag())]; exists {
+		return heap.decreaseKey(node, value, value.Key())
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// IncreaseKey updates the tag in the heap by the input key.
+// If the input key has a smaller key or -inf key, an error will be returned.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *DaryHeap) IncreaseKey(tag interface{}, key float64) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tag]; exists {
+		return heap.increaseKey(node, node.value, key)
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// IncreaseKeyValue updates the value in the heap by the input value.
+// If the input value has a smaller key or -inf key, an error will be returned.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *DaryHeap) IncreaseKeyValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	if math.IsInf(value.Key(), -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tagKey(value.Tag())]; exists {
+		return heap.increaseKey(node, value, value.Key())
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// Delete deletes the input tag in the heap.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *DaryHeap) Delete(tag interface{}) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	node, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Tag is not found ")
+	}
+
+	heap.remove(node.position)
+
+	return nil
+}
+
+// DeleteValue deletes the value in the heap by the input value.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *DaryHeap) DeleteValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	node, exists := heap.index[tagKey(value.Tag())]
+	if !exists {
+		return errors.New("Value is not found ")
+	}
+
+	heap.remove(node.position)
+
+	return nil
+}
+
+// GetTag searches and returns the key in the heap by the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *DaryHeap) GetTag(tag interface{}) (key float64) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		return heap.items[node.position].key
+	}
+
+	return math.Inf(-1)
+}
+
+// GetValue searches and returns the value in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *DaryHeap) GetValue(tag interface{}) (value Value) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		value = node.value
+	}
+
+	return
+}
+
+// ExtractTag searches and extracts the tag/key in the heap by the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *DaryHeap) ExtractTag(tag interface{}) (key float64) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		key = heap.items[node.position].key
+		heap.remove(node.position)
+		return
+	}
+
+	return math.Inf(-1)
+}
+
+// ExtractValue searches and extracts the value in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *DaryHeap) ExtractValue(tag interface{}) (value Value) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		value = node.value
+		heap.remove(node.position)
+		return
+	}
+
+	return nil
+}
+
+// String provides some basic debug information of the heap.
+// It returns the total number, arity, index size and current minimum value of the heap.
+// It also returns all keys in the order of the underlying array.
+func (heap *DaryHeap) String() string {
+	var buffer bytes.Buffer
+
+	if len(heap.items) != 0 {
+		buffer.WriteString(fmt.Sprintf("Total number: %d, Arity: %d, Index size: %d,\n", len(heap.items), heap.arity, len(heap.index)))
+		buffer.WriteString(fmt.Sprintf("Current minimun: key(%f), tag(%v), value(%v),\n", heap.items[0].key, heap.items[0].node.tag, heap.items[0].node.value))
+		buffer.WriteString(fmt.Sprintf("Heap detail:\n"))
+		buffer.WriteString(fmt.Sprintf("< "))
+		for _, item := range heap.items {
+			buffer.WriteString(fmt.Sprintf("%f ", item.key))
+		}
+		buffer.WriteString(fmt.Sprintf("> \n"))
+	} else {
+		buffer.WriteString(fmt.Sprintf("Heap is empty.\n"))
+	}
+
+	return buffer.String()
+}
+
+func (heap *DaryHeap) reset() {
+	*heap = *NewDaryHeap(heap.arity)
+}
+
+func (heap *DaryHeap) each(fn func(tag interface{}, key float64, value Value)) {
+	for _, item := range heap.items {
+		fn(item.node.tag, item.key, item.node.value)
+	}
+}
+
+func (heap *DaryHeap) insert(tag interface{}, key float64, value Value) error {
+	tag = tagKey(tag)
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if _, exists := heap.index[tag]; exists {
+		return errors.New("Duplicate tag is not allowed ")
+	}
+
+	node := new(daryNode)
+	node.position = len(heap.items)
+	node.tag = tag
+	node.value = value
+
+	heap.items = append(heap.items, daryItem{key, node})
+	heap.index[node.tag] = node
+	heap.up(node.position)
+
+	return nil
+}
+
+func (heap *DaryHeap) remove(position int) *daryNode {
+	node := heap.items[position].node
+	last := len(heap.items) - 1
+	if position != last {
+		heap.swap(position, last)
+	}
+	heap.items[last] = daryItem{}
+	heap.items = heap.items[:last]
+	delete(heap.index, node.tag)
+
+	if position != last {
+		heap.down(position)
+		heap.up(position)
+	}
+
+	return node
+}
+
+func (heap *DaryHeap) decreaseKey(n *daryNode, value Value, key float64) error {
+	if key >= heap.items[n.position].key {
+		return errors.New("New key is not smaller than current key ")
+	}
+
+	heap.items[n.position].key = key
+	n.value = value
+	heap.up(n.position)
+
+	return nil
+}
+
+func (heap *DaryHeap) increaseKey(n *daryNode, value Value, key float64) error {
+	if key <= heap.items[n.position].key {
+		return errors.New("New key is not larger than current key ")
+	}
+
+	heap.items[n.position].key = key
+	n.value = value
+	heap.down(n.position)
+
+	return nil
+}
+
+func (heap *DaryHeap) up(position int) {
+	for position > 0 {
+		parent := (position - 1) / heap.arity
+		if heap.items[parent].key <= heap.items[position].key {
+			break
+		}
+		heap.swap(parent, position)
+		position = parent
+	}
+}
+
+func (heap *DaryHeap) down(position int) {
+	for {
+		first := position*heap.arity + 1
+		if first >= len(heap.items) {
+			break
+		}
+
+		smallest := first
+		for child := first + 1; child < first+heap.arity && child < len(heap.items); child++ {
+			if heap.items[child].key < heap.items[smallest].key {
+				smallest = child
+			}
+		}
+		if heap.items[position].key <= heap.items[smallest].key {
+			break
+		}
+		heap.swap(position, smallest)
+		position = smallest
+	}
+}
+
+func (heap *DaryHeap) swap(i, j int) {
+	heap.items[i], heap.items[j] = heap.items[j], heap.items[i]
+	heap.items[i].node.position = i
+	heap.items[j].node.position = j
+}
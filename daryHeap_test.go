@@ -0,0 +1,115 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"fmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/starwander/GoFibonacciHeap/heaptest"
+	"math"
+	"math/rand"
+	"time"
+)
+
+var _ = Describe("Tests of daryHeap", func() {
+	var (
+		heap        *DaryHeap
+		anotherHeap *DaryHeap
+	)
+
+	BeforeEach(func() {
+		heap = NewDaryHeap(4)
+		anotherHeap = NewDaryHeap(4)
+	})
+
+	AfterEach(func() {
+		heap = nil
+		anotherHeap = nil
+	})
+
+	It("Given an arity smaller than 2, when call NewDaryHeap, it should panic.", func() {
+		Expect(func() { NewDaryHeap(1) }).Should(Panic())
+		Expect(NewDaryHeap(2).Arity()).Should(Equal(2))
+	})
+
+	It("Given an empty daryHeap, when call Minimum and ExtractMin api, it should return nil.", func() {
+		tag, key := heap.Minimum()
+		Expect(tag).Should(BeNil())
+		Expect(key).Should(BeEquivalentTo(math.Inf(-1)))
+		Expect(heap.ExtractMinValue()).Should(BeNil())
+		Expect(heap.String()).Should(BeEquivalentTo("Heap is empty.\n"))
+	})
+
+	It("Given a empty daryHeap, when call Insert api with invalid inputs, it should return error.", func() {
+		Expect(heap.Insert(nil, 0.0)).Should(HaveOccurred())
+		Expect(heap.InsertValue(nil)).Should(HaveOccurred())
+		Expect(heap.Insert(1000, math.Inf(-1))).Should(HaveOccurred())
+		Expect(heap.Insert(1000, 0.0)).ShouldNot(HaveOccurred())
+		Expect(heap.Insert(1000, 1.0)).Should(HaveOccurred())
+		Expect(heap.Num()).Should(BeEquivalentTo(1))
+	})
+
+	It("Given daryHeaps of different arities inserted multiple values, when call ExtractMinValue api, it should extract the values sorted by key.", func() {
+		rand.Seed(time.Now().Unix())
+		for arity := 2; arity <= 8; arity++ {
+			heap = NewDaryHeap(arity)
+			for i := 0; i < 2000; i++ {
+				demo := new(demoStruct)
+				demo.tag = i
+				demo.key = rand.Float64()
+				demo.value = fmt.Sprint(demo.key)
+				Expect(heap.InsertValue(demo)).ShouldNot(HaveOccurred())
+			}
+
+			lastKey := heap.MinimumValue().(*demoStruct).key
+			for i := 0; i < 2000; i++ {
+				extracted := heap.ExtractMinValue().(*demoStruct)
+				Expect(extracted.key).Should(BeNumerically(">=", lastKey))
+				Expect(heap.Num()).Should(BeEquivalentTo(1999 - i))
+				lastKey = extracted.key
+			}
+		}
+	})
+
+	It("Given a daryHeap inserted multiple values, when call DecreaseKey, IncreaseKey and Delete api, it should reorder the values.", func() {
+		for i := 0; i < 1000; i++ {
+			heap.Insert(i, float64(i+1000))
+		}
+
+		Expect(heap.DecreaseKey(500, 1500)).Should(HaveOccurred())
+		Expect(heap.IncreaseKey(500, 1000)).Should(HaveOccurred())
+		Expect(heap.DecreaseKey(500, -1)).ShouldNot(HaveOccurred())
+		Expect(heap.IncreaseKey(0, 5000)).ShouldNot(HaveOccurred())
+		Expect(heap.Delete(1)).ShouldNot(HaveOccurred())
+		Expect(heap.Delete(1)).Should(HaveOccurred())
+
+		tag, key := heap.ExtractMin()
+		Expect(tag).Should(BeEquivalentTo(500))
+		Expect(key).Should(BeEquivalentTo(-1))
+		tag, _ = heap.ExtractMin()
+		Expect(tag).Should(BeEquivalentTo(2))
+		Expect(heap.GetTag(0)).Should(BeEquivalentTo(5000))
+		Expect(heap.ExtractTag(0)).Should(BeEquivalentTo(5000))
+		Expect(heap.Num()).Should(BeEquivalentTo(996))
+	})
+
+	It("Given two daryHeaps, when call Union api, it should move all values of the input heap in and empty it.", func() {
+		for i := 0; i < 100; i++ {
+			heap.Insert(i, float64(100-i))
+			anotherHeap.InsertValue(&demoStruct{i + 100, float64(i), fmt.Sprint(i)})
+		}
+
+		Expect(heap.Union(anotherHeap)).ShouldNot(HaveOccurred())
+		Expect(heap.Num()).Should(BeEquivalentTo(200))
+		Expect(anotherHeap.Num()).Should(BeEquivalentTo(0))
+		Expect(heap.MinimumValue().(*demoStruct).tag).Should(Equal(100))
+		Expect(heap.Union(heap)).Should(HaveOccurred())
+	})
+
+	It("Given a daryHeap, when run the differential tester, it should never diverge from the reference model.", func() {
+		for seed := int64(0); seed < 20; seed++ {
+			Expect(heaptest.Run(NewDaryHeap(2+int(seed%4)), seed)).ShouldNot(HaveOccurred())
+		}
+	})
+})
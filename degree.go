@@ -0,0 +1,107 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"container/list"
+)
+
+// MaxDegree returns the upper bound of the degree of any node in the heap, i.e. of the number of children of the node.
+// A node of degree k roots a tree of at least F(k+2) nodes, where F is the Fibonacci sequence, so the bound is the largest k
+// with F(k+2) <= n for the n nodes of the heap, which is at most ⌊log_φ(n)⌋ where φ is the golden ratio.
+// The dead nodes of the lazy deletion mode are counted as nodes.
+func (heap *FibHeap) MaxDegree() uint {
+	if debugMode {
+		defer heap.guard.enter("MaxDegree")()
+	}
+
+	return maxDegree(heap.num + heap.dead)
+}
+
+// maxDegree returns the largest k with F(k+2) <= n, or 0 if n is 0.
+func maxDegree(n uint) uint {
+	var k uint
+	for a, b := uint(1), uint(2); b <= n && b > a; a, b = b, a+b {
+		k++
+	}
+
+	return k
+}
+
+// fitDegrees grows treeDegrees to exactly one slot per degree up to the bound of the current number of nodes.
+// It never shrinks, so the positions of the registered roots stay valid.
+func (heap *FibHeap) fitDegrees() {
+	if size := int(maxDegree(heap.num+heap.dead)) + 1; size > len(heap.treeDegrees) {
+		heap.treeDegrees = append(heap.treeDegrees, make([]*list.Element, size-len(heap.treeDegrees))...)
+	}
+}
+
+// registered reports whether the root is the tree kept in treeDegrees for its degree.
+func (heap *FibHeap) registered(e *list.Element) bool {
+	position := e.Value.(*node).position
+	return position < uint(len(heap.treeDegrees)) && heap.treeDegrees[position] == e
+}
+
+// DegreeHistogram returns the numbers of the nodes of the heap by their degrees, i.e. the i-th element is the number of nodes of i children,
+// and the last element is the one of the largest degree, or an empty slice if the heap is empty.
+// The dead nodes of the lazy deletion mode are counted as they are still in the trees. It runs in O(n).
+func (heap *FibHeap) DegreeHistogram() []int {
+	if debugMode {
+		defer heap.guard.enter("DegreeHistogram")()
+	}
+
+	histogram := []int{}
+	heap.walkDepth(func(n *node, depth int) {
+		for len(histogram) <= int(n.degree) {
+			histogram = append(histogram, 0)
+		}
+		histogram[n.degree]++
+	})
+
+	return histogram
+}
+
+// MaxTreeDepth returns the number of the nodes on the longest path from a root down to a leaf, or 0 if the heap is empty.
+// The cuts of DecreaseKey, IncreaseKey and Delete can leave the trees deeper than the binomial trees of the consolidation,
+// up to O(n) in the worst case, so a depth far above MaxDegree indicates degenerate trees. It runs in O(n).
+func (heap *FibHeap) MaxTreeDepth() int {
+	if debugMode {
+		defer heap.guard.enter("MaxTreeDepth")()
+	}
+
+	max := 0
+	heap.walkDepth(func(n *node, depth int) {
+		if depth > max {
+			max = depth
+		}
+	})
+
+	return max
+}
+
+// walkDepth calls fn with every node of the heap and its depth, counted from 1 for the roots.
+// Unlike walk, it includes the dead nodes, and keeps its own stack instead of recursing, as a degenerate tree can be as deep as the number of nodes.
+func (heap *FibHeap) walkDepth(fn func(n *node, depth int)) {
+	type frame struct {
+		e     *list.Element
+		depth int
+	}
+
+	var stack []frame
+	if e := heap.roots.Front(); e != nil {
+		stack = append(stack, frame{e, 1})
+	}
+	for len(stack) != 0 {
+		top := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		n := top.e.Value.(*node)
+		fn(n, top.depth)
+
+		if next := top.e.Next(); next != nil {
+			stack = append(stack, frame{next, top.depth})
+		}
+		if child := n.children.Front(); child != nil {
+			stack = append(stack, frame{child, top.depth + 1})
+		}
+	}
+}
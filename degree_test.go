@@ -0,0 +1,139 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"container/list"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"math/rand"
+)
+
+// subtreeSize returns the number of nodes of the trees, and checks the degree bound of every node on the way.
+func subtreeSize(trees *list.List, bound uint) uint {
+	var size uint
+	for e := trees.Front(); e != nil; e = e.Next() {
+		n := e.Value.(*node)
+		Expect(n.degree).Should(BeEquivalentTo(n.children.Len()))
+		Expect(n.degree).Should(BeNumerically("<=", bound))
+		children := subtreeSize(n.children, bound)
+		Expect(children + 1).Should(BeNumerically(">=", fibonacci(n.degree+2)))
+		size += children + 1
+	}
+
+	return size
+}
+
+func fibonacci(k uint) uint {
+	a, b := uint(0), uint(1)
+	for ; k != 0; k-- {
+		a, b = b, a+b
+	}
+
+	return a
+}
+
+var _ = Describe("Tests of degree bound", func() {
+	It("Given numbers of nodes, when compute the degree bound, it should be the largest k with F(k+2) <= n and at most floor(log_phi(n)).", func() {
+		expected := map[uint]uint{0: 0, 1: 0, 2: 1, 3: 2, 4: 2, 5: 3, 7: 3, 8: 4, 1000000: 28}
+		for n, k := range expected {
+			Expect(maxDegree(n)).Should(Equal(k))
+		}
+
+		phi := (1 + math.Sqrt(5)) / 2
+		for n := uint(1); n < 100000; n = n*3 + 1 {
+			Expect(float64(maxDegree(n))).Should(BeNumerically("<=", math.Floor(math.Log(float64(n))/math.Log(phi))))
+			Expect(fibonacci(maxDegree(n) + 2)).Should(BeNumerically("<=", n))
+			Expect(fibonacci(maxDegree(n) + 3)).Should(BeNumerically(">", n))
+		}
+		Expect(maxDegree(^uint(0))).Should(BeNumerically(">", 0))
+	})
+
+	It("Given a fibHeap under random operations, when check every node, it should never exceed MaxDegree.", func() {
+		heap := NewFibHeap()
+		Expect(heap.MaxDegree()).Should(BeEquivalentTo(0))
+		heap.SetLazyDelete(true)
+		random := rand.New(rand.NewSource(1909))
+		for round := 0; round < 20; round++ {
+			heap.SetConsolidationBudget(round % 3)
+			for i := 0; i < 2000; i++ {
+				tag := random.Intn(2000)
+				switch random.Intn(5) {
+				case 0, 1:
+					heap.Insert(tag, float64(random.Intn(100000)))
+				case 2:
+					heap.Delete(tag)
+				case 3:
+					heap.DecreaseKey(tag, float64(random.Intn(100000)))
+				case 4:
+					heap.ExtractMin()
+				}
+			}
+
+			Expect(subtreeSize(heap.roots, heap.MaxDegree())).Should(Equal(heap.num + heap.dead))
+		}
+	})
+	It("Given a fibHeap, when call DegreeHistogram and MaxTreeDepth apis, it should describe the shape of the trees.", func() {
+		heap := NewFibHeap()
+		Expect(heap.DegreeHistogram()).Should(BeEmpty())
+		Expect(heap.MaxTreeDepth()).Should(Equal(0))
+
+		for i := 0; i < 17; i++ {
+			heap.Insert(i, float64(i))
+		}
+		Expect(heap.DegreeHistogram()).Should(Equal([]int{17}))
+		Expect(heap.MaxTreeDepth()).Should(Equal(1))
+
+		// The consolidation makes a binomial tree of 16 nodes.
+		heap.ExtractMin()
+		Expect(heap.DegreeHistogram()).Should(Equal([]int{8, 4, 2, 1, 1}))
+		Expect(heap.MaxTreeDepth()).Should(Equal(5))
+
+		heap.IncreaseKey(1, 100)
+		Expect(heap.DegreeHistogram()).Should(Equal([]int{9, 4, 2, 1}))
+		Expect(heap.MaxTreeDepth()).Should(Equal(4))
+	})
+
+	It("Given a fibHeap under random operations, when call DegreeHistogram and MaxTreeDepth apis, it should agree with a recursive walk.", func() {
+		heap := NewFibHeap()
+		heap.SetLazyDelete(true)
+		random := rand.New(rand.NewSource(1915))
+		for i := 0; i < 20000; i++ {
+			tag := random.Intn(2000)
+			switch random.Intn(5) {
+			case 0, 1:
+				heap.Insert(tag, float64(random.Intn(100000)))
+			case 2:
+				heap.Delete(tag)
+			case 3:
+				heap.IncreaseKey(tag, float64(random.Intn(100000)))
+			case 4:
+				heap.ExtractMin()
+			}
+		}
+
+		var depth func(trees *list.List) int
+		histogram := make([]int, heap.MaxDegree()+1)
+		depth = func(trees *list.List) int {
+			max := 0
+			for e := trees.Front(); e != nil; e = e.Next() {
+				histogram[e.Value.(*node).degree]++
+				if d := depth(e.Value.(*node).children) + 1; d > max {
+					max = d
+				}
+			}
+			return max
+		}
+		Expect(heap.MaxTreeDepth()).Should(Equal(depth(heap.roots)))
+		for len(histogram) != 0 && histogram[len(histogram)-1] == 0 {
+			histogram = histogram[:len(histogram)-1]
+		}
+		Expect(heap.DegreeHistogram()).Should(Equal(histogram))
+		sum := 0
+		for _, count := range histogram {
+			sum += count
+		}
+		Expect(sum).Should(BeEquivalentTo(heap.num + heap.dead))
+	})
+})
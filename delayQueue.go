@@ -0,0 +1,162 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// DelayQueue delivers values through a channel when their ready time arrives, comparable to the DelayQueue of Java.
+// The values are kept in a FibHeap sorted by their ready time, and a single goroutine with a single timer delivers them.
+// Values with the same ready time are delivered in no particular order.
+// All methods of DelayQueue are concurrent safe.
+type DelayQueue struct {
+	mutex  sync.Mutex
+	heap   *FibHeap
+	base   time.Time
+	c      chan Value
+	wakeup chan struct{}
+	done   chan struct{}
+	closed bool
+}
+
+// NewDelayQueue creates an initialized DelayQueue and starts its delivering goroutine.
+// Close must be called to stop the goroutine when the queue is no longer used.
+func NewDelayQueue() *DelayQueue {
+	queue := new(DelayQueue)
+	queue.heap = NewFibHeap()
+	queue.base = time.Now()
+	queue.c = make(chan Value)
+	queue.wakeup = make(chan struct{}, 1)
+	queue.done = make(chan struct{})
+
+	go queue.deliver()
+
+	return queue
+}
+
+// C returns the channel which delivers the values when their ready time arrives.
+// The channel is closed after the queue is closed.
+func (queue *DelayQueue) C() <-chan Value {
+	return queue.c
+}
+
+// Num returns the number of values waiting for their ready time.
+func (queue *DelayQueue) Num() uint {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	return queue.heap.Num()
+}
+
+// Offer puts the input value into the queue, which will be delivered at readyAt.
+// A readyAt in the past makes the value ready immediately.
+// The key of the value is not used. Try to offer a duplicate tag value will cause an error return.
+// Try to offer to a closed queue will cause an error return.
+func (queue *DelayQueue) Offer(value Value, readyAt time.Time) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	if queue.closed {
+		return errors.New("Queue is closed ")
+	}
+
+	if err := queue.heap.insert(value.Tag(), float64(readyAt.Sub(queue.base)), value); err != nil {
+		return err
+	}
+
+	select {
+	case queue.wakeup <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// Remove removes the value of the input tag which has not been delivered yet.
+// If the input tag is not existed in the queue, an error will be returned.
+func (queue *DelayQueue) Remove(tag interface{}) error {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	return queue.heap.Delete(tag)
+}
+
+// Close stops the delivering goroutine and closes the channel.
+// All values which have not been delivered are dropped.
+func (queue *DelayQueue) Close() {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	if !queue.closed {
+		queue.closed = true
+		close(queue.done)
+	}
+}
+
+func (queue *DelayQueue) deliver() {
+	defer close(queue.c)
+
+	timer := time.NewTimer(time.Hour)
+	if !timer.Stop() {
+		<-timer.C
+	}
+
+	for {
+		queue.mutex.Lock()
+		value, wait, ready := queue.next()
+		queue.mutex.Unlock()
+
+		if ready {
+			select {
+			case queue.c <- value:
+			case <-queue.done:
+				return
+			}
+			continue
+		}
+
+		if wait < 0 {
+			select {
+			case <-queue.wakeup:
+			case <-queue.done:
+				return
+			}
+			continue
+		}
+
+		timer.Reset(wait)
+		select {
+		case <-timer.C:
+		case <-queue.wakeup:
+			if !timer.Stop() {
+				<-timer.C
+			}
+		case <-queue.done:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// next extracts the minimum value if it is ready.
+// Otherwise it returns the duration to wait, which is negative if the queue is empty.
+func (queue *DelayQueue) next() (value Value, wait time.Duration, ready bool) {
+	if queue.heap.Num() == 0 {
+		return nil, -1, false
+	}
+
+	_, key := queue.heap.Minimum()
+	wait = time.Duration(key) - time.Since(queue.base)
+	if wait > 0 {
+		return nil, wait, false
+	}
+
+	return queue.heap.ExtractMinValue(), 0, true
+}
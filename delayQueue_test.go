@@ -0,0 +1,77 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"time"
+)
+
+var _ = Describe("Tests of delayQueue", func() {
+	var (
+		queue *DelayQueue
+	)
+
+	BeforeEach(func() {
+		queue = NewDelayQueue()
+	})
+
+	AfterEach(func() {
+		queue.Close()
+		queue = nil
+	})
+
+	It("Given a delayQueue, when call Offer api with invalid inputs, it should return error.", func() {
+		Expect(queue.Offer(nil, time.Now())).Should(HaveOccurred())
+		Expect(queue.Offer(&demoStruct{1, 1, "1"}, time.Now().Add(time.Hour))).ShouldNot(HaveOccurred())
+		Expect(queue.Offer(&demoStruct{1, 1, "1"}, time.Now().Add(time.Hour))).Should(HaveOccurred())
+		Expect(queue.Num()).Should(BeEquivalentTo(1))
+
+		queue.Close()
+		Expect(queue.Offer(&demoStruct{2, 2, "2"}, time.Now())).Should(HaveOccurred())
+		Eventually(queue.C()).Should(BeClosed())
+	})
+
+	It("Given a delayQueue with values offered in random order, when read from the channel, it should deliver them in the order of ready time.", func() {
+		now := time.Now()
+		for _, i := range []int{3, 1, 4, 0, 2} {
+			Expect(queue.Offer(&demoStruct{i, 0, ""}, now.Add(time.Duration(i*20)*time.Millisecond))).ShouldNot(HaveOccurred())
+		}
+
+		for i := 0; i < 5; i++ {
+			var value Value
+			Eventually(queue.C()).Should(Receive(&value))
+			Expect(value.Tag()).Should(BeEquivalentTo(i))
+			Expect(time.Now()).Should(BeTemporally(">=", now.Add(time.Duration(i*20)*time.Millisecond)))
+		}
+		Expect(queue.Num()).Should(BeEquivalentTo(0))
+	})
+
+	It("Given a delayQueue waiting for a value, when offer an earlier one, it should deliver the earlier one first.", func() {
+		now := time.Now()
+		queue.Offer(&demoStruct{1, 0, ""}, now.Add(200*time.Millisecond))
+		time.Sleep(10 * time.Millisecond)
+		queue.Offer(&demoStruct{2, 0, ""}, now.Add(20*time.Millisecond))
+
+		var value Value
+		Eventually(queue.C()).Should(Receive(&value))
+		Expect(value.Tag()).Should(BeEquivalentTo(2))
+		Expect(time.Now()).Should(BeTemporally("<", now.Add(200*time.Millisecond)))
+		Eventually(queue.C()).Should(Receive(&value))
+		Expect(value.Tag()).Should(BeEquivalentTo(1))
+	})
+
+	It("Given a delayQueue with values, when call Remove api, it should not deliver the removed value.", func() {
+		now := time.Now()
+		queue.Offer(&demoStruct{1, 0, ""}, now.Add(20*time.Millisecond))
+		queue.Offer(&demoStruct{2, 0, ""}, now.Add(40*time.Millisecond))
+		Expect(queue.Remove(1)).ShouldNot(HaveOccurred())
+		Expect(queue.Remove(1)).Should(HaveOccurred())
+
+		var value Value
+		Eventually(queue.C()).Should(Receive(&value))
+		Expect(value.Tag()).Should(BeEquivalentTo(2))
+		Consistently(queue.C(), 50*time.Millisecond).ShouldNot(Receive())
+	})
+})
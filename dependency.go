@@ -0,0 +1,168 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"errors"
+)
+
+// AddDependency declares that the input tag is blocked by the blocker tag, e.g. a job which cannot run before the job it depends on,
+// and turns on the priority inheritance between them: whenever the key of the tag is decreased below the key of the blocker,
+// by DecreaseKey or any other method decreasing a key, the key of the blocker is decreased to the same key as well,
+// and so on through the blockers of the blocker, so that the whole chain blocking a boosted tag is pulled forward with it.
+// The key of the blocker is inherited at once if it is larger than the key of the tag. The inherited keys are kept when the tag is increased or removed.
+// A blocker pulled forward by inheritance has the same key as the tag, so the order between them is arbitrary.
+// The dependencies of a tag are dropped once it is extracted or deleted. They are not kept by Clone, Union, Export, Marshal or the write-ahead log.
+// If either tag does not exist in the heap, or the dependency would make a cycle, an error will be returned.
+// Adding an existing dependency does nothing.
+func (heap *FibHeap) AddDependency(tag, blocker interface{}) error {
+	if debugMode {
+		defer heap.guard.enter("AddDependency")()
+	}
+
+	tag, blocker = tagKey(tag), tagKey(blocker)
+	if tag == nil || blocker == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	n, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Value is not found ")
+	}
+	b, exists := heap.index[blocker]
+	if !exists {
+		return errors.New("Blocker is not found ")
+	}
+
+	if _, exists := heap.blockers[tag][blocker]; exists {
+		return nil
+	}
+	if heap.blocks(tag, blocker) {
+		return errors.New("Dependency cycle is not allowed ")
+	}
+
+	if heap.blockers == nil {
+		heap.blockers = make(map[interface{}]map[interface{}]struct{})
+		heap.dependents = make(map[interface{}]map[interface{}]struct{})
+	}
+	addEdge(heap.blockers, tag, blocker)
+	addEdge(heap.dependents, blocker, tag)
+
+	if heap.keyOf(b) > heap.keyOf(n) {
+		heap.decreaseKey(b, b.value, heap.keyOf(n))
+	}
+
+	return nil
+}
+
+// RemoveDependency removes the dependency of the input tag on the blocker tag, so the blocker no longer inherits the keys of the tag.
+// The keys inherited so far are kept. If the dependency does not exist, an error will be returned.
+func (heap *FibHeap) RemoveDependency(tag, blocker interface{}) error {
+	if debugMode {
+		defer heap.guard.enter("RemoveDependency")()
+	}
+
+	tag, blocker = tagKey(tag), tagKey(blocker)
+	if _, exists := heap.blockers[tag][blocker]; !exists {
+		return errors.New("Dependency is not found ")
+	}
+
+	removeEdge(heap.blockers, tag, blocker)
+	removeEdge(heap.dependents, blocker, tag)
+
+	return nil
+}
+
+// Blockers returns the tags directly blocking the input tag in no particular order, see AddDependency.
+// A tag which does not exist or has no blocker will return nil.
+func (heap *FibHeap) Blockers(tag interface{}) []interface{} {
+	if debugMode {
+		defer heap.guard.enter("Blockers")()
+	}
+
+	return edges(heap.blockers, tagKey(tag))
+}
+
+// Dependents returns the tags directly blocked by the input tag in no particular order, see AddDependency.
+// A tag which does not exist or blocks no tag will return nil.
+func (heap *FibHeap) Dependents(tag interface{}) []interface{} {
+	if debugMode {
+		defer heap.guard.enter("Dependents")()
+	}
+
+	return edges(heap.dependents, tagKey(tag))
+}
+
+// blocks reports whether the tag is the other tag or blocks it, directly or through other blockers.
+func (heap *FibHeap) blocks(tag, other interface{}) bool {
+	if tag == other {
+		return true
+	}
+
+	visited := map[interface{}]bool{other: true}
+	pending := []interface{}{other}
+	for len(pending) != 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		for blocker := range heap.blockers[current] {
+			if blocker == tag {
+				return true
+			}
+			if !visited[blocker] {
+				visited[blocker] = true
+				pending = append(pending, blocker)
+			}
+		}
+	}
+
+	return false
+}
+
+// inherit decreases the keys of the blockers of the node which are larger than its key, which in turn propagates to their blockers.
+func (heap *FibHeap) inherit(n *node) {
+	key := heap.keyOf(n)
+	for blocker := range heap.blockers[n.tag] {
+		if b := heap.index[blocker]; heap.keyOf(b) > key {
+			heap.decreaseKey(b, b.value, key)
+		}
+	}
+}
+
+// unblock drops all dependencies of the removed tag.
+func (heap *FibHeap) unblock(tag interface{}) {
+	if heap.blockers == nil {
+		return
+	}
+
+	for blocker := range heap.blockers[tag] {
+		removeEdge(heap.dependents, blocker, tag)
+	}
+	for dependent := range heap.dependents[tag] {
+		removeEdge(heap.blockers, dependent, tag)
+	}
+	delete(heap.blockers, tag)
+	delete(heap.dependents, tag)
+}
+
+func addEdge(graph map[interface{}]map[interface{}]struct{}, from, to interface{}) {
+	if graph[from] == nil {
+		graph[from] = make(map[interface{}]struct{})
+	}
+	graph[from][to] = struct{}{}
+}
+
+func removeEdge(graph map[interface{}]map[interface{}]struct{}, from, to interface{}) {
+	delete(graph[from], to)
+	if len(graph[from]) == 0 {
+		delete(graph, from)
+	}
+}
+
+func edges(graph map[interface{}]map[interface{}]struct{}, from interface{}) []interface{} {
+	var tags []interface{}
+	for to := range graph[from] {
+		tags = append(tags, to)
+	}
+
+	return tags
+}
@@ -0,0 +1,93 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tests of dependencies", func() {
+	var heap *FibHeap
+
+	BeforeEach(func() {
+		heap = NewFibHeap()
+		for i, job := range []string{"link", "compile", "generate", "test", "docs"} {
+			heap.Insert(job, float64(10*(i+1)))
+		}
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given a chain of dependencies, when decrease the key of the last dependent, it should pull the whole chain forward.", func() {
+		Expect(heap.AddDependency("link", "compile")).ShouldNot(HaveOccurred())
+		Expect(heap.AddDependency("compile", "generate")).ShouldNot(HaveOccurred())
+		Expect(heap.AddDependency("test", "compile")).ShouldNot(HaveOccurred())
+		Expect(heap.AddDependency("test", "compile")).ShouldNot(HaveOccurred())
+		// The blockers inherit the key of link at once.
+		Expect(heap.GetTag("compile")).Should(Equal(10.0))
+		Expect(heap.GetTag("generate")).Should(Equal(10.0))
+		Expect(heap.GetTag("test")).Should(Equal(40.0))
+
+		Expect(heap.DecreaseKey("test", 1)).ShouldNot(HaveOccurred())
+		Expect(heap.GetTag("compile")).Should(Equal(1.0))
+		Expect(heap.GetTag("generate")).Should(Equal(1.0))
+		Expect(heap.GetTag("link")).Should(Equal(10.0))
+		Expect(heap.GetTag("docs")).Should(Equal(50.0))
+
+		// The inherited keys are kept, and a larger key of a dependent is not inherited.
+		Expect(heap.IncreaseKey("test", 100)).ShouldNot(HaveOccurred())
+		Expect(heap.AdjustKey("link", -5)).ShouldNot(HaveOccurred())
+		Expect(heap.GetTag("compile")).Should(Equal(1.0))
+		Expect(heap.AdjustKey("link", -5)).ShouldNot(HaveOccurred())
+		Expect(heap.GetTag("compile")).Should(Equal(0.0))
+		Expect(heap.GetTag("generate")).Should(Equal(0.0))
+	})
+
+	It("Given some dependencies, when call the apis with invalid inputs or cycles, it should return errors and keep the graph.", func() {
+		Expect(heap.AddDependency("link", "compile")).ShouldNot(HaveOccurred())
+		Expect(heap.AddDependency("compile", "generate")).ShouldNot(HaveOccurred())
+		Expect(heap.AddDependency("generate", "link")).Should(HaveOccurred())
+		Expect(heap.AddDependency("link", "link")).Should(HaveOccurred())
+		Expect(heap.AddDependency("link", "deploy")).Should(HaveOccurred())
+		Expect(heap.AddDependency("deploy", "link")).Should(HaveOccurred())
+		Expect(heap.AddDependency(nil, "link")).Should(HaveOccurred())
+		Expect(heap.RemoveDependency("generate", "link")).Should(HaveOccurred())
+
+		Expect(heap.Blockers("link")).Should(Equal([]interface{}{"compile"}))
+		Expect(heap.Dependents("generate")).Should(Equal([]interface{}{"compile"}))
+		Expect(heap.Blockers("generate")).Should(BeNil())
+
+		Expect(heap.RemoveDependency("link", "compile")).ShouldNot(HaveOccurred())
+		Expect(heap.Blockers("link")).Should(BeNil())
+		Expect(heap.Dependents("compile")).Should(BeNil())
+		Expect(heap.DecreaseKey("link", 5)).ShouldNot(HaveOccurred())
+		Expect(heap.GetTag("compile")).Should(Equal(10.0))
+	})
+
+	It("Given some dependencies, when extract or delete the tags, it should drop their dependencies.", func() {
+		heap.SetLazyDelete(true)
+		Expect(heap.AddDependency("test", "compile")).ShouldNot(HaveOccurred())
+		Expect(heap.AddDependency("docs", "compile")).ShouldNot(HaveOccurred())
+		Expect(heap.AddDependency("compile", "generate")).ShouldNot(HaveOccurred())
+
+		Expect(heap.Delete("compile")).ShouldNot(HaveOccurred())
+		Expect(heap.Blockers("test")).Should(BeNil())
+		Expect(heap.Dependents("generate")).Should(BeNil())
+		Expect(heap.blockers).Should(BeEmpty())
+		Expect(heap.dependents).Should(BeEmpty())
+
+		Expect(heap.AddDependency("docs", "test")).ShouldNot(HaveOccurred())
+		tag, _ := heap.ExtractMin()
+		Expect(tag).Should(Equal("link"))
+		Expect(heap.ExtractMinK(2)).Should(HaveLen(2))
+		Expect(heap.Blockers("docs")).Should(BeNil())
+		Expect(heap.dependents).Should(BeEmpty())
+
+		Expect(heap.Insert("test", 1)).ShouldNot(HaveOccurred())
+		Expect(heap.DecreaseKey("docs", 0)).ShouldNot(HaveOccurred())
+		Expect(heap.GetTag("test")).Should(Equal(1.0))
+	})
+})
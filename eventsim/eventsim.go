@@ -0,0 +1,150 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+// Package eventsim implements a discrete-event simulation scheduler with a simulated clock.
+// The FibHeap is used as the event calendar. All events scheduled at the same time are kept in one bucket of the calendar,
+// so they are run in the order they were scheduled and a simulation is always reproducible.
+package eventsim
+
+import (
+	"container/list"
+	"errors"
+	"math"
+
+	fibHeap "github.com/starwander/GoFibonacciHeap"
+)
+
+// EventID identifies a scheduled event.
+type EventID uint64
+
+// Simulator runs the scheduled events in the order of their simulated time.
+// The events are run in the goroutine calling Step, RunUntil or Run, and they may schedule or cancel other events.
+// Please note that all methods of Simulator are not concurrent safe.
+type Simulator struct {
+	now      float64
+	seq      EventID
+	calendar *fibHeap.FibHeap
+	buckets  map[float64]*list.List
+	events   map[EventID]*event
+}
+
+type event struct {
+	id      EventID
+	at      float64
+	fn      func()
+	element *list.Element
+}
+
+// NewSimulator creates an initialized Simulator with the clock at 0.
+func NewSimulator() *Simulator {
+	sim := new(Simulator)
+	sim.calendar = fibHeap.NewFibHeap()
+	sim.buckets = make(map[float64]*list.List)
+	sim.events = make(map[EventID]*event)
+
+	return sim
+}
+
+// Now returns the current simulated time.
+func (sim *Simulator) Now() float64 {
+	return sim.now
+}
+
+// Pending returns the number of scheduled events which have not been run or cancelled.
+func (sim *Simulator) Pending() uint {
+	return uint(len(sim.events))
+}
+
+// Schedule schedules the fn to be run at the simulated time at and returns the id of the event.
+// Events at the same time are run in the order they were scheduled.
+// If the time is earlier than the current time or NaN, or the fn is nil, an error will be returned.
+func (sim *Simulator) Schedule(at float64, fn func()) (EventID, error) {
+	if fn == nil {
+		return 0, errors.New("Input fn is nil ")
+	}
+	if math.IsNaN(at) || at < sim.now {
+		return 0, errors.New("Event time is earlier than the current time ")
+	}
+
+	bucket, exists := sim.buckets[at]
+	if !exists {
+		bucket = list.New()
+		sim.buckets[at] = bucket
+		sim.calendar.Insert(at, at)
+	}
+
+	sim.seq++
+	e := &event{id: sim.seq, at: at, fn: fn}
+	e.element = bucket.PushBack(e)
+	sim.events[e.id] = e
+
+	return e.id, nil
+}
+
+// After schedules the fn to be run after the delay from the current time and returns the id of the event.
+// If the delay is negative or NaN, or the fn is nil, an error will be returned.
+func (sim *Simulator) After(delay float64, fn func()) (EventID, error) {
+	return sim.Schedule(sim.now+delay, fn)
+}
+
+// Cancel cancels the scheduled event.
+// If the event does not exist or has already been run, an error will be returned.
+func (sim *Simulator) Cancel(id EventID) error {
+	e, exists := sim.events[id]
+	if !exists {
+		return errors.New("Event is not found ")
+	}
+
+	sim.remove(e)
+
+	return nil
+}
+
+// Step advances the clock to the earliest scheduled event and runs it.
+// It returns false if there is no event to run.
+func (sim *Simulator) Step() bool {
+	at, _ := sim.calendar.Minimum()
+	if at == nil {
+		return false
+	}
+
+	e := sim.buckets[at.(float64)].Front().Value.(*event)
+	sim.remove(e)
+	sim.now = e.at
+	e.fn()
+
+	return true
+}
+
+// RunUntil runs all events scheduled not later than the time t in order, including the ones scheduled by the events themselves,
+// and then advances the clock to t.
+// If t is earlier than the current time, nothing will be run.
+func (sim *Simulator) RunUntil(t float64) {
+	for {
+		at, key := sim.calendar.Minimum()
+		if at == nil || key > t {
+			break
+		}
+		sim.Step()
+	}
+
+	if t > sim.now {
+		sim.now = t
+	}
+}
+
+// Run runs all events until there is no event left.
+// A simulation which keeps scheduling new events will never return.
+func (sim *Simulator) Run() {
+	for sim.Step() {
+	}
+}
+
+func (sim *Simulator) remove(e *event) {
+	bucket := sim.buckets[e.at]
+	bucket.Remove(e.element)
+	if bucket.Len() == 0 {
+		delete(sim.buckets, e.at)
+		sim.calendar.Delete(e.at)
+	}
+	delete(sim.events, e.id)
+}
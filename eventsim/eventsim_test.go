@@ -0,0 +1,117 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package eventsim
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+var _ = Describe("Tests of eventsim", func() {
+	var (
+		sim *Simulator
+	)
+
+	BeforeEach(func() {
+		sim = NewSimulator()
+	})
+
+	AfterEach(func() {
+		sim = nil
+	})
+
+	It("Given an empty simulator, when call Step and RunUntil api, it should only advance the clock.", func() {
+		Expect(sim.Step()).Should(BeFalse())
+		sim.RunUntil(10)
+		Expect(sim.Now()).Should(BeEquivalentTo(10))
+		sim.RunUntil(5)
+		Expect(sim.Now()).Should(BeEquivalentTo(10))
+	})
+
+	It("Given a simulator, when call Schedule api with invalid inputs, it should return error.", func() {
+		sim.RunUntil(10)
+		_, err := sim.Schedule(11, nil)
+		Expect(err).Should(HaveOccurred())
+		_, err = sim.Schedule(9, func() {})
+		Expect(err).Should(HaveOccurred())
+		_, err = sim.Schedule(math.NaN(), func() {})
+		Expect(err).Should(HaveOccurred())
+		_, err = sim.After(-1, func() {})
+		Expect(err).Should(HaveOccurred())
+		Expect(sim.Pending()).Should(BeEquivalentTo(0))
+	})
+
+	It("Given a simulator with scheduled events, when call RunUntil api, it should run the events in time order and ties in schedule order.", func() {
+		var order []int
+		record := func(i int) func() {
+			return func() { order = append(order, i) }
+		}
+		sim.Schedule(3, record(3))
+		sim.Schedule(1, record(1))
+		sim.Schedule(2, record(20))
+		sim.Schedule(2, record(21))
+		sim.Schedule(2, record(22))
+		sim.Schedule(5, record(5))
+
+		sim.RunUntil(3)
+		Expect(order).Should(Equal([]int{1, 20, 21, 22, 3}))
+		Expect(sim.Now()).Should(BeEquivalentTo(3))
+		Expect(sim.Pending()).Should(BeEquivalentTo(1))
+
+		sim.Run()
+		Expect(order).Should(Equal([]int{1, 20, 21, 22, 3, 5}))
+		Expect(sim.Now()).Should(BeEquivalentTo(5))
+	})
+
+	It("Given a simulator, when events schedule other events, it should run them at the simulated time.", func() {
+		var times []float64
+		var tick func()
+		tick = func() {
+			times = append(times, sim.Now())
+			if len(times) < 5 {
+				sim.After(2.5, tick)
+			}
+		}
+		sim.Schedule(1, tick)
+
+		sim.RunUntil(6)
+		Expect(times).Should(Equal([]float64{1, 3.5, 6}))
+		sim.Run()
+		Expect(times).Should(Equal([]float64{1, 3.5, 6, 8.5, 11}))
+	})
+
+	It("Given a simulator with scheduled events, when call Cancel api, it should not run the cancelled events.", func() {
+		var order []int
+		first, _ := sim.Schedule(1, func() { order = append(order, 1) })
+		second, _ := sim.Schedule(1, func() { order = append(order, 2) })
+		third, _ := sim.Schedule(2, func() { order = append(order, 3) })
+		sim.Schedule(1, func() {
+			order = append(order, 4)
+			Expect(sim.Cancel(third)).ShouldNot(HaveOccurred())
+		})
+
+		Expect(sim.Cancel(second)).ShouldNot(HaveOccurred())
+		Expect(sim.Cancel(second)).Should(HaveOccurred())
+		sim.Run()
+		Expect(order).Should(Equal([]int{1, 4}))
+		Expect(sim.Cancel(first)).Should(HaveOccurred())
+		Expect(sim.Pending()).Should(BeEquivalentTo(0))
+	})
+
+	It("Given a simulator with random events, when call Run api, it should run all events sorted by time.", func() {
+		random := rand.New(rand.NewSource(time.Now().Unix()))
+		var times []float64
+		for i := 0; i < 1000; i++ {
+			at := float64(random.Intn(100))
+			sim.Schedule(at, func() { times = append(times, sim.Now()) })
+		}
+
+		sim.Run()
+		Expect(times).Should(HaveLen(1000))
+		Expect(sort.Float64sAreSorted(times)).Should(BeTrue())
+	})
+})
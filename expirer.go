@@ -0,0 +1,213 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// ExpirerBatchSize is the maximum number of expired entries the Expirer pops while holding its lock.
+const ExpirerBatchSize = 1024
+
+// Expirer expires registered tags at their deadlines, e.g. sessions or cache entries with a TTL.
+// The deadlines are kept in a FibHeap, so extending a deadline by Touch is an IncreaseKey.
+// A background goroutine sleeps until the earliest deadline, pops the expired entries in batches of ExpirerBatchSize
+// and calls their callbacks one by one in the same goroutine, so the callbacks should return quickly.
+// All methods of Expirer are concurrent safe.
+type Expirer struct {
+	mutex     sync.Mutex
+	heap      *FibHeap
+	callbacks map[interface{}]func(tag interface{})
+	base      time.Time
+	wakeup    chan struct{}
+	done      chan struct{}
+	closed    bool
+}
+
+// NewExpirer creates an initialized Expirer and starts its goroutine.
+// Close must be called to stop the goroutine when the expirer is no longer used.
+func NewExpirer() *Expirer {
+	expirer := new(Expirer)
+	expirer.heap = NewFibHeap()
+	expirer.callbacks = make(map[interface{}]func(tag interface{}))
+	expirer.base = time.Now()
+	expirer.wakeup = make(chan struct{}, 1)
+	expirer.done = make(chan struct{})
+
+	go expirer.run()
+
+	return expirer
+}
+
+// Num returns the number of registered tags which have not expired.
+func (expirer *Expirer) Num() uint {
+	expirer.mutex.Lock()
+	defer expirer.mutex.Unlock()
+
+	return expirer.heap.Num()
+}
+
+// Register registers the input tag to expire at the deadline, when onExpire will be called with the tag.
+// onExpire can be nil if the tag only needs to be removed.
+// Try to register a duplicate tag will cause an error return.
+// Try to register to a closed expirer will cause an error return.
+func (expirer *Expirer) Register(tag interface{}, deadline time.Time, onExpire func(tag interface{})) error {
+	expirer.mutex.Lock()
+	defer expirer.mutex.Unlock()
+
+	if expirer.closed {
+		return errors.New("Expirer is closed ")
+	}
+
+	if err := expirer.heap.Insert(tag, expirer.key(deadline)); err != nil {
+		return err
+	}
+	expirer.callbacks[tagKey(tag)] = onExpire
+	expirer.notify()
+
+	return nil
+}
+
+// Touch moves the deadline of the input tag.
+// A later deadline is applied by IncreaseKey and an earlier one by DecreaseKey.
+// If the input tag is not registered or has expired, an error will be returned.
+func (expirer *Expirer) Touch(tag interface{}, deadline time.Time) error {
+	expirer.mutex.Lock()
+	defer expirer.mutex.Unlock()
+
+	current := expirer.heap.GetTag(tag)
+	if math.IsInf(current, -1) {
+		return errors.New("Tag is not found ")
+	}
+
+	key := expirer.key(deadline)
+	if key > current {
+		return expirer.heap.IncreaseKey(tag, key)
+	}
+	if key < current {
+		expirer.notify()
+		return expirer.heap.DecreaseKey(tag, key)
+	}
+
+	return nil
+}
+
+// Deadline returns the deadline of the input tag.
+// If the input tag is not registered or has expired, the zero time will be returned.
+func (expirer *Expirer) Deadline(tag interface{}) time.Time {
+	expirer.mutex.Lock()
+	defer expirer.mutex.Unlock()
+
+	key := expirer.heap.GetTag(tag)
+	if math.IsInf(key, -1) {
+		return time.Time{}
+	}
+
+	return expirer.base.Add(time.Duration(key))
+}
+
+// Remove unregisters the input tag without calling its callback.
+// If the input tag is not registered or has expired, an error will be returned.
+func (expirer *Expirer) Remove(tag interface{}) error {
+	expirer.mutex.Lock()
+	defer expirer.mutex.Unlock()
+
+	if err := expirer.heap.Delete(tag); err != nil {
+		return err
+	}
+	delete(expirer.callbacks, tagKey(tag))
+
+	return nil
+}
+
+// Close stops the goroutine of the expirer. The registered tags will never expire.
+func (expirer *Expirer) Close() {
+	expirer.mutex.Lock()
+	defer expirer.mutex.Unlock()
+
+	if !expirer.closed {
+		expirer.closed = true
+		close(expirer.done)
+	}
+}
+
+func (expirer *Expirer) key(deadline time.Time) float64 {
+	return float64(deadline.Sub(expirer.base))
+}
+
+func (expirer *Expirer) notify() {
+	select {
+	case expirer.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+func (expirer *Expirer) run() {
+	timer := time.NewTimer(time.Hour)
+	if !timer.Stop() {
+		<-timer.C
+	}
+
+	for {
+		expirer.mutex.Lock()
+		tags, callbacks, wait := expirer.expire()
+		expirer.mutex.Unlock()
+
+		for i, callback := range callbacks {
+			if callback != nil {
+				callback(tags[i])
+			}
+		}
+
+		if len(tags) == ExpirerBatchSize {
+			continue
+		}
+
+		if wait < 0 {
+			select {
+			case <-expirer.wakeup:
+			case <-expirer.done:
+				return
+			}
+			continue
+		}
+
+		timer.Reset(wait)
+		select {
+		case <-timer.C:
+		case <-expirer.wakeup:
+			if !timer.Stop() {
+				<-timer.C
+			}
+		case <-expirer.done:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// expire pops at most ExpirerBatchSize expired entries and returns their tags and callbacks,
+// and the duration to wait for the next deadline, which is negative if there is no registered tag.
+func (expirer *Expirer) expire() (tags []interface{}, callbacks []func(tag interface{}), wait time.Duration) {
+	now := float64(time.Since(expirer.base))
+	for len(tags) < ExpirerBatchSize && expirer.heap.Num() > 0 {
+		tag, key := expirer.heap.Minimum()
+		if key > now {
+			return tags, callbacks, time.Duration(key - now)
+		}
+
+		expirer.heap.ExtractMin()
+		tags = append(tags, tag)
+		callbacks = append(callbacks, expirer.callbacks[tagKey(tag)])
+		delete(expirer.callbacks, tagKey(tag))
+	}
+
+	if expirer.heap.Num() == 0 {
+		return tags, callbacks, -1
+	}
+
+	return tags, callbacks, 0
+}
@@ -0,0 +1,94 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"sync"
+	"time"
+)
+
+var _ = Describe("Tests of expirer", func() {
+	var (
+		expirer *Expirer
+		mutex   sync.Mutex
+		expired []interface{}
+	)
+
+	onExpire := func(tag interface{}) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		expired = append(expired, tag)
+	}
+
+	expiredTags := func() []interface{} {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]interface{}(nil), expired...)
+	}
+
+	BeforeEach(func() {
+		expirer = NewExpirer()
+		expired = nil
+	})
+
+	AfterEach(func() {
+		expirer.Close()
+		expirer = nil
+	})
+
+	It("Given an expirer, when call Register api with invalid inputs, it should return error.", func() {
+		deadline := time.Now().Add(time.Hour)
+		Expect(expirer.Register(nil, deadline, onExpire)).Should(HaveOccurred())
+		Expect(expirer.Register(1, deadline, onExpire)).ShouldNot(HaveOccurred())
+		Expect(expirer.Register(1, deadline, onExpire)).Should(HaveOccurred())
+		Expect(expirer.Deadline(1)).Should(BeTemporally("~", deadline))
+		Expect(expirer.Deadline(2)).Should(BeZero())
+		Expect(expirer.Touch(2, deadline)).Should(HaveOccurred())
+		Expect(expirer.Remove(2)).Should(HaveOccurred())
+
+		expirer.Close()
+		Expect(expirer.Register(2, deadline, onExpire)).Should(HaveOccurred())
+	})
+
+	It("Given an expirer with registered tags, when their deadlines arrive, it should call the callbacks in the order of deadlines.", func() {
+		now := time.Now()
+		expirer.Register(3, now.Add(60*time.Millisecond), onExpire)
+		expirer.Register(1, now.Add(20*time.Millisecond), onExpire)
+		expirer.Register(2, now.Add(40*time.Millisecond), nil)
+		expirer.Register(4, now.Add(40*time.Millisecond), onExpire)
+
+		Eventually(expiredTags).Should(Equal([]interface{}{1, 4, 3}))
+		Expect(time.Since(now)).Should(BeNumerically(">=", 60*time.Millisecond))
+		Expect(expirer.Num()).Should(BeEquivalentTo(0))
+	})
+
+	It("Given an expirer with registered tags, when call Touch and Remove api, it should move or cancel the expiration.", func() {
+		now := time.Now()
+		expirer.Register(1, now.Add(20*time.Millisecond), onExpire)
+		expirer.Register(2, now.Add(time.Hour), onExpire)
+		expirer.Register(3, now.Add(20*time.Millisecond), onExpire)
+
+		Expect(expirer.Touch(1, now.Add(80*time.Millisecond))).ShouldNot(HaveOccurred())
+		Expect(expirer.Touch(2, now.Add(40*time.Millisecond))).ShouldNot(HaveOccurred())
+		Expect(expirer.Touch(2, now.Add(40*time.Millisecond))).ShouldNot(HaveOccurred())
+		Expect(expirer.Remove(3)).ShouldNot(HaveOccurred())
+
+		Eventually(expiredTags).Should(Equal([]interface{}{2, 1}))
+		Expect(time.Since(now)).Should(BeNumerically(">=", 80*time.Millisecond))
+	})
+
+	It("Given an expirer with more expired tags than a batch, when the loop runs, it should expire all of them.", func() {
+		past := time.Now().Add(-time.Second)
+		for i := 0; i < 3*ExpirerBatchSize+1; i++ {
+			Expect(expirer.Register(i, past.Add(time.Duration(i)), onExpire)).ShouldNot(HaveOccurred())
+		}
+
+		Eventually(func() int { return len(expiredTags()) }).Should(Equal(3*ExpirerBatchSize + 1))
+		tags := expiredTags()
+		for i := range tags {
+			Expect(tags[i]).Should(Equal(i))
+		}
+	})
+})
@@ -0,0 +1,98 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Entry is the exported form of a value in the heap.
+type Entry struct {
+	// Tag is the tag of the value.
+	Tag interface{}
+	// Key is the key of the value in the heap.
+	Key float64
+	// Type is the name under which the type of the value is registered by RegisterValue.
+	// It is empty if the entry was inserted by tag without a value.
+	Type string
+	// Value is the encoded value. It is nil if Type is empty.
+	Value []byte
+}
+
+// Export returns all values in the heap as entries sorted by the ascending order of keys.
+// The values are encoded by their registered types, so the entries can be persisted together with the payloads.
+// If the type of any value is not registered or fails to be encoded, an error will be returned.
+// The heap is left untouched.
+func (heap *FibHeap) Export() ([]Entry, error) {
+	if debugMode {
+		defer heap.guard.enter("Export")()
+	}
+
+	entries := make([]Entry, 0, heap.num)
+	for _, node := range heap.index {
+		name, data, err := encodeValue(node.value)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, Entry{node.tag, heap.keyOf(node), name, data})
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+
+	return entries, nil
+}
+
+// Import decodes and pushes all input entries into the heap, typically the ones returned by Export.
+// A nil tag, a -inf key, a duplicate tag, an unregistered type or a value whose tag differs from its entry will cause an error return,
+// and no entry will be imported in that case.
+func (heap *FibHeap) Import(entries []Entry) error {
+	if debugMode {
+		defer heap.guard.enter("Import")()
+	}
+
+	return heap.importEntries(context.Background(), entries)
+}
+
+// importEntries decodes all entries first and pushes them only if all of them are valid and the context has not been cancelled meanwhile.
+func (heap *FibHeap) importEntries(ctx context.Context, entries []Entry) error {
+	values := make([]Value, len(entries))
+	tags := make(map[interface{}]bool, len(entries))
+	for i, entry := range entries {
+		if err := cancelled(ctx, i); err != nil {
+			return err
+		}
+		if entry.Tag == nil {
+			return errors.New("Input tag is nil ")
+		}
+		if math.IsInf(entry.Key, -1) {
+			return errors.New("Negative infinity key is reserved for internal usage ")
+		}
+		tag := tagKey(entry.Tag)
+		if _, exists := heap.index[tag]; exists || tags[tag] {
+			return errors.New("Duplicate tag is not allowed ")
+		}
+		tags[tag] = true
+
+		value, err := decodeValue(entry.Type, entry.Value)
+		if err != nil {
+			return err
+		}
+		if value != nil && tagKey(value.Tag()) != tag {
+			return fmt.Errorf("Tag of the decoded value %v does not match the entry %v ", value.Tag(), entry.Tag)
+		}
+		values[i] = value
+	}
+	if err := heap.checkQuota(uint(len(entries))); err != nil {
+		return err
+	}
+
+	for i, entry := range entries {
+		heap.insert(entry.Tag, entry.Key, values[i])
+	}
+
+	return nil
+}
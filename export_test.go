@@ -0,0 +1,183 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+)
+
+// payload is a Value with encoding support used by the serialization tests.
+type payload struct {
+	ID       int
+	Priority float64
+	Text     string
+}
+
+func (p *payload) Tag() interface{} {
+	return p.ID
+}
+
+func (p *payload) Key() float64 {
+	return p.Priority
+}
+
+func (p *payload) MarshalBinary() ([]byte, error) {
+	return json.Marshal(*p)
+}
+
+func (p *payload) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, p)
+}
+
+// record is a Value without encoding support, which is persisted by recordCodec.
+type record struct {
+	id       int
+	priority float64
+}
+
+func (r record) Tag() interface{} {
+	return r.id
+}
+
+func (r record) Key() float64 {
+	return r.priority
+}
+
+// recordCodec encodes a record as its id followed by its priority, and rejects negative ids.
+type recordCodec struct{}
+
+func (codec recordCodec) Encode(value Value) ([]byte, error) {
+	r := value.(record)
+	if r.id < 0 {
+		return nil, errors.New("negative id")
+	}
+
+	return []byte(fmt.Sprintf("%d %v", r.id, r.priority)), nil
+}
+
+func (codec recordCodec) Decode(data []byte) (Value, error) {
+	var r record
+	if _, err := fmt.Sscanf(string(data), "%d %v", &r.id, &r.priority); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func init() {
+	RegisterValue("fibHeap.payload", &payload{})
+	RegisterValueCodec("fibHeap.record", record{}, recordCodec{})
+}
+
+var _ = Describe("Tests of export", func() {
+	var (
+		heap *FibHeap
+	)
+
+	BeforeEach(func() {
+		heap = NewFibHeap()
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given invalid registrations, when call RegisterValue api, it should panic.", func() {
+		Expect(func() { RegisterValue("", &payload{}) }).Should(Panic())
+		Expect(func() { RegisterValue("nil", nil) }).Should(Panic())
+		Expect(func() { RegisterValue("demo", &demoStruct{}) }).Should(Panic())
+		Expect(func() { RegisterValue("fibHeap.payload", &payload{}) }).Should(Panic())
+		Expect(func() { RegisterValue("another", &payload{}) }).Should(Panic())
+		Expect(func() { RegisterValueCodec("another", record{}, recordCodec{}) }).Should(Panic())
+		Expect(func() { RegisterValueCodec("demo", &demoStruct{}, nil) }).Should(Panic())
+	})
+
+	It("Given a value type registered with a ValueCodec, when Export and Import, it should persist the values by the codec.", func() {
+		for i := 0; i < 10; i++ {
+			heap.InsertValue(record{i, float64(i) / 2})
+		}
+
+		entries, err := heap.Export()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(entries[3].Type).Should(Equal("fibHeap.record"))
+		Expect(string(entries[3].Value)).Should(Equal("3 1.5"))
+
+		imported := NewFibHeap()
+		Expect(imported.Import(entries)).ShouldNot(HaveOccurred())
+		Expect(imported.GetValue(3)).Should(Equal(record{3, 1.5}))
+
+		var buffer bytes.Buffer
+		Expect(heap.MarshalCompressed(&buffer, "gzip")).ShouldNot(HaveOccurred())
+		restored, err := Unmarshal(&buffer)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(restored.GetValue(9)).Should(Equal(record{9, 4.5}))
+	})
+
+	It("Given a ValueCodec failing to encode or decode, when Export or Import, it should return the error of the codec.", func() {
+		heap.InsertValue(record{-1, 1})
+		_, err := heap.Export()
+		Expect(err).Should(MatchError("negative id"))
+
+		Expect(heap.Import([]Entry{{Tag: 1, Key: 1, Type: "fibHeap.record", Value: []byte("x")}})).Should(HaveOccurred())
+		Expect(heap.Num()).Should(BeEquivalentTo(1))
+	})
+
+	It("Given a fibHeap with tags and values, when Export and Import, it should restore all tags, keys and values.", func() {
+		for i := 0; i < 100; i++ {
+			heap.InsertValue(&payload{i, float64(100 - i), string(rune('a' + i%26))})
+			heap.Insert(fmt.Sprint(i), float64(i))
+		}
+		heap.ExtractMin()
+		heap.DecreaseKey(50, -50)
+
+		entries, err := heap.Export()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(entries).Should(HaveLen(199))
+		Expect(entries[0].Tag).Should(Equal(50))
+		Expect(entries[0].Key).Should(BeEquivalentTo(-50))
+		for i := 1; i < len(entries); i++ {
+			Expect(entries[i].Key).Should(BeNumerically(">=", entries[i-1].Key))
+		}
+		Expect(heap.Num()).Should(BeEquivalentTo(199))
+
+		imported := NewFibHeap()
+		Expect(imported.Import(entries)).ShouldNot(HaveOccurred())
+		Expect(imported.Equal(heap)).Should(BeTrue())
+		Expect(imported.GetValue(10)).Should(Equal(&payload{10, 90, "k"}))
+		Expect(imported.GetValue("10")).Should(BeNil())
+		tag, key := imported.ExtractMin()
+		Expect(tag).Should(Equal(50))
+		Expect(key).Should(BeEquivalentTo(-50))
+	})
+
+	It("Given a fibHeap with an unregistered value type, when call Export api, it should return error.", func() {
+		heap.InsertValue(&demoStruct{1, 1, "1"})
+
+		entries, err := heap.Export()
+		Expect(err).Should(HaveOccurred())
+		Expect(entries).Should(BeNil())
+	})
+
+	It("Given invalid entries, when call Import api, it should return error and import nothing.", func() {
+		heap.Insert(1, 1)
+		data, _ := (&payload{2, 2, ""}).MarshalBinary()
+
+		Expect(heap.Import([]Entry{{Tag: nil, Key: 1}})).Should(HaveOccurred())
+		Expect(heap.Import([]Entry{{Tag: 3, Key: math.Inf(-1)}})).Should(HaveOccurred())
+		Expect(heap.Import([]Entry{{Tag: 3, Key: 3}, {Tag: 1, Key: 1}})).Should(HaveOccurred())
+		Expect(heap.Import([]Entry{{Tag: 3, Key: 3}, {Tag: 3, Key: 3}})).Should(HaveOccurred())
+		Expect(heap.Import([]Entry{{Tag: 3, Key: 3, Type: "unknown", Value: data}})).Should(HaveOccurred())
+		Expect(heap.Import([]Entry{{Tag: 3, Key: 3, Type: "fibHeap.payload", Value: []byte("{")}})).Should(HaveOccurred())
+		Expect(heap.Import([]Entry{{Tag: 3, Key: 3, Type: "fibHeap.payload", Value: data}})).Should(HaveOccurred())
+		Expect(heap.Num()).Should(BeEquivalentTo(1))
+
+		Expect(heap.Import([]Entry{{Tag: 2, Key: 0, Type: "fibHeap.payload", Value: data}})).ShouldNot(HaveOccurred())
+		Expect(heap.MinimumValue()).Should(Equal(&payload{2, 2, ""}))
+	})
+})
@@ -7,9 +7,12 @@ package fibHeap
 import (
 	"bytes"
 	"container/list"
+	"context"
 	"errors"
 	"fmt"
 	"math"
+	"reflect"
+	"sync/atomic"
 )
 
 // Value is the interface that all values push into or pop from the FibHeap by value interfaces must implement.
@@ -22,14 +25,62 @@ type Value interface {
 	Key() float64
 }
 
+// ErrNilValue is returned by the value interfaces if the input value is nil, or is an interface holding a nil pointer,
+// whose Tag and Key would panic.
+var ErrNilValue = errors.New("Input value is nil ")
+
 // FibHeap represents a Fibonacci Heap.
 // Please note that all methods of FibHeap are not concurrent safe.
 type FibHeap struct {
 	roots       *list.List
 	index       map[interface{}]*node
-	treeDegrees map[uint]*list.Element
+	treeDegrees []*list.Element
 	min         *node
 	num         uint
+	wal         *writeAheadLog
+	// offset is added to the key of every node lazily, see AddToAllKeys.
+	offset float64
+	// pool recycles the extracted nodes if the heap belongs to a HeapGroup.
+	pool *nodePool
+	// counts keeps the tags inserted more than once by InsertOrIncrement with their counts.
+	counts map[interface{}]uint
+	// weights keeps the tags whose weights of ExtractWeightedRandom are not 1.
+	weights map[interface{}]float64
+	// order keeps all nodes sorted by the key if the ordered index is on, see SetOrderedIndex.
+	order *orderedIndex
+	// sketch estimates the quantiles of the keys if the quantile sketch is on, see SetQuantileSketch.
+	sketch *keySketch
+	hooks  Hooks
+	// reported is the minimum reported last time to the OnMinChange hook and to the parent, see minChanged.
+	reported struct {
+		tag interface{}
+		key float64
+	}
+	// parent is the HeapOfHeaps the heap is attached to, if any.
+	parent *childLink
+	// blockers and dependents keep the dependencies between the tags in both directions, see AddDependency.
+	blockers   map[interface{}]map[interface{}]struct{}
+	dependents map[interface{}]map[interface{}]struct{}
+	// watchers keeps the channels of Watch by their tags.
+	watchers map[interface{}][]chan Event
+	// lazy turns on the lazy deletion mode, and dead is the number of dead nodes left in the trees, see SetLazyDelete.
+	lazy bool
+	dead uint
+	// budget is the maximum number of links per operation of the incremental consolidation, or 0 to consolidate fully by every ExtractMin,
+	// and done is the last root which has been consolidated, see SetConsolidationBudget.
+	budget int
+	done   *list.Element
+	// sequenced turns on the recording of the insertion order, see SetInsertionOrder.
+	sequenced bool
+	// profiled turns on the pprof labels of the internal phases, and phase is the name of the running one, see SetProfileLabels.
+	profiled bool
+	phase    string
+	// logger logs the internal phases if it is set, see SetLogger.
+	logger phaseLogger
+	// quota limits the number of values and the memory of the heap, see SetQuota.
+	quota Quota
+	// guard detects concurrent misuse in the debug mode, see debugMode.
+	guard guard
 }
 
 type node struct {
@@ -37,11 +88,14 @@ type node struct {
 	parent   *node
 	children *list.List
 	marked   bool
+	dead     bool
 	degree   uint
 	position uint
 	tag      interface{}
 	key      float64
 	value    Value
+	// seq is the insertion sequence number of the node, or 0 if the insertion order was not recorded, see SetInsertionOrder.
+	seq uint64
 }
 
 // NewFibHeap creates an initialized Fibonacci Heap.
@@ -49,18 +103,40 @@ func NewFibHeap() *FibHeap {
 	heap := new(FibHeap)
 	heap.roots = list.New()
 	heap.index = make(map[interface{}]*node)
-	heap.treeDegrees = make(map[uint]*list.Element)
 	heap.num = 0
 	heap.min = nil
+	heap.guard = newGuard()
 
 	return heap
 }
 
 // Num returns the total number of values in the heap.
 func (heap *FibHeap) Num() uint {
+	if debugMode {
+		defer heap.guard.enter("Num")()
+	}
+
 	return heap.num
 }
 
+// Len returns the total number of values in the heap as an int, the conventional type of lengths in Go, so no conversion is needed in arithmetic.
+func (heap *FibHeap) Len() int {
+	if debugMode {
+		defer heap.guard.enter("Len")()
+	}
+
+	return int(heap.num)
+}
+
+// IsEmpty reports whether the heap has no value.
+func (heap *FibHeap) IsEmpty() bool {
+	if debugMode {
+		defer heap.guard.enter("IsEmpty")()
+	}
+
+	return heap.num == 0
+}
+
 // Insert pushes the input tag and key into the heap.
 // Try to insert a duplicate tag value will cause an error return.
 // The valid range of the key is (-inf, +inf].
@@ -68,6 +144,11 @@ func (heap *FibHeap) Num() uint {
 // Insert will check the nil interface but not the interface with nil value.
 // Try to input of an interface with nil value will cause invalid address panic.
 func (heap *FibHeap) Insert(tag interface{}, key float64) error {
+	if debugMode {
+		defer heap.guard.enter("Insert")()
+	}
+
+	tag = tagKey(tag)
 	if tag == nil {
 		return errors.New("Input tag is nil ")
 	}
@@ -80,31 +161,107 @@ func (heap *FibHeap) Insert(tag interface{}, key float64) error {
 // Try to insert a duplicate tag value will cause an error return.
 // The valid range of the value's key is (-inf, +inf].
 // Try to insert a -inf key value will cause an error return.
-// Insert will check the nil interface but not the interface with nil value.
-// Try to input of an interface with nil value will cause invalid address panic.
+// Insert returns ErrNilValue for a nil interface, and for an interface with nil value as well,
+// so that Tag and Key of the value are never called on a nil pointer.
 func (heap *FibHeap) InsertValue(value Value) error {
-	if value == nil {
-		return errors.New("Input value is nil ")
+	if debugMode {
+		defer heap.guard.enter("InsertValue")()
+	}
+
+	if isNilValue(value) {
+		return ErrNilValue
 	}
 
 	return heap.insert(value.Tag(), value.Key(), value)
 }
 
+// InsertOrIncrement pushes the input tag and key into the heap like Insert, but if the tag already exists,
+// it increments the count of the tag instead of returning an error, and the key of the tag is left unchanged.
+// ExtractMin, ExtractTag and the other extracting methods decrement the count and only extract the tag when the count drops to zero,
+// while Delete and DeleteValue delete the tag regardless of its count.
+// The counts are not kept by Union, Export, Marshal or the write-ahead log.
+func (heap *FibHeap) InsertOrIncrement(tag interface{}, key float64) error {
+	if debugMode {
+		defer heap.guard.enter("InsertOrIncrement")()
+	}
+
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if _, exists := heap.index[tag]; !exists {
+		return heap.insert(tag, key, nil)
+	}
+
+	if heap.counts == nil {
+		heap.counts = make(map[interface{}]uint)
+	}
+	if heap.counts[tag] == 0 {
+		heap.counts[tag] = 1
+	}
+	heap.counts[tag]++
+
+	return nil
+}
+
+// Count returns how many times the input tag has been inserted by InsertOrIncrement and not yet extracted.
+// It returns 1 for a tag inserted by the other methods, and 0 if the tag does not exist in the heap.
+func (heap *FibHeap) Count(tag interface{}) uint {
+	if debugMode {
+		defer heap.guard.enter("Count")()
+	}
+
+	tag = tagKey(tag)
+	if _, exists := heap.index[tag]; !exists {
+		return 0
+	}
+
+	if count, exists := heap.counts[tag]; exists {
+		return count
+	}
+
+	return 1
+}
+
 // Minimum returns the current minimum tag and key in the heap sorted by the key.
 // Minimum will not extract the tag and key so the value will still exists in the heap.
 // An empty heap will return nil and -inf.
+// Minimum is kept for compatibility. New code should prefer PeekMin, which reports an empty heap explicitly instead of by the -inf key.
 func (heap *FibHeap) Minimum() (interface{}, float64) {
+	if debugMode {
+		defer heap.guard.enter("Minimum")()
+	}
+
 	if heap.num == 0 {
 		return nil, math.Inf(-1)
 	}
 
-	return heap.min.tag, heap.min.key
+	return heap.min.tag, heap.keyOf(heap.min)
+}
+
+// PeekMin returns the current minimum tag and key in the heap sorted by the key, without extracting them.
+// The ok result is false if and only if the heap is empty, in which case the tag is nil and the key is -inf.
+func (heap *FibHeap) PeekMin() (tag interface{}, key float64, ok bool) {
+	if debugMode {
+		defer heap.guard.enter("PeekMin")()
+	}
+
+	if heap.num == 0 {
+		return nil, math.Inf(-1), false
+	}
+
+	return heap.min.tag, heap.keyOf(heap.min), true
 }
 
 // MinimumValue returns the current minimum value in the heap sorted by the key.
 // MinimumValue will not extract the value so the value will still exists in the heap.
 // An empty heap will return nil.
 func (heap *FibHeap) MinimumValue() Value {
+	if debugMode {
+		defer heap.guard.enter("MinimumValue")()
+	}
+
 	if heap.num == 0 {
 		return nil
 	}
@@ -112,52 +269,631 @@ func (heap *FibHeap) MinimumValue() Value {
 	return heap.min.value
 }
 
+// MinimumValueKey returns the current minimum value in the heap together with its key in the heap,
+// which is the key it was sorted by even if the Key method of the value reports another one by now.
+// MinimumValueKey will not extract the value so the value will still exists in the heap.
+// An empty heap will return nil/-inf.
+func (heap *FibHeap) MinimumValueKey() (Value, float64) {
+	if debugMode {
+		defer heap.guard.enter("MinimumValueKey")()
+	}
+
+	if heap.num == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	return heap.min.value, heap.keyOf(heap.min)
+}
+
 // ExtractMin returns the current minimum tag and key in the heap and then extracts them from the heap.
 // An empty heap will return nil/-inf and extracts nothing.
 func (heap *FibHeap) ExtractMin() (interface{}, float64) {
+	if debugMode {
+		defer heap.guard.enter("ExtractMin")()
+	}
+
 	if heap.num == 0 {
 		return nil, math.Inf(-1)
 	}
 
+	if heap.decrement(heap.min) {
+		return heap.min.tag, heap.keyOf(heap.min)
+	}
 	min := heap.extractMin()
+	heap.fire(EventExtract, min, heap.keyOf(min))
 
-	return min.tag, min.key
+	return min.tag, heap.keyOf(min)
 }
 
 // ExtractMinValue returns the current minimum value in the heap and then extracts it from the heap.
 // An empty heap will return nil and extracts nothing.
 func (heap *FibHeap) ExtractMinValue() Value {
+	if debugMode {
+		defer heap.guard.enter("ExtractMinValue")()
+	}
+
 	if heap.num == 0 {
 		return nil
 	}
 
+	if heap.decrement(heap.min) {
+		return heap.min.value
+	}
 	min := heap.extractMin()
+	heap.fire(EventExtract, min, heap.keyOf(min))
 
 	return min.value
 }
 
-// Union merges the input heap in.
-// All values of the input heap must not have duplicate tags. Otherwise an error will be returned.
-func (heap *FibHeap) Union(anotherHeap *FibHeap) error {
-	for tag := range anotherHeap.index {
+// ExtractMinValueKey returns the current minimum value in the heap together with its key in the heap and then extracts it from the heap,
+// so the priority at the extraction is known without calling the Key method of the value, which may be stale.
+// An empty heap will return nil/-inf and extracts nothing.
+func (heap *FibHeap) ExtractMinValueKey() (Value, float64) {
+	if debugMode {
+		defer heap.guard.enter("ExtractMinValueKey")()
+	}
+
+	if heap.num == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	if heap.decrement(heap.min) {
+		return heap.min.value, heap.keyOf(heap.min)
+	}
+	min := heap.extractMin()
+	heap.fire(EventExtract, min, heap.keyOf(min))
+
+	return min.value, heap.keyOf(min)
+}
+
+// ExtractMinK extracts up to k minimum values from the heap and returns them sorted by the key, for the consumers draining the heap in batches.
+// Instead of k full ExtractMin cycles, the k minima are found by a best-first search from the roots,
+// and the heap is consolidated only once at the end.
+// The values of the tags inserted by Insert are returned as nil.
+// If any tag has been inserted more than once by InsertOrIncrement, the counts are consumed one by one as ExtractMinValue does.
+// An empty heap or a k smaller than 1 will return nil and extracts nothing.
+func (heap *FibHeap) ExtractMinK(k int) []Value {
+	if debugMode {
+		defer heap.guard.enter("ExtractMinK")()
+	}
+
+	if heap.num == 0 || k < 1 {
+		return nil
+	}
+
+	var values []Value
+	if len(heap.counts) != 0 {
+		for len(values) < k && heap.num != 0 {
+			if heap.decrement(heap.min) {
+				values = append(values, heap.min.value)
+				continue
+			}
+			min := heap.extractMin()
+			heap.fire(EventExtract, min, heap.keyOf(min))
+			values = append(values, min.value)
+		}
+
+		return values
+	}
+
+	var extracted []*node
+	candidates := NewKeyHeap()
+	for e := heap.roots.Front(); e != nil; e = e.Next() {
+		candidates.Insert(e.Value.(*node), e.Value.(*node).key)
+	}
+	for len(extracted) < k && candidates.Num() != 0 {
+		tag, _ := candidates.ExtractMin()
+		n := tag.(*node)
+		for e := n.children.Front(); e != nil; e = e.Next() {
+			candidates.Insert(e.Value.(*node), e.Value.(*node).key)
+		}
+
+		if n.parent == nil {
+			heap.removeRoot(n.self)
+		}
+		for e := n.children.Front(); e != nil; e = e.Next() {
+			e.Value.(*node).parent = nil
+			e.Value.(*node).self = heap.roots.PushBack(e.Value.(*node))
+		}
+		n.children.Init()
+		if n.dead {
+			heap.dead--
+			heap.recycle(n)
+			continue
+		}
+
+		delete(heap.index, n.tag)
+		delete(heap.weights, n.tag)
+		heap.num--
+		heap.logRemove(n.tag)
+		extracted = append(extracted, n)
+	}
+
+	heap.settle()
+	values = make([]Value, len(extracted))
+	for i, n := range extracted {
+		values[i] = n.value
+		heap.fire(EventExtract, n, heap.keyOf(n))
+		heap.recycle(n)
+	}
+
+	return values
+}
+
+// Maximum returns the current maximum tag and key in the heap sorted by the key.
+// Maximum will not extract the tag and key so the value will still exists in the heap.
+// FibHeap does not track the maximum, so Maximum scans all values in O(n). It is meant for reporting and operational tools.
+// Use MinMaxHeap instead if the maximum is needed frequently.
+// An empty heap will return nil and -inf.
+func (heap *FibHeap) Maximum() (interface{}, float64) {
+	if debugMode {
+		defer heap.guard.enter("Maximum")()
+	}
+
+	if heap.num == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	max := heap.maximum()
+
+	return max.tag, heap.keyOf(max)
+}
+
+// MaximumValue returns the current maximum value in the heap sorted by the key.
+// MaximumValue will not extract the value so the value will still exists in the heap.
+// MaximumValue scans all values in O(n).
+// An empty heap will return nil.
+func (heap *FibHeap) MaximumValue() Value {
+	if debugMode {
+		defer heap.guard.enter("MaximumValue")()
+	}
+
+	if heap.num == 0 {
+		return nil
+	}
+
+	return heap.maximum().value
+}
+
+// ExtractMax returns the current maximum tag and key in the heap and then extracts them from the heap.
+// ExtractMax scans all values in O(n) and then deletes the maximum in O(log n) amortized.
+// An empty heap will return nil/-inf and extracts nothing.
+func (heap *FibHeap) ExtractMax() (interface{}, float64) {
+	if debugMode {
+		defer heap.guard.enter("ExtractMax")()
+	}
+
+	if heap.num == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	max := heap.maximum()
+	key := heap.keyOf(max)
+	if !heap.decrement(max) {
+		heap.extractNode(max)
+	}
+
+	return max.tag, key
+}
+
+// ExtractMaxValue returns the current maximum value in the heap and then extracts it from the heap.
+// ExtractMaxValue scans all values in O(n) and then deletes the maximum in O(log n) amortized.
+// An empty heap will return nil and extracts nothing.
+func (heap *FibHeap) ExtractMaxValue() Value {
+	if debugMode {
+		defer heap.guard.enter("ExtractMaxValue")()
+	}
+
+	if heap.num == 0 {
+		return nil
+	}
+
+	max := heap.maximum()
+	if !heap.decrement(max) {
+		heap.extractNode(max)
+	}
+
+	return max.value
+}
+
+// Union moves all values of the input heap into the heap.
+// The input heap can be any implementation of PriorityQueue, and it is emptied afterwards so that no value is reachable from both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned and both heaps are left untouched.
+// If the input heap is a FibHeap as well, its trees are moved in as they are, so both the values and the tags inserted by Insert are kept
+// and only the index is merged value by value.
+func (heap *FibHeap) Union(anotherHeap PriorityQueue) error {
+	if debugMode {
+		defer heap.guard.enter("Union")()
+	}
+
+	return heap.union(context.Background(), anotherHeap)
+}
+
+func (heap *FibHeap) union(ctx context.Context, anotherHeap PriorityQueue) error {
+	if another, ok := anotherHeap.(*FibHeap); ok && another != heap {
+		return heap.merge(ctx, another)
+	}
+
+	if err := heap.unionInto(ctx, anotherHeap); err != nil {
+		return err
+	}
+
+	anotherHeap.reset()
+
+	return nil
+}
+
+// merge moves all nodes of another FibHeap into the heap by appending its trees to the roots, instead of inserting the values one by one.
+// The context is only checked while looking for duplicate tags, before anything is moved.
+func (heap *FibHeap) merge(ctx context.Context, another *FibHeap) error {
+	if heap.observing() {
+		var err error
+		heap.observe("union", func() { err = heap.merge(ctx, another) })
+		return err
+	}
+
+	i := 0
+	for tag := range another.index {
 		if _, exists := heap.index[tag]; exists {
 			return errors.New("Duplicate tag is found in the target heap ")
 		}
+		if err := cancelled(ctx, i); err != nil {
+			return err
+		}
+		i++
+	}
+	if err := heap.checkQuota(another.num); err != nil {
+		return err
+	}
+
+	roots, index, min, dead, offset := another.roots, another.index, another.min, another.dead, another.offset
+	another.reset()
+
+	if delta := offset - heap.offset; delta != 0 {
+		shiftKeys(roots, delta)
+	}
+	for e := roots.Front(); e != nil; e = e.Next() {
+		e.Value.(*node).self = heap.roots.PushBack(e.Value.(*node))
+	}
+	for tag, n := range index {
+		heap.index[tag] = n
+		heap.logPut(n)
+	}
+	heap.num += uint(len(index))
+	heap.dead += dead
+
+	if min != nil && (heap.min == nil || min.key < heap.min.key) {
+		heap.min = min
+	}
+	for _, n := range index {
+		heap.fire(EventInsert, n, heap.keyOf(n))
+	}
+
+	return nil
+}
+
+// shiftKeys adds the delta to the keys of all nodes of the trees.
+func shiftKeys(trees *list.List, delta float64) {
+	for e := trees.Front(); e != nil; e = e.Next() {
+		e.Value.(*node).key += delta
+		shiftKeys(e.Value.(*node).children, delta)
+	}
+}
+
+// UnionInto merges copies of all values of the input heap into the heap.
+// The input heap can be any implementation of PriorityQueue, and it is left untouched, so the values are shared by both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned.
+func (heap *FibHeap) UnionInto(anotherHeap PriorityQueue) error {
+	if debugMode {
+		defer heap.guard.enter("UnionInto")()
+	}
+
+	return heap.unionInto(context.Background(), anotherHeap)
+}
+
+// unionInto inserts copies of all values of the input heap, and deletes them all again if the context is cancelled in the middle.
+func (heap *FibHeap) unionInto(ctx context.Context, anotherHeap PriorityQueue) error {
+	if err := checkUnion(heap, anotherHeap); err != nil {
+		return err
+	}
+	if err := heap.checkQuota(anotherHeap.Num()); err != nil {
+		return err
+	}
+
+	var inserted []*node
+	var err error
+	anotherHeap.each(func(tag interface{}, key float64, value Value) {
+		if err == nil {
+			err = cancelled(ctx, len(inserted))
+		}
+		if err == nil && heap.insert(tag, key, value) == nil {
+			inserted = append(inserted, heap.index[tag])
+		}
+	})
+
+	if err != nil {
+		for _, n := range inserted {
+			heap.remove(n)
+		}
+	}
+
+	return err
+}
+
+// UnionNew creates a new heap with copies of all values of both input heaps, and both input heaps are left untouched.
+// The values themselves are shared by the new heap and the input heaps rather than copied.
+// The input heaps must not have duplicate tags. Otherwise an error will be returned.
+func UnionNew(a, b *FibHeap) (*FibHeap, error) {
+	heap := NewFibHeap()
+	if err := heap.UnionInto(a); err != nil {
+		return nil, err
+	}
+	if err := heap.UnionInto(b); err != nil {
+		return nil, err
+	}
+
+	return heap, nil
+}
+
+// Subtract deletes all values in the heap whose tags also exist in the input heap, regardless of their keys.
+// The input heap is left untouched.
+func (heap *FibHeap) Subtract(anotherHeap *FibHeap) {
+	if debugMode {
+		defer heap.guard.enter("Subtract")()
+	}
+
+	for tag := range anotherHeap.index {
+		if node, exists := heap.index[tag]; exists {
+			heap.remove(node)
+		}
+	}
+}
+
+// DeleteWhere deletes all values in the heap for which the input predicate returns true, and returns the number of deleted values.
+// The matched nodes are cut out in one pass followed by a single consolidation, instead of one ExtractMin per deleted value.
+// The predicate is called once for every value in no particular order, and it must not modify the heap.
+func (heap *FibHeap) DeleteWhere(pred func(tag interface{}, key float64, value Value) bool) int {
+	if debugMode {
+		defer heap.guard.enter("DeleteWhere")()
+	}
+
+	deleted, _ := heap.deleteWhere(context.Background(), pred)
+
+	return deleted
+}
+
+// deleteWhere deletes all values matching the predicate, or none of them if the context is cancelled before all values are matched.
+func (heap *FibHeap) deleteWhere(ctx context.Context, pred func(tag interface{}, key float64, value Value) bool) (int, error) {
+	var matched []*node
+	i := 0
+	for _, n := range heap.index {
+		if err := cancelled(ctx, i); err != nil {
+			return 0, err
+		}
+		i++
+		if pred(n.tag, heap.keyOf(n), n.value) {
+			matched = append(matched, n)
+		}
+	}
+
+	if len(matched) == 0 {
+		return 0, nil
+	}
+
+	for _, n := range matched {
+		heap.detach(n)
+	}
+
+	if heap.num == 0 {
+		heap.min = nil
+	} else {
+		heap.consolidate()
+	}
+	for _, n := range matched {
+		heap.fire(EventDelete, n, heap.keyOf(n))
+	}
+
+	return len(matched), nil
+}
+
+// TransformKeys replaces every key in the heap by f(key), e.g. to re-normalize all priorities.
+// If f keeps the order of the keys, i.e. it is non-decreasing like scaling by a positive factor or adding a constant,
+// the trees are kept as they are and the transformation takes O(n). Otherwise the heap is rebuilt from all values with one consolidation.
+// If f returns -inf for any key, an error will be returned and the heap is left untouched.
+func (heap *FibHeap) TransformKeys(f func(old float64) float64) error {
+	if debugMode {
+		defer heap.guard.enter("TransformKeys")()
+	}
+
+	nodes := make([]*node, 0, heap.num)
+	keys := make([]float64, 0, heap.num)
+	for _, n := range heap.index {
+		key := f(heap.keyOf(n))
+		if math.IsInf(key, -1) {
+			return errors.New("Negative infinity key is reserved for internal usage ")
+		}
+		nodes = append(nodes, n)
+		keys = append(keys, key)
+	}
+
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	heap.offset = 0
+	for i, n := range nodes {
+		n.key = keys[i]
+		heap.logPut(n)
+	}
+
+	// The keys of the dead nodes are not transformed, so they may break the order of the trees.
+	ordered := heap.dead == 0
+	for _, n := range nodes {
+		if n.parent != nil && n.key < n.parent.key {
+			ordered = false
+			break
+		}
 	}
 
-	for _, node := range anotherHeap.index {
-		heap.InsertValue(node.value)
+	if ordered {
+		heap.resetMin()
+	} else {
+		heap.rebuild(nodes)
+	}
+
+	for _, n := range nodes {
+		heap.fire(EventKeyChange, n, n.key)
 	}
 
 	return nil
 }
 
+// AddToAllKeys adds the input delta to the keys of all values in the heap in O(1), e.g. to age all priorities at once.
+// The delta is kept as a global offset which is applied lazily whenever a key is read, so the trees are never touched.
+// The keys inserted or updated afterwards are stored relative to the offset, so they may be returned with a rounding error in the magnitude of the offset.
+// If the write-ahead log mode is on or an OnKeyChange hook is set, the new keys of all values are logged or reported, which takes O(n).
+// Otherwise only the watched tags are reported to their watchers, see Watch.
+// A delta of inf or NaN will cause a panic.
+func (heap *FibHeap) AddToAllKeys(delta float64) {
+	if debugMode {
+		defer heap.guard.enter("AddToAllKeys")()
+	}
+
+	if math.IsInf(delta, 0) || math.IsNaN(delta) {
+		panic("fibHeap: delta of AddToAllKeys must be finite")
+	}
+
+	heap.offset += delta
+	if heap.wal != nil || heap.hooks.OnKeyChange != nil {
+		for _, n := range heap.index {
+			heap.logPut(n)
+			heap.fire(EventKeyChange, n, heap.keyOf(n))
+		}
+	} else if len(heap.watchers) != 0 {
+		for tag := range heap.watchers {
+			heap.fire(EventKeyChange, heap.index[tag], heap.keyOf(heap.index[tag]))
+		}
+	}
+	heap.minChanged()
+}
+
+// Equal reports whether both heaps have exactly the same tags with the same keys.
+// The values and the inner topologies of the heaps are not compared.
+func (heap *FibHeap) Equal(anotherHeap *FibHeap) bool {
+	if debugMode {
+		defer heap.guard.enter("Equal")()
+	}
+
+	if heap.num != anotherHeap.num {
+		return false
+	}
+
+	for tag, node := range heap.index {
+		if anotherNode, exists := anotherHeap.index[tag]; !exists || anotherHeap.keyOf(anotherNode) != heap.keyOf(node) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Diff compares the tags and keys of both heaps without extracting anything.
+// It returns the tags only in the heap, the tags only in the input heap and the common tags with different keys, all in no particular order.
+func (heap *FibHeap) Diff(anotherHeap *FibHeap) (onlyA, onlyB, keyChanged []interface{}) {
+	if debugMode {
+		defer heap.guard.enter("Diff")()
+	}
+
+	for tag, node := range heap.index {
+		if anotherNode, exists := anotherHeap.index[tag]; !exists {
+			onlyA = append(onlyA, tag)
+		} else if anotherHeap.keyOf(anotherNode) != heap.keyOf(node) {
+			keyChanged = append(keyChanged, tag)
+		}
+	}
+
+	for tag := range anotherHeap.index {
+		if _, exists := heap.index[tag]; !exists {
+			onlyB = append(onlyB, tag)
+		}
+	}
+
+	return
+}
+
+// TagKey is a tag and its key in the heap.
+type TagKey struct {
+	Tag interface{}
+	Key float64
+}
+
+// Tags returns a snapshot of all tags in the heap in no particular order.
+// The heap is left untouched, and an empty heap will return nil.
+func (heap *FibHeap) Tags() []interface{} {
+	if debugMode {
+		defer heap.guard.enter("Tags")()
+	}
+
+	if heap.num == 0 {
+		return nil
+	}
+
+	tags := make([]interface{}, 0, heap.num)
+	for tag := range heap.index {
+		tags = append(tags, tag)
+	}
+
+	return tags
+}
+
+// Entries returns a snapshot of all tags and their keys in the heap in no particular order.
+// The heap is left untouched, and an empty heap will return nil.
+func (heap *FibHeap) Entries() []TagKey {
+	if debugMode {
+		defer heap.guard.enter("Entries")()
+	}
+
+	if heap.num == 0 {
+		return nil
+	}
+
+	entries := make([]TagKey, 0, heap.num)
+	for tag, node := range heap.index {
+		entries = append(entries, TagKey{tag, heap.keyOf(node)})
+	}
+
+	return entries
+}
+
+// Values returns a snapshot of all values in the heap in O(n) and in no particular order.
+// The tags inserted by Insert have no value and are skipped, and a heap without any value will return nil.
+func (heap *FibHeap) Values() []Value {
+	if debugMode {
+		defer heap.guard.enter("Values")()
+	}
+
+	var values []Value
+	for _, node := range heap.index {
+		if node.value != nil {
+			values = append(values, node.value)
+		}
+	}
+
+	return values
+}
+
 // DecreaseKey updates the tag in the heap by the input key.
+// The value of the tag inserted by InsertValue is kept as it is, even though its Key no longer matches the key in the heap.
 // If the input key has a larger key or -inf key, an error will be returned.
 // If the input tag is not existed in the heap, an error will be returned.
 // DecreaseKey will check the nil interface but not the interface with nil value.
 // Try to input of an interface with nil value will cause invalid address panic.
 func (heap *FibHeap) DecreaseKey(tag interface{}, key float64) error {
+	if debugMode {
+		defer heap.guard.enter("DecreaseKey")()
+	}
+
+	tag = tagKey(tag)
 	if tag == nil {
 		return errors.New("Input tag is nil ")
 	}
@@ -167,7 +903,7 @@ func (heap *FibHeap) DecreaseKey(tag interface{}, key float64) error {
 	}
 
 	if node, exists := heap.index[tag]; exists {
-		return heap.decreaseKey(node, nil, key)
+		return heap.decreaseKey(node, node.value, key)
 	}
 
 	return errors.New("Value is not found ")
@@ -176,18 +912,22 @@ func (heap *FibHeap) DecreaseKey(tag interface{}, key float64) error {
 // DecreaseKeyValue updates the value in the heap by the input value.
 // If the input value has a larger key or -inf key, an error will be returned.
 // If the tag of the input value is not existed in the heap, an error will be returned.
-// DecreaseKeyValue will check the nil interface but not the interface with nil value.
-// Try to input of an interface with nil value will cause invalid address panic.
+// DecreaseKeyValue returns ErrNilValue for a nil interface, and for an interface with nil value as well,
+// so that Tag and Key of the value are never called on a nil pointer.
 func (heap *FibHeap) DecreaseKeyValue(value Value) error {
-	if value == nil {
-		return errors.New("Input value is nil ")
+	if debugMode {
+		defer heap.guard.enter("DecreaseKeyValue")()
+	}
+
+	if isNilValue(value) {
+		return ErrNilValue
 	}
 
 	if math.IsInf(value.Key(), -1) {
 		return errors.New("Negative infinity key is reserved for internal usage ")
 	}
 
-	if node, exists := heap.index[value.Tag()]; exists {
+	if node, exists := heap.index[tagKey(value.Tag())]; exists {
 		return heap.decreaseKey(node, value, value.Key())
 	}
 
@@ -195,11 +935,17 @@ func (heap *FibHeap) DecreaseKeyValue(value Value) error {
 }
 
 // IncreaseKey updates the tag in the heap by the input key.
+// The value of the tag inserted by InsertValue is kept as it is, even though its Key no longer matches the key in the heap.
 // If the input key has a smaller key or -inf key, an error will be returned.
 // If the input tag is not existed in the heap, an error will be returned.
 // IncreaseKey will check the nil interface but not the interface with nil value.
 // Try to input of an interface with nil value will cause invalid address panic.
 func (heap *FibHeap) IncreaseKey(tag interface{}, key float64) error {
+	if debugMode {
+		defer heap.guard.enter("IncreaseKey")()
+	}
+
+	tag = tagKey(tag)
 	if tag == nil {
 		return errors.New("Input tag is nil ")
 	}
@@ -209,7 +955,7 @@ func (heap *FibHeap) IncreaseKey(tag interface{}, key float64) error {
 	}
 
 	if node, exists := heap.index[tag]; exists {
-		return heap.increaseKey(node, nil, key)
+		return heap.increaseKey(node, node.value, key)
 	}
 
 	return errors.New("Value is not found ")
@@ -218,56 +964,110 @@ func (heap *FibHeap) IncreaseKey(tag interface{}, key float64) error {
 // IncreaseKeyValue updates the value in the heap by the input value.
 // If the input value has a smaller key or -inf key, an error will be returned.
 // If the tag of the input value is not existed in the heap, an error will be returned.
-// IncreaseKeyValue will check the nil interface but not the interface with nil value.
-// Try to input of an interface with nil value will cause invalid address panic.
+// IncreaseKeyValue returns ErrNilValue for a nil interface, and for an interface with nil value as well,
+// so that Tag and Key of the value are never called on a nil pointer.
 func (heap *FibHeap) IncreaseKeyValue(value Value) error {
-	if value == nil {
-		return errors.New("Input value is nil ")
+	if debugMode {
+		defer heap.guard.enter("IncreaseKeyValue")()
+	}
+
+	if isNilValue(value) {
+		return ErrNilValue
 	}
 
 	if math.IsInf(value.Key(), -1) {
 		return errors.New("Negative infinity key is reserved for internal usage ")
 	}
 
-	if node, exists := heap.index[value.Tag()]; exists {
+	if node, exists := heap.index[tagKey(value.Tag())]; exists {
 		return heap.increaseKey(node, value, value.Key())
 	}
 
 	return errors.New("Value is not found ")
 }
 
+// AdjustKey adds the input delta to the key of the input tag, decreasing it by a negative delta and increasing it by a positive one,
+// without a GetTag and DecreaseKey/IncreaseKey round trip. A delta which does not change the key is a no-op.
+// If the input tag is not existed in the heap, or the delta is NaN or makes the key -inf, an error will be returned.
+func (heap *FibHeap) AdjustKey(tag interface{}, delta float64) error {
+	if debugMode {
+		defer heap.guard.enter("AdjustKey")()
+	}
+
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if math.IsNaN(delta) {
+		return errors.New("Delta is NaN ")
+	}
+
+	node, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Value is not found ")
+	}
+
+	current := heap.keyOf(node)
+	key := current + delta
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if key < current {
+		return heap.decreaseKey(node, node.value, key)
+	}
+	if key > current {
+		return heap.increaseKey(node, node.value, key)
+	}
+
+	return nil
+}
+
 // Delete deletes the input tag in the heap.
 // If the input tag is not existed in the heap, an error will be returned.
+// Deleting a tag other than the minimum cuts its node out and promotes its children without any consolidation.
 // Delete will check the nil interface but not the interface with nil value.
 // Try to input of an interface with nil value will cause invalid address panic.
 func (heap *FibHeap) Delete(tag interface{}) error {
+	if debugMode {
+		defer heap.guard.enter("Delete")()
+	}
+
+	tag = tagKey(tag)
 	if tag == nil {
 		return errors.New("Input tag is nil ")
 	}
 
-	if _, exists := heap.index[tag]; !exists {
+	node, exists := heap.index[tag]
+	if !exists {
 		return errors.New("Tag is not found ")
 	}
 
-	heap.ExtractValue(tag)
+	heap.remove(node)
 
 	return nil
 }
 
 // DeleteValue deletes the value in the heap by the input value.
 // If the tag of the input value is not existed in the heap, an error will be returned.
-// DeleteValue will check the nil interface but not the interface with nil value.
-// Try to input of an interface with nil value will cause invalid address panic.
+// DeleteValue returns ErrNilValue for a nil interface, and for an interface with nil value as well,
+// so that Tag and Key of the value are never called on a nil pointer.
 func (heap *FibHeap) DeleteValue(value Value) error {
-	if value == nil {
-		return errors.New("Input value is nil ")
+	if debugMode {
+		defer heap.guard.enter("DeleteValue")()
 	}
 
-	if _, exists := heap.index[value.Tag()]; !exists {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	node, exists := heap.index[tagKey(value.Tag())]
+	if !exists {
 		return errors.New("Value is not found ")
 	}
 
-	heap.ExtractValue(value.Tag())
+	heap.remove(node)
 
 	return nil
 }
@@ -276,8 +1076,13 @@ func (heap *FibHeap) DeleteValue(value Value) error {
 // If the input tag does not exist in the heap, nil will be returned.
 // GetTag will not extract the value so the value will still exist in the heap.
 func (heap *FibHeap) GetTag(tag interface{}) (key float64) {
+	if debugMode {
+		defer heap.guard.enter("GetTag")()
+	}
+
+	tag = tagKey(tag)
 	if node, exists := heap.index[tag]; exists {
-		return node.key
+		return heap.keyOf(node)
 	}
 
 	return math.Inf(-1)
@@ -287,6 +1092,11 @@ func (heap *FibHeap) GetTag(tag interface{}) (key float64) {
 // If the input tag does not exist in the heap, nil will be returned.
 // GetValue will not extract the value so the value will still exist in the heap.
 func (heap *FibHeap) GetValue(tag interface{}) (value Value) {
+	if debugMode {
+		defer heap.guard.enter("GetValue")()
+	}
+
+	tag = tagKey(tag)
 	if node, exists := heap.index[tag]; exists {
 		value = node.value
 	}
@@ -298,9 +1108,16 @@ func (heap *FibHeap) GetValue(tag interface{}) (value Value) {
 // If the input tag does not exist in the heap, nil will be returned.
 // ExtractTag will extract the value so the value will no longer exist in the heap.
 func (heap *FibHeap) ExtractTag(tag interface{}) (key float64) {
+	if debugMode {
+		defer heap.guard.enter("ExtractTag")()
+	}
+
+	tag = tagKey(tag)
 	if node, exists := heap.index[tag]; exists {
-		key = node.key
-		heap.deleteNode(node)
+		key = heap.keyOf(node)
+		if !heap.decrement(node) {
+			heap.extractNode(node)
+		}
 		return
 	}
 
@@ -311,9 +1128,16 @@ func (heap *FibHeap) ExtractTag(tag interface{}) (key float64) {
 // If the input tag does not exist in the heap, nil will be returned.
 // ExtractValue will extract the value so the value will no longer exist in the heap.
 func (heap *FibHeap) ExtractValue(tag interface{}) (value Value) {
+	if debugMode {
+		defer heap.guard.enter("ExtractValue")()
+	}
+
+	tag = tagKey(tag)
 	if node, exists := heap.index[tag]; exists {
 		value = node.value
-		heap.deleteNode(node)
+		if !heap.decrement(node) {
+			heap.extractNode(node)
+		}
 		return
 	}
 
@@ -322,15 +1146,19 @@ func (heap *FibHeap) ExtractValue(tag interface{}) (value Value) {
 
 // String provides some basic debug information of the heap.
 // It returns the total number, roots size, index size and current minimum value of the heap.
-// It also returns the topology of the trees by dfs search.
+// It also returns the topology of the trees by dfs search, in which the dead nodes of the lazy deletion mode are shown in parentheses.
 func (heap *FibHeap) String() string {
+	if debugMode {
+		defer heap.guard.enter("String")()
+	}
+
 	var buffer bytes.Buffer
 
 	if heap.num != 0 {
 		buffer.WriteString(fmt.Sprintf("Total number: %d, Root Size: %d, Index size: %d,\n", heap.num, heap.roots.Len(), len(heap.index)))
-		buffer.WriteString(fmt.Sprintf("Current minimun: key(%f), tag(%v), value(%v),\n", heap.min.key, heap.min.tag, heap.min.value))
+		buffer.WriteString(fmt.Sprintf("Current minimun: key(%f), tag(%v), value(%v),\n", heap.keyOf(heap.min), heap.min.tag, heap.min.value))
 		buffer.WriteString(fmt.Sprintf("Heap detail:\n"))
-		probeTree(&buffer, heap.roots)
+		probeTree(&buffer, heap.roots, heap.offset)
 		buffer.WriteString(fmt.Sprintf("\n"))
 	} else {
 		buffer.WriteString(fmt.Sprintf("Heap is empty.\n"))
@@ -339,20 +1167,33 @@ func (heap *FibHeap) String() string {
 	return buffer.String()
 }
 
-func probeTree(buffer *bytes.Buffer, tree *list.List) {
+func probeTree(buffer *bytes.Buffer, tree *list.List, offset float64) {
 	buffer.WriteString(fmt.Sprintf("< "))
 	for e := tree.Front(); e != nil; e = e.Next() {
-		buffer.WriteString(fmt.Sprintf("%f ", e.Value.(*node).key))
+		if e.Value.(*node).dead {
+			buffer.WriteString(fmt.Sprintf("(%f) ", e.Value.(*node).key+offset))
+		} else {
+			buffer.WriteString(fmt.Sprintf("%f ", e.Value.(*node).key+offset))
+		}
 		if e.Value.(*node).children.Len() != 0 {
-			probeTree(buffer, e.Value.(*node).children)
+			probeTree(buffer, e.Value.(*node).children, offset)
 		}
 	}
 	buffer.WriteString(fmt.Sprintf("> "))
 }
 
 func (heap *FibHeap) consolidate() {
+	if heap.observing() {
+		heap.observe("consolidate", heap.consolidate)
+		return
+	}
+
+	heap.purgeRoots()
+	heap.fitDegrees()
 	for tree := heap.roots.Front(); tree != nil; tree = tree.Next() {
-		heap.treeDegrees[tree.Value.(*node).position] = nil
+		if tree.Value.(*node).position < uint(len(heap.treeDegrees)) {
+			heap.treeDegrees[tree.Value.(*node).position] = nil
+		}
 	}
 
 	for tree := heap.roots.Front(); tree != nil; {
@@ -383,11 +1224,40 @@ func (heap *FibHeap) consolidate() {
 		heap.treeDegrees[tree.Value.(*node).degree] = tree
 		tree.Value.(*node).position = tree.Value.(*node).degree
 	}
+	heap.done = heap.roots.Back()
 
 	heap.resetMin()
 }
 
+// keyOf returns the key of the node with the lazy offset applied.
+func (heap *FibHeap) keyOf(n *node) float64 {
+	return n.key + heap.offset
+}
+
+func (heap *FibHeap) reset() {
+	if heap.hooks.OnDelete != nil || len(heap.watchers) != 0 {
+		for _, n := range heap.index {
+			heap.fire(EventDelete, n, heap.keyOf(n))
+		}
+	}
+
+	wal, hooks, reported, parent, lazy, budget, order, sketch, sequenced, profiled, phase, logger, quota, guard :=
+		heap.wal, heap.hooks, heap.reported, heap.parent, heap.lazy, heap.budget, heap.order, heap.sketch, heap.sequenced, heap.profiled, heap.phase, heap.logger, heap.quota, heap.guard
+	*heap = *NewFibHeap()
+	heap.wal, heap.hooks, heap.reported, heap.parent, heap.lazy, heap.budget, heap.order, heap.sketch, heap.sequenced, heap.profiled, heap.phase, heap.logger, heap.quota, heap.guard =
+		wal, hooks, reported, parent, lazy, budget, order, sketch, sequenced, profiled, phase, logger, quota, guard
+	heap.logClear()
+	heap.minChanged()
+}
+
+func (heap *FibHeap) each(fn func(tag interface{}, key float64, value Value)) {
+	for _, node := range heap.index {
+		fn(node.tag, heap.keyOf(node), node.value)
+	}
+}
+
 func (heap *FibHeap) insert(tag interface{}, key float64, value Value) error {
+	tag = tagKey(tag)
 	if math.IsInf(key, -1) {
 		return errors.New("Negative infinity key is reserved for internal usage ")
 	}
@@ -395,12 +1265,18 @@ func (heap *FibHeap) insert(tag interface{}, key float64, value Value) error {
 	if _, exists := heap.index[tag]; exists {
 		return errors.New("Duplicate tag is not allowed ")
 	}
+	if err := heap.checkQuota(1); err != nil {
+		return err
+	}
 
-	node := new(node)
-	node.children = list.New()
+	node := heap.newNode()
 	node.tag = tag
-	node.key = key
+	node.key = key - heap.offset
 	node.value = value
+	node.seq = 0
+	if heap.sequenced {
+		node.seq = atomic.AddUint64(&insertionSeq, 1)
+	}
 
 	node.self = heap.roots.PushBack(node)
 	heap.index[node.tag] = node
@@ -409,6 +1285,11 @@ func (heap *FibHeap) insert(tag interface{}, key float64, value Value) error {
 	if heap.min == nil || heap.min.key > node.key {
 		heap.min = node
 	}
+	if heap.budget != 0 {
+		heap.step()
+	}
+	heap.logPut(node)
+	heap.fire(EventInsert, node, heap.keyOf(node))
 
 	return nil
 }
@@ -424,23 +1305,96 @@ func (heap *FibHeap) extractMin() *node {
 		}
 	}
 
-	heap.roots.Remove(heap.min.self)
-	heap.treeDegrees[min.position] = nil
+	heap.removeRoot(min.self)
 	delete(heap.index, heap.min.tag)
+	delete(heap.counts, heap.min.tag)
+	delete(heap.weights, heap.min.tag)
 	heap.num--
+	heap.logRemove(min.tag)
+
+	heap.settle()
+	heap.recycle(min)
 
+	return min
+}
+
+// settle consolidates the roots after an extraction and finds the new minimum.
+func (heap *FibHeap) settle() {
 	if heap.num == 0 {
 		heap.min = nil
-	} else {
+	} else if heap.budget == 0 {
 		heap.consolidate()
+	} else {
+		heap.step()
+		heap.resetMin()
 	}
-
-	return min
 }
 
+// deleteNode deletes the node out of the heap.
+// Only the minimum is extracted with a consolidation, while any other node is cut out and its children are promoted to roots,
+// so the minimum is unchanged and no consolidation is needed.
 func (heap *FibHeap) deleteNode(n *node) {
-	heap.decreaseKey(n, n.value, math.Inf(-1))
-	heap.ExtractMinValue()
+	if n == heap.min {
+		heap.extractMin()
+		return
+	}
+	if heap.lazy {
+		heap.bury(n)
+		return
+	}
+
+	heap.detach(n)
+	if heap.budget != 0 {
+		heap.step()
+	}
+	heap.recycle(n)
+}
+
+// detach cuts the node out of its tree, promotes its children to roots and removes it from the index.
+func (heap *FibHeap) detach(n *node) {
+	if n.parent != nil {
+		parent := n.parent
+		heap.cut(n)
+		heap.cascadingCut(parent)
+	}
+
+	for e := n.children.Front(); e != nil; e = e.Next() {
+		e.Value.(*node).parent = nil
+		e.Value.(*node).self = heap.roots.PushBack(e.Value.(*node))
+	}
+	heap.removeRoot(n.self)
+	delete(heap.index, n.tag)
+	delete(heap.counts, n.tag)
+	delete(heap.weights, n.tag)
+	heap.num--
+	heap.logRemove(n.tag)
+}
+
+// decrement consumes one count of the node inserted more than once by InsertOrIncrement, and reports whether the node is kept.
+func (heap *FibHeap) decrement(n *node) bool {
+	count := heap.counts[n.tag]
+	if count <= 1 {
+		return false
+	}
+
+	if count == 2 {
+		delete(heap.counts, n.tag)
+	} else {
+		heap.counts[n.tag] = count - 1
+	}
+
+	return true
+}
+
+func (heap *FibHeap) maximum() *node {
+	var max *node
+	for _, n := range heap.index {
+		if max == nil || n.key > max.key {
+			max = n
+		}
+	}
+
+	return max
 }
 
 func (heap *FibHeap) link(parent, child *node) {
@@ -451,6 +1405,7 @@ func (heap *FibHeap) link(parent, child *node) {
 }
 
 func (heap *FibHeap) resetMin() {
+	heap.purgeRoots()
 	heap.min = heap.roots.Front().Value.(*node)
 	for tree := heap.min.self.Next(); tree != nil; tree = tree.Next() {
 		if tree.Value.(*node).key < heap.min.key {
@@ -460,6 +1415,7 @@ func (heap *FibHeap) resetMin() {
 }
 
 func (heap *FibHeap) decreaseKey(n *node, value Value, key float64) error {
+	key -= heap.offset
 	if key >= n.key {
 		return errors.New("New key is not smaller than current key ")
 	}
@@ -477,31 +1433,50 @@ func (heap *FibHeap) decreaseKey(n *node, value Value, key float64) error {
 	if n.parent == nil && n.key < heap.min.key {
 		heap.min = n
 	}
+	if heap.budget != 0 {
+		heap.step()
+	}
+	if !math.IsInf(key, -1) {
+		heap.logPut(n)
+		heap.fire(EventKeyChange, n, heap.keyOf(n))
+		if heap.blockers != nil {
+			heap.inherit(n)
+		}
+	}
 
 	return nil
 }
 
 func (heap *FibHeap) increaseKey(n *node, value Value, key float64) error {
+	key -= heap.offset
 	if key <= n.key {
 		return errors.New("New key is not larger than current key ")
 	}
 
+	// The node is cut out as a single root, and all its children are promoted to roots,
+	// so the heap order holds regardless of the keys of the children and their subtrees.
 	n.key = key
 	n.value = value
-
-	child := n.children.Front()
-	for child != nil {
-		childNode := child.Value.(*node)
-		child = child.Next()
-		if childNode.key < n.key {
-			heap.cut(childNode)
-			heap.cascadingCut(n)
-		}
+	if n.parent != nil {
+		parent := n.parent
+		heap.cut(n)
+		heap.cascadingCut(parent)
+	}
+	for e := n.children.Front(); e != nil; e = e.Next() {
+		e.Value.(*node).parent = nil
+		e.Value.(*node).self = heap.roots.PushBack(e.Value.(*node))
 	}
+	n.children.Init()
+	n.degree = 0
 
 	if heap.min == n {
 		heap.resetMin()
 	}
+	if heap.budget != 0 {
+		heap.step()
+	}
+	heap.logPut(n)
+	heap.fire(EventKeyChange, n, heap.keyOf(n))
 
 	return nil
 }
@@ -515,6 +1490,11 @@ func (heap *FibHeap) cut(n *node) {
 }
 
 func (heap *FibHeap) cascadingCut(n *node) {
+	if n.marked && n.parent != nil && heap.observing() {
+		heap.observe("cascadingCut", func() { heap.cascadingCut(n) })
+		return
+	}
+
 	if n.parent != nil {
 		if !n.marked {
 			n.marked = true
@@ -525,3 +1505,17 @@ func (heap *FibHeap) cascadingCut(n *node) {
 		}
 	}
 }
+
+// isNilValue reports whether the value is nil or an interface holding a nil pointer, map, slice, channel or function.
+func isNilValue(value Value) bool {
+	if value == nil {
+		return true
+	}
+
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		return v.IsNil()
+	}
+
+	return false
+}
@@ -0,0 +1,218 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"container/heap"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+var benchSizes = []int{1000, 10000, 100000}
+
+func benchKeys(n int) []float64 {
+	rnd := rand.New(rand.NewSource(int64(n)))
+	keys := make([]float64, n)
+	for i := range keys {
+		keys[i] = rnd.Float64()
+	}
+
+	return keys
+}
+
+func newFilledFibHeap(keys []float64, offset int) *FibHeap {
+	fib := NewFibHeap()
+	for i, key := range keys {
+		fib.Insert(i+offset, key)
+	}
+
+	return fib
+}
+
+func BenchmarkFibHeapInsert(b *testing.B) {
+	for _, n := range benchSizes {
+		keys := benchKeys(n)
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				newFilledFibHeap(keys, 0)
+			}
+		})
+	}
+}
+
+func BenchmarkFibHeapExtractMin(b *testing.B) {
+	for _, n := range benchSizes {
+		keys := benchKeys(n)
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				fib := newFilledFibHeap(keys, 0)
+				b.StartTimer()
+				for fib.Num() != 0 {
+					fib.ExtractMin()
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFibHeapDecreaseKey(b *testing.B) {
+	for _, n := range benchSizes {
+		keys := benchKeys(n)
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				fib := newFilledFibHeap(keys, 0)
+				fib.ExtractMin()
+				b.StartTimer()
+				for tag, key := range keys {
+					fib.DecreaseKey(tag, key-1)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFibHeapUnion(b *testing.B) {
+	for _, n := range benchSizes {
+		keys := benchKeys(n)
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				fib := newFilledFibHeap(keys, 0)
+				another := newFilledFibHeap(keys, n)
+				b.StartTimer()
+				fib.Union(another)
+			}
+		})
+	}
+}
+
+// binaryHeap is an indexed binary heap built on container/heap, used as the comparison baseline.
+type binaryHeap struct {
+	items []*binaryItem
+	index map[interface{}]*binaryItem
+}
+
+type binaryItem struct {
+	tag      interface{}
+	key      float64
+	position int
+}
+
+func newBinaryHeap() *binaryHeap {
+	return &binaryHeap{index: make(map[interface{}]*binaryItem)}
+}
+
+func (h *binaryHeap) Len() int           { return len(h.items) }
+func (h *binaryHeap) Less(i, j int) bool { return h.items[i].key < h.items[j].key }
+
+func (h *binaryHeap) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].position = i
+	h.items[j].position = j
+}
+
+func (h *binaryHeap) Push(x interface{}) {
+	item := x.(*binaryItem)
+	item.position = len(h.items)
+	h.items = append(h.items, item)
+	h.index[item.tag] = item
+}
+
+func (h *binaryHeap) Pop() interface{} {
+	item := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	delete(h.index, item.tag)
+
+	return item
+}
+
+func (h *binaryHeap) decreaseKey(tag interface{}, key float64) {
+	item := h.index[tag]
+	item.key = key
+	heap.Fix(h, item.position)
+}
+
+func newFilledBinaryHeap(keys []float64) *binaryHeap {
+	bin := newBinaryHeap()
+	for i, key := range keys {
+		heap.Push(bin, &binaryItem{tag: i, key: key})
+	}
+
+	return bin
+}
+
+func BenchmarkContainerHeapInsert(b *testing.B) {
+	for _, n := range benchSizes {
+		keys := benchKeys(n)
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				newFilledBinaryHeap(keys)
+			}
+		})
+	}
+}
+
+func BenchmarkContainerHeapExtractMin(b *testing.B) {
+	for _, n := range benchSizes {
+		keys := benchKeys(n)
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				bin := newFilledBinaryHeap(keys)
+				b.StartTimer()
+				for bin.Len() != 0 {
+					heap.Pop(bin)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkContainerHeapDecreaseKey(b *testing.B) {
+	for _, n := range benchSizes {
+		keys := benchKeys(n)
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				bin := newFilledBinaryHeap(keys)
+				b.StartTimer()
+				for tag, key := range keys {
+					bin.decreaseKey(tag, key-1)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkContainerHeapUnion(b *testing.B) {
+	for _, n := range benchSizes {
+		keys := benchKeys(n)
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				bin := newFilledBinaryHeap(keys)
+				another := newFilledBinaryHeap(keys)
+				b.StartTimer()
+				for _, item := range another.items {
+					bin.items = append(bin.items, &binaryItem{tag: item.tag.(int) + n, key: item.key})
+				}
+				for j := range bin.items {
+					bin.items[j].position = j
+					bin.index[bin.items[j].tag] = bin.items[j]
+				}
+				heap.Init(bin)
+			}
+		})
+	}
+}
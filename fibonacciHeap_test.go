@@ -3,6 +3,7 @@
 package fibHeap
 
 import (
+	"container/list"
 	"fmt"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -57,6 +58,56 @@ var _ = Describe("Tests of fibHeap", func() {
 			Expect(heap.Num()).Should(BeEquivalentTo(10000))
 		})
 
+		It("Given a fibHeap, when call Len and IsEmpty api, it should return the number of values as an int.", func() {
+			Expect(heap.Len()).Should(Equal(0))
+			Expect(heap.IsEmpty()).Should(BeTrue())
+
+			heap.Insert(1, 10)
+			heap.Insert(2, 5)
+			Expect(heap.Len()).Should(Equal(2))
+			Expect(heap.IsEmpty()).Should(BeFalse())
+
+			heap.ExtractMin()
+			heap.ExtractMin()
+			Expect(heap.Len() - 1).Should(Equal(-1))
+			Expect(heap.IsEmpty()).Should(BeTrue())
+		})
+
+		It("Given a fibHeap, when call PeekMin api, it should report whether the heap is empty.", func() {
+			tag, key, ok := heap.PeekMin()
+			Expect(ok).Should(BeFalse())
+			Expect(tag).Should(BeNil())
+			Expect(key).Should(BeEquivalentTo(math.Inf(-1)))
+
+			heap.Insert(1, 10)
+			heap.Insert(2, 5)
+			tag, key, ok = heap.PeekMin()
+			Expect(ok).Should(BeTrue())
+			Expect(tag).Should(BeEquivalentTo(2))
+			Expect(key).Should(BeEquivalentTo(5))
+			Expect(heap.Num()).Should(BeEquivalentTo(2))
+		})
+
+		It("Given a fibHeap whose value is changed in place, when call MinimumValueKey and ExtractMinValueKey api, it should return the keys in the heap.", func() {
+			value, key := heap.ExtractMinValueKey()
+			Expect(value).Should(BeNil())
+			Expect(key).Should(BeEquivalentTo(math.Inf(-1)))
+
+			demo := &demoStruct{1, 1, "1"}
+			heap.InsertValue(demo)
+			heap.InsertValue(&demoStruct{2, 2, "2"})
+			demo.key = 3
+			heap.AddToAllKeys(10)
+
+			value, key = heap.MinimumValueKey()
+			Expect(value).Should(Equal(demo))
+			Expect(key).Should(BeEquivalentTo(11))
+			value, key = heap.ExtractMinValueKey()
+			Expect(value).Should(Equal(demo))
+			Expect(key).Should(BeEquivalentTo(11))
+			Expect(heap.Num()).Should(BeEquivalentTo(1))
+		})
+
 		It("Given an empty fibHeap, when call ExtractMin api, it should return nil.", func() {
 			tag, _ := heap.ExtractMin()
 			Expect(tag).Should(BeNil())
@@ -80,6 +131,32 @@ var _ = Describe("Tests of fibHeap", func() {
 			Expect(heap.Num()).Should(BeEquivalentTo(0))
 		})
 
+		It("Given an empty fibHeap, when call Maximum and ExtractMax api, it should return nil.", func() {
+			tag, key := heap.Maximum()
+			Expect(tag).Should(BeNil())
+			Expect(key).Should(BeEquivalentTo(math.Inf(-1)))
+			tag, key = heap.ExtractMax()
+			Expect(tag).Should(BeNil())
+			Expect(key).Should(BeEquivalentTo(math.Inf(-1)))
+		})
+
+		It("Given a fibHeap inserted multiple values, when call ExtractMax api, it should extract the maximum value inserted.", func() {
+			rand.Seed(time.Now().Unix())
+			for i := 0; i < 1000; i++ {
+				heap.Insert(i, rand.Float64())
+			}
+			heap.ExtractMin()
+
+			_, lastKey := heap.Maximum()
+			for i := 0; i < 999; i++ {
+				tag, key := heap.ExtractMax()
+				Expect(key).Should(BeNumerically("<=", lastKey))
+				Expect(heap.GetTag(tag)).Should(BeEquivalentTo(math.Inf(-1)))
+				Expect(heap.Num()).Should(BeEquivalentTo(998 - i))
+				lastKey = key
+			}
+		})
+
 		It("Given a fibHeap, when call DecreaseKey api with a nil value, it should return error.", func() {
 			Expect(heap.DecreaseKey(nil, 0.0)).Should(HaveOccurred())
 		})
@@ -168,6 +245,50 @@ var _ = Describe("Tests of fibHeap", func() {
 			}
 		})
 
+		It("Given a fibHeap with deep trees under random IncreaseKey, when compare it with a reference model, it should keep the heap order.", func() {
+			random := rand.New(rand.NewSource(1884))
+			model := make(map[int]float64)
+			for i := 0; i < 30000; i++ {
+				tag := random.Intn(300)
+				key := float64(random.Intn(1000)*300 + tag)
+				switch random.Intn(5) {
+				case 0:
+					_, exists := model[tag]
+					Expect(heap.Insert(tag, key) == nil).Should(Equal(!exists))
+					if !exists {
+						model[tag] = key
+					}
+				case 1, 2:
+					current, exists := model[tag]
+					Expect(heap.IncreaseKey(tag, key) == nil).Should(Equal(exists && key > current))
+					if exists && key > current {
+						model[tag] = key
+					}
+				case 3:
+					current, exists := model[tag]
+					Expect(heap.DecreaseKey(tag, key) == nil).Should(Equal(exists && key < current))
+					if exists && key < current {
+						model[tag] = key
+					}
+				case 4:
+					min, key := heap.ExtractMin()
+					if len(model) == 0 {
+						Expect(min).Should(BeNil())
+						break
+					}
+					for tag, modelKey := range model {
+						Expect(modelKey).Should(BeNumerically(">=", key))
+						if modelKey == key {
+							Expect(min).Should(Equal(tag))
+						}
+					}
+					delete(model, min.(int))
+				}
+				Expect(heap.Num()).Should(BeEquivalentTo(len(model)))
+				Expect(checkHeapOrder(heap.roots, nil)).Should(BeEquivalentTo(len(model)))
+			}
+		})
+
 		It("Given a fibHeap, when call Delete api with a nil value, it should return error.", func() {
 			Expect(heap.Delete(nil)).Should(HaveOccurred())
 		})
@@ -193,6 +314,26 @@ var _ = Describe("Tests of fibHeap", func() {
 			}
 			Expect(heap.Num()).Should(BeEquivalentTo(0))
 		})
+
+		It("Given a consolidated fibHeap, when call Delete api with non-minimum tags, it should cut them out without consolidation.", func() {
+			for i := 0; i < 1000; i++ {
+				heap.Insert(i, float64(i))
+			}
+			heap.ExtractMin()
+
+			for i := 998; i > 1; i -= 2 {
+				roots := heap.roots.Len()
+				Expect(heap.Delete(i)).ShouldNot(HaveOccurred())
+				Expect(heap.roots.Len()).Should(BeNumerically(">=", roots-1))
+				tag, _ := heap.Minimum()
+				Expect(tag).Should(BeEquivalentTo(1))
+			}
+			for i := 1; i < 1000; i += 2 {
+				tag, _ := heap.ExtractMin()
+				Expect(tag).Should(BeEquivalentTo(i))
+			}
+			Expect(heap.Num()).Should(BeEquivalentTo(0))
+		})
 	})
 
 	Context("behaviour tests of value interfaces", func() {
@@ -266,6 +407,62 @@ var _ = Describe("Tests of fibHeap", func() {
 			Expect(heap.Num()).Should(BeEquivalentTo(0))
 		})
 
+		It("Given a fibHeap inserted multiple values, when call ExtractMinK api, it should extract the k minimum values in order.", func() {
+			Expect(heap.ExtractMinK(10)).Should(BeNil())
+			rand.Seed(time.Now().Unix())
+			for i := 0; i < 10000; i++ {
+				demo := new(demoStruct)
+				demo.tag = i
+				demo.key = rand.Float64()
+				demo.value = fmt.Sprint(demo.key)
+				heap.InsertValue(demo)
+			}
+			heap.ExtractMinValue()
+			heap.Delete(1)
+
+			Expect(heap.ExtractMinK(0)).Should(BeNil())
+			lastKey := heap.MinimumValue().(*demoStruct).key
+			for i := 0; i < 9998; i += 100 {
+				values := heap.ExtractMinK(100)
+				if i+100 > 9998 {
+					Expect(values).Should(HaveLen(9998 - i))
+				} else {
+					Expect(values).Should(HaveLen(100))
+				}
+				for _, value := range values {
+					Expect(value.Key()).Should(BeNumerically(">=", lastKey))
+					lastKey = value.Key()
+				}
+				Expect(heap.Num()).Should(BeEquivalentTo(9998 - i - len(values)))
+			}
+			Expect(heap.ExtractMinK(10)).Should(BeNil())
+		})
+
+		It("Given an empty fibHeap, when call MaximumValue and ExtractMaxValue api, it should return nil.", func() {
+			Expect(heap.MaximumValue()).Should(BeNil())
+			Expect(heap.ExtractMaxValue()).Should(BeNil())
+		})
+
+		It("Given a fibHeap inserted multiple values, when call ExtractMaxValue api, it should extract the maximum value inserted.", func() {
+			rand.Seed(time.Now().Unix())
+			for i := 0; i < 1000; i++ {
+				demo := new(demoStruct)
+				demo.tag = i
+				demo.key = rand.Float64()
+				demo.value = fmt.Sprint(demo.key)
+				heap.InsertValue(demo)
+			}
+
+			lastKey := heap.MaximumValue().(*demoStruct).key
+			for i := 0; i < 1000; i++ {
+				extracted := heap.ExtractMaxValue().(*demoStruct)
+				Expect(extracted.key).Should(BeNumerically("<=", lastKey))
+				Expect(extracted.value).Should(Equal(fmt.Sprint(extracted.key)))
+				Expect(heap.Num()).Should(BeEquivalentTo(999 - i))
+				lastKey = extracted.key
+			}
+		})
+
 		It("Given a fibHeap, when call DecreaseKey api with a nil value, it should return error.", func() {
 			Expect(heap.DecreaseKeyValue(nil)).Should(HaveOccurred())
 		})
@@ -343,6 +540,28 @@ var _ = Describe("Tests of fibHeap", func() {
 			}
 		})
 
+		It("Given a fibHeap inserted multiple values, when call DecreaseKey and IncreaseKey api by tags, it should keep the values.", func() {
+			for i := 0; i < 100; i++ {
+				demo := new(demoStruct)
+				demo.tag = i
+				demo.key = float64(i)
+				demo.value = fmt.Sprint(demo.key)
+				heap.InsertValue(demo)
+			}
+
+			Expect(heap.DecreaseKey(50, -1)).ShouldNot(HaveOccurred())
+			Expect(heap.IncreaseKey(0, 1000)).ShouldNot(HaveOccurred())
+			Expect(heap.GetValue(50).(*demoStruct).value).Should(Equal("50"))
+			Expect(heap.GetValue(0).(*demoStruct).value).Should(Equal("0"))
+			Expect(heap.ExtractMinValue().(*demoStruct).tag).Should(Equal(50))
+			for i := 1; i < 100; i++ {
+				if i != 50 {
+					Expect(heap.ExtractMinValue().(*demoStruct).tag).Should(Equal(i))
+				}
+			}
+			Expect(heap.ExtractMinValue().(*demoStruct).tag).Should(Equal(0))
+		})
+
 		It("Given a fibHeap, when call IncreaseKey api with a nil value, it should return error.", func() {
 			Expect(heap.IncreaseKeyValue(nil)).Should(HaveOccurred())
 		})
@@ -587,6 +806,320 @@ var _ = Describe("Tests of fibHeap", func() {
 			Expect(anotherHeap.Num()).Should(BeEquivalentTo(1))
 		})
 
+		It("Given two fibHeaps with tags, when call Union api, it should move all tags in and empty the input heap.", func() {
+			heap.Insert(1, float64(1))
+			anotherHeap.Insert(2, float64(2))
+			anotherHeap.Insert(3, float64(0))
+
+			Expect(heap.Union(anotherHeap)).ShouldNot(HaveOccurred())
+			Expect(heap.Num()).Should(BeEquivalentTo(3))
+			Expect(heap.GetTag(2)).Should(BeEquivalentTo(2))
+			tag, _ := heap.ExtractMin()
+			Expect(tag).Should(BeEquivalentTo(3))
+			Expect(anotherHeap.Num()).Should(BeEquivalentTo(0))
+			Expect(anotherHeap.GetTag(2)).Should(BeEquivalentTo(math.Inf(-1)))
+			tag, _ = anotherHeap.Minimum()
+			Expect(tag).Should(BeNil())
+		})
+
+		It("Given two fibHeaps with both tags and values, when call Union api, it should move the trees in and keep all tags and values.", func() {
+			for i := 0; i < 100; i++ {
+				if i%2 == 0 {
+					heap.Insert(i, float64(i))
+					anotherHeap.InsertValue(&demoStruct{i + 1, float64(i + 1), fmt.Sprint(i + 1)})
+				} else {
+					heap.InsertValue(&demoStruct{i - 1 + 100, float64(i - 1 + 100), fmt.Sprint(i - 1 + 100)})
+					anotherHeap.Insert(i+100, float64(i+100))
+				}
+			}
+			heap.ExtractMin()
+			anotherHeap.ExtractMin()
+			anotherHeap.SetLazyDelete(true)
+			anotherHeap.Delete(99)
+			anotherHeap.AddToAllKeys(-50)
+			heap.AddToAllKeys(50)
+			anotherHeap.InsertValue(&demoStruct{0, 50, "0"})
+
+			Expect(heap.Union(anotherHeap)).ShouldNot(HaveOccurred())
+			Expect(heap.Num()).Should(BeEquivalentTo(198))
+			Expect(anotherHeap.Num()).Should(BeEquivalentTo(0))
+			Expect(anotherHeap.MinimumValue()).Should(BeNil())
+			Expect(heap.GetValue(0).(*demoStruct).value).Should(Equal("0"))
+			Expect(heap.GetValue(3).(*demoStruct).value).Should(Equal("3"))
+			Expect(heap.GetValue(2)).Should(BeNil())
+
+			lastKey := math.Inf(-1)
+			for heap.Num() != 0 {
+				tag, key := heap.ExtractMin()
+				Expect(key).Should(BeNumerically(">=", lastKey))
+				switch {
+				case tag.(int) == 0:
+					Expect(key).Should(BeEquivalentTo(50))
+				case tag.(int) < 100 && tag.(int)%2 == 0:
+					Expect(key).Should(BeEquivalentTo(tag.(int) + 50))
+				case tag.(int) < 100:
+					Expect(key).Should(BeEquivalentTo(tag.(int) - 50))
+				case tag.(int)%2 == 0:
+					Expect(key).Should(BeEquivalentTo(tag.(int) + 50))
+				default:
+					Expect(key).Should(BeEquivalentTo(tag.(int) - 50))
+				}
+				lastKey = key
+			}
+		})
+
+		It("Given two fibHeaps with tags, when call UnionInto api, it should copy all tags in and leave the input heap untouched.", func() {
+			heap.Insert(1, float64(1))
+			anotherHeap.Insert(2, float64(2))
+
+			Expect(heap.UnionInto(anotherHeap)).ShouldNot(HaveOccurred())
+			Expect(heap.Num()).Should(BeEquivalentTo(2))
+			Expect(heap.GetTag(2)).Should(BeEquivalentTo(2))
+			Expect(anotherHeap.Num()).Should(BeEquivalentTo(1))
+			Expect(anotherHeap.GetTag(2)).Should(BeEquivalentTo(2))
+		})
+
+		It("Given two fibHeaps with tags, when call UnionNew api, it should return a new heap with all tags and leave both heaps untouched.", func() {
+			heap.Insert(1, float64(1))
+			anotherHeap.Insert(2, float64(0))
+
+			unioned, err := UnionNew(heap, anotherHeap)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(unioned.Num()).Should(BeEquivalentTo(2))
+			tag, _ := unioned.ExtractMin()
+			Expect(tag).Should(BeEquivalentTo(2))
+			Expect(heap.Num()).Should(BeEquivalentTo(1))
+			Expect(anotherHeap.Num()).Should(BeEquivalentTo(1))
+			Expect(anotherHeap.GetTag(2)).Should(BeEquivalentTo(0))
+
+			unioned, err = UnionNew(heap, heap)
+			Expect(err).Should(HaveOccurred())
+			Expect(unioned).Should(BeNil())
+		})
+
+		It("Given two fibHeaps with overlapping tags, when call Subtract api, it should delete the common tags only from the heap.", func() {
+			for i := 0; i < 1000; i++ {
+				heap.Insert(i, float64(i))
+			}
+			heap.ExtractMin()
+			for i := 500; i < 1500; i++ {
+				anotherHeap.Insert(i, float64(-i))
+			}
+
+			heap.Subtract(anotherHeap)
+			Expect(heap.Num()).Should(BeEquivalentTo(499))
+			Expect(heap.GetTag(500)).Should(BeEquivalentTo(math.Inf(-1)))
+			Expect(anotherHeap.Num()).Should(BeEquivalentTo(1000))
+			for i := 1; i < 500; i++ {
+				tag, _ := heap.ExtractMin()
+				Expect(tag).Should(BeEquivalentTo(i))
+			}
+
+			anotherHeap.Subtract(anotherHeap)
+			Expect(anotherHeap.Num()).Should(BeEquivalentTo(0))
+		})
+
+		It("Given a fibHeap with consolidated trees, when call DeleteWhere api, it should delete all matched values in one pass.", func() {
+			Expect(heap.DeleteWhere(func(interface{}, float64, Value) bool { return true })).Should(Equal(0))
+
+			for i := 0; i < 1000; i++ {
+				heap.Insert(i, float64(i))
+			}
+			heap.ExtractMin()
+			heap.DecreaseKey(500, 0.5)
+			heap.IncreaseKey(2, 2000)
+
+			deleted := heap.DeleteWhere(func(tag interface{}, key float64, value Value) bool {
+				return tag.(int)%3 == 0 || key > 990
+			})
+			Expect(deleted).Should(Equal(340))
+			Expect(heap.Num()).Should(BeEquivalentTo(659))
+			Expect(heap.GetTag(3)).Should(Equal(math.Inf(-1)))
+
+			last := math.Inf(-1)
+			for heap.Num() != 0 {
+				tag, key := heap.ExtractMin()
+				Expect(tag.(int) % 3).ShouldNot(Equal(0))
+				Expect(key).Should(BeNumerically("<=", 990))
+				Expect(key).Should(BeNumerically(">=", last))
+				last = key
+			}
+
+			heap.Insert(1, 1)
+			Expect(heap.DeleteWhere(func(interface{}, float64, Value) bool { return true })).Should(Equal(1))
+			Expect(heap.Num()).Should(BeEquivalentTo(0))
+			Expect(heap.Insert(1, 1)).ShouldNot(HaveOccurred())
+		})
+
+		It("Given a fibHeap with consolidated trees, when call TransformKeys api, it should transform all keys and keep the heap ordered.", func() {
+			Expect(heap.TransformKeys(func(old float64) float64 { return -old })).ShouldNot(HaveOccurred())
+
+			for i := 0; i < 1000; i++ {
+				heap.Insert(i, float64(i))
+			}
+			heap.ExtractMin()
+			heap.DecreaseKey(500, 0.5)
+
+			Expect(heap.TransformKeys(func(old float64) float64 { return math.Inf(-1) })).Should(HaveOccurred())
+			Expect(heap.GetTag(999)).Should(BeEquivalentTo(999))
+
+			Expect(heap.TransformKeys(func(old float64) float64 { return old/10 + 1 })).ShouldNot(HaveOccurred())
+			Expect(heap.GetTag(999)).Should(BeEquivalentTo(100.9))
+			tag, key := heap.Minimum()
+			Expect(tag).Should(Equal(500))
+			Expect(key).Should(BeEquivalentTo(1.05))
+
+			Expect(heap.TransformKeys(func(old float64) float64 { return -old })).ShouldNot(HaveOccurred())
+			Expect(heap.Num()).Should(BeEquivalentTo(999))
+			last := math.Inf(-1)
+			for heap.Num() != 0 {
+				_, key := heap.ExtractMin()
+				Expect(key).Should(BeNumerically(">=", last))
+				last = key
+			}
+			Expect(last).Should(BeEquivalentTo(-1.05))
+		})
+
+		It("Given a fibHeap, when call AddToAllKeys api, it should shift all keys lazily and keep the later operations consistent.", func() {
+			for i := 0; i < 100; i++ {
+				heap.Insert(i, float64(i))
+			}
+			heap.ExtractMin()
+			Expect(func() { heap.AddToAllKeys(math.Inf(1)) }).Should(Panic())
+
+			heap.AddToAllKeys(-1000)
+			tag, key := heap.Minimum()
+			Expect(tag).Should(Equal(1))
+			Expect(key).Should(BeEquivalentTo(-999))
+			Expect(heap.GetTag(99)).Should(BeEquivalentTo(-901))
+
+			Expect(heap.Insert(100, -950)).ShouldNot(HaveOccurred())
+			Expect(heap.DecreaseKey(50, -990.5)).ShouldNot(HaveOccurred())
+			Expect(heap.DecreaseKey(60, -900)).Should(HaveOccurred())
+			Expect(heap.IncreaseKey(1, -500)).ShouldNot(HaveOccurred())
+			heap.AddToAllKeys(1000)
+			Expect(heap.Entries()).Should(ContainElement(TagKey{100, 50}))
+			Expect(heap.String()).Should(ContainSubstring("key(2.000000), tag(2)"))
+
+			expected := []float64{2, 3, 4, 5, 6, 7, 8, 9, 9.5}
+			for _, key := range expected {
+				_, min := heap.ExtractMin()
+				Expect(min).Should(Equal(key))
+			}
+			Expect(heap.ExtractTag(10)).Should(BeEquivalentTo(10))
+			Expect(heap.TransformKeys(func(old float64) float64 { return old * 2 })).ShouldNot(HaveOccurred())
+			_, key = heap.Maximum()
+			Expect(key).Should(BeEquivalentTo(1000))
+		})
+
+		It("Given a fibHeap with tags inserted by InsertOrIncrement, when extract them, it should decrement the counts first.", func() {
+			Expect(heap.InsertOrIncrement(nil, 1)).Should(HaveOccurred())
+			Expect(heap.Count(1)).Should(BeEquivalentTo(0))
+			for i := 0; i < 3; i++ {
+				Expect(heap.InsertOrIncrement(1, float64(10+i))).ShouldNot(HaveOccurred())
+			}
+			heap.InsertOrIncrement(2, 20)
+			heap.InsertOrIncrement(2, 20)
+			heap.Insert(3, 30)
+			heap.InsertOrIncrement(3, 30)
+			heap.InsertOrIncrement(4, 40)
+			Expect(heap.Count(1)).Should(BeEquivalentTo(3))
+			Expect(heap.Count(4)).Should(BeEquivalentTo(1))
+			Expect(heap.Num()).Should(BeEquivalentTo(4))
+
+			for i := 0; i < 3; i++ {
+				tag, key := heap.ExtractMin()
+				Expect(tag).Should(Equal(1))
+				Expect(key).Should(BeEquivalentTo(10))
+			}
+			Expect(heap.Count(1)).Should(BeEquivalentTo(0))
+
+			Expect(heap.ExtractTag(2)).Should(BeEquivalentTo(20))
+			Expect(heap.Count(2)).Should(BeEquivalentTo(1))
+			Expect(heap.Delete(3)).ShouldNot(HaveOccurred())
+			Expect(heap.Count(3)).Should(BeEquivalentTo(0))
+			heap.Insert(3, 30)
+			Expect(heap.Count(3)).Should(BeEquivalentTo(1))
+			Expect(heap.Num()).Should(BeEquivalentTo(3))
+		})
+
+		It("Given a fibHeap, when call AdjustKey api, it should move the keys by the deltas.", func() {
+			for i := 0; i < 10; i++ {
+				heap.InsertValue(&demoStruct{i, float64(i), fmt.Sprint(i)})
+			}
+
+			Expect(heap.AdjustKey(nil, 1)).Should(HaveOccurred())
+			Expect(heap.AdjustKey(10, 1)).Should(HaveOccurred())
+			Expect(heap.AdjustKey(5, math.NaN())).Should(HaveOccurred())
+			Expect(heap.AdjustKey(5, math.Inf(-1))).Should(HaveOccurred())
+			Expect(heap.AdjustKey(5, 0)).ShouldNot(HaveOccurred())
+
+			Expect(heap.AdjustKey(0, 4.5)).ShouldNot(HaveOccurred())
+			Expect(heap.AdjustKey(9, -8.5)).ShouldNot(HaveOccurred())
+			Expect(heap.GetTag(0)).Should(BeEquivalentTo(4.5))
+			Expect(heap.GetValue(9).(*demoStruct).value).Should(Equal("9"))
+
+			tag, key := heap.ExtractMin()
+			Expect(tag).Should(Equal(9))
+			Expect(key).Should(BeEquivalentTo(0.5))
+			tag, _ = heap.ExtractMin()
+			Expect(tag).Should(Equal(1))
+		})
+
+		It("Given two fibHeaps, when call Equal and Diff api, it should compare their tags and keys without extracting them.", func() {
+			Expect(heap.Equal(anotherHeap)).Should(BeTrue())
+			onlyA, onlyB, keyChanged := heap.Diff(anotherHeap)
+			Expect(onlyA).Should(BeEmpty())
+			Expect(onlyB).Should(BeEmpty())
+			Expect(keyChanged).Should(BeEmpty())
+
+			for i := 0; i < 100; i++ {
+				heap.Insert(i, float64(i))
+				anotherHeap.Insert(99-i, float64(99-i))
+			}
+			heap.ExtractMin()
+			anotherHeap.ExtractMin()
+			Expect(heap.Equal(anotherHeap)).Should(BeTrue())
+			Expect(anotherHeap.Equal(heap)).Should(BeTrue())
+
+			heap.Insert(100, 100)
+			anotherHeap.Insert(101, 101)
+			anotherHeap.DecreaseKey(50, 0.5)
+			Expect(heap.Equal(anotherHeap)).Should(BeFalse())
+			onlyA, onlyB, keyChanged = heap.Diff(anotherHeap)
+			Expect(onlyA).Should(ConsistOf(100))
+			Expect(onlyB).Should(ConsistOf(101))
+			Expect(keyChanged).Should(ConsistOf(50))
+			Expect(heap.Num()).Should(BeEquivalentTo(100))
+		})
+
+		It("Given a fibHeap, when call Tags and Entries api, it should list all tags and keys without extracting them.", func() {
+			Expect(heap.Tags()).Should(BeNil())
+			Expect(heap.Entries()).Should(BeNil())
+
+			heap.Insert(1, 1)
+			heap.InsertValue(&demoStruct{2, 2, "2"})
+			heap.Insert(3, 3)
+			heap.ExtractMin()
+			heap.DecreaseKey(3, 0.5)
+
+			Expect(heap.Tags()).Should(ConsistOf(2, 3))
+			Expect(heap.Entries()).Should(ConsistOf(TagKey{2, 2}, TagKey{3, 0.5}))
+			Expect(heap.Num()).Should(BeEquivalentTo(2))
+		})
+
+		It("Given a fibHeap with tags and values, when call Values api, it should list all values without extracting them.", func() {
+			Expect(heap.Values()).Should(BeNil())
+
+			one, two := &demoStruct{1, 1, "1"}, &demoStruct{2, 2, "2"}
+			heap.InsertValue(one)
+			heap.InsertValue(two)
+			heap.Insert(3, 3)
+
+			Expect(heap.Values()).Should(ConsistOf(one, two))
+			Expect(heap.Num()).Should(BeEquivalentTo(3))
+		})
+
 		It("Given one fibHeaps which has not a value with TAG, when GetTag this TAG, it should return nil.", func() {
 			rand.Seed(time.Now().Unix())
 			for i := 0; i < 1000; i++ {
@@ -781,7 +1314,7 @@ var _ = Describe("Tests of fibHeap", func() {
 		})
 	})
 
-	Context("benchmark", func() {
+	Context("stress test", func() {
 		BeforeEach(func() {
 			heap = NewFibHeap()
 		})
@@ -790,29 +1323,63 @@ var _ = Describe("Tests of fibHeap", func() {
 			heap = nil
 		})
 
-		Measure("Benchmark Go Fibonacci Heap", func(b Benchmarker) {
+		It("Given a fibHeap, when call 1000000 random operations, it should keep the minimum value correct.", func() {
 			rand.Seed(time.Now().Unix())
-			b.Time("1000000 radom operations", func() {
-				var (
-					insert, minimun, extract, decrease, get, delete, increase int64
-					min                                                       *demoStruct
-				)
-				for i := 0; i < 1000000; i++ {
-					if i%3 == 0 {
-						demo := new(demoStruct)
-						demo.tag = i
-						demo.key = rand.Float64()
-						demo.value = fmt.Sprint(demo.key)
-						Expect(heap.InsertValue(demo)).ShouldNot(HaveOccurred())
-						insert++
-						if min == nil || demo.key < min.key {
-							min = demo
+			var (
+				insert, minimun, extract, decrease, get, delete, increase int64
+				min                                                       *demoStruct
+			)
+			for i := 0; i < 1000000; i++ {
+				if i%3 == 0 {
+					demo := new(demoStruct)
+					demo.tag = i
+					demo.key = rand.Float64()
+					demo.value = fmt.Sprint(demo.key)
+					Expect(heap.InsertValue(demo)).ShouldNot(HaveOccurred())
+					insert++
+					if min == nil || demo.key < min.key {
+						min = demo
+					}
+				}
+				if i%5 == 0 {
+					if extracted := heap.ExtractMinValue(); extracted != nil {
+						extract++
+						Expect(extracted.(*demoStruct).key).Should(BeEquivalentTo(min.key))
+						if currentMin := heap.MinimumValue(); currentMin != nil {
+							minimun++
+							min = currentMin.(*demoStruct)
+						} else {
+							Expect(heap.Num()).Should(BeEquivalentTo(0))
+							min = nil
 						}
 					}
-					if i%5 == 0 {
-						if extracted := heap.ExtractMinValue(); extracted != nil {
-							extract++
-							Expect(extracted.(*demoStruct).key).Should(BeEquivalentTo(min.key))
+				}
+				if i%7 == 0 {
+					if currentMin := heap.MinimumValue(); currentMin != nil {
+						if min != nil {
+							minimun++
+							Expect(currentMin.(*demoStruct).key).Should(BeEquivalentTo(min.key))
+						}
+					}
+				}
+				if i%11 == 0 {
+					if temp := heap.GetValue(int(3 * rand.Int31n(int32(i/3)+1))); temp != nil {
+						get++
+						temp.(*demoStruct).key = temp.(*demoStruct).key / 2
+						heap.DecreaseKeyValue(temp)
+						decrease++
+						currentMin := heap.MinimumValue()
+						Expect(currentMin).ShouldNot(BeNil())
+						minimun++
+						min = currentMin.(*demoStruct)
+					}
+				}
+				if i%13 == 0 {
+					if temp := heap.GetValue(int(3 * rand.Int31n(int32(i/3)+1))); temp != nil {
+						get++
+						heap.DeleteValue(temp)
+						delete++
+						if min != nil && temp.Tag() == min.tag {
 							if currentMin := heap.MinimumValue(); currentMin != nil {
 								minimun++
 								min = currentMin.(*demoStruct)
@@ -822,60 +1389,22 @@ var _ = Describe("Tests of fibHeap", func() {
 							}
 						}
 					}
-					if i%7 == 0 {
-						if currentMin := heap.MinimumValue(); currentMin != nil {
-							if min != nil {
-								minimun++
-								Expect(currentMin.(*demoStruct).key).Should(BeEquivalentTo(min.key))
-							}
-						}
-					}
-					if i%11 == 0 {
-						if temp := heap.GetValue(int(3 * rand.Int31n(int32(i/3)+1))); temp != nil {
-							get++
-							temp.(*demoStruct).key = temp.(*demoStruct).key / 2
-							heap.DecreaseKeyValue(temp)
-							decrease++
-							currentMin := heap.MinimumValue()
-							Expect(currentMin).ShouldNot(BeNil())
-							minimun++
-							min = currentMin.(*demoStruct)
-						}
-					}
-					if i%13 == 0 {
-						if temp := heap.GetValue(int(3 * rand.Int31n(int32(i/3)+1))); temp != nil {
-							get++
-							heap.DeleteValue(temp)
-							delete++
-							if min != nil && temp.Tag() == min.tag {
-								if currentMin := heap.MinimumValue(); currentMin != nil {
-									minimun++
-									min = currentMin.(*demoStruct)
-								} else {
-									Expect(heap.Num()).Should(BeEquivalentTo(0))
-									min = nil
-								}
-							}
-						}
-					}
-					if i%17 == 0 {
-						if temp := heap.GetValue(int(3 * rand.Int31n(int32(i/3)+1))); temp != nil {
-							get++
-							temp.(*demoStruct).key = temp.(*demoStruct).key * 2
-							heap.IncreaseKeyValue(temp)
-							increase++
-							currentMin := heap.MinimumValue()
-							Expect(currentMin).ShouldNot(BeNil())
-							minimun++
-							min = currentMin.(*demoStruct)
-						}
+				}
+				if i%17 == 0 {
+					if temp := heap.GetValue(int(3 * rand.Int31n(int32(i/3)+1))); temp != nil {
+						get++
+						temp.(*demoStruct).key = temp.(*demoStruct).key * 2
+						heap.IncreaseKeyValue(temp)
+						increase++
+						currentMin := heap.MinimumValue()
+						Expect(currentMin).ShouldNot(BeNil())
+						minimun++
+						min = currentMin.(*demoStruct)
 					}
 				}
-				fmt.Println("Final heap size:", heap.Num())
-				fmt.Println("Total insert:", insert, "Total minimum:", minimun, "Total extract:", extract, "Total get:", get, "Total decrease:", decrease, "Total delete:", delete, "Total increase:", increase)
-				Expect(heap.Num()).Should(BeEquivalentTo(insert - extract - delete))
-			})
-		}, 10)
+			}
+			Expect(heap.Num()).Should(BeEquivalentTo(insert - extract - delete))
+		})
 	})
 })
 
@@ -892,3 +1421,18 @@ func (demo *demoStruct) Tag() interface{} {
 func (demo *demoStruct) Key() float64 {
 	return demo.key
 }
+
+// checkHeapOrder walks the trees and panics if any child is smaller than its parent or links to a wrong parent.
+// It returns the number of nodes in the trees.
+func checkHeapOrder(tree *list.List, parent *node) int {
+	count := 0
+	for e := tree.Front(); e != nil; e = e.Next() {
+		n := e.Value.(*node)
+		if n.parent != parent || n.self != e || (parent != nil && n.key < parent.key) || uint(n.children.Len()) != n.degree {
+			panic("heap order is broken")
+		}
+		count += 1 + checkHeapOrder(n.children, n)
+	}
+
+	return count
+}
@@ -0,0 +1,458 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Float32Heap represents an array based 4-ary Heap which stores its keys as float32, for memory bound workloads with tens of millions of values.
+// Float32Heap provides exactly the same methods as FibHeap so the two implementations can be swapped by changing the constructor only.
+// The keys are kept in their own contiguous slice of float32, which takes 4 bytes per value instead of the 8 bytes of float64 plus padding.
+//
+// The trade-off is precision: every key is rounded to the nearest float32, which has about 7 significant decimal digits.
+// Keys which round to the same float32 are equal in the heap and may be extracted in any order,
+// and the keys returned by the heap are the rounded ones, e.g. integers beyond 2^24 are not exact anymore.
+// Finite keys beyond the float32 range round to +inf, except the negative ones which would round to -inf and cause an error return.
+// Please note that all methods of Float32Heap are not concurrent safe.
+type Float32Heap struct {
+	keys  []float32
+	nodes []*float32Node
+	index map[interface{}]*float32Node
+}
+
+type float32Node struct {
+	position int
+	tag      interface{}
+	value    Value
+}
+
+// NewFloat32Heap creates an initialized Float32Heap.
+func NewFloat32Heap() *Float32Heap {
+	heap := new(Float32Heap)
+	heap.index = make(map[interface{}]*float32Node)
+
+	return heap
+}
+
+// Num returns the total number of values in the heap.
+func (heap *Float32Heap) Num() uint {
+	return uint(len(heap.keys))
+}
+
+// Insert pushes the input tag and key into the heap.
+// Try to insert a duplicate tag value will cause an error return.
+// The valid range of the key is (-inf, +inf] after rounding to float32.
+// Try to insert a key which rounds to -inf will cause an error return.
+func (heap *Float32Heap) Insert(tag interface{}, key float64) error {
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	return heap.insert(tag, key, nil)
+}
+
+// InsertValue pushes the input value into the heap.
+// The input value must implements the Value interface.
+// Try to insert a duplicate tag value will cause an error return.
+// The valid range of the value's key is (-inf, +inf] after rounding to float32.
+// Try to insert a key which rounds to -inf will cause an error return.
+func (heap *Float32Heap) InsertValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	return heap.insert(value.Tag(), value.Key(), value)
+}
+
+// Minimum returns the current minimum tag and rounded key in the heap sorted by the key.
+// An empty heap will return nil and -inf.
+func (heap *Float32Heap) Minimum() (interface{}, float64) {
+	if len(heap.keys) == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	return heap.nodes[0].tag, float64(heap.keys[0])
+}
+
+// MinimumValue returns the current minimum value in the heap sorted by the key.
+// An empty heap will return nil.
+func (heap *Float32Heap) MinimumValue() Value {
+	if len(heap.keys) == 0 {
+		return nil
+	}
+
+	return heap.nodes[0].value
+}
+
+// ExtractMin returns the current minimum tag and rounded key in the heap and then extracts them from the heap.
+// An empty heap will return nil/-inf and extracts nothing.
+func (heap *Float32Heap) ExtractMin() (interface{}, float64) {
+	if len(heap.keys) == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	key := float64(heap.keys[0])
+	min := heap.remove(0)
+
+	return min.tag, key
+}
+
+// ExtractMinValue returns the current minimum value in the heap and then extracts it from the heap.
+// An empty heap will return nil and extracts nothing.
+func (heap *Float32Heap) ExtractMinValue() Value {
+	if len(heap.keys) == 0 {
+		return nil
+	}
+
+	min := heap.remove(0)
+
+	return min.value
+}
+
+// Union moves all values of the input heap into the heap.
+// The input heap can be any implementation of PriorityQueue, and it is emptied afterwards so that no value is reachable from both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned and both heaps are left untouched.
+func (heap *Float32Heap) Union(anotherHeap PriorityQueue) error {
+	if err := heap.UnionInto(anotherHeap); err != nil {
+		return err
+	}
+
+	anotherHeap.reset()
+
+	return nil
+}
+
+// UnionInto merges copies of all values of the input heap into the heap.
+// The input heap can be any implementation of PriorityQueue, and it is left untouched, so the values are shared by both heaps.
+// All values of the input heap must not have duplicate tags or keys which round to -inf. Otherwise an error will be returned.
+func (heap *Float32Heap) UnionInto(anotherHeap PriorityQueue) error {
+	if err := checkUnion(heap, anotherHeap); err != nil {
+		return err
+	}
+
+	var err error
+	anotherHeap.each(func(tag interface{}, key float64, value Value) {
+		if _, e := toFloat32(key); e != nil {
+			err = e
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	anotherHeap.each(func(tag interface{}, key float64, value Value) {
+		heap.insert(tag, key, value)
+	})
+
+	return nil
+}
+
+// DecreaseKey updates the tag in the heap by the input key.
+// If the input key is not smaller than the current key after rounding or rounds to -inf, an error will be returned.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *Float32Heap) DecreaseKey(tag interface{}, key float64) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if node, exists := heap.index[tag]; exists {
+		return heap.decreaseKey(node, node.value, key)
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// DecreaseKeyValue updates the value in the heap by the input value.
+// If the input value's key is not smaller than the current key after rounding or rounds to -inf, an error will be returned.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *Float32Heap) DecreaseKeyValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	if node, exists := heap.index[tagKey(value.Tag())]; exists {
+		return heap.decreaseKey(node, value, value.Key())
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// IncreaseKey updates the tag in the heap by the input key.
+// If the input key is not larger than the current key after rounding or rounds to -inf, an error will be returned.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *Float32Heap) IncreaseKey(tag interface{}, key float64) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if node, exists := heap.index[tag]; exists {
+		return heap.increaseKey(node, node.value, key)
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// IncreaseKeyValue updates the value in the heap by the input value.
+// If the input value's key is not larger than the current key after rounding or rounds to -inf, an error will be returned.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *Float32Heap) IncreaseKeyValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	if node, exists := heap.index[tagKey(value.Tag())]; exists {
+		return heap.increaseKey(node, value, value.Key())
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// Delete deletes the input tag in the heap.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *Float32Heap) Delete(tag interface{}) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	node, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Tag is not found ")
+	}
+
+	heap.remove(node.position)
+
+	return nil
+}
+
+// DeleteValue deletes the value in the heap by the input value.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *Float32Heap) DeleteValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	node, exists := heap.index[tagKey(value.Tag())]
+	if !exists {
+		return errors.New("Value is not found ")
+	}
+
+	heap.remove(node.position)
+
+	return nil
+}
+
+// GetTag searches and returns the rounded key in the heap by the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *Float32Heap) GetTag(tag interface{}) (key float64) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		return float64(heap.keys[node.position])
+	}
+
+	return math.Inf(-1)
+}
+
+// GetValue searches and returns the value in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *Float32Heap) GetValue(tag interface{}) (value Value) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		value = node.value
+	}
+
+	return
+}
+
+// ExtractTag searches and extracts the tag/key in the heap by the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *Float32Heap) ExtractTag(tag interface{}) (key float64) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		key = float64(heap.keys[node.position])
+		heap.remove(node.position)
+		return
+	}
+
+	return math.Inf(-1)
+}
+
+// ExtractValue searches and extracts the value in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *Float32Heap) ExtractValue(tag interface{}) (value Value) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		value = node.value
+		heap.remove(node.position)
+		return
+	}
+
+	return nil
+}
+
+// String provides some basic debug information of the heap.
+// It returns the total number, index size and current minimum value of the heap.
+// It also returns all keys in the order of the underlying array.
+func (heap *Float32Heap) String() string {
+	var buffer bytes.Buffer
+
+	if len(heap.keys) != 0 {
+		buffer.WriteString(fmt.Sprintf("Total number: %d, Index size: %d,\n", len(heap.keys), len(heap.index)))
+		buffer.WriteString(fmt.Sprintf("Current minimun: key(%f), tag(%v), value(%v),\n", heap.keys[0], heap.nodes[0].tag, heap.nodes[0].value))
+		buffer.WriteString(fmt.Sprintf("Heap detail:\n"))
+		buffer.WriteString(fmt.Sprintf("< "))
+		for _, key := range heap.keys {
+			buffer.WriteString(fmt.Sprintf("%f ", key))
+		}
+		buffer.WriteString(fmt.Sprintf("> \n"))
+	} else {
+		buffer.WriteString(fmt.Sprintf("Heap is empty.\n"))
+	}
+
+	return buffer.String()
+}
+
+func (heap *Float32Heap) reset() {
+	*heap = *NewFloat32Heap()
+}
+
+func (heap *Float32Heap) each(fn func(tag interface{}, key float64, value Value)) {
+	for i, node := range heap.nodes {
+		fn(node.tag, float64(heap.keys[i]), node.value)
+	}
+}
+
+// toFloat32 rounds the key to float32 and rejects the keys which round to -inf.
+func toFloat32(key float64) (float32, error) {
+	rounded := float32(key)
+	if math.IsInf(float64(rounded), -1) {
+		if math.IsInf(key, -1) {
+			return 0, errors.New("Negative infinity key is reserved for internal usage ")
+		}
+		return 0, errors.New("Key is out of the float32 range ")
+	}
+
+	return rounded, nil
+}
+
+func (heap *Float32Heap) insert(tag interface{}, key float64, value Value) error {
+	tag = tagKey(tag)
+	rounded, err := toFloat32(key)
+	if err != nil {
+		return err
+	}
+
+	if _, exists := heap.index[tag]; exists {
+		return errors.New("Duplicate tag is not allowed ")
+	}
+
+	node := new(float32Node)
+	node.position = len(heap.keys)
+	node.tag = tag
+	node.value = value
+
+	heap.keys = append(heap.keys, rounded)
+	heap.nodes = append(heap.nodes, node)
+	heap.index[node.tag] = node
+	heap.up(node.position)
+
+	return nil
+}
+
+func (heap *Float32Heap) remove(position int) *float32Node {
+	node := heap.nodes[position]
+	last := len(heap.keys) - 1
+	if position != last {
+		heap.swap(position, last)
+	}
+	heap.nodes[last] = nil
+	heap.keys = heap.keys[:last]
+	heap.nodes = heap.nodes[:last]
+	delete(heap.index, node.tag)
+
+	if position != last {
+		heap.down(position)
+		heap.up(position)
+	}
+
+	return node
+}
+
+func (heap *Float32Heap) decreaseKey(n *float32Node, value Value, key float64) error {
+	rounded, err := toFloat32(key)
+	if err != nil {
+		return err
+	}
+
+	if rounded >= heap.keys[n.position] {
+		return errors.New("New key is not smaller than current key ")
+	}
+
+	heap.keys[n.position] = rounded
+	n.value = value
+	heap.up(n.position)
+
+	return nil
+}
+
+func (heap *Float32Heap) increaseKey(n *float32Node, value Value, key float64) error {
+	rounded, err := toFloat32(key)
+	if err != nil {
+		return err
+	}
+
+	if rounded <= heap.keys[n.position] {
+		return errors.New("New key is not larger than current key ")
+	}
+
+	heap.keys[n.position] = rounded
+	n.value = value
+	heap.down(n.position)
+
+	return nil
+}
+
+func (heap *Float32Heap) up(position int) {
+	for position > 0 {
+		parent := (position - 1) / DefaultArity
+		if heap.keys[parent] <= heap.keys[position] {
+			break
+		}
+		heap.swap(parent, position)
+		position = parent
+	}
+}
+
+func (heap *Float32Heap) down(position int) {
+	for {
+		first := position*DefaultArity + 1
+		if first >= len(heap.keys) {
+			break
+		}
+
+		smallest := first
+		for child := first + 1; child < first+DefaultArity && child < len(heap.keys); child++ {
+			if heap.keys[child] < heap.keys[smallest] {
+				smallest = child
+			}
+		}
+		if heap.keys[position] <= heap.keys[smallest] {
+			break
+		}
+		heap.swap(position, smallest)
+		position = smallest
+	}
+}
+
+func (heap *Float32Heap) swap(i, j int) {
+	heap.keys[i], heap.keys[j] = heap.keys[j], heap.keys[i]
+	heap.nodes[i], heap.nodes[j] = heap.nodes[j], heap.nodes[i]
+	heap.nodes[i].position = i
+	heap.nodes[j].position = j
+}
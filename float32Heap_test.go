@@ -0,0 +1,89 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/starwander/GoFibonacciHeap/heaptest"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+var _ = Describe("Tests of float32Heap", func() {
+	var (
+		heap *Float32Heap
+	)
+
+	BeforeEach(func() {
+		heap = NewFloat32Heap()
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given an empty float32Heap, when call Minimum and ExtractMin api, it should return nil.", func() {
+		tag, key := heap.Minimum()
+		Expect(tag).Should(BeNil())
+		Expect(key).Should(BeEquivalentTo(math.Inf(-1)))
+		Expect(heap.ExtractMinValue()).Should(BeNil())
+		Expect(heap.String()).Should(BeEquivalentTo("Heap is empty.\n"))
+	})
+
+	It("Given a float32Heap, when call the apis with keys beyond the float32 range, it should round them or return error.", func() {
+		Expect(heap.Insert(nil, 0.0)).Should(HaveOccurred())
+		Expect(heap.Insert(1, math.Inf(-1))).Should(HaveOccurred())
+		Expect(heap.Insert(1, -1e300)).Should(HaveOccurred())
+		Expect(heap.Insert(1, 1e300)).ShouldNot(HaveOccurred())
+		Expect(heap.GetTag(1)).Should(Equal(math.Inf(1)))
+		Expect(heap.DecreaseKey(1, -1e300)).Should(HaveOccurred())
+		Expect(heap.DecreaseKey(1, 1e10)).ShouldNot(HaveOccurred())
+		Expect(heap.Num()).Should(BeEquivalentTo(1))
+
+		anotherHeap := NewFibHeap()
+		anotherHeap.Insert(2, -1e300)
+		Expect(heap.UnionInto(anotherHeap)).Should(HaveOccurred())
+		Expect(heap.Num()).Should(BeEquivalentTo(1))
+	})
+
+	It("Given a float32Heap, when call the apis with keys closer than the float32 precision, it should treat them as equal.", func() {
+		Expect(heap.Insert(1, 1.0)).ShouldNot(HaveOccurred())
+		Expect(heap.DecreaseKey(1, 1.0-1e-9)).Should(HaveOccurred())
+		Expect(heap.IncreaseKey(1, 1.0+1e-9)).Should(HaveOccurred())
+		Expect(heap.IncreaseKey(1, 1.1)).ShouldNot(HaveOccurred())
+		Expect(heap.GetTag(1)).Should(Equal(float64(float32(1.1))))
+
+		Expect(heap.Insert(2, 1<<24+1)).ShouldNot(HaveOccurred())
+		Expect(heap.ExtractTag(2)).Should(BeEquivalentTo(1 << 24))
+	})
+
+	It("Given a float32Heap inserted multiple values, when call ExtractMinValue api, it should extract the values sorted by the rounded key.", func() {
+		random := rand.New(rand.NewSource(1))
+		keys := make([]float64, 0, 2000)
+		for i := 0; i < 2000; i++ {
+			key := random.NormFloat64() * 1000
+			Expect(heap.InsertValue(&demoStruct{i, key, ""})).ShouldNot(HaveOccurred())
+			keys = append(keys, float64(float32(key)))
+		}
+		for i := 0; i < 500; i++ {
+			Expect(heap.Delete(i)).ShouldNot(HaveOccurred())
+			keys[i] = math.Inf(1)
+		}
+		sort.Float64s(keys)
+
+		for _, key := range keys[:1500] {
+			_, min := heap.Minimum()
+			Expect(min).Should(Equal(key))
+			Expect(float64(float32(heap.ExtractMinValue().Key()))).Should(Equal(key))
+		}
+		Expect(heap.Num()).Should(BeEquivalentTo(0))
+	})
+
+	It("Given a float32Heap, when run the differential tester, it should never diverge from the reference model.", func() {
+		for seed := int64(0); seed < 20; seed++ {
+			Expect(heaptest.Run(NewFloat32Heap(), seed)).ShouldNot(HaveOccurred())
+		}
+	})
+})
@@ -0,0 +1,149 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"errors"
+)
+
+// ErrFrozen is returned by the mutating methods of a FrozenHeap.
+var ErrFrozen = errors.New("Heap is frozen ")
+
+// FrozenHeap is a read only wrapper of a FibHeap returned by Freeze, e.g. for the plugins or consumers which must never modify the heap.
+// Its reading methods read the wrapped heap as the methods of FibHeap of the same names do, and its mutating methods return ErrFrozen.
+// The methods which cannot report an error, like ExtractMin, are not provided at all, so FrozenHeap is not a PriorityQueue.
+type FrozenHeap struct {
+	heap *FibHeap
+}
+
+// Freeze returns a read only wrapper of the heap in O(1).
+// Unlike Snapshot, nothing is copied, so the wrapper sees the later mutations of the heap,
+// and it must not be read concurrently with the mutations as all methods of FibHeap.
+func (heap *FibHeap) Freeze() *FrozenHeap {
+	return &FrozenHeap{heap}
+}
+
+// Num returns the total number of values in the heap.
+func (frozen *FrozenHeap) Num() uint {
+	return frozen.heap.Num()
+}
+
+// Len returns the total number of values in the heap as an int.
+func (frozen *FrozenHeap) Len() int {
+	return frozen.heap.Len()
+}
+
+// IsEmpty reports whether the heap has no value.
+func (frozen *FrozenHeap) IsEmpty() bool {
+	return frozen.heap.IsEmpty()
+}
+
+// Count returns how many times the input tag has been inserted by InsertOrIncrement and not yet extracted.
+func (frozen *FrozenHeap) Count(tag interface{}) uint {
+	return frozen.heap.Count(tag)
+}
+
+// Minimum returns the current minimum tag and key in the heap. An empty heap will return nil/-inf.
+func (frozen *FrozenHeap) Minimum() (interface{}, float64) {
+	return frozen.heap.Minimum()
+}
+
+// PeekMin returns the current minimum tag and key in the heap, and whether the heap has any value.
+func (frozen *FrozenHeap) PeekMin() (tag interface{}, key float64, ok bool) {
+	return frozen.heap.PeekMin()
+}
+
+// MinimumValue returns the current minimum value in the heap. An empty heap will return nil.
+func (frozen *FrozenHeap) MinimumValue() Value {
+	return frozen.heap.MinimumValue()
+}
+
+// GetTag searches and returns the key in the heap by the input tag. If the input tag does not exist in the heap, -inf will be returned.
+func (frozen *FrozenHeap) GetTag(tag interface{}) float64 {
+	return frozen.heap.GetTag(tag)
+}
+
+// GetValue searches and returns the value in the heap by the input tag. If the input tag does not exist in the heap, nil will be returned.
+func (frozen *FrozenHeap) GetValue(tag interface{}) Value {
+	return frozen.heap.GetValue(tag)
+}
+
+// Tags returns a snapshot of all tags in the heap in no particular order.
+func (frozen *FrozenHeap) Tags() []interface{} {
+	return frozen.heap.Tags()
+}
+
+// Entries returns a snapshot of all tags in the heap with their keys in no particular order.
+func (frozen *FrozenHeap) Entries() []TagKey {
+	return frozen.heap.Entries()
+}
+
+// Values returns a snapshot of all values in the heap in no particular order.
+func (frozen *FrozenHeap) Values() []Value {
+	return frozen.heap.Values()
+}
+
+// NextAbove returns the tag and key of the smallest key in the heap which is larger than the input key.
+func (frozen *FrozenHeap) NextAbove(key float64) (tag interface{}, next float64, ok bool) {
+	return frozen.heap.NextAbove(key)
+}
+
+// LargestBelow returns the tag and key of the largest key in the heap which is smaller than the input key.
+func (frozen *FrozenHeap) LargestBelow(key float64) (tag interface{}, previous float64, ok bool) {
+	return frozen.heap.LargestBelow(key)
+}
+
+// CountBelow returns the number of values in the heap whose keys are smaller than the input key.
+func (frozen *FrozenHeap) CountBelow(key float64) int {
+	return frozen.heap.CountBelow(key)
+}
+
+// KeyQuantile returns the q-quantile of the keys in the heap.
+func (frozen *FrozenHeap) KeyQuantile(q float64) (key float64, ok bool) {
+	return frozen.heap.KeyQuantile(q)
+}
+
+// String provides some basic debug information of the heap.
+func (frozen *FrozenHeap) String() string {
+	return frozen.heap.String()
+}
+
+// Insert returns ErrFrozen.
+func (frozen *FrozenHeap) Insert(tag interface{}, key float64) error {
+	return ErrFrozen
+}
+
+// InsertValue returns ErrFrozen.
+func (frozen *FrozenHeap) InsertValue(value Value) error {
+	return ErrFrozen
+}
+
+// DecreaseKey returns ErrFrozen.
+func (frozen *FrozenHeap) DecreaseKey(tag interface{}, key float64) error {
+	return ErrFrozen
+}
+
+// DecreaseKeyValue returns ErrFrozen.
+func (frozen *FrozenHeap) DecreaseKeyValue(value Value) error {
+	return ErrFrozen
+}
+
+// IncreaseKey returns ErrFrozen.
+func (frozen *FrozenHeap) IncreaseKey(tag interface{}, key float64) error {
+	return ErrFrozen
+}
+
+// IncreaseKeyValue returns ErrFrozen.
+func (frozen *FrozenHeap) IncreaseKeyValue(value Value) error {
+	return ErrFrozen
+}
+
+// Delete returns ErrFrozen.
+func (frozen *FrozenHeap) Delete(tag interface{}) error {
+	return ErrFrozen
+}
+
+// DeleteValue returns ErrFrozen.
+func (frozen *FrozenHeap) DeleteValue(value Value) error {
+	return ErrFrozen
+}
@@ -0,0 +1,67 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+)
+
+var _ = Describe("Tests of frozen heap", func() {
+	var heap *FibHeap
+	var frozen *FrozenHeap
+
+	BeforeEach(func() {
+		heap = NewFibHeap()
+		for i := 0; i < 10; i++ {
+			heap.InsertValue(&demoStruct{i, float64(i), ""})
+		}
+		frozen = heap.Freeze()
+	})
+
+	AfterEach(func() {
+		heap = nil
+		frozen = nil
+	})
+
+	It("Given a frozen fibHeap, when call the mutating apis, it should return ErrFrozen and leave the heap untouched.", func() {
+		Expect(frozen.Insert(10, 10)).Should(Equal(ErrFrozen))
+		Expect(frozen.InsertValue(&demoStruct{10, 10, ""})).Should(Equal(ErrFrozen))
+		Expect(frozen.DecreaseKey(5, 0)).Should(Equal(ErrFrozen))
+		Expect(frozen.DecreaseKeyValue(&demoStruct{5, 0, ""})).Should(Equal(ErrFrozen))
+		Expect(frozen.IncreaseKey(5, 50)).Should(Equal(ErrFrozen))
+		Expect(frozen.IncreaseKeyValue(&demoStruct{5, 50, ""})).Should(Equal(ErrFrozen))
+		Expect(frozen.Delete(5)).Should(Equal(ErrFrozen))
+		Expect(frozen.DeleteValue(&demoStruct{5, 5, ""})).Should(Equal(ErrFrozen))
+
+		Expect(frozen.Num()).Should(BeEquivalentTo(10))
+		Expect(frozen.GetTag(5)).Should(BeEquivalentTo(5))
+	})
+
+	It("Given a frozen fibHeap, when mutate the heap itself, it should see the mutations by the reading apis.", func() {
+		heap.ExtractMin()
+		heap.Insert(-1, math.Inf(1))
+
+		Expect(frozen.Len()).Should(Equal(10))
+		Expect(frozen.IsEmpty()).Should(BeFalse())
+		min, key := frozen.Minimum()
+		Expect(min).Should(BeEquivalentTo(1))
+		Expect(key).Should(BeEquivalentTo(1))
+		Expect(frozen.MinimumValue().Tag()).Should(BeEquivalentTo(1))
+		Expect(frozen.GetValue(-1)).Should(BeNil())
+		Expect(frozen.GetTag(0)).Should(Equal(math.Inf(-1)))
+		Expect(frozen.Count(3)).Should(BeEquivalentTo(1))
+		Expect(frozen.Tags()).Should(HaveLen(10))
+		Expect(frozen.Entries()).Should(HaveLen(10))
+		Expect(frozen.Values()).Should(HaveLen(9))
+		Expect(frozen.CountBelow(5)).Should(Equal(4))
+		next, _, _ := frozen.NextAbove(5)
+		Expect(next).Should(BeEquivalentTo(6))
+		previous, _, _ := frozen.LargestBelow(math.Inf(1))
+		Expect(previous).Should(BeEquivalentTo(9))
+		median, _ := frozen.KeyQuantile(0.5)
+		Expect(median).Should(BeEquivalentTo(5))
+		Expect(frozen.String()).Should(Equal(heap.String()))
+	})
+})
@@ -0,0 +1,21 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+//go:build !fibheapdebug
+// +build !fibheapdebug
+
+package fibHeap
+
+// debugMode reports whether the package is built with the fibheapdebug tag, which turns on the detection of concurrent misuse of FibHeap.
+// The checks are guarded by this constant, so they are compiled out of the normal build.
+const debugMode = false
+
+// guard detects overlapping calls of a FibHeap from different goroutines in the debug mode, and is empty otherwise.
+type guard struct{}
+
+func newGuard() guard {
+	return guard{}
+}
+
+func (g guard) enter(method string) func() {
+	return func() {}
+}
@@ -0,0 +1,105 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+//go:build fibheapdebug
+// +build fibheapdebug
+
+package fibHeap
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+)
+
+// debugMode reports whether the package is built with the fibheapdebug tag, which turns on the detection of concurrent misuse of FibHeap.
+// Build or test with -tags fibheapdebug to turn it on.
+const debugMode = true
+
+// guard detects overlapping calls of a FibHeap from different goroutines, like the detection of concurrent map writes of the runtime.
+// Every method takes the ownership of the heap by an atomic compare-and-swap of the goroutine id and releases it on return,
+// so a call from another goroutine while the heap is owned panics with the stacks of both goroutines.
+// Nested calls from the owner, e.g. by a hook or inside Txn, are allowed.
+// Calls which do not overlap are not detected, so the race detector is still the tool to find all data races.
+type guard struct {
+	state *guardState
+}
+
+type guardState struct {
+	owner  int64
+	depth  int
+	method atomic.Value
+}
+
+func newGuard() guard {
+	return guard{new(guardState)}
+}
+
+// enter takes the ownership of the heap for the current goroutine and returns the function releasing it.
+func (g guard) enter(method string) func() {
+	state := g.state
+	if state == nil {
+		return func() {}
+	}
+
+	var buf [64]byte
+	id := goroutineID(buf[:runtime.Stack(buf[:], false)])
+	if !atomic.CompareAndSwapInt64(&state.owner, 0, id) {
+		owner := atomic.LoadInt64(&state.owner)
+		if owner != id {
+			running, _ := state.method.Load().(string)
+			stacks := allStacks()
+			panic(fmt.Sprintf("fibHeap: concurrent call of FibHeap.%s in goroutine %d while FibHeap.%s is running in goroutine %d\n\n%s\n%s",
+				method, id, running, owner, goroutineStack(stacks, owner), goroutineStack(stacks, id)))
+		}
+		state.depth++
+		return state.exit
+	}
+
+	state.method.Store(method)
+	state.depth = 1
+
+	return state.exit
+}
+
+func (state *guardState) exit() {
+	state.depth--
+	if state.depth == 0 {
+		atomic.StoreInt64(&state.owner, 0)
+	}
+}
+
+// goroutineID parses the id of the goroutine from the first line of its stack, e.g. "goroutine 18 [running]:".
+func goroutineID(stack []byte) int64 {
+	stack = bytes.TrimPrefix(stack, []byte("goroutine "))
+	if i := bytes.IndexByte(stack, ' '); i >= 0 {
+		stack = stack[:i]
+	}
+	id, _ := strconv.ParseInt(string(stack), 10, 64)
+
+	return id
+}
+
+// allStacks returns the stacks of all goroutines.
+func allStacks() []byte {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// goroutineStack finds the stack of the goroutine in the stacks of all goroutines, which are separated by blank lines.
+func goroutineStack(stacks []byte, id int64) []byte {
+	for _, stack := range bytes.Split(stacks, []byte("\n\n")) {
+		if goroutineID(stack) == id {
+			return append(stack, '\n')
+		}
+	}
+
+	return []byte(fmt.Sprintf("goroutine %d has exited\n", id))
+}
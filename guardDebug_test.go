@@ -0,0 +1,56 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+//go:build fibheapdebug
+// +build fibheapdebug
+
+package fibHeap
+
+import (
+	"fmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+)
+
+var _ = Describe("Tests of guard in the debug mode", func() {
+	It("Given a heap in a call, when call it from another goroutine, it should panic with the stacks of both goroutines.", func() {
+		var heap *FibHeap
+		var message string
+		heap = NewFibHeapWithHooks(Hooks{OnInsert: func(tag interface{}, key float64, value Value) {
+			Expect(heap.GetTag(tag)).Should(Equal(key))
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				defer func() {
+					message = fmt.Sprint(recover())
+				}()
+				heap.Num()
+			}()
+			<-done
+		}})
+
+		Expect(heap.Insert(1, 1)).ShouldNot(HaveOccurred())
+		Expect(message).Should(ContainSubstring("fibHeap: concurrent call of FibHeap.Num"))
+		Expect(message).Should(ContainSubstring("while FibHeap.Insert is running"))
+		Expect(message).Should(ContainSubstring("guardDebug_test.go"))
+		Expect(message).Should(ContainSubstring("(*FibHeap).Insert"))
+		Expect(heap.guard.state.owner).Should(BeZero())
+	})
+
+	It("Given a heap, when call it from different goroutines one after another, it should not panic.", func() {
+		heap := NewFibHeap()
+		heap.Insert(1, 1)
+		done := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			defer close(done)
+			tag, _ := heap.ExtractMin()
+			Expect(tag).Should(Equal(1))
+			heap.reset()
+		}()
+		<-done
+		Expect(heap.Num()).Should(BeZero())
+		Expect(func() { heap.AddToAllKeys(math.NaN()) }).Should(Panic())
+		Expect(heap.guard.state.owner).Should(BeZero())
+	})
+})
@@ -0,0 +1,49 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"sync/atomic"
+)
+
+// Handle is a tag generated by InsertAuto for the values which have no natural tag.
+// It is a distinct type, so it never equals a tag of another type, e.g. an int of the same number.
+type Handle uint64
+
+// lastHandle is the last Handle generated. It is shared by all heaps, so the handles are unique in the process.
+var lastHandle uint64
+
+// InsertAuto pushes the input key and value into the heap under a newly generated unique Handle, and returns the handle,
+// so the callers without a natural tag do not need to invent synthetic counters. The handle is then used as the tag by the tag methods,
+// e.g. DecreaseKey(handle, key) or GetValue(handle).
+// The value can be nil to insert the key only. Otherwise it is stored as it is, and its Tag method is never called,
+// so the value methods looking a value up by its tag, e.g. DecreaseKeyValue, only find it if the Tag method returns the handle.
+// As the handles are unique across all heaps, the heaps of generated handles can be merged by Union,
+// and Unmarshal and Replay make sure the handles generated afterwards never collide with the ones they restore.
+// Try to insert a -inf key will cause an error return.
+func (heap *FibHeap) InsertAuto(key float64, value Value) (Handle, error) {
+	if debugMode {
+		defer heap.guard.enter("InsertAuto")()
+	}
+
+	if isNilValue(value) {
+		value = nil
+	}
+
+	handle := Handle(atomic.AddUint64(&lastHandle, 1))
+	if err := heap.insert(handle, key, value); err != nil {
+		return 0, err
+	}
+
+	return handle, nil
+}
+
+// observeHandle makes sure the handles generated afterwards are larger than the input restored handle.
+func observeHandle(handle Handle) {
+	for {
+		last := atomic.LoadUint64(&lastHandle)
+		if uint64(handle) <= last || atomic.CompareAndSwapUint64(&lastHandle, last, uint64(handle)) {
+			return
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"sync/atomic"
+)
+
+var _ = Describe("Tests of auto handles", func() {
+	var heap *FibHeap
+
+	BeforeEach(func() {
+		heap = NewFibHeap()
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given fibHeaps, when call InsertAuto api, it should generate unique handles usable as tags.", func() {
+		first, err := heap.InsertAuto(2, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		second, err := heap.InsertAuto(1, &demoStruct{1, 1, "1"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(first).ShouldNot(Equal(second))
+		Expect(heap.GetValue(second)).Should(Equal(&demoStruct{1, 1, "1"}))
+		Expect(heap.GetTag(uint64(first))).Should(Equal(math.Inf(-1)))
+
+		another := NewFibHeap()
+		third, _ := another.InsertAuto(0, nil)
+		Expect(heap.Union(another)).Should(Succeed())
+		Expect(heap.DecreaseKey(first, -1)).Should(Succeed())
+		tag, _ := heap.ExtractMin()
+		Expect(tag).Should(Equal(first))
+		tag, _ = heap.ExtractMin()
+		Expect(tag).Should(Equal(third))
+
+		_, err = heap.InsertAuto(math.Inf(-1), nil)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("Given a marshaled fibHeap of handles, when unmarshal it after the handles are reset, it should never generate a restored handle again.", func() {
+		handle, _ := heap.InsertAuto(1, nil)
+		var buffer bytes.Buffer
+		Expect(heap.Marshal(&buffer)).Should(Succeed())
+
+		atomic.StoreUint64(&lastHandle, 0)
+		restored, err := Unmarshal(&buffer)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(restored.GetTag(handle)).Should(BeEquivalentTo(1))
+		next, err := restored.InsertAuto(2, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(next).Should(BeNumerically(">", handle))
+	})
+})
@@ -0,0 +1,264 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"container/list"
+	"errors"
+	"math"
+)
+
+// maxPoolSize is the maximum number of free nodes kept by a nodePool.
+const maxPoolSize = 4096
+
+// nodePool keeps the nodes extracted from the heaps of a HeapGroup, so that they can be reused by the inserts of any heap of the group.
+type nodePool struct {
+	free []*node
+}
+
+// HeapGroup manages many FibHeaps by namespaces, e.g. one heap per tenant, so every tag is identified by the namespace and the tag.
+// The heaps share a pool of free nodes, and the minimum of every heap is kept in an outer FibHeap,
+// so the global minimum across all namespaces is found in O(1) and extracted in O(log n) amortized.
+// A namespace exists as long as its heap is not empty.
+// Please note that all methods of HeapGroup are not concurrent safe.
+type HeapGroup struct {
+	heaps map[string]*FibHeap
+	mins  *FibHeap
+	pool  *nodePool
+	quota Quota
+}
+
+// NewHeapGroup creates an initialized empty HeapGroup.
+func NewHeapGroup() *HeapGroup {
+	group := new(HeapGroup)
+	group.heaps = make(map[string]*FibHeap)
+	group.mins = NewFibHeap()
+	group.pool = new(nodePool)
+
+	return group
+}
+
+// SetQuota sets the quota of every namespace, so that one namespace, e.g. of a tenant, cannot starve the others.
+// Once an insert would exceed the quota of its namespace, Insert and InsertValue return a *QuotaError instead, see FibHeap.SetQuota.
+// The zero Quota turns off the limits, which is the default.
+func (group *HeapGroup) SetQuota(quota Quota) {
+	group.quota = quota
+	for _, heap := range group.heaps {
+		heap.quota = quota
+	}
+}
+
+// Num returns the total number of values in all namespaces.
+func (group *HeapGroup) Num() uint {
+	var num uint
+	for _, heap := range group.heaps {
+		num += heap.Num()
+	}
+
+	return num
+}
+
+// NumOf returns the number of values in the input namespace.
+func (group *HeapGroup) NumOf(namespace string) uint {
+	if heap, exists := group.heaps[namespace]; exists {
+		return heap.Num()
+	}
+
+	return 0
+}
+
+// Namespaces returns all non-empty namespaces in no particular order.
+func (group *HeapGroup) Namespaces() []string {
+	namespaces := make([]string, 0, len(group.heaps))
+	for namespace := range group.heaps {
+		namespaces = append(namespaces, namespace)
+	}
+
+	return namespaces
+}
+
+// Insert pushes the input tag and key into the heap of the input namespace, which is created if it does not exist.
+// The same tag can exist in different namespaces. Try to insert a duplicate tag into the same namespace will cause an error return.
+func (group *HeapGroup) Insert(namespace string, tag interface{}, key float64) error {
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	return group.update(namespace, true, func(heap *FibHeap) error {
+		return heap.insert(tag, key, nil)
+	})
+}
+
+// InsertValue pushes the input value into the heap of the input namespace, which is created if it does not exist.
+func (group *HeapGroup) InsertValue(namespace string, value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	return group.update(namespace, true, func(heap *FibHeap) error {
+		return heap.insert(value.Tag(), value.Key(), value)
+	})
+}
+
+// DecreaseKey updates the tag in the input namespace by the input smaller key.
+// If the namespace or the tag does not exist, or the key is not smaller, an error will be returned.
+func (group *HeapGroup) DecreaseKey(namespace string, tag interface{}, key float64) error {
+	return group.update(namespace, false, func(heap *FibHeap) error {
+		return heap.DecreaseKey(tag, key)
+	})
+}
+
+// IncreaseKey updates the tag in the input namespace by the input larger key.
+// If the namespace or the tag does not exist, or the key is not larger, an error will be returned.
+func (group *HeapGroup) IncreaseKey(namespace string, tag interface{}, key float64) error {
+	return group.update(namespace, false, func(heap *FibHeap) error {
+		return heap.IncreaseKey(tag, key)
+	})
+}
+
+// Delete deletes the tag in the input namespace.
+// If the namespace or the tag does not exist, an error will be returned.
+func (group *HeapGroup) Delete(namespace string, tag interface{}) error {
+	return group.update(namespace, false, func(heap *FibHeap) error {
+		return heap.Delete(tag)
+	})
+}
+
+// DeleteNamespace deletes all values in the input namespace.
+func (group *HeapGroup) DeleteNamespace(namespace string) {
+	if _, exists := group.heaps[namespace]; exists {
+		delete(group.heaps, namespace)
+		group.mins.Delete(namespace)
+	}
+}
+
+// GetTag searches and returns the key of the tag in the input namespace.
+// If the namespace or the tag does not exist, -inf will be returned.
+func (group *HeapGroup) GetTag(namespace string, tag interface{}) float64 {
+	if heap, exists := group.heaps[namespace]; exists {
+		return heap.GetTag(tag)
+	}
+
+	return math.Inf(-1)
+}
+
+// GetValue searches and returns the value of the tag in the input namespace.
+// If the namespace or the tag does not exist, nil will be returned.
+func (group *HeapGroup) GetValue(namespace string, tag interface{}) Value {
+	if heap, exists := group.heaps[namespace]; exists {
+		return heap.GetValue(tag)
+	}
+
+	return nil
+}
+
+// Minimum returns the namespace, tag and key of the global minimum across all namespaces.
+// An empty group will return an empty namespace, nil and -inf.
+func (group *HeapGroup) Minimum() (string, interface{}, float64) {
+	namespace, _ := group.mins.Minimum()
+	if namespace == nil {
+		return "", nil, math.Inf(-1)
+	}
+
+	tag, key := group.heaps[namespace.(string)].Minimum()
+
+	return namespace.(string), tag, key
+}
+
+// ExtractMin returns the namespace, tag and key of the global minimum across all namespaces and then extracts them.
+// An empty group will return an empty namespace, nil and -inf and extracts nothing.
+func (group *HeapGroup) ExtractMin() (string, interface{}, float64) {
+	namespace, _ := group.mins.Minimum()
+	if namespace == nil {
+		return "", nil, math.Inf(-1)
+	}
+
+	return group.ExtractMinOf(namespace.(string))
+}
+
+// MinimumOf returns the namespace, tag and key of the minimum in the input namespace.
+// An empty or not existed namespace will return the namespace, nil and -inf.
+func (group *HeapGroup) MinimumOf(namespace string) (string, interface{}, float64) {
+	heap, exists := group.heaps[namespace]
+	if !exists {
+		return namespace, nil, math.Inf(-1)
+	}
+
+	tag, key := heap.Minimum()
+
+	return namespace, tag, key
+}
+
+// ExtractMinOf returns the namespace, tag and key of the minimum in the input namespace and then extracts them.
+// An empty or not existed namespace will return the namespace, nil and -inf and extracts nothing.
+func (group *HeapGroup) ExtractMinOf(namespace string) (string, interface{}, float64) {
+	var tag interface{}
+	key := math.Inf(-1)
+	group.update(namespace, false, func(heap *FibHeap) error {
+		tag, key = heap.ExtractMin()
+		return nil
+	})
+
+	return namespace, tag, key
+}
+
+// update applies fn to the heap of the namespace and then refreshes the minimum of the namespace in the outer heap.
+// The heap is created if create is true, and it is removed from the group once it becomes empty.
+func (group *HeapGroup) update(namespace string, create bool, fn func(heap *FibHeap) error) error {
+	heap, exists := group.heaps[namespace]
+	if !exists {
+		if !create {
+			return errors.New("Namespace is not found ")
+		}
+		heap = NewFibHeap()
+		heap.pool = group.pool
+		heap.quota = group.quota
+	}
+
+	err := fn(heap)
+	if heap.Num() == 0 {
+		if exists {
+			delete(group.heaps, namespace)
+			group.mins.Delete(namespace)
+		}
+		return err
+	}
+	group.heaps[namespace] = heap
+
+	_, min := heap.Minimum()
+	current := group.mins.GetTag(namespace)
+	if math.IsInf(current, -1) {
+		group.mins.Insert(namespace, min)
+	} else if min < current {
+		group.mins.DecreaseKey(namespace, min)
+	} else if min > current {
+		group.mins.IncreaseKey(namespace, min)
+	}
+
+	return err
+}
+
+// newNode takes a node from the pool of the heap, or allocates a new one.
+func (heap *FibHeap) newNode() *node {
+	if heap.pool == nil || len(heap.pool.free) == 0 {
+		n := new(node)
+		n.children = list.New()
+		return n
+	}
+
+	last := len(heap.pool.free) - 1
+	n := heap.pool.free[last]
+	heap.pool.free[last] = nil
+	heap.pool.free = heap.pool.free[:last]
+	*n = node{children: n.children.Init()}
+
+	return n
+}
+
+// recycle puts an extracted node into the pool of the heap.
+// The node is only reused by a later insert, so the caller of extractMin can still read it.
+func (heap *FibHeap) recycle(n *node) {
+	if heap.pool != nil && len(heap.pool.free) < maxPoolSize {
+		heap.pool.free = append(heap.pool.free, n)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"fmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"math/rand"
+)
+
+var _ = Describe("Tests of heapGroup", func() {
+	var (
+		group *HeapGroup
+	)
+
+	BeforeEach(func() {
+		group = NewHeapGroup()
+	})
+
+	AfterEach(func() {
+		group = nil
+	})
+
+	It("Given an empty heapGroup, when call Minimum and ExtractMin api, it should return nil.", func() {
+		namespace, tag, key := group.Minimum()
+		Expect(namespace).Should(BeEmpty())
+		Expect(tag).Should(BeNil())
+		Expect(key).Should(Equal(math.Inf(-1)))
+		_, tag, _ = group.ExtractMin()
+		Expect(tag).Should(BeNil())
+		_, tag, _ = group.ExtractMinOf("a")
+		Expect(tag).Should(BeNil())
+		Expect(group.Delete("a", 1)).Should(HaveOccurred())
+		Expect(group.GetTag("a", 1)).Should(Equal(math.Inf(-1)))
+		Expect(group.Num()).Should(BeEquivalentTo(0))
+	})
+
+	It("Given a heapGroup with several namespaces, when call the apis, it should keep the tags apart and track the global minimum.", func() {
+		Expect(group.Insert("a", nil, 1)).Should(HaveOccurred())
+		Expect(group.Insert("a", 1, 10)).ShouldNot(HaveOccurred())
+		Expect(group.Insert("a", 1, 20)).Should(HaveOccurred())
+		Expect(group.Insert("b", 1, 5)).ShouldNot(HaveOccurred())
+		Expect(group.InsertValue("b", &demoStruct{2, 7, "2"})).ShouldNot(HaveOccurred())
+		Expect(group.Insert("c", 1, 30)).ShouldNot(HaveOccurred())
+		Expect(group.Namespaces()).Should(ConsistOf("a", "b", "c"))
+		Expect(group.NumOf("b")).Should(BeEquivalentTo(2))
+		Expect(group.GetValue("b", 2).(*demoStruct).value).Should(Equal("2"))
+
+		namespace, tag, key := group.Minimum()
+		Expect([]interface{}{namespace, tag, key}).Should(Equal([]interface{}{"b", 1, 5.0}))
+
+		Expect(group.DecreaseKey("c", 1, 1)).ShouldNot(HaveOccurred())
+		Expect(group.IncreaseKey("b", 1, 50)).ShouldNot(HaveOccurred())
+		Expect(group.DecreaseKey("d", 1, 1)).Should(HaveOccurred())
+		namespace, tag, key = group.ExtractMin()
+		Expect([]interface{}{namespace, tag, key}).Should(Equal([]interface{}{"c", 1, 1.0}))
+		Expect(group.Namespaces()).Should(ConsistOf("a", "b"))
+
+		namespace, tag, key = group.MinimumOf("b")
+		Expect([]interface{}{namespace, tag, key}).Should(Equal([]interface{}{"b", 2, 7.0}))
+		Expect(group.Delete("b", 2)).ShouldNot(HaveOccurred())
+		group.DeleteNamespace("a")
+		namespace, tag, key = group.ExtractMin()
+		Expect([]interface{}{namespace, tag, key}).Should(Equal([]interface{}{"b", 1, 50.0}))
+		Expect(group.Num()).Should(BeEquivalentTo(0))
+		Expect(group.mins.Num()).Should(BeEquivalentTo(0))
+	})
+
+	It("Given a heapGroup under random operations, when extract all values, it should return them in the global order and reuse the nodes.", func() {
+		random := rand.New(rand.NewSource(1))
+		for i := 0; i < 10000; i++ {
+			namespace := fmt.Sprint(random.Intn(10))
+			tag := random.Intn(100)
+			switch random.Intn(4) {
+			case 0, 1:
+				group.Insert(namespace, tag, random.Float64()*1000)
+			case 2:
+				group.ExtractMin()
+			case 3:
+				group.DecreaseKey(namespace, tag, group.GetTag(namespace, tag)-random.Float64()*100)
+			}
+		}
+		Expect(group.pool.free).ShouldNot(BeEmpty())
+
+		last := math.Inf(-1)
+		num := group.Num()
+		for i := uint(0); i < num; i++ {
+			namespace, _, key := group.ExtractMin()
+			Expect(namespace).ShouldNot(BeEmpty())
+			Expect(key).Should(BeNumerically(">=", last))
+			last = key
+		}
+		Expect(group.Namespaces()).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,144 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"errors"
+	"math"
+)
+
+// HeapOfHeaps composes many FibHeaps into one priority queue, e.g. the queues of a scheduler, by keeping every attached child heap
+// as an entry of a parent FibHeap keyed by the current minimum of the child.
+// Unlike HeapGroup, the child heaps are used directly through all their methods, and the parent is kept consistent automatically,
+// as every child reports the changes of its minimum to the parent, in the same way as the OnMinChange hook.
+// So the global minimum across all children is found in O(1), and every change of the minimum of a child costs O(log n) amortized in the parent,
+// where n is the number of children.
+// An empty child stays attached, but is only an entry of the parent while it has values.
+// Please note that all methods of HeapOfHeaps, and the methods of its children, are not concurrent safe.
+type HeapOfHeaps struct {
+	mins     *FibHeap
+	children map[interface{}]*FibHeap
+}
+
+// childLink is the link from a child heap to its HeapOfHeaps, under the name of the child.
+type childLink struct {
+	heaps *HeapOfHeaps
+	name  interface{}
+}
+
+// NewHeapOfHeaps creates an initialized HeapOfHeaps without any child.
+func NewHeapOfHeaps() *HeapOfHeaps {
+	heaps := new(HeapOfHeaps)
+	heaps.mins = NewFibHeap()
+	heaps.children = make(map[interface{}]*FibHeap)
+
+	return heaps
+}
+
+// Attach adds the input heap as a child under the input name, including all its values.
+// Try to attach a nil name or heap, a duplicate name, or a heap which is already attached to any HeapOfHeaps will cause an error return.
+func (heaps *HeapOfHeaps) Attach(name interface{}, child *FibHeap) error {
+	if name == nil {
+		return errors.New("Input name is nil ")
+	}
+	if child == nil {
+		return errors.New("Input heap is nil ")
+	}
+	name = tagKey(name)
+	if _, exists := heaps.children[name]; exists {
+		return errors.New("Duplicate name is not allowed ")
+	}
+	if child.parent != nil {
+		return errors.New("Heap is already attached ")
+	}
+
+	// The reported minimum is stale if the heap has been changed without the hook and the parent.
+	tag, key := child.Minimum()
+	child.reported.tag, child.reported.key = tag, key
+	child.parent = &childLink{heaps, name}
+	heaps.children[name] = child
+	heaps.update(name, tag, key)
+
+	return nil
+}
+
+// Detach removes the child of the input name and returns it with all its values, so it is a standalone heap again.
+// If the name does not exist, nil will be returned.
+func (heaps *HeapOfHeaps) Detach(name interface{}) *FibHeap {
+	name = tagKey(name)
+	child, exists := heaps.children[name]
+	if !exists {
+		return nil
+	}
+
+	delete(heaps.children, name)
+	heaps.mins.Delete(name)
+	child.parent = nil
+
+	return child
+}
+
+// Child returns the child heap of the input name.
+// If the name does not exist, nil will be returned.
+func (heaps *HeapOfHeaps) Child(name interface{}) *FibHeap {
+	return heaps.children[tagKey(name)]
+}
+
+// Len returns the number of children, including the empty ones.
+func (heaps *HeapOfHeaps) Len() int {
+	return len(heaps.children)
+}
+
+// Num returns the total number of values in all children in O(n), where n is the number of children.
+func (heaps *HeapOfHeaps) Num() uint {
+	var num uint
+	for _, child := range heaps.children {
+		num += child.Num()
+	}
+
+	return num
+}
+
+// Minimum returns the name of the child holding the global minimum across all children, and the tag and key of the minimum.
+// If all children are empty, nil, nil and -inf will be returned.
+func (heaps *HeapOfHeaps) Minimum() (interface{}, interface{}, float64) {
+	name, _ := heaps.mins.Minimum()
+	if name == nil {
+		return nil, nil, math.Inf(-1)
+	}
+
+	tag, key := heaps.children[name].Minimum()
+
+	return name, tag, key
+}
+
+// ExtractMin returns the name of the child holding the global minimum across all children, and the tag and key of the minimum,
+// and then extracts them from the child.
+// If all children are empty, nil, nil and -inf will be returned and nothing is extracted.
+func (heaps *HeapOfHeaps) ExtractMin() (interface{}, interface{}, float64) {
+	name, _ := heaps.mins.Minimum()
+	if name == nil {
+		return nil, nil, math.Inf(-1)
+	}
+
+	tag, key := heaps.children[name].ExtractMin()
+
+	return name, tag, key
+}
+
+// update moves the child of the input name in the parent by the new minimum of the child, or removes it if the child is empty.
+func (heaps *HeapOfHeaps) update(name interface{}, tag interface{}, key float64) {
+	if tag == nil {
+		heaps.mins.Delete(name)
+		return
+	}
+
+	current := heaps.mins.GetTag(name)
+	if math.IsInf(current, -1) {
+		heaps.mins.Insert(name, key)
+	} else if key < current {
+		heaps.mins.DecreaseKey(name, key)
+	} else if key > current {
+		heaps.mins.IncreaseKey(name, key)
+	}
+}
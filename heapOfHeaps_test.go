@@ -0,0 +1,114 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"math/rand"
+)
+
+var _ = Describe("Tests of heapOfHeaps", func() {
+	var heaps *HeapOfHeaps
+
+	BeforeEach(func() {
+		heaps = NewHeapOfHeaps()
+	})
+
+	AfterEach(func() {
+		heaps = nil
+	})
+
+	It("Given a heapOfHeaps, when attach and detach children, it should check the inputs and track the minima of the children.", func() {
+		name, tag, key := heaps.ExtractMin()
+		Expect([]interface{}{name, tag, key}).Should(Equal([]interface{}{nil, nil, math.Inf(-1)}))
+
+		a, b := NewFibHeap(), NewFibHeap()
+		a.Insert("a1", 3)
+		Expect(heaps.Attach("a", a)).ShouldNot(HaveOccurred())
+		Expect(heaps.Attach("b", b)).ShouldNot(HaveOccurred())
+		Expect(heaps.Attach(nil, NewFibHeap())).Should(HaveOccurred())
+		Expect(heaps.Attach("c", nil)).Should(HaveOccurred())
+		Expect(heaps.Attach("a", NewFibHeap())).Should(HaveOccurred())
+		Expect(heaps.Attach("c", a)).Should(HaveOccurred())
+		Expect(NewHeapOfHeaps().Attach("a", a)).Should(HaveOccurred())
+		Expect(heaps.Len()).Should(Equal(2))
+		Expect(heaps.Child("b")).Should(BeIdenticalTo(b))
+		Expect(heaps.Child("c")).Should(BeNil())
+
+		b.Insert("b1", 2)
+		name, tag, key = heaps.Minimum()
+		Expect([]interface{}{name, tag, key}).Should(Equal([]interface{}{"b", "b1", 2.0}))
+		a.DecreaseKey("a1", 1)
+		name, tag, key = heaps.Minimum()
+		Expect([]interface{}{name, tag, key}).Should(Equal([]interface{}{"a", "a1", 1.0}))
+		a.IncreaseKey("a1", 4)
+		b.Insert("b2", 5)
+		Expect(heaps.Num()).Should(BeEquivalentTo(3))
+
+		Expect(heaps.Detach("b")).Should(BeIdenticalTo(b))
+		Expect(heaps.Detach("b")).Should(BeNil())
+		name, tag, key = heaps.Minimum()
+		Expect([]interface{}{name, tag, key}).Should(Equal([]interface{}{"a", "a1", 4.0}))
+
+		// The detached heap changes without its parent, and is attached again.
+		b.ExtractMin()
+		b.Insert("b3", 6)
+		b.DecreaseKey("b3", 5)
+		Expect(heaps.Attach("b", b)).ShouldNot(HaveOccurred())
+		b.DecreaseKey("b2", 3)
+		name, tag, key = heaps.ExtractMin()
+		Expect([]interface{}{name, tag, key}).Should(Equal([]interface{}{"b", "b2", 3.0}))
+		name, tag, key = heaps.ExtractMin()
+		Expect([]interface{}{name, tag, key}).Should(Equal([]interface{}{"a", "a1", 4.0}))
+		Expect(a.Num()).Should(BeEquivalentTo(0))
+		Expect(heaps.Len()).Should(Equal(2))
+	})
+
+	It("Given a heapOfHeaps of many children under random operations, when extract all values, it should return them in the global order.", func() {
+		random := rand.New(rand.NewSource(1920))
+		children := make([]*FibHeap, 100)
+		for i := range children {
+			children[i] = NewFibHeapWithHooks(Hooks{OnMinChange: func(tag interface{}, key float64, value Value) {}})
+			Expect(heaps.Attach(i, children[i])).ShouldNot(HaveOccurred())
+		}
+
+		for i := 0; i < 20000; i++ {
+			child := children[random.Intn(len(children))]
+			tag := random.Intn(100)
+			switch random.Intn(5) {
+			case 0, 1:
+				child.Insert(tag, random.Float64())
+			case 2:
+				child.DecreaseKey(tag, random.Float64())
+			case 3:
+				child.IncreaseKey(tag, random.Float64())
+			case 4:
+				child.ExtractMin()
+			}
+
+			name, _, key := heaps.Minimum()
+			min := math.Inf(1)
+			for _, child := range children {
+				if _, childKey := child.Minimum(); child.Num() != 0 && childKey < min {
+					min = childKey
+				}
+			}
+			if name == nil {
+				Expect(heaps.Num()).Should(BeEquivalentTo(0))
+			} else {
+				Expect(key).Should(Equal(min))
+			}
+		}
+
+		last := math.Inf(-1)
+		for num := heaps.Num(); num != 0; num-- {
+			_, _, key := heaps.ExtractMin()
+			Expect(key).Should(BeNumerically(">=", last))
+			last = key
+		}
+		name, _, _ := heaps.Minimum()
+		Expect(name).Should(BeNil())
+	})
+})
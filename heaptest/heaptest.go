@@ -0,0 +1,305 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+// Package heaptest provides a naive reference model and a randomized differential tester for the heaps of package fibHeap.
+// The tester drives a heap and the model with the same pseudo random operation sequence and reports the first divergence.
+// A failure can always be reproduced by running the tester again with the reported seed.
+package heaptest
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Heap is the tag/key interface which the differential tester drives.
+// FibHeap and the reference Model both implement it.
+type Heap interface {
+	Insert(tag interface{}, key float64) error
+	Minimum() (interface{}, float64)
+	ExtractMin() (interface{}, float64)
+	DecreaseKey(tag interface{}, key float64) error
+	IncreaseKey(tag interface{}, key float64) error
+	Delete(tag interface{}) error
+	GetTag(tag interface{}) float64
+	ExtractTag(tag interface{}) float64
+	Num() uint
+}
+
+// Entry is a tag/key pair stored in the reference model.
+type Entry struct {
+	Tag interface{}
+	Key float64
+}
+
+// Model is a naive reference heap keeping all entries in a slice sorted by key.
+// Every operation is O(n) and written to be obviously correct rather than fast.
+type Model struct {
+	entries []Entry
+}
+
+// NewModel creates an empty reference model.
+func NewModel() *Model {
+	return new(Model)
+}
+
+// Num returns the total number of entries in the model.
+func (model *Model) Num() uint {
+	return uint(len(model.entries))
+}
+
+// Insert adds the tag and key into the model following the same rules as FibHeap.Insert.
+func (model *Model) Insert(tag interface{}, key float64) error {
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if model.find(tag) >= 0 {
+		return errors.New("Duplicate tag is not allowed ")
+	}
+
+	position := sort.Search(len(model.entries), func(i int) bool { return model.entries[i].Key > key })
+	model.entries = append(model.entries, Entry{})
+	copy(model.entries[position+1:], model.entries[position:])
+	model.entries[position] = Entry{tag, key}
+
+	return nil
+}
+
+// Minimum returns the first tag and key of the model, or nil and -inf if the model is empty.
+func (model *Model) Minimum() (interface{}, float64) {
+	if len(model.entries) == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	return model.entries[0].Tag, model.entries[0].Key
+}
+
+// ExtractMin removes and returns the first tag and key of the model, or nil and -inf if the model is empty.
+func (model *Model) ExtractMin() (interface{}, float64) {
+	if len(model.entries) == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	min := model.entries[0]
+	model.remove(0)
+
+	return min.Tag, min.Key
+}
+
+// DecreaseKey updates the key of the tag following the same rules as FibHeap.DecreaseKey.
+func (model *Model) DecreaseKey(tag interface{}, key float64) error {
+	return model.update(tag, key, func(old float64) bool { return key < old })
+}
+
+// IncreaseKey updates the key of the tag following the same rules as FibHeap.IncreaseKey.
+func (model *Model) IncreaseKey(tag interface{}, key float64) error {
+	return model.update(tag, key, func(old float64) bool { return key > old })
+}
+
+// Delete removes the tag from the model following the same rules as FibHeap.Delete.
+func (model *Model) Delete(tag interface{}) error {
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	position := model.find(tag)
+	if position < 0 {
+		return errors.New("Tag is not found ")
+	}
+	model.remove(position)
+
+	return nil
+}
+
+// GetTag returns the key of the tag, or -inf if the tag does not exist.
+func (model *Model) GetTag(tag interface{}) float64 {
+	if position := model.find(tag); position >= 0 {
+		return model.entries[position].Key
+	}
+
+	return math.Inf(-1)
+}
+
+// ExtractTag removes the tag and returns its key, or -inf if the tag does not exist.
+func (model *Model) ExtractTag(tag interface{}) float64 {
+	if position := model.find(tag); position >= 0 {
+		key := model.entries[position].Key
+		model.remove(position)
+		return key
+	}
+
+	return math.Inf(-1)
+}
+
+// Entries returns a copy of all entries sorted by key.
+func (model *Model) Entries() []Entry {
+	entries := make([]Entry, len(model.entries))
+	copy(entries, model.entries)
+
+	return entries
+}
+
+func (model *Model) update(tag interface{}, key float64, valid func(old float64) bool) error {
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	position := model.find(tag)
+	if position < 0 {
+		return errors.New("Value is not found ")
+	}
+
+	if !valid(model.entries[position].Key) {
+		return errors.New("New key is not valid for the update ")
+	}
+
+	model.remove(position)
+	model.Insert(tag, key)
+
+	return nil
+}
+
+func (model *Model) find(tag interface{}) int {
+	for i := range model.entries {
+		if model.entries[i].Tag == tag {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (model *Model) remove(position int) {
+	model.entries = append(model.entries[:position], model.entries[position+1:]...)
+}
+
+// Config controls the operation sequence generated by the differential tester.
+type Config struct {
+	// Steps is the number of random operations to run.
+	Steps int
+	// Tags is the size of the tag space. Tags are the integers in [0, Tags).
+	// A small tag space produces more duplicate inserts and operations on existing tags.
+	Tags int
+	// Keys is the number of distinct keys. Keys are the integers in [0, Keys).
+	// A small key space produces more ties.
+	Keys int
+}
+
+// DefaultConfig is the configuration used by Run.
+var DefaultConfig = Config{Steps: 10000, Tags: 512, Keys: 1024}
+
+// Failure describes the first divergence found between a heap and the reference model.
+type Failure struct {
+	// Seed reproduces the whole operation sequence when passed to Run again.
+	Seed int64
+	// Step is the zero based index of the diverging operation.
+	Step int
+	// Ops lists all operations executed up to and including the diverging one.
+	Ops []string
+	// Reason describes the divergence.
+	Reason string
+}
+
+// Error implements the error interface.
+func (failure *Failure) Error() string {
+	return fmt.Sprintf("heaptest: seed %d diverged at step %d (%s): %s", failure.Seed, failure.Step, failure.Ops[len(failure.Ops)-1], failure.Reason)
+}
+
+// Run drives the heap and a fresh reference model with DefaultConfig operations generated from the seed.
+// The heap must be empty. A *Failure is returned on the first divergence.
+func Run(heap Heap, seed int64) error {
+	return RunConfig(heap, seed, DefaultConfig)
+}
+
+// RunConfig is the same as Run but with a custom configuration.
+func RunConfig(heap Heap, seed int64, config Config) error {
+	rnd := rand.New(rand.NewSource(seed))
+	model := NewModel()
+	failure := &Failure{Seed: seed}
+
+	fail := func(format string, args ...interface{}) error {
+		failure.Reason = fmt.Sprintf(format, args...)
+		return failure
+	}
+
+	for step := 0; step < config.Steps; step++ {
+		failure.Step = step
+		tag := rnd.Intn(config.Tags)
+		key := float64(rnd.Intn(config.Keys))
+
+		switch op := rnd.Intn(8); op {
+		case 0, 1:
+			failure.Ops = append(failure.Ops, fmt.Sprintf("Insert(%d, %v)", tag, key))
+			if err := checkError(heap.Insert(tag, key), model.Insert(tag, key)); err != "" {
+				return fail("%s", err)
+			}
+		case 2:
+			failure.Ops = append(failure.Ops, "ExtractMin()")
+			gotTag, gotKey := heap.ExtractMin()
+			_, wantKey := model.Minimum()
+			if gotKey != wantKey {
+				return fail("got key %v, want %v", gotKey, wantKey)
+			}
+			if gotTag != nil && model.ExtractTag(gotTag) != gotKey {
+				return fail("extracted tag %v does not carry key %v", gotTag, gotKey)
+			}
+		case 3:
+			failure.Ops = append(failure.Ops, fmt.Sprintf("DecreaseKey(%d, %v)", tag, key))
+			if err := checkError(heap.DecreaseKey(tag, key), model.DecreaseKey(tag, key)); err != "" {
+				return fail("%s", err)
+			}
+		case 4:
+			failure.Ops = append(failure.Ops, fmt.Sprintf("IncreaseKey(%d, %v)", tag, key))
+			if err := checkError(heap.IncreaseKey(tag, key), model.IncreaseKey(tag, key)); err != "" {
+				return fail("%s", err)
+			}
+		case 5:
+			failure.Ops = append(failure.Ops, fmt.Sprintf("Delete(%d)", tag))
+			if err := checkError(heap.Delete(tag), model.Delete(tag)); err != "" {
+				return fail("%s", err)
+			}
+		case 6:
+			failure.Ops = append(failure.Ops, fmt.Sprintf("GetTag(%d)", tag))
+			if got, want := heap.GetTag(tag), model.GetTag(tag); got != want {
+				return fail("got key %v, want %v", got, want)
+			}
+		case 7:
+			failure.Ops = append(failure.Ops, fmt.Sprintf("ExtractTag(%d)", tag))
+			if got, want := heap.ExtractTag(tag), model.ExtractTag(tag); got != want {
+				return fail("got key %v, want %v", got, want)
+			}
+		}
+
+		if heap.Num() != model.Num() {
+			return fail("got size %d, want %d", heap.Num(), model.Num())
+		}
+
+		gotTag, gotKey := heap.Minimum()
+		if _, wantKey := model.Minimum(); gotKey != wantKey {
+			return fail("got minimum key %v, want %v", gotKey, wantKey)
+		}
+		if gotTag != nil && model.GetTag(gotTag) != gotKey {
+			return fail("minimum tag %v does not carry key %v", gotTag, gotKey)
+		}
+	}
+
+	return nil
+}
+
+func checkError(got, want error) string {
+	if (got == nil) != (want == nil) {
+		return fmt.Sprintf("got error %v, want %v", got, want)
+	}
+
+	return ""
+}
@@ -0,0 +1,78 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package heaptest
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	fibHeap "github.com/starwander/GoFibonacciHeap"
+	"math"
+)
+
+var _ = Describe("Tests of heaptest", func() {
+	var model *Model
+
+	Context("behaviour tests of the reference model", func() {
+		BeforeEach(func() {
+			model = NewModel()
+		})
+
+		It("Given an empty model, when call Minimum api, it should return nil and -inf.", func() {
+			tag, key := model.Minimum()
+			Expect(tag).Should(BeNil())
+			Expect(key).Should(BeEquivalentTo(math.Inf(-1)))
+		})
+
+		It("Given a model inserted multiple values, when call ExtractMin api, it should return them sorted by key.", func() {
+			for i := 0; i < 100; i++ {
+				Expect(model.Insert(i, float64((i*37)%100))).ShouldNot(HaveOccurred())
+			}
+			Expect(model.Insert(1, 0)).Should(HaveOccurred())
+
+			for i := 0; i < 100; i++ {
+				_, key := model.ExtractMin()
+				Expect(key).Should(BeEquivalentTo(i))
+			}
+			Expect(model.Num()).Should(BeEquivalentTo(0))
+		})
+
+		It("Given a model with values, when call DecreaseKey and IncreaseKey api, it should validate and reorder the values.", func() {
+			model.Insert("a", 10)
+			model.Insert("b", 20)
+
+			Expect(model.DecreaseKey("b", 30)).Should(HaveOccurred())
+			Expect(model.IncreaseKey("a", 5)).Should(HaveOccurred())
+			Expect(model.DecreaseKey("b", 5)).ShouldNot(HaveOccurred())
+			Expect(model.IncreaseKey("a", 25)).ShouldNot(HaveOccurred())
+			Expect(model.Entries()).Should(Equal([]Entry{{"b", 5}, {"a", 25}}))
+		})
+	})
+
+	Context("differential tests", func() {
+		It("Given a fibHeap, when run the differential tester with multiple seeds, it should never diverge from the model.", func() {
+			for seed := int64(0); seed < 20; seed++ {
+				Expect(Run(fibHeap.NewFibHeap(), seed)).ShouldNot(HaveOccurred())
+			}
+		})
+
+		It("Given a broken heap, when run the differential tester, it should report a reproducible failure.", func() {
+			err := Run(&brokenHeap{NewModel()}, 7)
+			Expect(err).Should(HaveOccurred())
+			failure := err.(*Failure)
+			Expect(failure.Seed).Should(BeEquivalentTo(7))
+			Expect(failure.Ops).Should(HaveLen(failure.Step + 1))
+
+			again := Run(&brokenHeap{NewModel()}, 7).(*Failure)
+			Expect(again.Step).Should(Equal(failure.Step))
+			Expect(again.Ops).Should(Equal(failure.Ops))
+		})
+	})
+})
+
+type brokenHeap struct {
+	*Model
+}
+
+func (heap *brokenHeap) Num() uint {
+	return heap.Model.Num() + 1
+}
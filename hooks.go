@@ -0,0 +1,96 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"math"
+)
+
+// Hooks are the optional callbacks of a FibHeap which are invoked after every mutation with the tag, key and value involved,
+// e.g. to mirror the heap into metrics, logs or a secondary index without wrapping every call site.
+// Nil callbacks are skipped. The callbacks are called synchronously and must not modify the heap.
+type Hooks struct {
+	// OnInsert is called when a tag is inserted, including by Union, Import and Replay.
+	OnInsert func(tag interface{}, key float64, value Value)
+	// OnExtract is called with the last key when a tag is extracted by ExtractMin, ExtractTag or the other extracting methods.
+	OnExtract func(tag interface{}, key float64, value Value)
+	// OnKeyChange is called with the new key when the key of a tag is changed by DecreaseKey, IncreaseKey or the other updating methods.
+	OnKeyChange func(tag interface{}, key float64, value Value)
+	// OnDelete is called with the last key when a tag is deleted by Delete or the other deleting methods, or when the heap is emptied by Union.
+	OnDelete func(tag interface{}, key float64, value Value)
+	// OnMinChange is called with the new minimum when the tag or the key of the minimum is changed by any mutation,
+	// or with nil, -inf and nil when the heap becomes empty.
+	OnMinChange func(tag interface{}, key float64, value Value)
+}
+
+// NewFibHeapWithHooks creates an initialized Fibonacci Heap which calls the input hooks on every mutation.
+func NewFibHeapWithHooks(hooks Hooks) *FibHeap {
+	heap := NewFibHeap()
+	heap.hooks = hooks
+
+	return heap
+}
+
+// fire calls the hook of the kind, if it is set, with the tag and value of the node and the input key,
+// delivers the event to the watchers of the tag unless it is an insertion, and reports the minimum if it has changed.
+func (heap *FibHeap) fire(kind EventKind, n *node, key float64) {
+	var hook func(tag interface{}, key float64, value Value)
+	switch kind {
+	case EventInsert:
+		hook = heap.hooks.OnInsert
+	case EventExtract:
+		hook = heap.hooks.OnExtract
+	case EventKeyChange:
+		hook = heap.hooks.OnKeyChange
+	case EventDelete:
+		hook = heap.hooks.OnDelete
+	}
+	if hook != nil {
+		hook(n.tag, key, n.value)
+	}
+
+	if kind != EventInsert && len(heap.watchers) != 0 {
+		heap.notify(Event{kind, n.tag, key, n.value})
+	}
+	heap.minChanged()
+}
+
+// minChanged calls the OnMinChange hook and updates the parent of the heap, see HeapOfHeaps,
+// if the minimum differs from the one reported last time.
+func (heap *FibHeap) minChanged() {
+	if heap.hooks.OnMinChange == nil && heap.parent == nil {
+		return
+	}
+
+	var tag interface{}
+	var value Value
+	key := math.Inf(-1)
+	if heap.num != 0 {
+		tag, key, value = heap.min.tag, heap.keyOf(heap.min), heap.min.value
+	}
+	if tag == heap.reported.tag && (tag == nil || key == heap.reported.key) {
+		return
+	}
+	heap.reported.tag, heap.reported.key = tag, key
+
+	if heap.hooks.OnMinChange != nil {
+		heap.hooks.OnMinChange(tag, key, value)
+	}
+	if heap.parent != nil {
+		heap.parent.heaps.update(heap.parent.name, tag, key)
+	}
+}
+
+// extractNode extracts the node out of the heap and calls the OnExtract hook.
+func (heap *FibHeap) extractNode(n *node) {
+	key := heap.keyOf(n)
+	heap.deleteNode(n)
+	heap.fire(EventExtract, n, key)
+}
+
+// remove deletes the node out of the heap and calls the OnDelete hook.
+func (heap *FibHeap) remove(n *node) {
+	key := heap.keyOf(n)
+	heap.deleteNode(n)
+	heap.fire(EventDelete, n, key)
+}
@@ -0,0 +1,113 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"fmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tests of hooks", func() {
+	var (
+		heap   *FibHeap
+		events []string
+		mirror map[interface{}]float64
+	)
+
+	set := func(event string) func(tag interface{}, key float64, value Value) {
+		return func(tag interface{}, key float64, value Value) {
+			mirror[tag] = key
+			events = append(events, fmt.Sprintf("%s %v %v", event, tag, key))
+		}
+	}
+
+	unset := func(event string) func(tag interface{}, key float64, value Value) {
+		return func(tag interface{}, key float64, value Value) {
+			delete(mirror, tag)
+			events = append(events, fmt.Sprintf("%s %v %v", event, tag, key))
+		}
+	}
+
+	BeforeEach(func() {
+		events = nil
+		mirror = make(map[interface{}]float64)
+		heap = NewFibHeapWithHooks(Hooks{
+			OnInsert:    set("insert"),
+			OnExtract:   unset("extract"),
+			OnKeyChange: set("change"),
+			OnDelete:    unset("delete"),
+		})
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given a fibHeap with hooks, when mutate it, it should call the hooks with the tags and keys.", func() {
+		heap.Insert(1, 1)
+		heap.InsertValue(&demoStruct{2, 2, "2"})
+		heap.Insert(3, 3)
+		heap.Insert(1, 10)
+		heap.DecreaseKey(3, 0.5)
+		heap.IncreaseKeyValue(&demoStruct{2, 20, "20"})
+		heap.AdjustKey(1, 1)
+		heap.ExtractMin()
+		heap.Delete(1)
+		heap.ExtractValue(2)
+
+		Expect(events).Should(Equal([]string{
+			"insert 1 1", "insert 2 2", "insert 3 3", "change 3 0.5", "change 2 20", "change 1 2",
+			"extract 3 0.5", "delete 1 2", "extract 2 20",
+		}))
+		Expect(mirror).Should(BeEmpty())
+	})
+
+	It("Given a fibHeap with hooks, when call the bulk apis, it should keep a mirror of the heap in sync.", func() {
+		for i := 0; i < 100; i++ {
+			heap.Insert(i, float64(i))
+		}
+		heap.ExtractMin()
+		heap.DeleteWhere(func(tag interface{}, key float64, value Value) bool { return key > 90 })
+		heap.TransformKeys(func(old float64) float64 { return -old })
+		heap.AddToAllKeys(100)
+		heap.ExtractMax()
+		heap.ExtractTag(50)
+		another := NewFibHeap()
+		another.Insert(1000, 1000)
+		Expect(heap.Union(another)).ShouldNot(HaveOccurred())
+
+		Expect(mirror).Should(HaveLen(int(heap.Num())))
+		for tag, key := range mirror {
+			Expect(heap.GetTag(tag)).Should(Equal(key))
+		}
+
+		Expect(another.Union(heap)).ShouldNot(HaveOccurred())
+		Expect(mirror).Should(BeEmpty())
+	})
+
+	It("Given a fibHeap with an OnMinChange hook, when mutate it, it should report every change of the minimum only.", func() {
+		var mins []string
+		heap = NewFibHeapWithHooks(Hooks{OnMinChange: func(tag interface{}, key float64, value Value) {
+			mins = append(mins, fmt.Sprintf("%v %v", tag, key))
+		}})
+		heap.Insert(2, 2)
+		heap.Insert(3, 3)
+		heap.Insert(1, 1)
+		heap.DecreaseKey(3, 2.5)
+		heap.DecreaseKey(2, 0.5)
+		heap.IncreaseKey(2, 5)
+		heap.AddToAllKeys(10)
+		heap.Delete(3)
+		heap.ExtractMin()
+		heap.ExtractMin()
+		Expect(mins).Should(Equal([]string{"2 2", "1 1", "2 0.5", "1 1", "1 11", "2 15", "<nil> -Inf"}))
+
+		mins = nil
+		another := NewFibHeap()
+		another.Insert(4, 4)
+		Expect(heap.Union(another)).ShouldNot(HaveOccurred())
+		Expect(another.Union(heap)).ShouldNot(HaveOccurred())
+		Expect(mins).Should(Equal([]string{"4 4", "<nil> -Inf"}))
+	})
+})
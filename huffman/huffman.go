@@ -0,0 +1,95 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+// Package huffman builds Huffman codes from symbol frequencies on top of the FibHeap.
+// The tree is built by repeatedly extracting the two least frequent trees and inserting their merged tree back.
+package huffman
+
+import (
+	"errors"
+	"math"
+
+	fibHeap "github.com/starwander/GoFibonacciHeap"
+)
+
+// Node is a node of the Huffman tree.
+// A leaf node holds a symbol, an internal node holds the merged frequency of its two children.
+type Node struct {
+	Symbol    interface{}
+	Frequency float64
+	Left      *Node
+	Right     *Node
+}
+
+// IsLeaf returns whether the node is a leaf which holds a symbol.
+func (node *Node) IsLeaf() bool {
+	return node.Left == nil && node.Right == nil
+}
+
+// BuildTree builds the Huffman tree from the frequencies of all symbols.
+// An empty input will return a nil tree.
+// If any frequency is negative, NaN or +inf, an error will be returned.
+func BuildTree(frequencies map[interface{}]float64) (*Node, error) {
+	heap := fibHeap.NewFibHeap()
+	for symbol, frequency := range frequencies {
+		if frequency < 0 || math.IsNaN(frequency) || math.IsInf(frequency, 1) {
+			return nil, errors.New("Frequency must be a non-negative finite number ")
+		}
+		heap.Insert(&Node{Symbol: symbol, Frequency: frequency}, frequency)
+	}
+
+	if heap.Num() == 0 {
+		return nil, nil
+	}
+
+	for heap.Num() > 1 {
+		left, _ := heap.ExtractMin()
+		right, _ := heap.ExtractMin()
+		merged := &Node{
+			Frequency: left.(*Node).Frequency + right.(*Node).Frequency,
+			Left:      left.(*Node),
+			Right:     right.(*Node),
+		}
+		heap.Insert(merged, merged.Frequency)
+	}
+
+	root, _ := heap.ExtractMin()
+
+	return root.(*Node), nil
+}
+
+// Codes returns the code of every symbol in the tree as a string of '0' and '1'.
+// The left branch is '0' and the right branch is '1'.
+// A tree of a single symbol gives the symbol the code "0".
+func Codes(root *Node) map[interface{}]string {
+	codes := make(map[interface{}]string)
+	if root == nil {
+		return codes
+	}
+	if root.IsLeaf() {
+		codes[root.Symbol] = "0"
+		return codes
+	}
+
+	var walk func(node *Node, code []byte)
+	walk = func(node *Node, code []byte) {
+		if node.IsLeaf() {
+			codes[node.Symbol] = string(code)
+			return
+		}
+		walk(node.Left, append(code, '0'))
+		walk(node.Right, append(code, '1'))
+	}
+	walk(root, nil)
+
+	return codes
+}
+
+// CodeLengths returns the code length of every symbol in the tree.
+func CodeLengths(root *Node) map[interface{}]int {
+	lengths := make(map[interface{}]int)
+	for symbol, code := range Codes(root) {
+		lengths[symbol] = len(code)
+	}
+
+	return lengths
+}
@@ -0,0 +1,95 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package huffman
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+// optimalCost calculates the total weighted code length of a Huffman code by the naive sorted slice algorithm as the reference.
+func optimalCost(frequencies map[interface{}]float64) float64 {
+	var weights []float64
+	for _, frequency := range frequencies {
+		weights = append(weights, frequency)
+	}
+
+	cost := 0.0
+	for len(weights) > 1 {
+		sort.Float64s(weights)
+		merged := weights[0] + weights[1]
+		cost += merged
+		weights = append(weights[2:], merged)
+	}
+
+	return cost
+}
+
+func weightedLength(frequencies map[interface{}]float64, lengths map[interface{}]int) float64 {
+	total := 0.0
+	for symbol, frequency := range frequencies {
+		total += frequency * float64(lengths[symbol])
+	}
+
+	return total
+}
+
+var _ = Describe("Tests of huffman", func() {
+	It("Given empty or invalid frequencies, when call BuildTree api, it should return nil or error.", func() {
+		root, err := BuildTree(nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(root).Should(BeNil())
+		Expect(Codes(root)).Should(BeEmpty())
+
+		_, err = BuildTree(map[interface{}]float64{"a": 1, "b": -1})
+		Expect(err).Should(HaveOccurred())
+		_, err = BuildTree(map[interface{}]float64{"a": math.NaN()})
+		Expect(err).Should(HaveOccurred())
+		_, err = BuildTree(map[interface{}]float64{"a": math.Inf(1)})
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("Given a single symbol, when call Codes api, it should return a one bit code.", func() {
+		root, err := BuildTree(map[interface{}]float64{'x': 10})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(Codes(root)).Should(Equal(map[interface{}]string{'x': "0"}))
+	})
+
+	It("Given known frequencies, when call CodeLengths api, it should return the optimal code lengths.", func() {
+		frequencies := map[interface{}]float64{"a": 45, "b": 13, "c": 12, "d": 16, "e": 9, "f": 5}
+		root, err := BuildTree(frequencies)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(root.Frequency).Should(BeEquivalentTo(100))
+		Expect(CodeLengths(root)).Should(Equal(map[interface{}]int{"a": 1, "b": 3, "c": 3, "d": 3, "e": 4, "f": 4}))
+	})
+
+	It("Given random frequencies, when call Codes api, it should return an optimal prefix free code.", func() {
+		random := rand.New(rand.NewSource(time.Now().Unix()))
+		for round := 0; round < 20; round++ {
+			frequencies := make(map[interface{}]float64)
+			symbols := 2 + random.Intn(200)
+			for symbol := 0; symbol < symbols; symbol++ {
+				frequencies[symbol] = float64(random.Intn(1000))
+			}
+
+			root, err := BuildTree(frequencies)
+			Expect(err).ShouldNot(HaveOccurred())
+			codes := Codes(root)
+			Expect(codes).Should(HaveLen(len(frequencies)))
+			Expect(weightedLength(frequencies, CodeLengths(root))).Should(BeEquivalentTo(optimalCost(frequencies)))
+
+			for symbol, code := range codes {
+				for another, anotherCode := range codes {
+					if symbol != another {
+						Expect(strings.HasPrefix(anotherCode, code)).Should(BeFalse())
+					}
+				}
+			}
+		}
+	})
+})
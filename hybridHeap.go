@@ -0,0 +1,218 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"fmt"
+)
+
+// DefaultHybridThreshold is the threshold of the HybridHeap created by New.
+const DefaultHybridThreshold = 64
+
+// HybridHeap is a priority queue which keeps a small number of values in a binary heap and switches to a FibHeap only when it grows,
+// for the workloads of many tiny queues where the constant factors of the Fibonacci structure dominate.
+// It moves all values into a FibHeap once the number of values exceeds the threshold,
+// and back into a binary heap once the number drops to half of the threshold, so a size around the threshold does not switch back and forth.
+// Every switch is O(n), which is amortized by the inserts or extractions since the last switch.
+// HybridHeap provides exactly the same methods as FibHeap so the two implementations can be swapped by changing the constructor only.
+// Please note that all methods of HybridHeap are not concurrent safe.
+type HybridHeap struct {
+	threshold uint
+	backend   PriorityQueue
+	large     bool
+}
+
+// NewHybridHeap creates an initialized HybridHeap which switches to a FibHeap beyond the input number of values.
+// A threshold smaller than 2 will cause a panic.
+func NewHybridHeap(threshold int) *HybridHeap {
+	if threshold < 2 {
+		panic("fibHeap: threshold of HybridHeap must be at least 2")
+	}
+
+	heap := new(HybridHeap)
+	heap.threshold = uint(threshold)
+	heap.backend = NewDaryHeap(2)
+
+	return heap
+}
+
+// Large reports whether the values are currently kept in a FibHeap.
+func (heap *HybridHeap) Large() bool {
+	return heap.large
+}
+
+// Num returns the total number of values in the heap.
+func (heap *HybridHeap) Num() uint {
+	return heap.backend.Num()
+}
+
+// Insert pushes the input tag and key into the heap.
+// Try to insert a nil tag, a duplicate tag or a -inf key will cause an error return.
+func (heap *HybridHeap) Insert(tag interface{}, key float64) error {
+	defer heap.grow()
+
+	return heap.backend.Insert(tag, key)
+}
+
+// InsertValue pushes the input value into the heap.
+// Try to insert a nil value, a value with a duplicate tag or a -inf key will cause an error return.
+func (heap *HybridHeap) InsertValue(value Value) error {
+	defer heap.grow()
+
+	return heap.backend.InsertValue(value)
+}
+
+// Minimum returns the current minimum tag and key in the heap sorted by the key.
+// An empty heap will return nil and -inf.
+func (heap *HybridHeap) Minimum() (interface{}, float64) {
+	return heap.backend.Minimum()
+}
+
+// MinimumValue returns the current minimum value in the heap sorted by the key.
+// An empty heap will return nil.
+func (heap *HybridHeap) MinimumValue() Value {
+	return heap.backend.MinimumValue()
+}
+
+// ExtractMin returns the current minimum tag and key in the heap and then extracts them from the heap.
+// An empty heap will return nil/-inf and extracts nothing.
+func (heap *HybridHeap) ExtractMin() (interface{}, float64) {
+	defer heap.shrink()
+
+	return heap.backend.ExtractMin()
+}
+
+// ExtractMinValue returns the current minimum value in the heap and then extracts it from the heap.
+// An empty heap will return nil and extracts nothing.
+func (heap *HybridHeap) ExtractMinValue() Value {
+	defer heap.shrink()
+
+	return heap.backend.ExtractMinValue()
+}
+
+// Union moves all values of the input heap into the heap.
+// The input heap can be any implementation of PriorityQueue, and it is emptied afterwards so that no value is reachable from both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned and both heaps are left untouched.
+func (heap *HybridHeap) Union(anotherHeap PriorityQueue) error {
+	defer heap.grow()
+
+	return heap.backend.Union(anotherHeap)
+}
+
+// UnionInto merges copies of all values of the input heap into the heap.
+// The input heap can be any implementation of PriorityQueue, and it is left untouched, so the values are shared by both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned.
+func (heap *HybridHeap) UnionInto(anotherHeap PriorityQueue) error {
+	defer heap.grow()
+
+	return heap.backend.UnionInto(anotherHeap)
+}
+
+// DecreaseKey updates the tag in the heap by the input key.
+// If the input key is not smaller than the current key or is -inf, or the tag does not exist, an error will be returned.
+func (heap *HybridHeap) DecreaseKey(tag interface{}, key float64) error {
+	return heap.backend.DecreaseKey(tag, key)
+}
+
+// DecreaseKeyValue updates the value in the heap by the input value.
+// If the input value's key is not smaller than the current key or is -inf, or the tag does not exist, an error will be returned.
+func (heap *HybridHeap) DecreaseKeyValue(value Value) error {
+	return heap.backend.DecreaseKeyValue(value)
+}
+
+// IncreaseKey updates the tag in the heap by the input key.
+// If the input key is not larger than the current key, or the tag does not exist, an error will be returned.
+func (heap *HybridHeap) IncreaseKey(tag interface{}, key float64) error {
+	return heap.backend.IncreaseKey(tag, key)
+}
+
+// IncreaseKeyValue updates the value in the heap by the input value.
+// If the input value's key is not larger than the current key, or the tag does not exist, an error will be returned.
+func (heap *HybridHeap) IncreaseKeyValue(value Value) error {
+	return heap.backend.IncreaseKeyValue(value)
+}
+
+// Delete deletes the input tag in the heap.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *HybridHeap) Delete(tag interface{}) error {
+	defer heap.shrink()
+
+	return heap.backend.Delete(tag)
+}
+
+// DeleteValue deletes the value in the heap by the input value.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *HybridHeap) DeleteValue(value Value) error {
+	defer heap.shrink()
+
+	return heap.backend.DeleteValue(value)
+}
+
+// GetTag searches and returns the key in the heap by the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *HybridHeap) GetTag(tag interface{}) float64 {
+	return heap.backend.GetTag(tag)
+}
+
+// GetValue searches and returns the value in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *HybridHeap) GetValue(tag interface{}) Value {
+	return heap.backend.GetValue(tag)
+}
+
+// ExtractTag searches and extracts the tag/key in the heap by the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *HybridHeap) ExtractTag(tag interface{}) float64 {
+	defer heap.shrink()
+
+	return heap.backend.ExtractTag(tag)
+}
+
+// ExtractValue searches and extracts the value in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *HybridHeap) ExtractValue(tag interface{}) Value {
+	defer heap.shrink()
+
+	return heap.backend.ExtractValue(tag)
+}
+
+// String provides some basic debug information of the heap.
+// It returns the current backend and its debug information.
+func (heap *HybridHeap) String() string {
+	backend := "binary heap"
+	if heap.large {
+		backend = "Fibonacci heap"
+	}
+
+	return fmt.Sprintf("Hybrid heap backed by %s, threshold: %d,\n%s", backend, heap.threshold, heap.backend.String())
+}
+
+func (heap *HybridHeap) reset() {
+	heap.backend = NewDaryHeap(2)
+	heap.large = false
+}
+
+func (heap *HybridHeap) each(fn func(tag interface{}, key float64, value Value)) {
+	heap.backend.each(fn)
+}
+
+// grow moves all values into a FibHeap if the number of values exceeds the threshold.
+func (heap *HybridHeap) grow() {
+	if !heap.large && heap.backend.Num() > heap.threshold {
+		heap.migrate(NewFibHeap())
+		heap.large = true
+	}
+}
+
+// shrink moves all values into a binary heap if the number of values drops to half of the threshold.
+func (heap *HybridHeap) shrink() {
+	if heap.large && heap.backend.Num() <= heap.threshold/2 {
+		heap.migrate(NewDaryHeap(2))
+		heap.large = false
+	}
+}
+
+func (heap *HybridHeap) migrate(backend PriorityQueue) {
+	backend.Union(heap.backend)
+	heap.backend = backend
+}
@@ -0,0 +1,72 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/starwander/GoFibonacciHeap/heaptest"
+	"math"
+)
+
+var _ = Describe("Tests of hybridHeap", func() {
+	var (
+		heap *HybridHeap
+	)
+
+	BeforeEach(func() {
+		heap = NewHybridHeap(8)
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given an invalid threshold, when call NewHybridHeap api, it should panic.", func() {
+		Expect(func() { NewHybridHeap(1) }).Should(Panic())
+	})
+
+	It("Given an empty hybridHeap, when call Minimum and ExtractMin api, it should return nil.", func() {
+		tag, key := heap.Minimum()
+		Expect(tag).Should(BeNil())
+		Expect(key).Should(Equal(math.Inf(-1)))
+		Expect(heap.ExtractMinValue()).Should(BeNil())
+		Expect(heap.Large()).Should(BeFalse())
+		Expect(heap.String()).Should(ContainSubstring("binary heap"))
+	})
+
+	It("Given a hybridHeap, when it grows beyond and shrinks below the threshold, it should switch the backend and keep all values.", func() {
+		for i := 0; i < 8; i++ {
+			Expect(heap.InsertValue(&demoStruct{i, float64(10 - i), ""})).ShouldNot(HaveOccurred())
+		}
+		Expect(heap.Large()).Should(BeFalse())
+		Expect(heap.Insert(8, 100)).ShouldNot(HaveOccurred())
+		Expect(heap.Large()).Should(BeTrue())
+		Expect(heap.backend).Should(BeAssignableToTypeOf(&FibHeap{}))
+		Expect(heap.Insert(8, 100)).Should(HaveOccurred())
+		Expect(heap.DecreaseKey(8, 0)).ShouldNot(HaveOccurred())
+		Expect(heap.IncreaseKeyValue(&demoStruct{7, 50, "increased"})).ShouldNot(HaveOccurred())
+
+		tag, key := heap.ExtractMin()
+		Expect(tag).Should(Equal(8))
+		Expect(key).Should(BeEquivalentTo(0))
+		Expect(heap.Delete(0)).ShouldNot(HaveOccurred())
+		Expect(heap.ExtractTag(1)).Should(BeEquivalentTo(9))
+		Expect(heap.Large()).Should(BeTrue())
+		Expect(heap.ExtractValue(2).Key()).Should(BeEquivalentTo(8))
+		Expect(heap.Large()).Should(BeTrue())
+		Expect(heap.DeleteValue(&demoStruct{3, 0, ""})).ShouldNot(HaveOccurred())
+		Expect(heap.Large()).Should(BeFalse())
+		Expect(heap.Num()).Should(BeEquivalentTo(4))
+
+		Expect(heap.ExtractMinValue().Tag()).Should(Equal(6))
+		Expect(heap.GetValue(7).(*demoStruct).value).Should(Equal("increased"))
+		Expect(heap.GetTag(4)).Should(BeEquivalentTo(6))
+	})
+
+	It("Given hybridHeaps with small thresholds, when run the differential tester, it should never diverge from the reference model.", func() {
+		for seed := int64(0); seed < 20; seed++ {
+			Expect(heaptest.Run(NewHybridHeap(2+int(seed)), seed)).ShouldNot(HaveOccurred())
+		}
+	})
+})
@@ -0,0 +1,53 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"sort"
+)
+
+// insertionSeq is the last insertion sequence number given to a node. It is shared by all heaps,
+// so the values moved between heaps by Union keep an insertion order comparable to the values already there.
+var insertionSeq uint64
+
+// SetInsertionOrder turns on or off the recording of the insertion order of the heap,
+// which gives every value inserted afterwards a sequence number, e.g. for the audit tools reconstructing the order of arrival regardless of the keys.
+// The sequence number of a value is kept by the key updates, and a value inserted again after being extracted gets a new one.
+// Turning off the recording keeps the sequence numbers already given.
+func (heap *FibHeap) SetInsertionOrder(enabled bool) {
+	if debugMode {
+		defer heap.guard.enter("SetInsertionOrder")()
+	}
+
+	heap.sequenced = enabled
+}
+
+// InsertionOrder reports whether the recording of the insertion order of the heap is on.
+func (heap *FibHeap) InsertionOrder() bool {
+	if debugMode {
+		defer heap.guard.enter("InsertionOrder")()
+	}
+
+	return heap.sequenced
+}
+
+// IterateInsertionOrder calls fn for every value in the heap in the order of insertion, until fn returns false.
+// The values inserted while the recording of the insertion order was off come first in no particular order.
+// The values are sorted in O(n log n), and fn must not modify the heap.
+func (heap *FibHeap) IterateInsertionOrder(fn func(tag interface{}, key float64, value Value) bool) {
+	if debugMode {
+		defer heap.guard.enter("IterateInsertionOrder")()
+	}
+
+	nodes := make([]*node, 0, heap.num)
+	for _, n := range heap.index {
+		nodes = append(nodes, n)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].seq < nodes[j].seq })
+
+	for _, n := range nodes {
+		if !fn(n.tag, heap.keyOf(n), n.value) {
+			return
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math/rand"
+)
+
+var _ = Describe("Tests of insertion order", func() {
+	var heap *FibHeap
+
+	BeforeEach(func() {
+		heap = NewFibHeap()
+		heap.SetInsertionOrder(true)
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given a fibHeap recording the insertion order, when call IterateInsertionOrder api after random updates, it should visit the values in the order of insertion.", func() {
+		Expect(heap.InsertionOrder()).Should(BeTrue())
+		random := rand.New(rand.NewSource(1896))
+		for i := 0; i < 1000; i++ {
+			heap.Insert(i, random.Float64())
+		}
+		for i := 0; i < 1000; i += 2 {
+			heap.DecreaseKey(i, -random.Float64())
+		}
+		for i := 0; i < 100; i++ {
+			heap.ExtractMin()
+		}
+		another := NewFibHeap()
+		another.SetInsertionOrder(true)
+		another.Insert(1000, 0.5)
+		Expect(heap.Union(another)).Should(Succeed())
+
+		var tags []interface{}
+		heap.IterateInsertionOrder(func(tag interface{}, key float64, value Value) bool {
+			tags = append(tags, tag)
+			return true
+		})
+		Expect(tags).Should(HaveLen(901))
+		for i := 1; i < len(tags); i++ {
+			Expect(tags[i].(int)).Should(BeNumerically(">", tags[i-1].(int)))
+		}
+
+		visited := 0
+		heap.IterateInsertionOrder(func(tag interface{}, key float64, value Value) bool {
+			visited++
+			return visited < 10
+		})
+		Expect(visited).Should(Equal(10))
+	})
+
+	It("Given a fibHeap with values inserted before the recording, when call IterateInsertionOrder api, it should visit them first.", func() {
+		heap.SetInsertionOrder(false)
+		heap.Insert("old", 1)
+		heap.SetInsertionOrder(true)
+		heap.Insert("new", 0)
+		heap.Insert("newer", 2)
+
+		var tags []interface{}
+		heap.IterateInsertionOrder(func(tag interface{}, key float64, value Value) bool {
+			tags = append(tags, tag)
+			return true
+		})
+		Expect(tags).Should(Equal([]interface{}{"old", "new", "newer"}))
+	})
+})
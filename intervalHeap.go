@@ -0,0 +1,586 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// IntervalHeap represents an array based Interval Heap, a double-ended priority queue as described by van Leeuwen and Wood.
+// IntervalHeap provides the same methods as MinMaxHeap, including Maximum and ExtractMax, and shares the tag index conventions of FibHeap.
+// Every node of the implicit binary tree holds two values which form an interval containing the intervals of all its descendants.
+// Both Minimum and Maximum are O(1), and all other operations are O(log n) on a tree of half the height of a binary heap.
+// Please note that all methods of IntervalHeap are not concurrent safe.
+type IntervalHeap struct {
+	items []intervalItem
+	index map[interface{}]*intervalNode
+}
+
+// intervalItem keeps the key inline in the array.
+// Items at 2k and 2k+1 are the left and right endpoints of node k. The last node may only have its left endpoint.
+type intervalItem struct {
+	key  float64
+	node *intervalNode
+}
+
+type intervalNode struct {
+	position int
+	tag      interface{}
+	value    Value
+}
+
+// NewIntervalHeap creates an initialized Interval Heap.
+func NewIntervalHeap() *IntervalHeap {
+	heap := new(IntervalHeap)
+	heap.index = make(map[interface{}]*intervalNode)
+
+	return heap
+}
+
+// Num returns the total number of values in the heap.
+func (heap *IntervalHeap) Num() uint {
+	return uint(len(heap.items))
+}
+
+// Insert pushes the input tag and key into the heap.
+// Try to insert a duplicate tag value will cause an error return.
+// The valid range of the key is (-inf, +inf].
+// Try to insert a -inf key value will cause an error return.
+func (heap *IntervalHeap) Insert(tag interface{}, key float64) error {
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	return heap.insert(tag, key, nil)
+}
+
+// InsertValue pushes the input value into the heap.
+// The input value must implements the Value interface.
+// Try to insert a duplicate tag value will cause an error return.
+// The valid range of the value's key is (-inf, +inf].
+// Try to insert a -inf key value will cause an error return.
+func (heap *IntervalHeap) InsertValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	return heap.insert(value.Tag(), value.Key(), value)
+}
+
+// Minimum returns the current minimum tag and key in the heap sorted by the key.
+// An empty heap will return nil and -inf.
+func (heap *IntervalHeap) Minimum() (interface{}, float64) {
+	if len(heap.items) == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	return heap.items[0].node.tag, heap.items[0].key
+}
+
+// MinimumValue returns the current minimum value in the heap sorted by the key.
+// An empty heap will return nil.
+func (heap *IntervalHeap) MinimumValue() Value {
+	if len(heap.items) == 0 {
+		return nil
+	}
+
+	return heap.items[0].node.value
+}
+
+// ExtractMin returns the current minimum tag and key in the heap and then extracts them from the heap.
+// An empty heap will return nil/-inf and extracts nothing.
+func (heap *IntervalHeap) ExtractMin() (interface{}, float64) {
+	if len(heap.items) == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	key := heap.items[0].key
+	min := heap.remove(0)
+
+	return min.tag, key
+}
+
+// ExtractMinValue returns the current minimum value in the heap and then extracts it from the heap.
+// An empty heap will return nil and extracts nothing.
+func (heap *IntervalHeap) ExtractMinValue() Value {
+	if len(heap.items) == 0 {
+		return nil
+	}
+
+	min := heap.remove(0)
+
+	return min.value
+}
+
+// Maximum returns the current maximum tag and key in the heap sorted by the key.
+// Maximum will not extract the tag and key so the value will still exists in the heap.
+// An empty heap will return nil and -inf.
+func (heap *IntervalHeap) Maximum() (interface{}, float64) {
+	if len(heap.items) == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	max := heap.maxPosition()
+
+	return heap.items[max].node.tag, heap.items[max].key
+}
+
+// MaximumValue returns the current maximum value in the heap sorted by the key.
+// MaximumValue will not extract the value so the value will still exists in the heap.
+// An empty heap will return nil.
+func (heap *IntervalHeap) MaximumValue() Value {
+	if len(heap.items) == 0 {
+		return nil
+	}
+
+	return heap.items[heap.maxPosition()].node.value
+}
+
+// ExtractMax returns the current maximum tag and key in the heap and then extracts them from the heap.
+// An empty heap will return nil/-inf and extracts nothing.
+func (heap *IntervalHeap) ExtractMax() (interface{}, float64) {
+	if len(heap.items) == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	max := heap.maxPosition()
+	key := heap.items[max].key
+	node := heap.remove(max)
+
+	return node.tag, key
+}
+
+// ExtractMaxValue returns the current maximum value in the heap and then extracts it from the heap.
+// An empty heap will return nil and extracts nothing.
+func (heap *IntervalHeap) ExtractMaxValue() Value {
+	if len(heap.items) == 0 {
+		return nil
+	}
+
+	node := heap.remove(heap.maxPosition())
+
+	return node.value
+}
+
+// Union moves all values of the input heap into the heap.
+// The input heap can be any implementation of PriorityQueue, and it is emptied afterwards so that no value is reachable from both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned and both heaps are left untouched.
+func (heap *IntervalHeap) Union(anotherHeap PriorityQueue) error {
+	if err := heap.UnionInto(anotherHeap); err != nil {
+		return err
+	}
+
+	anotherHeap.reset()
+
+	return nil
+}
+
+// UnionInto merges copies of all values of the input heap into the heap.
+// The input heap can be any implementation of PriorityQueue, and it is left untouched, so the values are shared by both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned.
+func (heap *IntervalHeap) UnionInto(anotherHeap PriorityQueue) error {
+	if err := checkUnion(heap, anotherHeap); err != nil {
+		return err
+	}
+
+	anotherHeap.each(func(tag interface{}, key float64, value Value) {
+		heap.insert(tag, key, value)
+	})
+
+	return nil
+}
+
+// DecreaseKey updates the tag in the heap by the input key.
+// If the input key has a larger key or -inf key, an error will be returned.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *IntervalHeap) DecreaseKey(tag interface{}, key float64) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tag]; exists {
+		return heap.decreaseKey(node, node.value, key)
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// DecreaseKeyValue updates the value in the heap by the input value.
+// If the input value has a larger key or -inf key, an error will be returned.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *IntervalHeap) DecreaseKeyValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	if math.IsInf(value.Key(), -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tagKey(value.Tag())]; exists {
+		return heap.decreaseKey(node, value, value.Key())
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// IncreaseKey updates the tag in the heap by the input key.
+// If the input key has a smaller key or -inf key, an error will be returned.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *IntervalHeap) IncreaseKey(tag interface{}, key float64) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tag]; exists {
+		return heap.increaseKey(node, node.value, key)
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// IncreaseKeyValue updates the value in the heap by the input value.
+// If the input value has a smaller key or -inf key, an error will be returned.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *IntervalHeap) IncreaseKeyValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	if math.IsInf(value.Key(), -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tagKey(value.Tag())]; exists {
+		return heap.increaseKey(node, value, value.Key())
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// Delete deletes the input tag in the heap.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *IntervalHeap) Delete(tag interface{}) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	node, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Tag is not found ")
+	}
+
+	heap.remove(node.position)
+
+	return nil
+}
+
+// DeleteValue deletes the value in the heap by the input value.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *IntervalHeap) DeleteValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	node, exists := heap.index[tagKey(value.Tag())]
+	if !exists {
+		return errors.New("Value is not found ")
+	}
+
+	heap.remove(node.position)
+
+	return nil
+}
+
+// GetTag searches and returns the key in the heap by the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *IntervalHeap) GetTag(tag interface{}) (key float64) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		return heap.items[node.position].key
+	}
+
+	return math.Inf(-1)
+}
+
+// GetValue searches and returns the value in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *IntervalHeap) GetValue(tag interface{}) (value Value) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		value = node.value
+	}
+
+	return
+}
+
+// ExtractTag searches and extracts the tag/key in the heap by the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *IntervalHeap) ExtractTag(tag interface{}) (key float64) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		key = heap.items[node.position].key
+		heap.remove(node.position)
+		return
+	}
+
+	return math.Inf(-1)
+}
+
+// ExtractValue searches and extracts the value in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *IntervalHeap) ExtractValue(tag interface{}) (value Value) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		value = node.value
+		heap.remove(node.position)
+		return
+	}
+
+	return nil
+}
+
+// String provides some basic debug information of the heap.
+// It returns the total number, index size, current minimum and maximum value of the heap.
+// It also returns all keys in the order of the underlying array.
+func (heap *IntervalHeap) String() string {
+	var buffer bytes.Buffer
+
+	if len(heap.items) != 0 {
+		max := heap.items[heap.maxPosition()]
+		buffer.WriteString(fmt.Sprintf("Total number: %d, Index size: %d,\n", len(heap.items), len(heap.index)))
+		buffer.WriteString(fmt.Sprintf("Current minimun: key(%f), tag(%v), value(%v),\n", heap.items[0].key, heap.items[0].node.tag, heap.items[0].node.value))
+		buffer.WriteString(fmt.Sprintf("Current maximum: key(%f), tag(%v), value(%v),\n", max.key, max.node.tag, max.node.value))
+		buffer.WriteString(fmt.Sprintf("Heap detail:\n"))
+		buffer.WriteString(fmt.Sprintf("< "))
+		for _, item := range heap.items {
+			buffer.WriteString(fmt.Sprintf("%f ", item.key))
+		}
+		buffer.WriteString(fmt.Sprintf("> \n"))
+	} else {
+		buffer.WriteString(fmt.Sprintf("Heap is empty.\n"))
+	}
+
+	return buffer.String()
+}
+
+func (heap *IntervalHeap) reset() {
+	*heap = *NewIntervalHeap()
+}
+
+func (heap *IntervalHeap) each(fn func(tag interface{}, key float64, value Value)) {
+	for _, item := range heap.items {
+		fn(item.node.tag, item.key, item.node.value)
+	}
+}
+
+func (heap *IntervalHeap) insert(tag interface{}, key float64, value Value) error {
+	tag = tagKey(tag)
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if _, exists := heap.index[tag]; exists {
+		return errors.New("Duplicate tag is not allowed ")
+	}
+
+	node := new(intervalNode)
+	node.position = len(heap.items)
+	node.tag = tag
+	node.value = value
+
+	heap.items = append(heap.items, intervalItem{key, node})
+	heap.index[node.tag] = node
+	heap.fix(node.position)
+
+	return nil
+}
+
+func (heap *IntervalHeap) maxPosition() int {
+	if len(heap.items) == 1 {
+		return 0
+	}
+
+	return 1
+}
+
+func (heap *IntervalHeap) remove(position int) *intervalNode {
+	node := heap.items[position].node
+	last := len(heap.items) - 1
+	if position != last {
+		heap.swap(position, last)
+	}
+	heap.items[last] = intervalItem{}
+	heap.items = heap.items[:last]
+	delete(heap.index, node.tag)
+
+	if position != last {
+		heap.fix(position)
+	}
+
+	return node
+}
+
+func (heap *IntervalHeap) decreaseKey(n *intervalNode, value Value, key float64) error {
+	if key >= heap.items[n.position].key {
+		return errors.New("New key is not smaller than current key ")
+	}
+
+	heap.items[n.position].key = key
+	n.value = value
+	heap.fix(n.position)
+
+	return nil
+}
+
+func (heap *IntervalHeap) increaseKey(n *intervalNode, value Value, key float64) error {
+	if key <= heap.items[n.position].key {
+		return errors.New("New key is not larger than current key ")
+	}
+
+	heap.items[n.position].key = key
+	n.value = value
+	heap.fix(n.position)
+
+	return nil
+}
+
+// fix restores the heap order after the key at the position changed arbitrarily.
+func (heap *IntervalHeap) fix(position int) {
+	left, right := position&^1, position|1
+
+	// The last node may only have one endpoint, which has no children but must stay inside the interval of its parent.
+	if right >= len(heap.items) {
+		if left > 0 && heap.items[left].key < heap.items[heap.parentLeft(left)].key {
+			heap.minUp(left)
+		} else {
+			heap.maxUp(left)
+		}
+		return
+	}
+
+	// If the two endpoints get crossed, the untouched endpoint is moved to the other end and has to be pushed down from there.
+	if heap.items[left].key > heap.items[right].key {
+		heap.swap(left, right)
+		if position == left {
+			heap.maxUp(right)
+			heap.minDown(left)
+		} else {
+			heap.minUp(left)
+			heap.maxDown(right)
+		}
+		return
+	}
+
+	if position == left {
+		if left > 0 && heap.items[left].key < heap.items[heap.parentLeft(left)].key {
+			heap.minUp(left)
+		} else {
+			heap.minDown(left)
+		}
+	} else {
+		if right > 1 && heap.items[right].key > heap.items[heap.parentLeft(right)+1].key {
+			heap.maxUp(right)
+		} else {
+			heap.maxDown(right)
+		}
+	}
+}
+
+func (heap *IntervalHeap) parentLeft(position int) int {
+	return ((position/2 - 1) / 2) * 2
+}
+
+// minUp moves the item up along the left endpoints.
+func (heap *IntervalHeap) minUp(position int) {
+	for position > 1 {
+		parent := heap.parentLeft(position)
+		if heap.items[position].key >= heap.items[parent].key {
+			break
+		}
+		heap.swap(position, parent)
+		position = parent
+	}
+}
+
+// maxUp moves the item up along the right endpoints.
+func (heap *IntervalHeap) maxUp(position int) {
+	for position > 1 {
+		parent := heap.parentLeft(position) + 1
+		if heap.items[position].key <= heap.items[parent].key {
+			break
+		}
+		heap.swap(position, parent)
+		position = parent
+	}
+}
+
+// minDown moves the item down along the left endpoints.
+func (heap *IntervalHeap) minDown(position int) {
+	for {
+		smallest := -1
+		for child := 2*position + 2; child <= 2*position+4 && child < len(heap.items); child += 2 {
+			if smallest < 0 || heap.items[child].key < heap.items[smallest].key {
+				smallest = child
+			}
+		}
+		if smallest < 0 || heap.items[position].key <= heap.items[smallest].key {
+			return
+		}
+
+		heap.swap(position, smallest)
+		if smallest+1 < len(heap.items) && heap.items[smallest].key > heap.items[smallest+1].key {
+			heap.swap(smallest, smallest+1)
+		}
+		position = smallest
+	}
+}
+
+// maxDown moves the item down along the right endpoints.
+// The only endpoint of the last node counts as its right endpoint as well.
+func (heap *IntervalHeap) maxDown(position int) {
+	for {
+		largest := -1
+		for child := 2*position + 1; child <= 2*position+3 && child-1 < len(heap.items); child += 2 {
+			candidate := child
+			if candidate >= len(heap.items) {
+				candidate--
+			}
+			if largest < 0 || heap.items[candidate].key > heap.items[largest].key {
+				largest = candidate
+			}
+		}
+		if largest < 0 || heap.items[position].key >= heap.items[largest].key {
+			return
+		}
+
+		heap.swap(position, largest)
+		if largest&1 == 0 {
+			return
+		}
+		if heap.items[largest-1].key > heap.items[largest].key {
+			heap.swap(largest-1, largest)
+		}
+		position = largest
+	}
+}
+
+func (heap *IntervalHeap) swap(i, j int) {
+	heap.items[i], heap.items[j] = heap.items[j], heap.items[i]
+	heap.items[i].node.position = i
+	heap.items[j].node.position = j
+}
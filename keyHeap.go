@@ -0,0 +1,228 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// KeyHeap is a priority queue of tags and keys only, for the workloads which never use the value interfaces.
+// It has only one family of methods, e.g. Insert(tag, key), instead of the parallel tag and value families of FibHeap,
+// and it keeps no value for any tag: the tags and keys are kept in two contiguous slices of an array based 4-ary heap,
+// with an index from every tag to its position, so no node is allocated per tag.
+// Insert, ExtractMin, DecreaseKey, IncreaseKey and Delete are O(log n), and Minimum and GetTag are O(1).
+// See ValueHeap for the value counterpart.
+// Please note that all methods of KeyHeap are not concurrent safe.
+type KeyHeap struct {
+	keys  []float64
+	tags  []interface{}
+	index map[interface{}]int
+}
+
+// NewKeyHeap creates an initialized empty KeyHeap.
+func NewKeyHeap() *KeyHeap {
+	heap := new(KeyHeap)
+	heap.index = make(map[interface{}]int)
+
+	return heap
+}
+
+// Num returns the total number of tags in the heap.
+func (heap *KeyHeap) Num() uint {
+	return uint(len(heap.keys))
+}
+
+// Insert pushes the input tag and key into the heap.
+// Try to insert a nil tag, a duplicate tag or a -inf key will cause an error return.
+func (heap *KeyHeap) Insert(tag interface{}, key float64) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+	if _, exists := heap.index[tag]; exists {
+		return errors.New("Duplicate tag is not allowed ")
+	}
+
+	heap.keys = append(heap.keys, key)
+	heap.tags = append(heap.tags, tag)
+	heap.index[tag] = len(heap.keys) - 1
+	heap.up(len(heap.keys) - 1)
+
+	return nil
+}
+
+// Minimum returns the current minimum tag and key in the heap.
+// An empty heap will return nil and -inf.
+func (heap *KeyHeap) Minimum() (interface{}, float64) {
+	if len(heap.keys) == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	return heap.tags[0], heap.keys[0]
+}
+
+// ExtractMin returns the current minimum tag and key in the heap and then extracts them.
+// An empty heap will return nil/-inf and extracts nothing.
+func (heap *KeyHeap) ExtractMin() (interface{}, float64) {
+	if len(heap.keys) == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	return heap.remove(0)
+}
+
+// DecreaseKey updates the tag in the heap by the input smaller key.
+// If the tag does not exist, or the key is -inf or not smaller, an error will be returned.
+func (heap *KeyHeap) DecreaseKey(tag interface{}, key float64) error {
+	tag = tagKey(tag)
+	position, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Tag is not found ")
+	}
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+	if key >= heap.keys[position] {
+		return errors.New("New key is not smaller than current key ")
+	}
+
+	heap.keys[position] = key
+	heap.up(position)
+
+	return nil
+}
+
+// IncreaseKey updates the tag in the heap by the input larger key.
+// If the tag does not exist, or the key is not larger, an error will be returned.
+func (heap *KeyHeap) IncreaseKey(tag interface{}, key float64) error {
+	tag = tagKey(tag)
+	position, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Tag is not found ")
+	}
+	if key <= heap.keys[position] {
+		return errors.New("New key is not larger than current key ")
+	}
+
+	heap.keys[position] = key
+	heap.down(position)
+
+	return nil
+}
+
+// Delete deletes the input tag in the heap.
+// If the tag does not exist, an error will be returned.
+func (heap *KeyHeap) Delete(tag interface{}) error {
+	tag = tagKey(tag)
+	position, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Tag is not found ")
+	}
+	heap.remove(position)
+
+	return nil
+}
+
+// GetTag returns the key of the input tag.
+// If the tag does not exist, -inf will be returned.
+func (heap *KeyHeap) GetTag(tag interface{}) float64 {
+	tag = tagKey(tag)
+	if position, exists := heap.index[tag]; exists {
+		return heap.keys[position]
+	}
+
+	return math.Inf(-1)
+}
+
+// ExtractTag extracts the input tag and returns its key.
+// If the tag does not exist, -inf will be returned.
+func (heap *KeyHeap) ExtractTag(tag interface{}) float64 {
+	tag = tagKey(tag)
+	position, exists := heap.index[tag]
+	if !exists {
+		return math.Inf(-1)
+	}
+
+	_, key := heap.remove(position)
+
+	return key
+}
+
+// String provides some basic debug information of the heap.
+// It returns the total number and the current minimum of the heap.
+func (heap *KeyHeap) String() string {
+	var buffer bytes.Buffer
+
+	if len(heap.keys) != 0 {
+		buffer.WriteString(fmt.Sprintf("Total number: %d,\n", len(heap.keys)))
+		buffer.WriteString(fmt.Sprintf("Current minimun: key(%f), tag(%v),\n", heap.keys[0], heap.tags[0]))
+	} else {
+		buffer.WriteString(fmt.Sprintf("Heap is empty.\n"))
+	}
+
+	return buffer.String()
+}
+
+func (heap *KeyHeap) remove(position int) (interface{}, float64) {
+	tag, key := heap.tags[position], heap.keys[position]
+	last := len(heap.keys) - 1
+	if position != last {
+		heap.swap(position, last)
+	}
+	heap.tags[last] = nil
+	heap.keys = heap.keys[:last]
+	heap.tags = heap.tags[:last]
+	delete(heap.index, tag)
+
+	if position != last {
+		heap.down(position)
+		heap.up(position)
+	}
+
+	return tag, key
+}
+
+func (heap *KeyHeap) up(position int) {
+	for position > 0 {
+		parent := (position - 1) / DefaultArity
+		if heap.keys[parent] <= heap.keys[position] {
+			break
+		}
+		heap.swap(parent, position)
+		position = parent
+	}
+}
+
+func (heap *KeyHeap) down(position int) {
+	for {
+		first := position*DefaultArity + 1
+		if first >= len(heap.keys) {
+			break
+		}
+
+		smallest := first
+		for child := first + 1; child < first+DefaultArity && child < len(heap.keys); child++ {
+			if heap.keys[child] < heap.keys[smallest] {
+				smallest = child
+			}
+		}
+		if heap.keys[position] <= heap.keys[smallest] {
+			break
+		}
+		heap.swap(position, smallest)
+		position = smallest
+	}
+}
+
+func (heap *KeyHeap) swap(i, j int) {
+	heap.keys[i], heap.keys[j] = heap.keys[j], heap.keys[i]
+	heap.tags[i], heap.tags[j] = heap.tags[j], heap.tags[i]
+	heap.index[heap.tags[i]] = i
+	heap.index[heap.tags[j]] = j
+}
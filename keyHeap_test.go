@@ -0,0 +1,54 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/starwander/GoFibonacciHeap/heaptest"
+	"math"
+)
+
+var _ = Describe("Tests of keyHeap", func() {
+	var (
+		heap *KeyHeap
+	)
+
+	BeforeEach(func() {
+		heap = NewKeyHeap()
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given an empty keyHeap, when call Minimum and ExtractMin api, it should return nil.", func() {
+		tag, key := heap.Minimum()
+		Expect(tag).Should(BeNil())
+		Expect(key).Should(Equal(math.Inf(-1)))
+		tag, _ = heap.ExtractMin()
+		Expect(tag).Should(BeNil())
+		Expect(heap.ExtractTag(1)).Should(Equal(math.Inf(-1)))
+		Expect(heap.Delete(1)).Should(HaveOccurred())
+		Expect(heap.String()).Should(Equal("Heap is empty.\n"))
+	})
+
+	It("Given a keyHeap, when call the apis with invalid input, it should return errors.", func() {
+		Expect(heap.Insert(nil, 1)).Should(HaveOccurred())
+		Expect(heap.Insert(1, math.Inf(-1))).Should(HaveOccurred())
+		Expect(heap.Insert(1, 1)).ShouldNot(HaveOccurred())
+		Expect(heap.Insert(1, 2)).Should(HaveOccurred())
+		Expect(heap.DecreaseKey(1, 1)).Should(HaveOccurred())
+		Expect(heap.DecreaseKey(1, math.Inf(-1))).Should(HaveOccurred())
+		Expect(heap.DecreaseKey(2, 0)).Should(HaveOccurred())
+		Expect(heap.IncreaseKey(1, 1)).Should(HaveOccurred())
+		Expect(heap.IncreaseKey(2, 2)).Should(HaveOccurred())
+		Expect(heap.String()).Should(ContainSubstring("tag(1)"))
+	})
+
+	It("Given keyHeaps, when run the differential tester, it should never diverge from the reference model.", func() {
+		for seed := int64(0); seed < 20; seed++ {
+			Expect(heaptest.Run(NewKeyHeap(), seed)).ShouldNot(HaveOccurred())
+		}
+	})
+})
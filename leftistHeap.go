@@ -0,0 +1,492 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// LeftistHeap represents a Leftist Heap.
+// LeftistHeap provides exactly the same methods as FibHeap so the two implementations can be swapped by changing the constructor only.
+// Every node keeps its rank, the length of its right spine, no smaller than the rank of its right child,
+// so that two heaps can be melded in worst-case O(log n) by merging their right spines.
+// Please note that all methods of LeftistHeap are not concurrent safe.
+type LeftistHeap struct {
+	root  *leftistNode
+	index map[interface{}]*leftistNode
+	num   uint
+}
+
+type leftistNode struct {
+	parent *leftistNode
+	left   *leftistNode
+	right  *leftistNode
+	rank   int
+	tag    interface{}
+	key    float64
+	value  Value
+}
+
+// NewLeftistHeap creates an initialized Leftist Heap.
+func NewLeftistHeap() *LeftistHeap {
+	heap := new(LeftistHeap)
+	heap.index = make(map[interface{}]*leftistNode)
+	heap.num = 0
+	heap.root = nil
+
+	return heap
+}
+
+// Num returns the total number of values in the heap.
+func (heap *LeftistHeap) Num() uint {
+	return heap.num
+}
+
+// Insert pushes the input tag and key into the heap.
+// Try to insert a duplicate tag value will cause an error return.
+// The valid range of the key is (-inf, +inf].
+// Try to insert a -inf key value will cause an error return.
+func (heap *LeftistHeap) Insert(tag interface{}, key float64) error {
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	return heap.insert(tag, key, nil)
+}
+
+// InsertValue pushes the input value into the heap.
+// The input value must implements the Value interface.
+// Try to insert a duplicate tag value will cause an error return.
+// The valid range of the value's key is (-inf, +inf].
+// Try to insert a -inf key value will cause an error return.
+func (heap *LeftistHeap) InsertValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	return heap.insert(value.Tag(), value.Key(), value)
+}
+
+// Minimum returns the current minimum tag and key in the heap sorted by the key.
+// An empty heap will return nil and -inf.
+func (heap *LeftistHeap) Minimum() (interface{}, float64) {
+	if heap.num == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	return heap.root.tag, heap.root.key
+}
+
+// MinimumValue returns the current minimum value in the heap sorted by the key.
+// An empty heap will return nil.
+func (heap *LeftistHeap) MinimumValue() Value {
+	if heap.num == 0 {
+		return nil
+	}
+
+	return heap.root.value
+}
+
+// ExtractMin returns the current minimum tag and key in the heap and then extracts them from the heap.
+// An empty heap will return nil/-inf and extracts nothing.
+func (heap *LeftistHeap) ExtractMin() (interface{}, float64) {
+	if heap.num == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	min := heap.extractMin()
+
+	return min.tag, min.key
+}
+
+// ExtractMinValue returns the current minimum value in the heap and then extracts it from the heap.
+// An empty heap will return nil and extracts nothing.
+func (heap *LeftistHeap) ExtractMinValue() Value {
+	if heap.num == 0 {
+		return nil
+	}
+
+	min := heap.extractMin()
+
+	return min.value
+}
+
+// Union moves all values of the input heap into the heap.
+// The input heap can be any implementation of PriorityQueue, and it is emptied afterwards so that no value is reachable from both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned and both heaps are left untouched.
+func (heap *LeftistHeap) Union(anotherHeap PriorityQueue) error {
+	if err := heap.UnionInto(anotherHeap); err != nil {
+		return err
+	}
+
+	anotherHeap.reset()
+
+	return nil
+}
+
+// UnionInto merges copies of all values of the input heap into the heap.
+// The input heap can be any implementation of PriorityQueue, and it is left untouched, so the values are shared by both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned.
+func (heap *LeftistHeap) UnionInto(anotherHeap PriorityQueue) error {
+	if err := checkUnion(heap, anotherHeap); err != nil {
+		return err
+	}
+
+	anotherHeap.each(func(tag interface{}, key float64, value Value) {
+		heap.insert(tag, key, value)
+	})
+
+	return nil
+}
+
+// DecreaseKey updates the tag in the heap by the input key.
+// If the input key has a larger key or -inf key, an error will be returned.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *LeftistHeap) DecreaseKey(tag interface{}, key float64) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tag]; exists {
+		return heap.decreaseKey(node, node.value, key)
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// DecreaseKeyValue updates the value in the heap by the input value.
+// If the input value has a larger key or -inf key, an error will be returned.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *LeftistHeap) DecreaseKeyValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	if math.IsInf(value.Key(), -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tagKey(value.Tag())]; exists {
+		return heap.decreaseKey(node, value, value.Key())
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// IncreaseKey updates the tag in the heap by the input key.
+// If the input key has a smaller key or -inf key, an error will be returned.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *LeftistHeap) IncreaseKey(tag interface{}, key float64) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tag]; exists {
+		return heap.increaseKey(node, node.value, key)
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// IncreaseKeyValue updates the value in the heap by the input value.
+// If the input value has a smaller key or -inf key, an error will be returned.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *LeftistHeap) IncreaseKeyValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	if math.IsInf(value.Key(), -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tagKey(value.Tag())]; exists {
+		return heap.increaseKey(node, value, value.Key())
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// Delete deletes the input tag in the heap.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *LeftistHeap) Delete(tag interface{}) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	node, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Tag is not found ")
+	}
+
+	heap.deleteNode(node)
+
+	return nil
+}
+
+// DeleteValue deletes the value in the heap by the input value.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *LeftistHeap) DeleteValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	node, exists := heap.index[tagKey(value.Tag())]
+	if !exists {
+		return errors.New("Value is not found ")
+	}
+
+	heap.deleteNode(node)
+
+	return nil
+}
+
+// GetTag searches and returns the key in the heap by the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *LeftistHeap) GetTag(tag interface{}) (key float64) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		return node.key
+	}
+
+	return math.Inf(-1)
+}
+
+// GetValue searches and returns the value in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *LeftistHeap) GetValue(tag interface{}) (value Value) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		value = node.value
+	}
+
+	return
+}
+
+// ExtractTag searches and extracts the tag/key in the heap by the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *LeftistHeap) ExtractTag(tag interface{}) (key float64) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		key = node.key
+		heap.deleteNode(node)
+		return
+	}
+
+	return math.Inf(-1)
+}
+
+// ExtractValue searches and extracts the value in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *LeftistHeap) ExtractValue(tag interface{}) (value Value) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		value = node.value
+		heap.deleteNode(node)
+		return
+	}
+
+	return nil
+}
+
+// String provides some basic debug information of the heap.
+// It returns the total number, index size and current minimum value of the heap.
+// It also returns the topology of the tree by dfs search.
+func (heap *LeftistHeap) String() string {
+	var buffer bytes.Buffer
+
+	if heap.num != 0 {
+		buffer.WriteString(fmt.Sprintf("Total number: %d, Index size: %d,\n", heap.num, len(heap.index)))
+		buffer.WriteString(fmt.Sprintf("Current minimun: key(%f), tag(%v), value(%v),\n", heap.root.key, heap.root.tag, heap.root.value))
+		buffer.WriteString(fmt.Sprintf("Heap detail:\n"))
+		probeLeftistTree(&buffer, heap.root)
+		buffer.WriteString(fmt.Sprintf("\n"))
+	} else {
+		buffer.WriteString(fmt.Sprintf("Heap is empty.\n"))
+	}
+
+	return buffer.String()
+}
+
+func probeLeftistTree(buffer *bytes.Buffer, n *leftistNode) {
+	buffer.WriteString(fmt.Sprintf("< %f ", n.key))
+	if n.left != nil {
+		probeLeftistTree(buffer, n.left)
+	}
+	if n.right != nil {
+		probeLeftistTree(buffer, n.right)
+	}
+	buffer.WriteString(fmt.Sprintf("> "))
+}
+
+func (heap *LeftistHeap) reset() {
+	*heap = *NewLeftistHeap()
+}
+
+func (heap *LeftistHeap) each(fn func(tag interface{}, key float64, value Value)) {
+	for _, node := range heap.index {
+		fn(node.tag, node.key, node.value)
+	}
+}
+
+func (heap *LeftistHeap) insert(tag interface{}, key float64, value Value) error {
+	tag = tagKey(tag)
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if _, exists := heap.index[tag]; exists {
+		return errors.New("Duplicate tag is not allowed ")
+	}
+
+	node := new(leftistNode)
+	node.rank = 1
+	node.tag = tag
+	node.key = key
+	node.value = value
+
+	heap.index[node.tag] = node
+	heap.num++
+	heap.setRoot(heap.meld(heap.root, node))
+
+	return nil
+}
+
+func (heap *LeftistHeap) extractMin() *leftistNode {
+	min := heap.root
+	heap.deleteNode(min)
+
+	return min
+}
+
+func (heap *LeftistHeap) deleteNode(n *leftistNode) {
+	heap.replace(n, heap.takeChildren(n))
+	delete(heap.index, n.tag)
+	heap.num--
+}
+
+func (heap *LeftistHeap) decreaseKey(n *leftistNode, value Value, key float64) error {
+	if key >= n.key {
+		return errors.New("New key is not smaller than current key ")
+	}
+
+	n.key = key
+	n.value = value
+	if n.parent != nil && n.key < n.parent.key {
+		heap.replace(n, nil)
+		heap.setRoot(heap.meld(heap.root, n))
+	}
+
+	return nil
+}
+
+func (heap *LeftistHeap) increaseKey(n *leftistNode, value Value, key float64) error {
+	if key <= n.key {
+		return errors.New("New key is not larger than current key ")
+	}
+
+	n.key = key
+	n.value = value
+
+	heap.replace(n, heap.takeChildren(n))
+	heap.setRoot(heap.meld(heap.root, n))
+
+	return nil
+}
+
+// takeChildren detaches both children of n, leaving n a single node tree, and returns them melded.
+func (heap *LeftistHeap) takeChildren(n *leftistNode) *leftistNode {
+	left, right := n.left, n.right
+	if left != nil {
+		left.parent = nil
+	}
+	if right != nil {
+		right.parent = nil
+	}
+	n.left = nil
+	n.right = nil
+	n.rank = 1
+
+	return heap.meld(left, right)
+}
+
+// replace puts the detached tree sub at the place of n in the tree and restores the ranks of the ancestors.
+func (heap *LeftistHeap) replace(n, sub *leftistNode) {
+	parent := n.parent
+	n.parent = nil
+	if parent == nil {
+		heap.setRoot(sub)
+		return
+	}
+
+	if parent.left == n {
+		parent.left = sub
+	} else {
+		parent.right = sub
+	}
+	if sub != nil {
+		sub.parent = parent
+	}
+
+	for ; parent != nil; parent = parent.parent {
+		if rankOfLeftist(parent.left) < rankOfLeftist(parent.right) {
+			parent.left, parent.right = parent.right, parent.left
+		}
+		rank := rankOfLeftist(parent.right) + 1
+		if rank == parent.rank {
+			break
+		}
+		parent.rank = rank
+	}
+}
+
+func (heap *LeftistHeap) setRoot(root *leftistNode) {
+	heap.root = root
+	if root != nil {
+		root.parent = nil
+	}
+}
+
+// meld merges the right spines of two detached trees and returns the new root.
+func (heap *LeftistHeap) meld(a, b *leftistNode) *leftistNode {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if b.key < a.key {
+		a, b = b, a
+	}
+
+	a.right = heap.meld(a.right, b)
+	a.right.parent = a
+	if rankOfLeftist(a.left) < rankOfLeftist(a.right) {
+		a.left, a.right = a.right, a.left
+	}
+	a.rank = rankOfLeftist(a.right) + 1
+
+	return a
+}
+
+func rankOfLeftist(n *leftistNode) int {
+	if n == nil {
+		return 0
+	}
+
+	return n.rank
+}
@@ -0,0 +1,168 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"fmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/starwander/GoFibonacciHeap/heaptest"
+	"math"
+	"math/rand"
+	"time"
+)
+
+var _ = Describe("Tests of leftistHeap", func() {
+	var (
+		heap        *LeftistHeap
+		anotherHeap *LeftistHeap
+	)
+
+	BeforeEach(func() {
+		heap = NewLeftistHeap()
+		anotherHeap = NewLeftistHeap()
+	})
+
+	AfterEach(func() {
+		heap = nil
+		anotherHeap = nil
+	})
+
+	It("Given an empty leftistHeap, when call Minimum and ExtractMin api, it should return nil.", func() {
+		tag, key := heap.Minimum()
+		Expect(tag).Should(BeNil())
+		Expect(key).Should(BeEquivalentTo(math.Inf(-1)))
+		Expect(heap.ExtractMinValue()).Should(BeNil())
+		Expect(heap.String()).Should(BeEquivalentTo("Heap is empty.\n"))
+	})
+
+	It("Given an empty leftistHeap, when call Insert api with invalid inputs, it should return error.", func() {
+		Expect(heap.Insert(nil, 0.0)).Should(HaveOccurred())
+		Expect(heap.InsertValue(nil)).Should(HaveOccurred())
+		Expect(heap.Insert(1000, math.Inf(-1))).Should(HaveOccurred())
+		Expect(heap.Insert(1000, 0.0)).ShouldNot(HaveOccurred())
+		Expect(heap.Insert(1000, 1.0)).Should(HaveOccurred())
+		Expect(heap.Num()).Should(BeEquivalentTo(1))
+	})
+
+	It("Given a leftistHeap inserted multiple values, when call ExtractMinValue api, it should extract the values sorted by key.", func() {
+		rand.Seed(time.Now().Unix())
+		for i := 0; i < 10000; i++ {
+			demo := new(demoStruct)
+			demo.tag = i
+			demo.key = rand.Float64()
+			demo.value = fmt.Sprint(demo.key)
+			Expect(heap.InsertValue(demo)).ShouldNot(HaveOccurred())
+		}
+
+		lastKey := heap.MinimumValue().(*demoStruct).key
+		for i := 0; i < 10000; i++ {
+			extracted := heap.ExtractMinValue().(*demoStruct)
+			Expect(extracted.key).Should(BeNumerically(">=", lastKey))
+			Expect(extracted.value).Should(Equal(fmt.Sprint(extracted.key)))
+			Expect(heap.Num()).Should(BeEquivalentTo(9999 - i))
+			lastKey = extracted.key
+		}
+	})
+
+	It("Given a leftistHeap inserted multiple values, when call DecreaseKey and IncreaseKey api, it should reorder the values.", func() {
+		for i := 0; i < 1000; i++ {
+			heap.Insert(i, float64(i+1000))
+		}
+
+		Expect(heap.DecreaseKey(500, 1500)).Should(HaveOccurred())
+		Expect(heap.IncreaseKey(500, 1000)).Should(HaveOccurred())
+		Expect(heap.DecreaseKey(500, -1)).ShouldNot(HaveOccurred())
+		Expect(heap.IncreaseKey(0, 5000)).ShouldNot(HaveOccurred())
+
+		tag, key := heap.ExtractMin()
+		Expect(tag).Should(BeEquivalentTo(500))
+		Expect(key).Should(BeEquivalentTo(-1))
+		tag, _ = heap.ExtractMin()
+		Expect(tag).Should(BeEquivalentTo(1))
+		Expect(heap.GetTag(0)).Should(BeEquivalentTo(5000))
+	})
+
+	It("Given a leftistHeap with a value, when call DecreaseKey api by tag, it should keep the stored value.", func() {
+		demo := &demoStruct{1, 10, "10"}
+		heap.InsertValue(demo)
+
+		Expect(heap.DecreaseKey(1, 5)).ShouldNot(HaveOccurred())
+		Expect(heap.GetValue(1)).Should(BeIdenticalTo(demo))
+	})
+
+	It("Given a leftistHeap inserted multiple values, when call Delete api, it should remove the value from the heap.", func() {
+		for i := 0; i < 1000; i++ {
+			heap.Insert(i, float64(i))
+		}
+
+		Expect(heap.Delete(nil)).Should(HaveOccurred())
+		Expect(heap.Delete(1000)).Should(HaveOccurred())
+		for i := 0; i < 1000; i += 2 {
+			Expect(heap.Delete(i)).ShouldNot(HaveOccurred())
+		}
+		Expect(heap.Num()).Should(BeEquivalentTo(500))
+		Expect(heap.ExtractTag(999)).Should(BeEquivalentTo(999))
+		Expect(heap.ExtractValue(999)).Should(BeNil())
+		for i := 1; i < 999; i += 2 {
+			tag, _ := heap.ExtractMin()
+			Expect(tag).Should(BeEquivalentTo(i))
+		}
+	})
+
+	It("Given two leftistHeaps, when call Union api, it should move all values of the input heap in and empty it.", func() {
+		heap.Insert(1, 1)
+		heap.InsertValue(&demoStruct{2, 2, "2"})
+		anotherHeap.Insert(3, 0)
+		anotherHeap.InsertValue(&demoStruct{4, 4, "4"})
+
+		Expect(heap.Union(anotherHeap)).ShouldNot(HaveOccurred())
+		Expect(heap.Num()).Should(BeEquivalentTo(4))
+		Expect(anotherHeap.Num()).Should(BeEquivalentTo(0))
+		Expect(heap.GetValue(4).(*demoStruct).value).Should(Equal("4"))
+		tag, _ := heap.Minimum()
+		Expect(tag).Should(BeEquivalentTo(3))
+
+		Expect(heap.Union(heap)).Should(HaveOccurred())
+	})
+
+	It("Given a leftistHeap, when run the differential tester, it should never diverge from the reference model.", func() {
+		for seed := int64(0); seed < 20; seed++ {
+			Expect(heaptest.Run(NewLeftistHeap(), seed)).ShouldNot(HaveOccurred())
+		}
+	})
+
+	It("Given a leftistHeap, when call random operations, it should keep the heap order and the leftist property.", func() {
+		random := rand.New(rand.NewSource(time.Now().Unix()))
+		for i := 0; i < 3000; i++ {
+			tag := random.Intn(500)
+			key := heap.GetTag(tag)
+			switch {
+			case math.IsInf(key, -1):
+				heap.Insert(tag, float64(random.Intn(1000)))
+			case random.Intn(3) == 0:
+				heap.Delete(tag)
+			case random.Intn(2) == 0:
+				heap.DecreaseKey(tag, key-float64(random.Intn(100)+1))
+			default:
+				heap.IncreaseKey(tag, key+float64(random.Intn(100)+1))
+			}
+			Expect(checkLeftistTree(heap.root, nil)).Should(BeEquivalentTo(heap.Num()))
+		}
+	})
+})
+
+func checkLeftistTree(n, parent *leftistNode) int {
+	if n == nil {
+		return 0
+	}
+
+	Expect(n.parent).Should(BeIdenticalTo(parent))
+	if parent != nil {
+		Expect(n.key).Should(BeNumerically(">=", parent.key))
+	}
+	Expect(rankOfLeftist(n.left)).Should(BeNumerically(">=", rankOfLeftist(n.right)))
+	Expect(n.rank).Should(Equal(rankOfLeftist(n.right) + 1))
+
+	return checkLeftistTree(n.left, n) + checkLeftistTree(n.right, n) + 1
+}
@@ -0,0 +1,32 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"time"
+)
+
+// minLoggedCascade is the minimum number of cuts of a cascade to be logged, as the short cascades are the normal case.
+const minLoggedCascade = 8
+
+// phaseLogger logs the internal phases of the heap. It is implemented by a *slog.Logger wrapper in the builds of go1.21 and later,
+// so that the heap does not depend on log/slog in the earlier ones, see SetLogger.
+type phaseLogger interface {
+	enabled() bool
+	log(msg string, args ...interface{})
+}
+
+// logPhase logs the phase which has just run, with the number of roots and values before it.
+func (heap *FibHeap) logPhase(phase string, roots int, num uint, elapsed time.Duration) {
+	switch phase {
+	case "cascadingCut":
+		// Every cut of the cascade makes a new root.
+		if cuts := heap.roots.Len() - roots; cuts >= minLoggedCascade {
+			heap.logger.log("fibHeap cascadingCut", "cuts", cuts, "values", heap.num, "duration", elapsed)
+		}
+	case "union":
+		heap.logger.log("fibHeap union", "moved", heap.num-num, "values", heap.num, "duration", elapsed)
+	default:
+		heap.logger.log("fibHeap "+phase, "roots", roots, "trees", heap.roots.Len(), "values", heap.num, "duration", elapsed)
+	}
+}
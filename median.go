@@ -0,0 +1,79 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"errors"
+	"math"
+)
+
+// Median maintains the running median of a stream of numbers.
+// The lower half of the numbers is kept in a MinMaxHeap serving its maximum and the upper half in a FibHeap serving its minimum,
+// so Add is O(log n) and Median is O(1).
+// Please note that all methods of Median are not concurrent safe.
+type Median struct {
+	lower *MinMaxHeap
+	upper *FibHeap
+	seq   uint64
+}
+
+// NewMedian creates an initialized Median without any number.
+func NewMedian() *Median {
+	median := new(Median)
+	median.lower = NewMinMaxHeap()
+	median.upper = NewFibHeap()
+
+	return median
+}
+
+// Num returns the total number of numbers added.
+func (median *Median) Num() uint {
+	return median.lower.Num() + median.upper.Num()
+}
+
+// Add adds the input number.
+// The valid range of the number is (-inf, +inf]. Try to add a -inf or NaN will cause an error return.
+func (median *Median) Add(x float64) error {
+	if math.IsInf(x, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+	if math.IsNaN(x) {
+		return errors.New("Input number is NaN ")
+	}
+
+	// The tags are only used to tell duplicate numbers apart.
+	median.seq++
+	if _, max := median.lower.Maximum(); median.lower.Num() == 0 || x <= max {
+		median.lower.Insert(median.seq, x)
+	} else {
+		median.upper.Insert(median.seq, x)
+	}
+
+	if median.lower.Num() > median.upper.Num()+1 {
+		tag, key := median.lower.ExtractMax()
+		median.upper.Insert(tag, key)
+	} else if median.upper.Num() > median.lower.Num() {
+		tag, key := median.upper.ExtractMin()
+		median.lower.Insert(tag, key)
+	}
+
+	return nil
+}
+
+// Median returns the median of all numbers added.
+// For an even number of numbers, the mean of the two middle ones is returned.
+// An empty Median will return -inf.
+func (median *Median) Median() float64 {
+	if median.lower.Num() == 0 {
+		return math.Inf(-1)
+	}
+
+	_, max := median.lower.Maximum()
+	if median.lower.Num() > median.upper.Num() {
+		return max
+	}
+
+	_, min := median.upper.Minimum()
+
+	return (max + min) / 2
+}
@@ -0,0 +1,72 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+var _ = Describe("Tests of median", func() {
+	var (
+		median *Median
+	)
+
+	BeforeEach(func() {
+		median = NewMedian()
+	})
+
+	AfterEach(func() {
+		median = nil
+	})
+
+	It("Given an empty median, when call Median api, it should return -inf.", func() {
+		Expect(median.Median()).Should(BeEquivalentTo(math.Inf(-1)))
+		Expect(median.Num()).Should(BeEquivalentTo(0))
+	})
+
+	It("Given a median, when call Add api with invalid inputs, it should return error.", func() {
+		Expect(median.Add(math.Inf(-1))).Should(HaveOccurred())
+		Expect(median.Add(math.NaN())).Should(HaveOccurred())
+		Expect(median.Add(math.Inf(1))).ShouldNot(HaveOccurred())
+		Expect(median.Num()).Should(BeEquivalentTo(1))
+		Expect(median.Median()).Should(BeEquivalentTo(math.Inf(1)))
+	})
+
+	It("Given a median, when call Add api with duplicate numbers, it should count all of them.", func() {
+		for i := 0; i < 5; i++ {
+			median.Add(1)
+		}
+		median.Add(2)
+		median.Add(2)
+		Expect(median.Num()).Should(BeEquivalentTo(7))
+		Expect(median.Median()).Should(BeEquivalentTo(1))
+		median.Add(2)
+		Expect(median.Median()).Should(BeEquivalentTo(1))
+		median.Add(2)
+		median.Add(2)
+		Expect(median.Median()).Should(BeEquivalentTo(1.5))
+	})
+
+	It("Given a median, when call Add api with random numbers, it should keep returning the median of all numbers added.", func() {
+		random := rand.New(rand.NewSource(time.Now().Unix()))
+		var numbers []float64
+		for i := 0; i < 2000; i++ {
+			x := float64(random.Intn(1000))
+			numbers = append(numbers, x)
+			Expect(median.Add(x)).ShouldNot(HaveOccurred())
+
+			sorted := append([]float64(nil), numbers...)
+			sort.Float64s(sorted)
+			expected := sorted[len(sorted)/2]
+			if len(sorted)%2 == 0 {
+				expected = (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+			}
+			Expect(median.Median()).Should(BeEquivalentTo(expected))
+		}
+	})
+})
@@ -0,0 +1,553 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// MinMaxHeap represents an array based Min-Max Heap as described by Atkinson, Sack, Santoro and Strothotte.
+// MinMaxHeap provides all methods of FibHeap, plus Maximum and ExtractMax which serve the other end of the queue.
+// Both Minimum and Maximum are O(1), and all other operations are O(log n).
+// It suits use cases like load shedding which serve the best value but evict the worst one when over capacity.
+// Please note that all methods of MinMaxHeap are not concurrent safe.
+type MinMaxHeap struct {
+	items []minMaxItem
+	index map[interface{}]*minMaxNode
+}
+
+// minMaxItem keeps the key inline in the array so that sifting only touches contiguous memory.
+// Items on even levels are not larger than all their descendants, items on odd levels are not smaller than all their descendants.
+type minMaxItem struct {
+	key  float64
+	node *minMaxNode
+}
+
+type minMaxNode struct {
+	position int
+	tag      interface{}
+	value    Value
+}
+
+// NewMinMaxHeap creates an initialized Min-Max Heap.
+func NewMinMaxHeap() *MinMaxHeap {
+	heap := new(MinMaxHeap)
+	heap.index = make(map[interface{}]*minMaxNode)
+
+	return heap
+}
+
+// Num returns the total number of values in the heap.
+func (heap *MinMaxHeap) Num() uint {
+	return uint(len(heap.items))
+}
+
+// Insert pushes the input tag and key into the heap.
+// Try to insert a duplicate tag value will cause an error return.
+// The valid range of the key is (-inf, +inf].
+// Try to insert a -inf key value will cause an error return.
+func (heap *MinMaxHeap) Insert(tag interface{}, key float64) error {
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	return heap.insert(tag, key, nil)
+}
+
+// InsertValue pushes the input value into the heap.
+// The input value must implements the Value interface.
+// Try to insert a duplicate tag value will cause an error return.
+// The valid range of the value's key is (-inf, +inf].
+// Try to insert a -inf key value will cause an error return.
+func (heap *MinMaxHeap) InsertValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	return heap.insert(value.Tag(), value.Key(), value)
+}
+
+// Minimum returns the current minimum tag and key in the heap sorted by the key.
+// An empty heap will return nil and -inf.
+func (heap *MinMaxHeap) Minimum() (interface{}, float64) {
+	if len(heap.items) == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	return heap.items[0].node.tag, heap.items[0].key
+}
+
+// MinimumValue returns the current minimum value in the heap sorted by the key.
+// An empty heap will return nil.
+func (heap *MinMaxHeap) MinimumValue() Value {
+	if len(heap.items) == 0 {
+		return nil
+	}
+
+	return heap.items[0].node.value
+}
+
+// ExtractMin returns the current minimum tag and key in the heap and then extracts them from the heap.
+// An empty heap will return nil/-inf and extracts nothing.
+func (heap *MinMaxHeap) ExtractMin() (interface{}, float64) {
+	if len(heap.items) == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	key := heap.items[0].key
+	min := heap.remove(0)
+
+	return min.tag, key
+}
+
+// ExtractMinValue returns the current minimum value in the heap and then extracts it from the heap.
+// An empty heap will return nil and extracts nothing.
+func (heap *MinMaxHeap) ExtractMinValue() Value {
+	if len(heap.items) == 0 {
+		return nil
+	}
+
+	min := heap.remove(0)
+
+	return min.value
+}
+
+// Maximum returns the current maximum tag and key in the heap sorted by the key.
+// Maximum will not extract the tag and key so the value will still exists in the heap.
+// An empty heap will return nil and -inf.
+func (heap *MinMaxHeap) Maximum() (interface{}, float64) {
+	if len(heap.items) == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	max := heap.maxPosition()
+
+	return heap.items[max].node.tag, heap.items[max].key
+}
+
+// MaximumValue returns the current maximum value in the heap sorted by the key.
+// MaximumValue will not extract the value so the value will still exists in the heap.
+// An empty heap will return nil.
+func (heap *MinMaxHeap) MaximumValue() Value {
+	if len(heap.items) == 0 {
+		return nil
+	}
+
+	return heap.items[heap.maxPosition()].node.value
+}
+
+// ExtractMax returns the current maximum tag and key in the heap and then extracts them from the heap.
+// An empty heap will return nil/-inf and extracts nothing.
+func (heap *MinMaxHeap) ExtractMax() (interface{}, float64) {
+	if len(heap.items) == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	max := heap.maxPosition()
+	key := heap.items[max].key
+	node := heap.remove(max)
+
+	return node.tag, key
+}
+
+// ExtractMaxValue returns the current maximum value in the heap and then extracts it from the heap.
+// An empty heap will return nil and extracts nothing.
+func (heap *MinMaxHeap) ExtractMaxValue() Value {
+	if len(heap.items) == 0 {
+		return nil
+	}
+
+	node := heap.remove(heap.maxPosition())
+
+	return node.value
+}
+
+// Union moves all values of the input heap into the heap.
+// The input heap can be any implementation of PriorityQueue, and it is emptied afterwards so that no value is reachable from both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned and both heaps are left untouched.
+func (heap *MinMaxHeap) Union(anotherHeap PriorityQueue) error {
+	if err := heap.UnionInto(anotherHeap); err != nil {
+		return err
+	}
+
+	anotherHeap.reset()
+
+	return nil
+}
+
+// UnionInto merges copies of all values of the input heap into the heap.
+// The input heap can be any implementation of PriorityQueue, and it is left untouched, so the values are shared by both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned.
+func (heap *MinMaxHeap) UnionInto(anotherHeap PriorityQueue) error {
+	if err := checkUnion(heap, anotherHeap); err != nil {
+		return err
+	}
+
+	anotherHeap.each(func(tag interface{}, key float64, value Value) {
+		heap.insert(tag, key, value)
+	})
+
+	return nil
+}
+
+// DecreaseKey updates the tag in the heap by the input key.
+// If the input key has a larger key or -inf key, an error will be returned.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *MinMaxHeap) DecreaseKey(tag interface{}, key float64) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tag]; exists {
+		return heap.decreaseKey(node, node.value, key)
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// DecreaseKeyValue updates the value in the heap by the input value.
+// If the input value has a larger key or -inf key, an error will be returned.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *MinMaxHeap) DecreaseKeyValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	if math.IsInf(value.Key(), -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tagKey(value.Tag())]; exists {
+		return heap.decreaseKey(node, value, value.Key())
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// IncreaseKey updates the tag in the heap by the input key.
+// If the input key has a smaller key or -inf key, an error will be returned.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *MinMaxHeap) IncreaseKey(tag interface{}, key float64) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tag]; exists {
+		return heap.increaseKey(node, node.value, key)
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// IncreaseKeyValue updates the value in the heap by the input value.
+// If the input value has a smaller key or -inf key, an error will be returned.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *MinMaxHeap) IncreaseKeyValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	if math.IsInf(value.Key(), -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tagKey(value.Tag())]; exists {
+		return heap.increaseKey(node, value, value.Key())
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// Delete deletes the input tag in the heap.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *MinMaxHeap) Delete(tag interface{}) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	node, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Tag is not found ")
+	}
+
+	heap.remove(node.position)
+
+	return nil
+}
+
+// DeleteValue deletes the value in the heap by the input value.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *MinMaxHeap) DeleteValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	node, exists := heap.index[tagKey(value.Tag())]
+	if !exists {
+		return errors.New("Value is not found ")
+	}
+
+	heap.remove(node.position)
+
+	return nil
+}
+
+// GetTag searches and returns the key in the heap by the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *MinMaxHeap) GetTag(tag interface{}) (key float64) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		return heap.items[node.position].key
+	}
+
+	return math.Inf(-1)
+}
+
+// GetValue searches and returns the value in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *MinMaxHeap) GetValue(tag interface{}) (value Value) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		value = node.value
+	}
+
+	return
+}
+
+// ExtractTag searches and extracts the tag/key in the heap by the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *MinMaxHeap) ExtractTag(tag interface{}) (key float64) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		key = heap.items[node.position].key
+		heap.remove(node.position)
+		return
+	}
+
+	return math.Inf(-1)
+}
+
+// ExtractValue searches and extracts the value in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *MinMaxHeap) ExtractValue(tag interface{}) (value Value) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		value = node.value
+		heap.remove(node.position)
+		return
+	}
+
+	return nil
+}
+
+// String provides some basic debug information of the heap.
+// It returns the total number, index size, current minimum and maximum value of the heap.
+// It also returns all keys in the order of the underlying array.
+func (heap *MinMaxHeap) String() string {
+	var buffer bytes.Buffer
+
+	if len(heap.items) != 0 {
+		max := heap.items[heap.maxPosition()]
+		buffer.WriteString(fmt.Sprintf("Total number: %d, Index size: %d,\n", len(heap.items), len(heap.index)))
+		buffer.WriteString(fmt.Sprintf("Current minimun: key(%f), tag(%v), value(%v),\n", heap.items[0].key, heap.items[0].node.tag, heap.items[0].node.value))
+		buffer.WriteString(fmt.Sprintf("Current maximum: key(%f), tag(%v), value(%v),\n", max.key, max.node.tag, max.node.value))
+		buffer.WriteString(fmt.Sprintf("Heap detail:\n"))
+		buffer.WriteString(fmt.Sprintf("< "))
+		for _, item := range heap.items {
+			buffer.WriteString(fmt.Sprintf("%f ", item.key))
+		}
+		buffer.WriteString(fmt.Sprintf("> \n"))
+	} else {
+		buffer.WriteString(fmt.Sprintf("Heap is empty.\n"))
+	}
+
+	return buffer.String()
+}
+
+func (heap *MinMaxHeap) reset() {
+	*heap = *NewMinMaxHeap()
+}
+
+func (heap *MinMaxHeap) each(fn func(tag interface{}, key float64, value Value)) {
+	for _, item := range heap.items {
+		fn(item.node.tag, item.key, item.node.value)
+	}
+}
+
+func (heap *MinMaxHeap) insert(tag interface{}, key float64, value Value) error {
+	tag = tagKey(tag)
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if _, exists := heap.index[tag]; exists {
+		return errors.New("Duplicate tag is not allowed ")
+	}
+
+	node := new(minMaxNode)
+	node.position = len(heap.items)
+	node.tag = tag
+	node.value = value
+
+	heap.items = append(heap.items, minMaxItem{key, node})
+	heap.index[node.tag] = node
+	heap.up(node.position)
+
+	return nil
+}
+
+func (heap *MinMaxHeap) maxPosition() int {
+	switch len(heap.items) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+
+	if heap.items[2].key > heap.items[1].key {
+		return 2
+	}
+
+	return 1
+}
+
+func (heap *MinMaxHeap) remove(position int) *minMaxNode {
+	node := heap.items[position].node
+	last := len(heap.items) - 1
+	if position != last {
+		heap.swap(position, last)
+	}
+	heap.items[last] = minMaxItem{}
+	heap.items = heap.items[:last]
+	delete(heap.index, node.tag)
+
+	if position != last {
+		heap.fix(position)
+	}
+
+	return node
+}
+
+func (heap *MinMaxHeap) decreaseKey(n *minMaxNode, value Value, key float64) error {
+	if key >= heap.items[n.position].key {
+		return errors.New("New key is not smaller than current key ")
+	}
+
+	heap.items[n.position].key = key
+	n.value = value
+	heap.fix(n.position)
+
+	return nil
+}
+
+func (heap *MinMaxHeap) increaseKey(n *minMaxNode, value Value, key float64) error {
+	if key <= heap.items[n.position].key {
+		return errors.New("New key is not larger than current key ")
+	}
+
+	heap.items[n.position].key = key
+	n.value = value
+	heap.fix(n.position)
+
+	return nil
+}
+
+// fix restores the heap order after the key at the position changed arbitrarily.
+func (heap *MinMaxHeap) fix(position int) {
+	node := heap.items[position].node
+	heap.down(position)
+	heap.up(node.position)
+}
+
+func isMinLevel(position int) bool {
+	return bits.Len(uint(position+1))%2 == 1
+}
+
+// less compares the items at i and j in the order of the level of position: smaller on min levels, larger on max levels.
+func (heap *MinMaxHeap) less(i, j int, min bool) bool {
+	if min {
+		return heap.items[i].key < heap.items[j].key
+	}
+
+	return heap.items[i].key > heap.items[j].key
+}
+
+func (heap *MinMaxHeap) up(position int) {
+	if position == 0 {
+		return
+	}
+
+	min := isMinLevel(position)
+	parent := (position - 1) / 2
+	if heap.less(parent, position, min) {
+		heap.swap(parent, position)
+		heap.upLevel(parent, !min)
+	} else {
+		heap.upLevel(position, min)
+	}
+}
+
+// upLevel moves the item up along the grandparents, which are on the same kind of level.
+func (heap *MinMaxHeap) upLevel(position int, min bool) {
+	for position > 2 {
+		grandparent := ((position-1)/2 - 1) / 2
+		if !heap.less(position, grandparent, min) {
+			break
+		}
+		heap.swap(position, grandparent)
+		position = grandparent
+	}
+}
+
+// down moves the item down along the children and grandchildren.
+func (heap *MinMaxHeap) down(position int) {
+	min := isMinLevel(position)
+	for {
+		first := 2*position + 1
+		if first >= len(heap.items) {
+			return
+		}
+
+		// Find the smallest(or largest on max levels) among the children and grandchildren.
+		best := first
+		for _, candidate := range [...]int{first + 1, 2*first + 1, 2*first + 2, 2*first + 3, 2*first + 4} {
+			if candidate < len(heap.items) && heap.less(candidate, best, min) {
+				best = candidate
+			}
+		}
+
+		if !heap.less(best, position, min) {
+			return
+		}
+		heap.swap(best, position)
+		if best <= first+1 {
+			return
+		}
+
+		if parent := (best - 1) / 2; heap.less(parent, best, min) {
+			heap.swap(parent, best)
+		}
+		position = best
+	}
+}
+
+func (heap *MinMaxHeap) swap(i, j int) {
+	heap.items[i], heap.items[j] = heap.items[j], heap.items[i]
+	heap.items[i].node.position = i
+	heap.items[j].node.position = j
+}
@@ -0,0 +1,99 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+// Package mst implements the Prim's minimum spanning tree algorithm on top of the FibHeap.
+// With DecreaseKey in O(1) amortized, Prim runs in O(E + V log V).
+package mst
+
+import (
+	"math"
+
+	fibHeap "github.com/starwander/GoFibonacciHeap"
+)
+
+// Edge is an undirected edge between the vertices From and To.
+type Edge struct {
+	From   interface{}
+	To     interface{}
+	Weight float64
+}
+
+// Graph is the interface of an undirected graph which Prim runs on.
+// The vertices are used as tags of the heap, so they must be comparable and not nil.
+type Graph interface {
+	// Vertices returns all vertices of the graph.
+	Vertices() []interface{}
+	// Edges returns all edges of the vertex, in which From is the vertex itself.
+	Edges(vertex interface{}) []Edge
+}
+
+// AdjacencyList is a Graph backed by a map from every vertex to its edges.
+type AdjacencyList map[interface{}][]Edge
+
+// Vertices returns all vertices of the graph.
+func (graph AdjacencyList) Vertices() []interface{} {
+	vertices := make([]interface{}, 0, len(graph))
+	for vertex := range graph {
+		vertices = append(vertices, vertex)
+	}
+
+	return vertices
+}
+
+// Edges returns all edges of the vertex.
+func (graph AdjacencyList) Edges(vertex interface{}) []Edge {
+	return graph[vertex]
+}
+
+// AddVertex adds a vertex without any edge.
+func (graph AdjacencyList) AddVertex(vertex interface{}) {
+	if _, exists := graph[vertex]; !exists {
+		graph[vertex] = nil
+	}
+}
+
+// AddEdge adds an undirected edge between the vertices a and b.
+func (graph AdjacencyList) AddEdge(a, b interface{}, weight float64) {
+	graph[a] = append(graph[a], Edge{a, b, weight})
+	graph[b] = append(graph[b], Edge{b, a, weight})
+}
+
+// Prim returns the edges of the minimum spanning tree of the graph.
+// If the graph is not connected, the edges of the minimum spanning forest are returned.
+// Negative weights are allowed.
+func Prim(graph Graph) []Edge {
+	var tree []Edge
+	done := make(map[interface{}]bool)
+	best := make(map[interface{}]Edge)
+
+	heap := fibHeap.NewFibHeap()
+	for _, root := range graph.Vertices() {
+		if done[root] {
+			continue
+		}
+
+		heap.Insert(root, 0)
+		for heap.Num() > 0 {
+			vertex, _ := heap.ExtractMin()
+			done[vertex] = true
+			if edge, exists := best[vertex]; exists {
+				tree = append(tree, edge)
+			}
+
+			for _, edge := range graph.Edges(vertex) {
+				if done[edge.To] {
+					continue
+				}
+
+				key := heap.GetTag(edge.To)
+				if math.IsInf(key, -1) {
+					heap.Insert(edge.To, edge.Weight)
+				} else if heap.DecreaseKey(edge.To, edge.Weight) != nil {
+					continue
+				}
+				best[edge.To] = edge
+			}
+		}
+	}
+
+	return tree
+}
@@ -0,0 +1,116 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package mst
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// kruskal calculates the total weight of the minimum spanning forest as the reference.
+func kruskal(vertices int, edges []Edge) float64 {
+	parent := make([]int, vertices)
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	sort.Slice(edges, func(i, j int) bool { return edges[i].Weight < edges[j].Weight })
+	total := 0.0
+	for _, edge := range edges {
+		a, b := find(edge.From.(int)), find(edge.To.(int))
+		if a != b {
+			parent[a] = b
+			total += edge.Weight
+		}
+	}
+
+	return total
+}
+
+func totalWeight(tree []Edge) float64 {
+	total := 0.0
+	for _, edge := range tree {
+		total += edge.Weight
+	}
+
+	return total
+}
+
+var _ = Describe("Tests of mst", func() {
+	It("Given an empty graph, when call Prim api, it should return no edge.", func() {
+		Expect(Prim(make(AdjacencyList))).Should(BeEmpty())
+	})
+
+	It("Given a connected graph, when call Prim api, it should return the minimum spanning tree.", func() {
+		graph := make(AdjacencyList)
+		graph.AddEdge("a", "b", 4)
+		graph.AddEdge("a", "h", 8)
+		graph.AddEdge("b", "h", 11)
+		graph.AddEdge("b", "c", 8)
+		graph.AddEdge("c", "d", 7)
+		graph.AddEdge("c", "f", 4)
+		graph.AddEdge("c", "i", 2)
+		graph.AddEdge("d", "e", 9)
+		graph.AddEdge("d", "f", 14)
+		graph.AddEdge("e", "f", 10)
+		graph.AddEdge("f", "g", 2)
+		graph.AddEdge("g", "h", 1)
+		graph.AddEdge("g", "i", 6)
+		graph.AddEdge("h", "i", 7)
+
+		tree := Prim(graph)
+		Expect(tree).Should(HaveLen(8))
+		Expect(totalWeight(tree)).Should(BeEquivalentTo(37))
+
+		connected := map[interface{}]bool{tree[0].From: true}
+		for _, edge := range tree {
+			Expect(connected[edge.From]).Should(BeTrue())
+			Expect(connected[edge.To]).Should(BeFalse())
+			connected[edge.To] = true
+		}
+		Expect(connected).Should(HaveLen(9))
+	})
+
+	It("Given a disconnected graph, when call Prim api, it should return the minimum spanning forest.", func() {
+		graph := make(AdjacencyList)
+		graph.AddEdge(1, 2, -1)
+		graph.AddEdge(2, 3, 5)
+		graph.AddEdge(1, 3, 2)
+		graph.AddEdge(4, 5, 3)
+		graph.AddVertex(6)
+
+		tree := Prim(graph)
+		Expect(tree).Should(HaveLen(3))
+		Expect(totalWeight(tree)).Should(BeEquivalentTo(4))
+	})
+
+	It("Given random graphs, when call Prim api, it should return a forest as light as Kruskal.", func() {
+		random := rand.New(rand.NewSource(time.Now().Unix()))
+		for round := 0; round < 20; round++ {
+			graph := make(AdjacencyList)
+			var edges []Edge
+			for vertex := 0; vertex < 200; vertex++ {
+				graph.AddVertex(vertex)
+			}
+			for i := 0; i < 600; i++ {
+				a, b, weight := random.Intn(200), random.Intn(200), float64(random.Intn(100))
+				if a != b {
+					graph.AddEdge(a, b, weight)
+					edges = append(edges, Edge{a, b, weight})
+				}
+			}
+
+			Expect(totalWeight(Prim(graph))).Should(BeEquivalentTo(kruskal(200, edges)))
+		}
+	})
+})
@@ -0,0 +1,230 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// MultiHeap is a priority queue in which duplicate tags are allowed, for the workloads where the tag is a category rather than an identity.
+// Every tag keeps all of its entries, and the methods by tag, e.g. GetTag and ExtractTag, operate on the entry with the smallest key of the tag.
+// Internally, all entries are kept in one FibHeap and the entries of every tag in another FibHeap, so all methods keep the bounds of FibHeap.
+// Please note that all methods of MultiHeap are not concurrent safe.
+type MultiHeap struct {
+	heap *FibHeap
+	tags map[interface{}]*FibHeap
+}
+
+// multiEntry is the unique tag of an entry in the inner heaps.
+type multiEntry struct {
+	tag   interface{}
+	value Value
+}
+
+// NewMultiHeap creates an initialized empty MultiHeap.
+func NewMultiHeap() *MultiHeap {
+	heap := new(MultiHeap)
+	heap.heap = NewFibHeap()
+	heap.tags = make(map[interface{}]*FibHeap)
+
+	return heap
+}
+
+// Num returns the total number of entries in the heap.
+func (heap *MultiHeap) Num() uint {
+	return heap.heap.Num()
+}
+
+// Count returns the number of entries of the input tag.
+func (heap *MultiHeap) Count(tag interface{}) uint {
+	tag = tagKey(tag)
+	if entries, exists := heap.tags[tag]; exists {
+		return entries.Num()
+	}
+
+	return 0
+}
+
+// Insert pushes a new entry of the input tag and key into the heap, even if the tag already exists.
+// Try to insert a nil tag or a -inf key will cause an error return.
+func (heap *MultiHeap) Insert(tag interface{}, key float64) error {
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	return heap.insert(tag, key, nil)
+}
+
+// InsertValue pushes a new entry of the input value into the heap, even if its tag already exists.
+// Try to insert a nil value or a value with -inf key will cause an error return.
+func (heap *MultiHeap) InsertValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	return heap.insert(value.Tag(), value.Key(), value)
+}
+
+// Minimum returns the tag and key of the current minimum entry in the heap.
+// An empty heap will return nil and -inf.
+func (heap *MultiHeap) Minimum() (interface{}, float64) {
+	entry, key := heap.heap.Minimum()
+	if entry == nil {
+		return nil, key
+	}
+
+	return entry.(*multiEntry).tag, key
+}
+
+// MinimumValue returns the value of the current minimum entry in the heap.
+// An empty heap or an entry inserted by Insert will return nil.
+func (heap *MultiHeap) MinimumValue() Value {
+	entry, _ := heap.heap.Minimum()
+	if entry == nil {
+		return nil
+	}
+
+	return entry.(*multiEntry).value
+}
+
+// ExtractMin returns the tag and key of the current minimum entry in the heap and then extracts the entry.
+// An empty heap will return nil/-inf and extracts nothing.
+func (heap *MultiHeap) ExtractMin() (interface{}, float64) {
+	entry, key := heap.heap.ExtractMin()
+	if entry == nil {
+		return nil, key
+	}
+	heap.remove(entry.(*multiEntry))
+
+	return entry.(*multiEntry).tag, key
+}
+
+// ExtractMinValue returns the value of the current minimum entry in the heap and then extracts the entry.
+// An empty heap will return nil and extracts nothing.
+func (heap *MultiHeap) ExtractMinValue() Value {
+	entry, _ := heap.heap.ExtractMin()
+	if entry == nil {
+		return nil
+	}
+	heap.remove(entry.(*multiEntry))
+
+	return entry.(*multiEntry).value
+}
+
+// GetTag returns the smallest key of the entries of the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *MultiHeap) GetTag(tag interface{}) float64 {
+	tag = tagKey(tag)
+	if entries, exists := heap.tags[tag]; exists {
+		_, key := entries.Minimum()
+		return key
+	}
+
+	return math.Inf(-1)
+}
+
+// GetValue returns the value of the entry with the smallest key of the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *MultiHeap) GetValue(tag interface{}) Value {
+	tag = tagKey(tag)
+	if entries, exists := heap.tags[tag]; exists {
+		entry, _ := entries.Minimum()
+		return entry.(*multiEntry).value
+	}
+
+	return nil
+}
+
+// ExtractTag extracts the entry with the smallest key of the input tag and returns the key.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *MultiHeap) ExtractTag(tag interface{}) float64 {
+	tag = tagKey(tag)
+	entries, exists := heap.tags[tag]
+	if !exists {
+		return math.Inf(-1)
+	}
+
+	entry, key := entries.ExtractMin()
+	if entries.Num() == 0 {
+		delete(heap.tags, tag)
+	}
+	heap.heap.Delete(entry)
+
+	return key
+}
+
+// ExtractValue extracts the entry with the smallest key of the input tag and returns its value.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *MultiHeap) ExtractValue(tag interface{}) Value {
+	tag = tagKey(tag)
+	entries, exists := heap.tags[tag]
+	if !exists {
+		return nil
+	}
+
+	entry, _ := entries.Minimum()
+	heap.ExtractTag(tag)
+
+	return entry.(*multiEntry).value
+}
+
+// DeleteAll deletes all entries of the input tag and returns the number of deleted entries.
+func (heap *MultiHeap) DeleteAll(tag interface{}) uint {
+	tag = tagKey(tag)
+	entries, exists := heap.tags[tag]
+	if !exists {
+		return 0
+	}
+
+	for entry := range entries.index {
+		heap.heap.Delete(entry)
+	}
+	delete(heap.tags, tag)
+
+	return entries.Num()
+}
+
+// String provides some basic debug information of the heap.
+// It returns the total number of entries, the number of distinct tags and the current minimum entry.
+func (heap *MultiHeap) String() string {
+	var buffer bytes.Buffer
+
+	if heap.heap.Num() != 0 {
+		entry, key := heap.heap.Minimum()
+		buffer.WriteString(fmt.Sprintf("Total number: %d, Tag number: %d,\n", heap.heap.Num(), len(heap.tags)))
+		buffer.WriteString(fmt.Sprintf("Current minimun: key(%f), tag(%v), value(%v),\n", key, entry.(*multiEntry).tag, entry.(*multiEntry).value))
+	} else {
+		buffer.WriteString(fmt.Sprintf("Heap is empty.\n"))
+	}
+
+	return buffer.String()
+}
+
+func (heap *MultiHeap) insert(tag interface{}, key float64, value Value) error {
+	tag = tagKey(tag)
+	entry := &multiEntry{tag, value}
+	if err := heap.heap.insert(entry, key, nil); err != nil {
+		return err
+	}
+
+	entries, exists := heap.tags[tag]
+	if !exists {
+		entries = NewFibHeap()
+		heap.tags[tag] = entries
+	}
+	entries.insert(entry, key, nil)
+
+	return nil
+}
+
+// remove deletes the entry extracted from the main heap from the heap of its tag.
+func (heap *MultiHeap) remove(entry *multiEntry) {
+	entries := heap.tags[entry.tag]
+	entries.Delete(entry)
+	if entries.Num() == 0 {
+		delete(heap.tags, entry.tag)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+var _ = Describe("Tests of multiHeap", func() {
+	var (
+		heap *MultiHeap
+	)
+
+	BeforeEach(func() {
+		heap = NewMultiHeap()
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given an empty multiHeap, when call Minimum and ExtractMin api, it should return nil.", func() {
+		tag, key := heap.Minimum()
+		Expect(tag).Should(BeNil())
+		Expect(key).Should(Equal(math.Inf(-1)))
+		Expect(heap.MinimumValue()).Should(BeNil())
+		tag, _ = heap.ExtractMin()
+		Expect(tag).Should(BeNil())
+		Expect(heap.ExtractMinValue()).Should(BeNil())
+		Expect(heap.GetTag(1)).Should(Equal(math.Inf(-1)))
+		Expect(heap.ExtractValue(1)).Should(BeNil())
+		Expect(heap.DeleteAll(1)).Should(BeEquivalentTo(0))
+		Expect(heap.String()).Should(Equal("Heap is empty.\n"))
+	})
+
+	It("Given a multiHeap with duplicate tags, when call the apis by tag, it should operate on the smallest entry of the tag.", func() {
+		Expect(heap.Insert(nil, 1)).Should(HaveOccurred())
+		Expect(heap.Insert("a", math.Inf(-1))).Should(HaveOccurred())
+		Expect(heap.Insert("a", 5)).ShouldNot(HaveOccurred())
+		Expect(heap.InsertValue(&demoStruct{1, 3, "first"})).ShouldNot(HaveOccurred())
+		Expect(heap.InsertValue(&demoStruct{1, 1, "second"})).ShouldNot(HaveOccurred())
+		Expect(heap.Insert("a", 2)).ShouldNot(HaveOccurred())
+		Expect(heap.Insert("a", 2)).ShouldNot(HaveOccurred())
+		Expect(heap.Num()).Should(BeEquivalentTo(5))
+		Expect(heap.Count("a")).Should(BeEquivalentTo(3))
+
+		Expect(heap.GetTag("a")).Should(BeEquivalentTo(2))
+		Expect(heap.GetValue(1).(*demoStruct).value).Should(Equal("second"))
+		Expect(heap.ExtractValue(1).(*demoStruct).value).Should(Equal("second"))
+		Expect(heap.ExtractTag("a")).Should(BeEquivalentTo(2))
+		Expect(heap.Count("a")).Should(BeEquivalentTo(2))
+
+		tag, key := heap.ExtractMin()
+		Expect(tag).Should(Equal("a"))
+		Expect(key).Should(BeEquivalentTo(2))
+		Expect(heap.MinimumValue().(*demoStruct).value).Should(Equal("first"))
+		Expect(heap.ExtractMinValue().(*demoStruct).value).Should(Equal("first"))
+		Expect(heap.Count(1)).Should(BeEquivalentTo(0))
+
+		heap.Insert("a", 10)
+		Expect(heap.DeleteAll("a")).Should(BeEquivalentTo(2))
+		Expect(heap.Num()).Should(BeEquivalentTo(0))
+	})
+
+	It("Given a multiHeap under random operations, when extract all entries, it should return them in order.", func() {
+		random := rand.New(rand.NewSource(1))
+		keys := make(map[int][]float64)
+		for i := 0; i < 10000; i++ {
+			tag := random.Intn(50)
+			if random.Intn(3) != 0 {
+				key := float64(random.Intn(1000))
+				heap.Insert(tag, key)
+				keys[tag] = append(keys[tag], key)
+			} else if len(keys[tag]) != 0 {
+				sort.Float64s(keys[tag])
+				Expect(heap.ExtractTag(tag)).Should(Equal(keys[tag][0]))
+				keys[tag] = keys[tag][1:]
+			}
+		}
+
+		var expected []float64
+		for tag, list := range keys {
+			Expect(heap.Count(tag)).Should(BeEquivalentTo(len(list)))
+			expected = append(expected, list...)
+		}
+		sort.Float64s(expected)
+		Expect(heap.Num()).Should(BeEquivalentTo(len(expected)))
+		for _, key := range expected {
+			_, min := heap.ExtractMin()
+			Expect(min).Should(Equal(key))
+		}
+		Expect(heap.tags).Should(BeEmpty())
+	})
+})
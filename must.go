@@ -0,0 +1,55 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MustInsert is Insert which panics instead of returning an error, for the tests and initialization paths where an error is a bug.
+func (heap *FibHeap) MustInsert(tag interface{}, key float64) {
+	must("Insert", heap.Insert(tag, key))
+}
+
+// MustInsertValue is InsertValue which panics instead of returning an error.
+func (heap *FibHeap) MustInsertValue(value Value) {
+	must("InsertValue", heap.InsertValue(value))
+}
+
+// MustDecreaseKey is DecreaseKey which panics instead of returning an error.
+func (heap *FibHeap) MustDecreaseKey(tag interface{}, key float64) {
+	must("DecreaseKey", heap.DecreaseKey(tag, key))
+}
+
+// MustDecreaseKeyValue is DecreaseKeyValue which panics instead of returning an error.
+func (heap *FibHeap) MustDecreaseKeyValue(value Value) {
+	must("DecreaseKeyValue", heap.DecreaseKeyValue(value))
+}
+
+// MustIncreaseKey is IncreaseKey which panics instead of returning an error.
+func (heap *FibHeap) MustIncreaseKey(tag interface{}, key float64) {
+	must("IncreaseKey", heap.IncreaseKey(tag, key))
+}
+
+// MustIncreaseKeyValue is IncreaseKeyValue which panics instead of returning an error.
+func (heap *FibHeap) MustIncreaseKeyValue(value Value) {
+	must("IncreaseKeyValue", heap.IncreaseKeyValue(value))
+}
+
+// MustDelete is Delete which panics instead of returning an error.
+func (heap *FibHeap) MustDelete(tag interface{}) {
+	must("Delete", heap.Delete(tag))
+}
+
+// MustDeleteValue is DeleteValue which panics instead of returning an error.
+func (heap *FibHeap) MustDeleteValue(value Value) {
+	must("DeleteValue", heap.DeleteValue(value))
+}
+
+// must panics with the error returned by the method, if any.
+func must(method string, err error) {
+	if err != nil {
+		panic(fmt.Sprintf("fibHeap: %s failed: %s", method, strings.TrimSpace(err.Error())))
+	}
+}
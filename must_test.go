@@ -0,0 +1,47 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tests of Must apis", func() {
+	var heap *FibHeap
+
+	BeforeEach(func() {
+		heap = NewFibHeap()
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given a fibHeap, when call the Must apis with valid input, it should behave as the apis returning errors.", func() {
+		heap.MustInsert(1, 10)
+		heap.MustInsertValue(&demoStruct{2, 20, "2"})
+		heap.MustDecreaseKey(1, 5)
+		heap.MustDecreaseKeyValue(&demoStruct{2, 15, "2"})
+		heap.MustIncreaseKey(1, 30)
+		heap.MustIncreaseKeyValue(&demoStruct{2, 25, "2"})
+		Expect(heap.GetTag(1)).Should(BeEquivalentTo(30))
+		Expect(heap.GetTag(2)).Should(BeEquivalentTo(25))
+		heap.MustDelete(1)
+		heap.MustDeleteValue(&demoStruct{2, 25, "2"})
+		Expect(heap.Num()).Should(BeEquivalentTo(0))
+	})
+
+	It("Given a fibHeap, when call the Must apis with invalid input, it should panic with the error.", func() {
+		heap.MustInsert(1, 10)
+		Expect(func() { heap.MustInsert(1, 10) }).Should(PanicWith("fibHeap: Insert failed: Duplicate tag is not allowed"))
+		Expect(func() { heap.MustInsertValue(nil) }).Should(Panic())
+		Expect(func() { heap.MustDecreaseKey(1, 20) }).Should(Panic())
+		Expect(func() { heap.MustDecreaseKeyValue(&demoStruct{2, 1, ""}) }).Should(Panic())
+		Expect(func() { heap.MustIncreaseKey(1, 5) }).Should(Panic())
+		Expect(func() { heap.MustIncreaseKeyValue(&demoStruct{2, 1, ""}) }).Should(Panic())
+		Expect(func() { heap.MustDelete(2) }).Should(Panic())
+		Expect(func() { heap.MustDeleteValue(&demoStruct{2, 1, ""}) }).Should(Panic())
+		Expect(heap.GetTag(1)).Should(BeEquivalentTo(10))
+	})
+})
@@ -0,0 +1,265 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// Ordered is a key which orders itself, as an alternative to float64 keys for priorities which cannot be converted to float64 without loss,
+// e.g. big.Float, version numbers or composite structs.
+// Less must be a strict weak ordering, and it is only called with keys inserted into the same heap.
+type Ordered interface {
+	// Less reports whether the key must be extracted before the other key.
+	Less(other Ordered) bool
+}
+
+// OrderedHeap is a priority queue of tags with Ordered keys and the same tag interfaces as FibHeap.
+// It is a pairing heap as PairingHeap, since a pairing heap needs nothing but comparisons.
+// Please note that all methods of OrderedHeap are not concurrent safe.
+type OrderedHeap struct {
+	root  *orderedNode
+	index map[interface{}]*orderedNode
+	num   uint
+}
+
+type orderedNode struct {
+	child   *orderedNode
+	sibling *orderedNode
+	// prev points to the parent if the node is the leftmost child, otherwise to the previous sibling.
+	prev *orderedNode
+	tag  interface{}
+	key  Ordered
+}
+
+// NewOrderedHeap creates an initialized empty OrderedHeap.
+func NewOrderedHeap() *OrderedHeap {
+	heap := new(OrderedHeap)
+	heap.index = make(map[interface{}]*orderedNode)
+
+	return heap
+}
+
+// Num returns the total number of tags in the heap.
+func (heap *OrderedHeap) Num() uint {
+	return heap.num
+}
+
+// Insert pushes the input tag and key into the heap.
+// Try to insert a nil tag, a nil key or a duplicate tag will cause an error return.
+func (heap *OrderedHeap) Insert(tag interface{}, key Ordered) error {
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if key == nil {
+		return errors.New("Input key is nil ")
+	}
+
+	if _, exists := heap.index[tag]; exists {
+		return errors.New("Duplicate tag is not allowed ")
+	}
+
+	node := new(orderedNode)
+	node.tag = tag
+	node.key = key
+
+	heap.index[tag] = node
+	heap.num++
+	heap.root = heap.meld(heap.root, node)
+
+	return nil
+}
+
+// Minimum returns the current minimum tag and key in the heap.
+// An empty heap will return nil and nil.
+func (heap *OrderedHeap) Minimum() (interface{}, Ordered) {
+	if heap.num == 0 {
+		return nil, nil
+	}
+
+	return heap.root.tag, heap.root.key
+}
+
+// ExtractMin returns the current minimum tag and key in the heap and then extracts them from the heap.
+// An empty heap will return nil/nil and extracts nothing.
+func (heap *OrderedHeap) ExtractMin() (interface{}, Ordered) {
+	if heap.num == 0 {
+		return nil, nil
+	}
+
+	min := heap.root
+	heap.deleteNode(min)
+
+	return min.tag, min.key
+}
+
+// DecreaseKey updates the tag in the heap by the input key, which must be less than the current key.
+// If the input key is nil or not less than the current key, an error will be returned.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *OrderedHeap) DecreaseKey(tag interface{}, key Ordered) error {
+	if key == nil {
+		return errors.New("Input key is nil ")
+	}
+
+	node, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Value is not found ")
+	}
+
+	if !key.Less(node.key) {
+		return errors.New("New key is not smaller than current key ")
+	}
+
+	node.key = key
+	if node != heap.root {
+		heap.detach(node)
+		heap.root = heap.meld(heap.root, node)
+	}
+
+	return nil
+}
+
+// IncreaseKey updates the tag in the heap by the input key, which must be larger than the current key.
+// If the input key is nil or the current key is not less than it, an error will be returned.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *OrderedHeap) IncreaseKey(tag interface{}, key Ordered) error {
+	if key == nil {
+		return errors.New("Input key is nil ")
+	}
+
+	node, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Value is not found ")
+	}
+
+	if !node.key.Less(key) {
+		return errors.New("New key is not larger than current key ")
+	}
+
+	heap.deleteNode(node)
+	node.key = key
+	heap.index[tag] = node
+	heap.num++
+	heap.root = heap.meld(heap.root, node)
+
+	return nil
+}
+
+// Delete deletes the input tag in the heap.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *OrderedHeap) Delete(tag interface{}) error {
+	node, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Tag is not found ")
+	}
+
+	heap.deleteNode(node)
+
+	return nil
+}
+
+// GetTag searches and returns the key in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *OrderedHeap) GetTag(tag interface{}) Ordered {
+	if node, exists := heap.index[tag]; exists {
+		return node.key
+	}
+
+	return nil
+}
+
+// String provides some basic debug information of the heap.
+func (heap *OrderedHeap) String() string {
+	var buffer bytes.Buffer
+
+	if heap.num != 0 {
+		buffer.WriteString(fmt.Sprintf("Total number: %d, Index size: %d,\n", heap.num, len(heap.index)))
+		buffer.WriteString(fmt.Sprintf("Current minimun: key(%v), tag(%v),\n", heap.root.key, heap.root.tag))
+	} else {
+		buffer.WriteString(fmt.Sprintf("Heap is empty.\n"))
+	}
+
+	return buffer.String()
+}
+
+func (heap *OrderedHeap) deleteNode(n *orderedNode) {
+	children := n.child
+	n.child = nil
+	if n == heap.root {
+		heap.root = heap.combine(children)
+	} else {
+		heap.detach(n)
+		heap.root = heap.meld(heap.root, heap.combine(children))
+	}
+	delete(heap.index, n.tag)
+	heap.num--
+}
+
+// detach cuts the subtree rooted at the non-root node n out of the tree.
+func (heap *OrderedHeap) detach(n *orderedNode) {
+	if n.prev.child == n {
+		n.prev.child = n.sibling
+	} else {
+		n.prev.sibling = n.sibling
+	}
+	if n.sibling != nil {
+		n.sibling.prev = n.prev
+	}
+	n.prev = nil
+	n.sibling = nil
+}
+
+// meld links two detached trees and returns the new root.
+func (heap *OrderedHeap) meld(a, b *orderedNode) *orderedNode {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	if b.key.Less(a.key) {
+		a, b = b, a
+	}
+	b.prev = a
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+
+	return a
+}
+
+// combine melds a list of siblings into one tree by the standard two-pass pairing.
+func (heap *OrderedHeap) combine(first *orderedNode) *orderedNode {
+	if first == nil {
+		return nil
+	}
+
+	var pairs []*orderedNode
+	for first != nil {
+		a := first
+		b := a.sibling
+		if b == nil {
+			first = nil
+		} else {
+			first = b.sibling
+			b.prev = nil
+			b.sibling = nil
+		}
+		a.prev = nil
+		a.sibling = nil
+		pairs = append(pairs, heap.meld(a, b))
+	}
+
+	root := pairs[len(pairs)-1]
+	for i := len(pairs) - 2; i >= 0; i-- {
+		root = heap.meld(pairs[i], root)
+	}
+
+	return root
+}
@@ -0,0 +1,132 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math/big"
+	"math/rand"
+	"sort"
+)
+
+// version is a composite Ordered key.
+type version struct {
+	major, minor int
+}
+
+func (v version) Less(other Ordered) bool {
+	o := other.(version)
+	return v.major < o.major || (v.major == o.major && v.minor < o.minor)
+}
+
+// bigKey is an Ordered key with more precision than float64.
+type bigKey struct {
+	*big.Float
+}
+
+func (k bigKey) Less(other Ordered) bool {
+	return k.Cmp(other.(bigKey).Float) < 0
+}
+
+var _ = Describe("Tests of orderedHeap", func() {
+	var (
+		heap *OrderedHeap
+	)
+
+	BeforeEach(func() {
+		heap = NewOrderedHeap()
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given an empty orderedHeap, when call Minimum and ExtractMin api, it should return nil.", func() {
+		tag, key := heap.Minimum()
+		Expect(tag).Should(BeNil())
+		Expect(key).Should(BeNil())
+		tag, key = heap.ExtractMin()
+		Expect(tag).Should(BeNil())
+		Expect(key).Should(BeNil())
+		Expect(heap.String()).Should(Equal("Heap is empty.\n"))
+	})
+
+	It("Given an orderedHeap, when call the apis with invalid inputs, it should return error.", func() {
+		Expect(heap.Insert(nil, version{1, 0})).Should(HaveOccurred())
+		Expect(heap.Insert(1, nil)).Should(HaveOccurred())
+		Expect(heap.Insert(1, version{1, 0})).ShouldNot(HaveOccurred())
+		Expect(heap.Insert(1, version{2, 0})).Should(HaveOccurred())
+		Expect(heap.DecreaseKey(1, version{1, 0})).Should(HaveOccurred())
+		Expect(heap.DecreaseKey(2, version{0, 0})).Should(HaveOccurred())
+		Expect(heap.DecreaseKey(1, nil)).Should(HaveOccurred())
+		Expect(heap.IncreaseKey(1, version{0, 9})).Should(HaveOccurred())
+		Expect(heap.IncreaseKey(2, version{3, 0})).Should(HaveOccurred())
+		Expect(heap.Delete(2)).Should(HaveOccurred())
+		Expect(heap.GetTag(2)).Should(BeNil())
+		Expect(heap.Num()).Should(BeEquivalentTo(1))
+	})
+
+	It("Given an orderedHeap with composite keys, when extract all of them, it should return them in order.", func() {
+		versions := []version{{1, 10}, {1, 2}, {0, 99}, {2, 0}, {1, 9}}
+		for i, v := range versions {
+			heap.Insert(i, v)
+		}
+		Expect(heap.DecreaseKey(3, version{0, 1})).ShouldNot(HaveOccurred())
+		Expect(heap.IncreaseKey(2, version{1, 5})).ShouldNot(HaveOccurred())
+		Expect(heap.GetTag(2)).Should(Equal(version{1, 5}))
+
+		var tags []interface{}
+		for heap.Num() != 0 {
+			tag, _ := heap.ExtractMin()
+			tags = append(tags, tag)
+		}
+		Expect(tags).Should(Equal([]interface{}{3, 1, 2, 4, 0}))
+	})
+
+	It("Given an orderedHeap with keys beyond the float64 precision, when extract all of them, it should not reorder them.", func() {
+		base := new(big.Float).SetPrec(200).SetFloat64(1e20)
+		for i := 0; i < 100; i++ {
+			key := new(big.Float).SetPrec(200).Add(base, big.NewFloat(float64(99-i)))
+			heap.Insert(i, bigKey{key})
+		}
+
+		for i := 99; i >= 0; i-- {
+			tag, _ := heap.ExtractMin()
+			Expect(tag).Should(Equal(i))
+		}
+	})
+
+	It("Given an orderedHeap under random operations, when extract all of them, it should return them in order.", func() {
+		random := rand.New(rand.NewSource(1))
+		keys := make(map[int]int)
+		for i := 0; i < 10000; i++ {
+			tag := random.Intn(1000)
+			key := random.Intn(100000)
+			if _, exists := keys[tag]; !exists {
+				heap.Insert(tag, version{key, 0})
+				keys[tag] = key
+			} else if random.Intn(3) == 0 {
+				heap.Delete(tag)
+				delete(keys, tag)
+			} else if key < keys[tag] {
+				heap.DecreaseKey(tag, version{key, 0})
+				keys[tag] = key
+			} else if key > keys[tag] {
+				heap.IncreaseKey(tag, version{key, 0})
+				keys[tag] = key
+			}
+		}
+
+		var expected []int
+		for _, key := range keys {
+			expected = append(expected, key)
+		}
+		sort.Ints(expected)
+		Expect(heap.Num()).Should(BeEquivalentTo(len(expected)))
+		for _, key := range expected {
+			_, min := heap.ExtractMin()
+			Expect(min.(version).major).Should(Equal(key))
+		}
+	})
+})
@@ -0,0 +1,285 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"container/list"
+	"math"
+	"math/rand"
+)
+
+// maxSkipLevel is the maximum number of levels of the skip list of an orderedIndex, enough for 2^32 values.
+const maxSkipLevel = 32
+
+// orderedIndex is a skip list of all nodes of a FibHeap sorted by their keys, see SetOrderedIndex.
+// The entries of equal keys are sorted by the order of their puts, so every entry has a unique position.
+// Every link also keeps its span, i.e. the number of entries it skips plus 1, so the rank of a key is found in O(log n).
+type orderedIndex struct {
+	head    skipEntry
+	level   int
+	entries map[interface{}]*skipEntry
+	seq     uint64
+	random  *rand.Rand
+}
+
+type skipEntry struct {
+	n    *node
+	key  float64
+	seq  uint64
+	next []*skipEntry
+	span []int
+}
+
+// SetOrderedIndex turns on or off the ordered index of the heap, which keeps all values sorted by the key in a skip list,
+// so NextAbove and LargestBelow take O(log n) instead of scanning all values, e.g. for the schedulers bucketing the work by deadline bands.
+// The index is updated by every insert, extraction, deletion and key update in O(log n) expected.
+// Turning on the index builds it from the current values in O(n log n).
+func (heap *FibHeap) SetOrderedIndex(enabled bool) {
+	if debugMode {
+		defer heap.guard.enter("SetOrderedIndex")()
+	}
+
+	if !enabled {
+		heap.order = nil
+		return
+	}
+
+	if heap.order == nil {
+		heap.order = newOrderedIndex()
+		for _, n := range heap.index {
+			heap.order.put(n)
+		}
+	}
+}
+
+// NextAbove returns the tag and key of the smallest key in the heap which is larger than the input key, without extracting them.
+// The ok result is false if there is no such key. Without the ordered index, NextAbove scans all values in O(n).
+func (heap *FibHeap) NextAbove(key float64) (tag interface{}, next float64, ok bool) {
+	if debugMode {
+		defer heap.guard.enter("NextAbove")()
+	}
+
+	var found *node
+	if math.IsNaN(key) {
+		return nil, math.Inf(-1), false
+	} else if heap.order != nil {
+		found = heap.order.above(key - heap.offset)
+	} else {
+		for _, n := range heap.index {
+			if heap.keyOf(n) > key && (found == nil || n.key < found.key) {
+				found = n
+			}
+		}
+	}
+
+	if found == nil {
+		return nil, math.Inf(-1), false
+	}
+
+	return found.tag, heap.keyOf(found), true
+}
+
+// CountBelow returns the number of values in the heap whose keys are smaller than the input key, without extracting them,
+// e.g. to count the jobs due within the next minute.
+// With the ordered index, CountBelow takes O(log n). Without it, CountBelow only visits the nodes whose parents are below the key,
+// so it takes O(r + c log n) where r is the number of roots and c is the count, which is cheap as long as the count is small.
+// Each tag inserted more than once by InsertOrIncrement is counted once, the same as by Num.
+func (heap *FibHeap) CountBelow(key float64) int {
+	if debugMode {
+		defer heap.guard.enter("CountBelow")()
+	}
+
+	if math.IsNaN(key) {
+		return 0
+	} else if heap.order != nil {
+		return heap.order.rank(key - heap.offset)
+	}
+
+	return heap.countBelow(heap.roots, key-heap.offset)
+}
+
+// LargestBelow returns the tag and key of the largest key in the heap which is smaller than the input key, without extracting them.
+// The ok result is false if there is no such key. Without the ordered index, LargestBelow scans all values in O(n).
+func (heap *FibHeap) LargestBelow(key float64) (tag interface{}, previous float64, ok bool) {
+	if debugMode {
+		defer heap.guard.enter("LargestBelow")()
+	}
+
+	var found *node
+	if math.IsNaN(key) {
+		return nil, math.Inf(-1), false
+	} else if heap.order != nil {
+		found = heap.order.below(key - heap.offset)
+	} else {
+		for _, n := range heap.index {
+			if heap.keyOf(n) < key && (found == nil || n.key > found.key) {
+				found = n
+			}
+		}
+	}
+
+	if found == nil {
+		return nil, math.Inf(-1), false
+	}
+
+	return found.tag, heap.keyOf(found), true
+}
+
+// countBelow counts the live nodes of the trees whose keys are smaller than the input raw key, skipping the subtrees of larger keys.
+func (heap *FibHeap) countBelow(trees *list.List, key float64) int {
+	count := 0
+	for e := trees.Front(); e != nil; e = e.Next() {
+		n := e.Value.(*node)
+		if n.key >= key {
+			continue
+		}
+		if !n.dead {
+			count++
+		}
+		count += heap.countBelow(n.children, key)
+	}
+
+	return count
+}
+
+func newOrderedIndex() *orderedIndex {
+	order := new(orderedIndex)
+	order.head.next = make([]*skipEntry, maxSkipLevel)
+	order.head.span = make([]int, maxSkipLevel)
+	order.level = 1
+	order.entries = make(map[interface{}]*skipEntry)
+	order.random = rand.New(rand.NewSource(1))
+
+	return order
+}
+
+// put inserts the node, or moves it to its new key.
+func (order *orderedIndex) put(n *node) {
+	if entry, exists := order.entries[n.tag]; exists {
+		if entry.n == n && entry.key == n.key {
+			return
+		}
+		order.remove(n.tag)
+	}
+
+	level := 1
+	for level < maxSkipLevel && order.random.Int63()&3 == 0 {
+		level++
+	}
+	for ; order.level < level; order.level++ {
+		order.head.span[order.level] = len(order.entries)
+	}
+
+	order.seq++
+	entry := &skipEntry{n: n, key: n.key, seq: order.seq, next: make([]*skipEntry, level), span: make([]int, level)}
+	// previous keeps the predecessors of the new entry on every level, and ranks keeps their ranks, i.e. the numbers of entries up to and including them.
+	x, rank := &order.head, 0
+	var previous [maxSkipLevel]*skipEntry
+	var ranks [maxSkipLevel]int
+	for i := order.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].before(entry) {
+			rank += x.span[i]
+			x = x.next[i]
+		}
+		previous[i], ranks[i] = x, rank
+	}
+
+	for i := 0; i < order.level; i++ {
+		if i < level {
+			entry.next[i] = previous[i].next[i]
+			previous[i].next[i] = entry
+			entry.span[i] = previous[i].span[i] - (ranks[0] - ranks[i])
+			previous[i].span[i] = ranks[0] - ranks[i] + 1
+		} else {
+			previous[i].span[i]++
+		}
+	}
+	order.entries[n.tag] = entry
+}
+
+func (order *orderedIndex) remove(tag interface{}) {
+	entry, exists := order.entries[tag]
+	if !exists {
+		return
+	}
+	delete(order.entries, tag)
+
+	x := &order.head
+	for i := order.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].before(entry) {
+			x = x.next[i]
+		}
+		if x.next[i] == entry {
+			x.span[i] += entry.span[i] - 1
+			x.next[i] = entry.next[i]
+		} else {
+			x.span[i]--
+		}
+	}
+}
+
+func (order *orderedIndex) clear() {
+	*order = *newOrderedIndex()
+}
+
+// above returns the node of the smallest key larger than the input key, or nil.
+func (order *orderedIndex) above(key float64) *node {
+	x := &order.head
+	for i := order.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].key <= key {
+			x = x.next[i]
+		}
+	}
+
+	if x.next[0] == nil {
+		return nil
+	}
+
+	return x.next[0].n
+}
+
+// below returns the node of the largest key smaller than the input key, or nil.
+func (order *orderedIndex) below(key float64) *node {
+	x := &order.head
+	for i := order.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].key < key {
+			x = x.next[i]
+		}
+	}
+
+	if x == &order.head {
+		return nil
+	}
+
+	return x.n
+}
+
+// at returns the node of the input rank, counting from 0 for the smallest key. The rank must be smaller than the number of entries.
+func (order *orderedIndex) at(rank int) *node {
+	x, traversed := &order.head, 0
+	for i := order.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && traversed+x.span[i] <= rank+1 {
+			traversed += x.span[i]
+			x = x.next[i]
+		}
+	}
+
+	return x.n
+}
+
+// rank returns the number of entries whose keys are smaller than the input key.
+func (order *orderedIndex) rank(key float64) int {
+	x, rank := &order.head, 0
+	for i := order.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].key < key {
+			rank += x.span[i]
+			x = x.next[i]
+		}
+	}
+
+	return rank
+}
+
+func (entry *skipEntry) before(another *skipEntry) bool {
+	return entry.key < another.key || (entry.key == another.key && entry.seq < another.seq)
+}
@@ -0,0 +1,111 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"math/rand"
+)
+
+var _ = Describe("Tests of ordered index", func() {
+	var heap, reference *FibHeap
+
+	BeforeEach(func() {
+		heap = NewFibHeap()
+		heap.SetOrderedIndex(true)
+		reference = NewFibHeap()
+	})
+
+	AfterEach(func() {
+		heap = nil
+		reference = nil
+	})
+
+	It("Given a fibHeap with the ordered index, when call NextAbove and LargestBelow api, it should return the neighbouring keys.", func() {
+		for i := 0; i < 100; i++ {
+			heap.Insert(i, float64(i*10))
+		}
+		heap.AddToAllKeys(5)
+
+		tag, key, ok := heap.NextAbove(100)
+		Expect(ok).Should(BeTrue())
+		Expect(tag).Should(BeEquivalentTo(10))
+		Expect(key).Should(BeEquivalentTo(105))
+		tag, key, ok = heap.NextAbove(105)
+		Expect(ok).Should(BeTrue())
+		Expect(tag).Should(BeEquivalentTo(11))
+		Expect(key).Should(BeEquivalentTo(115))
+		_, _, ok = heap.NextAbove(995)
+		Expect(ok).Should(BeFalse())
+
+		tag, key, ok = heap.LargestBelow(100)
+		Expect(ok).Should(BeTrue())
+		Expect(tag).Should(BeEquivalentTo(9))
+		Expect(key).Should(BeEquivalentTo(95))
+		_, _, ok = heap.LargestBelow(5)
+		Expect(ok).Should(BeFalse())
+		_, _, ok = heap.LargestBelow(math.NaN())
+		Expect(ok).Should(BeFalse())
+
+		NewFibHeap().Union(heap)
+		_, _, ok = heap.NextAbove(math.Inf(-1))
+		Expect(ok).Should(BeFalse())
+		heap.SetOrderedIndex(false)
+		Expect(heap.order).Should(BeNil())
+	})
+
+	It("Given fibHeaps with and without the ordered index, when call CountBelow api, it should count the keys smaller than the input key.", func() {
+		for i := 0; i < 1000; i++ {
+			heap.Insert(i, float64(i%100))
+			reference.Insert(i, float64(i%100))
+		}
+		heap.ExtractMin()
+		reference.ExtractMin()
+		heap.AddToAllKeys(-50)
+		reference.AddToAllKeys(-50)
+
+		Expect(heap.CountBelow(0)).Should(Equal(499))
+		Expect(reference.CountBelow(0)).Should(Equal(499))
+		Expect(heap.CountBelow(-50)).Should(Equal(0))
+		Expect(reference.CountBelow(math.Inf(1))).Should(Equal(999))
+		Expect(heap.CountBelow(math.Inf(1))).Should(Equal(999))
+		Expect(heap.CountBelow(math.NaN())).Should(Equal(0))
+	})
+
+	It("Given a fibHeap with the ordered index under random operations, it should answer the same as a fibHeap without it.", func() {
+		heap.SetLazyDelete(true)
+		random := rand.New(rand.NewSource(1890))
+		for i := 0; i < 20000; i++ {
+			tag := random.Intn(500)
+			// The keys are unique so that both heaps extract the same tags.
+			key := float64(random.Intn(1000)*500 + tag)
+			switch random.Intn(9) {
+			case 0, 1:
+				Expect(heap.Insert(tag, key) == nil).Should(Equal(reference.Insert(tag, key) == nil))
+			case 2:
+				Expect(heap.Delete(tag) == nil).Should(Equal(reference.Delete(tag) == nil))
+			case 3:
+				Expect(heap.DecreaseKey(tag, key) == nil).Should(Equal(reference.DecreaseKey(tag, key) == nil))
+			case 4:
+				Expect(heap.IncreaseKey(tag, key) == nil).Should(Equal(reference.IncreaseKey(tag, key) == nil))
+			case 5:
+				min, minKey := heap.ExtractMin()
+				referenceMin, referenceKey := reference.ExtractMin()
+				Expect([]interface{}{min, minKey}).Should(Equal([]interface{}{referenceMin, referenceKey}))
+			case 6:
+				tag, next, ok := heap.NextAbove(key)
+				referenceTag, referenceNext, referenceOk := reference.NextAbove(key)
+				Expect([]interface{}{tag, next, ok}).Should(Equal([]interface{}{referenceTag, referenceNext, referenceOk}))
+			case 7:
+				tag, previous, ok := heap.LargestBelow(key)
+				referenceTag, referencePrevious, referenceOk := reference.LargestBelow(key)
+				Expect([]interface{}{tag, previous, ok}).Should(Equal([]interface{}{referenceTag, referencePrevious, referenceOk}))
+			case 8:
+				Expect(heap.CountBelow(key)).Should(Equal(reference.CountBelow(key)))
+			}
+			Expect(len(heap.order.entries)).Should(BeEquivalentTo(heap.Num()))
+		}
+	})
+})
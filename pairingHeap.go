@@ -0,0 +1,491 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// PairingHeap represents a Pairing Heap.
+// PairingHeap provides exactly the same methods as FibHeap so the two implementations can be swapped by changing the constructor only.
+// Pairing heaps have worse theoretical bounds than Fibonacci heaps on DecreaseKey but usually better constants on real workloads.
+// Please note that all methods of PairingHeap are not concurrent safe.
+type PairingHeap struct {
+	root  *pairingNode
+	index map[interface{}]*pairingNode
+	num   uint
+}
+
+type pairingNode struct {
+	child   *pairingNode
+	sibling *pairingNode
+	// prev points to the parent if the node is the leftmost child, otherwise to the previous sibling.
+	prev  *pairingNode
+	tag   interface{}
+	key   float64
+	value Value
+}
+
+// NewPairingHeap creates an initialized Pairing Heap.
+func NewPairingHeap() *PairingHeap {
+	heap := new(PairingHeap)
+	heap.index = make(map[interface{}]*pairingNode)
+	heap.num = 0
+	heap.root = nil
+
+	return heap
+}
+
+// Num returns the total number of values in the heap.
+func (heap *PairingHeap) Num() uint {
+	return heap.num
+}
+
+// Insert pushes the input tag and key into the heap.
+// Try to insert a duplicate tag value will cause an error return.
+// The valid range of the key is (-inf, +inf].
+// Try to insert a -inf key value will cause an error return.
+func (heap *PairingHeap) Insert(tag interface{}, key float64) error {
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	return heap.insert(tag, key, nil)
+}
+
+// InsertValue pushes the input value into the heap.
+// The input value must implements the Value interface.
+// Try to insert a duplicate tag value will cause an error return.
+// The valid range of the value's key is (-inf, +inf].
+// Try to insert a -inf key value will cause an error return.
+func (heap *PairingHeap) InsertValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	return heap.insert(value.Tag(), value.Key(), value)
+}
+
+// Minimum returns the current minimum tag and key in the heap sorted by the key.
+// An empty heap will return nil and -inf.
+func (heap *PairingHeap) Minimum() (interface{}, float64) {
+	if heap.num == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	return heap.root.tag, heap.root.key
+}
+
+// MinimumValue returns the current minimum value in the heap sorted by the key.
+// An empty heap will return nil.
+func (heap *PairingHeap) MinimumValue() Value {
+	if heap.num == 0 {
+		return nil
+	}
+
+	return heap.root.value
+}
+
+// ExtractMin returns the current minimum tag and key in the heap and then extracts them from the heap.
+// An empty heap will return nil/-inf and extracts nothing.
+func (heap *PairingHeap) ExtractMin() (interface{}, float64) {
+	if heap.num == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	min := heap.extractMin()
+
+	return min.tag, min.key
+}
+
+// ExtractMinValue returns the current minimum value in the heap and then extracts it from the heap.
+// An empty heap will return nil and extracts nothing.
+func (heap *PairingHeap) ExtractMinValue() Value {
+	if heap.num == 0 {
+		return nil
+	}
+
+	min := heap.extractMin()
+
+	return min.value
+}
+
+// Union moves all values of the input heap into the heap.
+// The input heap can be any implementation of PriorityQueue, and it is emptied afterwards so that no value is reachable from both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned and both heaps are left untouched.
+func (heap *PairingHeap) Union(anotherHeap PriorityQueue) error {
+	if err := heap.UnionInto(anotherHeap); err != nil {
+		return err
+	}
+
+	anotherHeap.reset()
+
+	return nil
+}
+
+// UnionInto merges copies of all values of the input heap into the heap.
+// The input heap can be any implementation of PriorityQueue, and it is left untouched, so the values are shared by both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned.
+func (heap *PairingHeap) UnionInto(anotherHeap PriorityQueue) error {
+	if err := checkUnion(heap, anotherHeap); err != nil {
+		return err
+	}
+
+	anotherHeap.each(func(tag interface{}, key float64, value Value) {
+		heap.insert(tag, key, value)
+	})
+
+	return nil
+}
+
+// DecreaseKey updates the tag in the heap by the input key.
+// If the input key has a larger key or -inf key, an error will be returned.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *PairingHeap) DecreaseKey(tag interface{}, key float64) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tag]; exists {
+		return heap.decreaseKey(node, node.value, key)
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// DecreaseKeyValue updates the value in the heap by the input value.
+// If the input value has a larger key or -inf key, an error will be returned.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *PairingHeap) DecreaseKeyValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	if math.IsInf(value.Key(), -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tagKey(value.Tag())]; exists {
+		return heap.decreaseKey(node, value, value.Key())
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// IncreaseKey updates the tag in the heap by the input key.
+// If the input key has a smaller key or -inf key, an error will be returned.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *PairingHeap) IncreaseKey(tag interface{}, key float64) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tag]; exists {
+		return heap.increaseKey(node, node.value, key)
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// IncreaseKeyValue updates the value in the heap by the input value.
+// If the input value has a smaller key or -inf key, an error will be returned.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *PairingHeap) IncreaseKeyValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	if math.IsInf(value.Key(), -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tagKey(value.Tag())]; exists {
+		return heap.increaseKey(node, value, value.Key())
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// Delete deletes the input tag in the heap.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *PairingHeap) Delete(tag interface{}) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	node, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Tag is not found ")
+	}
+
+	heap.deleteNode(node)
+
+	return nil
+}
+
+// DeleteValue deletes the value in the heap by the input value.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *PairingHeap) DeleteValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	node, exists := heap.index[tagKey(value.Tag())]
+	if !exists {
+		return errors.New("Value is not found ")
+	}
+
+	heap.deleteNode(node)
+
+	return nil
+}
+
+// GetTag searches and returns the key in the heap by the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *PairingHeap) GetTag(tag interface{}) (key float64) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		return node.key
+	}
+
+	return math.Inf(-1)
+}
+
+// GetValue searches and returns the value in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *PairingHeap) GetValue(tag interface{}) (value Value) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		value = node.value
+	}
+
+	return
+}
+
+// ExtractTag searches and extracts the tag/key in the heap by the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *PairingHeap) ExtractTag(tag interface{}) (key float64) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		key = node.key
+		heap.deleteNode(node)
+		return
+	}
+
+	return math.Inf(-1)
+}
+
+// ExtractValue searches and extracts the value in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *PairingHeap) ExtractValue(tag interface{}) (value Value) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		value = node.value
+		heap.deleteNode(node)
+		return
+	}
+
+	return nil
+}
+
+// String provides some basic debug information of the heap.
+// It returns the total number, index size and current minimum value of the heap.
+// It also returns the topology of the tree by dfs search.
+func (heap *PairingHeap) String() string {
+	var buffer bytes.Buffer
+
+	if heap.num != 0 {
+		buffer.WriteString(fmt.Sprintf("Total number: %d, Index size: %d,\n", heap.num, len(heap.index)))
+		buffer.WriteString(fmt.Sprintf("Current minimun: key(%f), tag(%v), value(%v),\n", heap.root.key, heap.root.tag, heap.root.value))
+		buffer.WriteString(fmt.Sprintf("Heap detail:\n"))
+		probePairingTree(&buffer, heap.root)
+		buffer.WriteString(fmt.Sprintf("\n"))
+	} else {
+		buffer.WriteString(fmt.Sprintf("Heap is empty.\n"))
+	}
+
+	return buffer.String()
+}
+
+func probePairingTree(buffer *bytes.Buffer, tree *pairingNode) {
+	buffer.WriteString(fmt.Sprintf("< "))
+	for n := tree; n != nil; n = n.sibling {
+		buffer.WriteString(fmt.Sprintf("%f ", n.key))
+		if n.child != nil {
+			probePairingTree(buffer, n.child)
+		}
+	}
+	buffer.WriteString(fmt.Sprintf("> "))
+}
+
+func (heap *PairingHeap) reset() {
+	*heap = *NewPairingHeap()
+}
+
+func (heap *PairingHeap) each(fn func(tag interface{}, key float64, value Value)) {
+	for _, node := range heap.index {
+		fn(node.tag, node.key, node.value)
+	}
+}
+
+func (heap *PairingHeap) insert(tag interface{}, key float64, value Value) error {
+	tag = tagKey(tag)
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if _, exists := heap.index[tag]; exists {
+		return errors.New("Duplicate tag is not allowed ")
+	}
+
+	node := new(pairingNode)
+	node.tag = tag
+	node.key = key
+	node.value = value
+
+	heap.index[node.tag] = node
+	heap.num++
+	heap.root = heap.meld(heap.root, node)
+
+	return nil
+}
+
+func (heap *PairingHeap) extractMin() *pairingNode {
+	min := heap.root
+
+	heap.root = heap.combine(min.child)
+	delete(heap.index, min.tag)
+	heap.num--
+	min.child = nil
+
+	return min
+}
+
+func (heap *PairingHeap) deleteNode(n *pairingNode) {
+	if n == heap.root {
+		heap.extractMin()
+		return
+	}
+
+	heap.detach(n)
+	heap.root = heap.meld(heap.root, heap.combine(n.child))
+	delete(heap.index, n.tag)
+	heap.num--
+	n.child = nil
+}
+
+func (heap *PairingHeap) decreaseKey(n *pairingNode, value Value, key float64) error {
+	if key >= n.key {
+		return errors.New("New key is not smaller than current key ")
+	}
+
+	n.key = key
+	n.value = value
+	if n != heap.root {
+		heap.detach(n)
+		heap.root = heap.meld(heap.root, n)
+	}
+
+	return nil
+}
+
+func (heap *PairingHeap) increaseKey(n *pairingNode, value Value, key float64) error {
+	if key <= n.key {
+		return errors.New("New key is not larger than current key ")
+	}
+
+	n.key = key
+	n.value = value
+
+	children := heap.combine(n.child)
+	n.child = nil
+	if n == heap.root {
+		heap.root = children
+	} else {
+		heap.detach(n)
+		heap.root = heap.meld(heap.root, children)
+	}
+	heap.root = heap.meld(heap.root, n)
+
+	return nil
+}
+
+// detach cuts the subtree rooted at the non-root node n out of the tree.
+func (heap *PairingHeap) detach(n *pairingNode) {
+	if n.prev.child == n {
+		n.prev.child = n.sibling
+	} else {
+		n.prev.sibling = n.sibling
+	}
+	if n.sibling != nil {
+		n.sibling.prev = n.prev
+	}
+	n.prev = nil
+	n.sibling = nil
+}
+
+// meld links two detached trees and returns the new root.
+func (heap *PairingHeap) meld(a, b *pairingNode) *pairingNode {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	if b.key < a.key {
+		a, b = b, a
+	}
+	b.prev = a
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+
+	return a
+}
+
+// combine melds a list of siblings into one tree by the standard two-pass pairing.
+func (heap *PairingHeap) combine(first *pairingNode) *pairingNode {
+	if first == nil {
+		return nil
+	}
+
+	var pairs []*pairingNode
+	for first != nil {
+		a := first
+		b := a.sibling
+		if b == nil {
+			first = nil
+		} else {
+			first = b.sibling
+			b.prev = nil
+			b.sibling = nil
+		}
+		a.prev = nil
+		a.sibling = nil
+		pairs = append(pairs, heap.meld(a, b))
+	}
+
+	root := pairs[len(pairs)-1]
+	for i := len(pairs) - 2; i >= 0; i-- {
+		root = heap.meld(pairs[i], root)
+	}
+
+	return root
+}
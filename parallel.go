@@ -0,0 +1,106 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// minParallelBuild is the minimum number of values per goroutine of a parallel build, below which a goroutine costs more than it saves.
+const minParallelBuild = 4096
+
+// NewFibHeapParallel creates a heap of all input values, for the initial loads of many values.
+// The values are partitioned across GOMAXPROCS goroutines, each building a sub-heap, and the sub-heaps are then merged pairwise in parallel
+// by moving their trees, in the way Union does.
+// A nil value, a -inf key or a duplicate tag will cause an error return, and no heap will be created in that case.
+func NewFibHeapParallel(values []Value) (*FibHeap, error) {
+	return buildParallel(len(values), false, func(part *FibHeap, from, to int) error {
+		for _, value := range values[from:to] {
+			if isNilValue(value) {
+				return ErrNilValue
+			}
+			if err := part.insert(value.Tag(), value.Key(), value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ImportParallel is Import which decodes and pushes the entries across GOMAXPROCS goroutines, for the restores of many entries.
+// The invalid entries cause the same errors as Import, and no entry will be imported in that case.
+// The hooks and the watchers of the heap are called from the calling goroutine only, after all entries are decoded.
+func (heap *FibHeap) ImportParallel(entries []Entry) error {
+	if debugMode {
+		defer heap.guard.enter("ImportParallel")()
+	}
+
+	built, err := buildParallel(len(entries), heap.sequenced, func(part *FibHeap, from, to int) error {
+		return part.importEntries(context.Background(), entries[from:to])
+	})
+	if err != nil {
+		return err
+	}
+
+	return heap.merge(context.Background(), built)
+}
+
+// buildParallel builds a sub-heap of every partition of the n inputs in its own goroutine, and merges the sub-heaps pairwise in parallel.
+// The first error of any goroutine is returned.
+func buildParallel(n int, sequenced bool, build func(part *FibHeap, from, to int) error) (*FibHeap, error) {
+	workers := runtime.GOMAXPROCS(0)
+	if most := n / minParallelBuild; workers > most {
+		workers = most
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	parts := make([]*FibHeap, workers)
+	err := parallel(workers, func(i int) error {
+		parts[i] = NewFibHeap()
+		parts[i].sequenced = sequenced
+		return build(parts[i], n*i/workers, n*(i+1)/workers)
+	})
+
+	for err == nil && len(parts) > 1 {
+		pairs := parts
+		err = parallel(len(pairs)/2, func(i int) error {
+			return pairs[2*i].merge(context.Background(), pairs[2*i+1])
+		})
+		parts = make([]*FibHeap, (len(pairs)+1)/2)
+		for i := range parts {
+			parts[i] = pairs[2*i]
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return parts[0], nil
+}
+
+// parallel calls fn with 0 to n-1, each in its own goroutine, and returns the error of the lowest input failing.
+func parallel(n int, fn func(i int) error) error {
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = fn(i)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"math/rand"
+)
+
+var _ = Describe("Tests of parallel build", func() {
+	var values []Value
+
+	BeforeEach(func() {
+		random := rand.New(rand.NewSource(1908))
+		values = nil
+		for i := 0; i < 5*minParallelBuild+7; i++ {
+			values = append(values, &demoStruct{i, float64(random.Intn(1000)*100000 + i), ""})
+		}
+	})
+
+	It("Given many values, when call NewFibHeapParallel api, it should build the same heap as inserting them one by one.", func() {
+		reference := NewFibHeap()
+		for _, value := range values {
+			reference.InsertValue(value)
+		}
+
+		heap, err := NewFibHeapParallel(values)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(heap.Num()).Should(BeEquivalentTo(len(values)))
+		Expect(heap.Equal(reference)).Should(BeTrue())
+		Expect(heap.Validate(false)).ShouldNot(HaveOccurred())
+		for reference.Num() != 0 {
+			Expect(heap.ExtractMinValue()).Should(Equal(reference.ExtractMinValue()))
+		}
+
+		heap, err = NewFibHeapParallel(nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(heap.Num()).Should(BeEquivalentTo(0))
+	})
+
+	It("Given invalid values in any partition, when call NewFibHeapParallel api, it should return error.", func() {
+		values[len(values)-1] = &demoStruct{0, 1, ""}
+		_, err := NewFibHeapParallel(values)
+		Expect(err).Should(HaveOccurred())
+
+		values[len(values)-1] = nil
+		_, err = NewFibHeapParallel(values)
+		Expect(err).Should(Equal(ErrNilValue))
+
+		values[len(values)-1] = &demoStruct{-1, math.Inf(-1), ""}
+		_, err = NewFibHeapParallel(values)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("Given exported entries, when call ImportParallel api, it should import them all or none of them.", func() {
+		source := NewFibHeap()
+		for i := 0; i < 3*minParallelBuild; i++ {
+			source.InsertValue(&payload{i, float64(i), "text"})
+		}
+		source.Insert("tag", 0.5)
+		entries, err := source.Export()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		var inserted int
+		heap := NewFibHeapWithHooks(Hooks{OnInsert: func(tag interface{}, key float64, value Value) { inserted++ }})
+		heap.Insert("tag", 1)
+		Expect(heap.ImportParallel(entries)).Should(HaveOccurred())
+		Expect(heap.Num()).Should(BeEquivalentTo(1))
+
+		heap.Delete("tag")
+		inserted = 0
+		Expect(heap.ImportParallel(entries)).ShouldNot(HaveOccurred())
+		Expect(inserted).Should(Equal(len(entries)))
+		Expect(heap.Equal(source)).Should(BeTrue())
+		Expect(heap.GetValue(7)).Should(Equal(&payload{7, 7, "text"}))
+
+		entries[0].Type = "unregistered"
+		Expect(NewFibHeap().ImportParallel(entries)).Should(HaveOccurred())
+	})
+})
@@ -0,0 +1,190 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// PersistentHeap represents an immutable meldable heap implemented as a persistent leftist heap.
+// Insert, ExtractMin and Union never modify the heap they are called on. They return a new heap instead,
+// which shares all untouched nodes with the old one by copying only the O(log n) nodes on the merge path.
+// Every version is a cheap snapshot, so PersistentHeap suits functional-style code,
+// and any version can be read by multiple goroutines at the same time without locks.
+// As keeping a tag index would defeat the structure sharing, PersistentHeap does not support DecreaseKey, IncreaseKey or Delete by tag,
+// and duplicate tags are not checked.
+type PersistentHeap struct {
+	root *persistentNode
+	num  uint
+}
+
+type persistentNode struct {
+	left  *persistentNode
+	right *persistentNode
+	rank  int
+	tag   interface{}
+	key   float64
+	value Value
+}
+
+// NewPersistentHeap creates an empty Persistent Heap.
+func NewPersistentHeap() *PersistentHeap {
+	return new(PersistentHeap)
+}
+
+// Num returns the total number of values in the heap.
+func (heap *PersistentHeap) Num() uint {
+	return heap.num
+}
+
+// Insert returns a new heap containing all values of the heap plus the input tag and key.
+// The valid range of the key is (-inf, +inf].
+// Try to insert a -inf key value will cause an error return and a nil heap.
+func (heap *PersistentHeap) Insert(tag interface{}, key float64) (*PersistentHeap, error) {
+	if tag == nil {
+		return nil, errors.New("Input tag is nil ")
+	}
+
+	return heap.insert(tag, key, nil)
+}
+
+// InsertValue returns a new heap containing all values of the heap plus the input value.
+// The input value must implements the Value interface.
+// The valid range of the value's key is (-inf, +inf].
+// Try to insert a -inf key value will cause an error return and a nil heap.
+func (heap *PersistentHeap) InsertValue(value Value) (*PersistentHeap, error) {
+	if isNilValue(value) {
+		return nil, ErrNilValue
+	}
+
+	return heap.insert(value.Tag(), value.Key(), value)
+}
+
+// Minimum returns the current minimum tag and key in the heap sorted by the key.
+// An empty heap will return nil and -inf.
+func (heap *PersistentHeap) Minimum() (interface{}, float64) {
+	if heap.root == nil {
+		return nil, math.Inf(-1)
+	}
+
+	return heap.root.tag, heap.root.key
+}
+
+// MinimumValue returns the current minimum value in the heap sorted by the key.
+// An empty heap will return nil.
+func (heap *PersistentHeap) MinimumValue() Value {
+	if heap.root == nil {
+		return nil
+	}
+
+	return heap.root.value
+}
+
+// ExtractMin returns the current minimum tag and key in the heap and a new heap without them.
+// The heap itself is left untouched.
+// An empty heap will return nil/-inf and itself.
+func (heap *PersistentHeap) ExtractMin() (interface{}, float64, *PersistentHeap) {
+	if heap.root == nil {
+		return nil, math.Inf(-1), heap
+	}
+
+	return heap.root.tag, heap.root.key, heap.extractMin()
+}
+
+// ExtractMinValue returns the current minimum value in the heap and a new heap without it.
+// The heap itself is left untouched.
+// An empty heap will return nil and itself.
+func (heap *PersistentHeap) ExtractMinValue() (Value, *PersistentHeap) {
+	if heap.root == nil {
+		return nil, heap
+	}
+
+	return heap.root.value, heap.extractMin()
+}
+
+// Union returns a new heap containing all values of both heaps in O(log n).
+// Both input heaps are left untouched.
+func (heap *PersistentHeap) Union(anotherHeap *PersistentHeap) *PersistentHeap {
+	return &PersistentHeap{
+		root: meldPersistent(heap.root, anotherHeap.root),
+		num:  heap.num + anotherHeap.num,
+	}
+}
+
+// String provides some basic debug information of the heap.
+// It returns the total number and current minimum value of the heap.
+// It also returns the topology of the tree by dfs search.
+func (heap *PersistentHeap) String() string {
+	var buffer bytes.Buffer
+
+	if heap.root != nil {
+		buffer.WriteString(fmt.Sprintf("Total number: %d,\n", heap.num))
+		buffer.WriteString(fmt.Sprintf("Current minimun: key(%f), tag(%v), value(%v),\n", heap.root.key, heap.root.tag, heap.root.value))
+		buffer.WriteString(fmt.Sprintf("Heap detail:\n"))
+		probePersistentTree(&buffer, heap.root)
+		buffer.WriteString(fmt.Sprintf("\n"))
+	} else {
+		buffer.WriteString(fmt.Sprintf("Heap is empty.\n"))
+	}
+
+	return buffer.String()
+}
+
+func probePersistentTree(buffer *bytes.Buffer, n *persistentNode) {
+	buffer.WriteString(fmt.Sprintf("< %f ", n.key))
+	if n.left != nil {
+		probePersistentTree(buffer, n.left)
+	}
+	if n.right != nil {
+		probePersistentTree(buffer, n.right)
+	}
+	buffer.WriteString(fmt.Sprintf("> "))
+}
+
+func (heap *PersistentHeap) insert(tag interface{}, key float64, value Value) (*PersistentHeap, error) {
+	if math.IsInf(key, -1) {
+		return nil, errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	node := &persistentNode{rank: 1, tag: tag, key: key, value: value}
+
+	return &PersistentHeap{root: meldPersistent(heap.root, node), num: heap.num + 1}, nil
+}
+
+func (heap *PersistentHeap) extractMin() *PersistentHeap {
+	return &PersistentHeap{root: meldPersistent(heap.root.left, heap.root.right), num: heap.num - 1}
+}
+
+func rankOfPersistent(n *persistentNode) int {
+	if n == nil {
+		return 0
+	}
+
+	return n.rank
+}
+
+// meldPersistent melds two leftist trees along their right spines.
+// The nodes on the merge path are copied so that both input trees are left untouched.
+func meldPersistent(a, b *persistentNode) *persistentNode {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if b.key < a.key {
+		a, b = b, a
+	}
+
+	copied := *a
+	copied.right = meldPersistent(a.right, b)
+	if rankOfPersistent(copied.left) < rankOfPersistent(copied.right) {
+		copied.left, copied.right = copied.right, copied.left
+	}
+	copied.rank = rankOfPersistent(copied.right) + 1
+
+	return &copied
+}
@@ -0,0 +1,148 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"fmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+var _ = Describe("Tests of persistentHeap", func() {
+	var (
+		heap *PersistentHeap
+	)
+
+	BeforeEach(func() {
+		heap = NewPersistentHeap()
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given an empty persistentHeap, when call Minimum and ExtractMin api, it should return nil.", func() {
+		tag, key := heap.Minimum()
+		Expect(tag).Should(BeNil())
+		Expect(key).Should(BeEquivalentTo(math.Inf(-1)))
+		value, next := heap.ExtractMinValue()
+		Expect(value).Should(BeNil())
+		Expect(next).Should(BeIdenticalTo(heap))
+		Expect(heap.String()).Should(BeEquivalentTo("Heap is empty.\n"))
+	})
+
+	It("Given an empty persistentHeap, when call Insert api with invalid inputs, it should return error.", func() {
+		next, err := heap.Insert(nil, 0.0)
+		Expect(err).Should(HaveOccurred())
+		Expect(next).Should(BeNil())
+		_, err = heap.InsertValue(nil)
+		Expect(err).Should(HaveOccurred())
+		_, err = heap.Insert(1000, math.Inf(-1))
+		Expect(err).Should(HaveOccurred())
+		Expect(heap.Num()).Should(BeEquivalentTo(0))
+	})
+
+	It("Given a persistentHeap inserted multiple values, when call ExtractMinValue api, it should extract the values sorted by key.", func() {
+		rand.Seed(time.Now().Unix())
+		for i := 0; i < 10000; i++ {
+			demo := new(demoStruct)
+			demo.tag = i
+			demo.key = rand.Float64()
+			demo.value = fmt.Sprint(demo.key)
+			heap, _ = heap.InsertValue(demo)
+		}
+
+		lastKey := heap.MinimumValue().(*demoStruct).key
+		for i := 0; i < 10000; i++ {
+			var value Value
+			value, heap = heap.ExtractMinValue()
+			extracted := value.(*demoStruct)
+			Expect(extracted.key).Should(BeNumerically(">=", lastKey))
+			Expect(extracted.value).Should(Equal(fmt.Sprint(extracted.key)))
+			Expect(heap.Num()).Should(BeEquivalentTo(9999 - i))
+			lastKey = extracted.key
+		}
+	})
+
+	It("Given a persistentHeap, when call Insert and ExtractMin api, it should leave every older version untouched.", func() {
+		versions := []*PersistentHeap{heap}
+		for i := 0; i < 100; i++ {
+			next, err := versions[i].Insert(i, float64(100-i))
+			Expect(err).ShouldNot(HaveOccurred())
+			versions = append(versions, next)
+		}
+
+		for i := 0; i < 50; i++ {
+			_, _, next := versions[100].ExtractMin()
+			Expect(next.Num()).Should(BeEquivalentTo(99))
+		}
+
+		for i, version := range versions {
+			Expect(version.Num()).Should(BeEquivalentTo(i))
+			keys := make([]float64, 0, i)
+			for version.Num() > 0 {
+				var key float64
+				_, key, version = version.ExtractMin()
+				keys = append(keys, key)
+			}
+			Expect(sort.Float64sAreSorted(keys)).Should(BeTrue())
+			if i > 0 {
+				Expect(keys[0]).Should(BeEquivalentTo(101 - i))
+			}
+		}
+	})
+
+	It("Given two persistentHeaps, when call Union api, it should return a new heap with all values of both heaps.", func() {
+		anotherHeap := NewPersistentHeap()
+		for i := 0; i < 100; i++ {
+			heap, _ = heap.Insert(i, float64(2*i))
+			anotherHeap, _ = anotherHeap.Insert(i+100, float64(2*i+1))
+		}
+
+		union := heap.Union(anotherHeap)
+		Expect(union.Num()).Should(BeEquivalentTo(200))
+		Expect(heap.Num()).Should(BeEquivalentTo(100))
+		Expect(anotherHeap.Num()).Should(BeEquivalentTo(100))
+		for i := 0; i < 200; i++ {
+			var key float64
+			_, key, union = union.ExtractMin()
+			Expect(key).Should(BeEquivalentTo(i))
+		}
+		_, key := heap.Minimum()
+		Expect(key).Should(BeEquivalentTo(0))
+		_, key = anotherHeap.Minimum()
+		Expect(key).Should(BeEquivalentTo(1))
+	})
+
+	It("Given a persistentHeap shared by multiple goroutines, when they extract from it at the same time, it should return the same result to all of them.", func() {
+		for i := 0; i < 1000; i++ {
+			heap, _ = heap.Insert(i, float64(i))
+		}
+
+		var wg sync.WaitGroup
+		results := make([]float64, 8)
+		for g := range results {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				local := heap
+				for local.Num() > 0 {
+					var key float64
+					_, key, local = local.ExtractMin()
+					results[g] += key
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		for _, result := range results {
+			Expect(result).Should(BeEquivalentTo(999 * 1000 / 2))
+		}
+		Expect(heap.Num()).Should(BeEquivalentTo(1000))
+	})
+})
@@ -0,0 +1,154 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"errors"
+	"math"
+)
+
+// PriorityCache is a fixed capacity cache which evicts the entry with the minimum priority when the capacity is exceeded.
+// The priorities are kept in a FibHeap with the keys of the cache as tags, so updating a priority is a DecreaseKey or IncreaseKey.
+// It suits cost-aware caches like GDSF(Greedy Dual Size Frequency), in which the priority of an entry is computed as
+// Clock() + frequency * cost / size, and Clock is raised to the priority of every evicted entry to age the remaining ones.
+// Please note that all methods of PriorityCache are not concurrent safe.
+type PriorityCache struct {
+	capacity int
+	heap     *FibHeap
+	values   map[interface{}]interface{}
+	clock    float64
+	onEvict  func(key, value interface{})
+}
+
+// NewPriorityCache creates an empty PriorityCache holding at most capacity entries.
+// onEvict is called with every evicted entry and can be nil. A []byte key is passed to it as a BytesTag, as the heap returns it.
+// A capacity smaller than 1 will cause a panic.
+func NewPriorityCache(capacity int, onEvict func(key, value interface{})) *PriorityCache {
+	if capacity < 1 {
+		panic("fibHeap: capacity of PriorityCache must be at least 1")
+	}
+
+	cache := new(PriorityCache)
+	cache.capacity = capacity
+	cache.heap = NewFibHeap()
+	cache.values = make(map[interface{}]interface{})
+	cache.onEvict = onEvict
+
+	return cache
+}
+
+// Len returns the number of entries in the cache.
+func (cache *PriorityCache) Len() int {
+	return len(cache.values)
+}
+
+// Capacity returns the maximum number of entries in the cache.
+func (cache *PriorityCache) Capacity() int {
+	return cache.capacity
+}
+
+// Clock returns the priority of the last evicted entry, which is 0 before any eviction.
+func (cache *PriorityCache) Clock() float64 {
+	return cache.clock
+}
+
+// Set stores the value of the key with the priority, replacing the value and priority of an existing key.
+// If the capacity is exceeded, the entry with the minimum priority is evicted, which can be the input one.
+// The valid range of the priority is (-inf, +inf].
+// If the key is nil or the priority is invalid, an error will be returned.
+func (cache *PriorityCache) Set(key, value interface{}, priority float64) error {
+	if key == nil {
+		return errors.New("Input key is nil ")
+	}
+	if math.IsNaN(priority) {
+		return errors.New("Input priority is NaN ")
+	}
+	if math.IsInf(priority, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	key = tagKey(key)
+	if _, exists := cache.values[key]; exists {
+		cache.values[key] = value
+		return cache.setPriority(key, priority)
+	}
+
+	cache.heap.Insert(key, priority)
+	cache.values[key] = value
+	for len(cache.values) > cache.capacity {
+		cache.evict()
+	}
+
+	return nil
+}
+
+// Get returns the value of the key and whether the key exists in the cache.
+// The priority of the key is not changed.
+func (cache *PriorityCache) Get(key interface{}) (interface{}, bool) {
+	value, exists := cache.values[tagKey(key)]
+
+	return value, exists
+}
+
+// Priority returns the priority of the key.
+// If the key does not exist in the cache, -inf will be returned.
+func (cache *PriorityCache) Priority(key interface{}) float64 {
+	return cache.heap.GetTag(key)
+}
+
+// SetPriority updates the priority of an existing key.
+// If the key does not exist in the cache or the priority is invalid, an error will be returned.
+func (cache *PriorityCache) SetPriority(key interface{}, priority float64) error {
+	if math.IsNaN(priority) {
+		return errors.New("Input priority is NaN ")
+	}
+	key = tagKey(key)
+	if _, exists := cache.values[key]; !exists {
+		return errors.New("Key is not found ")
+	}
+
+	return cache.setPriority(key, priority)
+}
+
+// Min returns the key and priority of the entry to be evicted next.
+// An empty cache will return nil and -inf.
+func (cache *PriorityCache) Min() (interface{}, float64) {
+	return cache.heap.Minimum()
+}
+
+// Remove removes the key from the cache without calling onEvict.
+// If the key does not exist in the cache, an error will be returned.
+func (cache *PriorityCache) Remove(key interface{}) error {
+	key = tagKey(key)
+	if _, exists := cache.values[key]; !exists {
+		return errors.New("Key is not found ")
+	}
+
+	cache.heap.Delete(key)
+	delete(cache.values, key)
+
+	return nil
+}
+
+func (cache *PriorityCache) setPriority(key interface{}, priority float64) error {
+	current := cache.heap.GetTag(key)
+	if priority < current {
+		return cache.heap.DecreaseKey(key, priority)
+	}
+	if priority > current {
+		return cache.heap.IncreaseKey(key, priority)
+	}
+
+	return nil
+}
+
+func (cache *PriorityCache) evict() {
+	key, priority := cache.heap.ExtractMin()
+	value := cache.values[key]
+	delete(cache.values, key)
+	cache.clock = priority
+
+	if cache.onEvict != nil {
+		cache.onEvict(key, value)
+	}
+}
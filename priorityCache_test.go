@@ -0,0 +1,106 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+)
+
+var _ = Describe("Tests of priorityCache", func() {
+	var (
+		cache   *PriorityCache
+		evicted []interface{}
+	)
+
+	BeforeEach(func() {
+		evicted = nil
+		cache = NewPriorityCache(3, func(key, value interface{}) {
+			evicted = append(evicted, key)
+		})
+	})
+
+	AfterEach(func() {
+		cache = nil
+	})
+
+	It("Given invalid inputs, when call NewPriorityCache and Set api, it should panic or return error.", func() {
+		Expect(func() { NewPriorityCache(0, nil) }).Should(Panic())
+		Expect(cache.Set(nil, 1, 1)).Should(HaveOccurred())
+		Expect(cache.Set(1, 1, math.NaN())).Should(HaveOccurred())
+		Expect(cache.Set(1, 1, math.Inf(-1))).Should(HaveOccurred())
+		Expect(cache.SetPriority(1, 1)).Should(HaveOccurred())
+		Expect(cache.Remove(1)).Should(HaveOccurred())
+		Expect(cache.Len()).Should(Equal(0))
+		Expect(cache.Capacity()).Should(Equal(3))
+
+		key, priority := cache.Min()
+		Expect(key).Should(BeNil())
+		Expect(priority).Should(BeEquivalentTo(math.Inf(-1)))
+	})
+
+	It("Given a full priorityCache, when call Set api with new keys, it should evict the entries with the minimum priority.", func() {
+		cache.Set("a", 1, 5)
+		cache.Set("b", 2, 1)
+		cache.Set("c", 3, 3)
+		Expect(cache.Len()).Should(Equal(3))
+		Expect(evicted).Should(BeEmpty())
+
+		cache.Set("d", 4, 4)
+		Expect(evicted).Should(Equal([]interface{}{"b"}))
+		Expect(cache.Clock()).Should(BeEquivalentTo(1))
+		_, exists := cache.Get("b")
+		Expect(exists).Should(BeFalse())
+
+		cache.Set("e", 5, 2)
+		Expect(evicted).Should(Equal([]interface{}{"b", "e"}))
+		Expect(cache.Len()).Should(Equal(3))
+
+		value, exists := cache.Get("a")
+		Expect(exists).Should(BeTrue())
+		Expect(value).Should(Equal(1))
+	})
+
+	It("Given a priorityCache, when update the values and priorities, it should evict by the new priorities.", func() {
+		cache.Set("a", 1, 5)
+		cache.Set("b", 2, 1)
+		cache.Set("c", 3, 3)
+
+		Expect(cache.Set("b", 20, 10)).ShouldNot(HaveOccurred())
+		Expect(cache.SetPriority("a", 0)).ShouldNot(HaveOccurred())
+		Expect(cache.SetPriority("a", math.NaN())).Should(HaveOccurred())
+		Expect(cache.Priority("a")).Should(BeEquivalentTo(0))
+		Expect(cache.Priority("x")).Should(BeEquivalentTo(math.Inf(-1)))
+		key, _ := cache.Min()
+		Expect(key).Should(Equal("a"))
+
+		Expect(cache.Remove("c")).ShouldNot(HaveOccurred())
+		cache.Set("d", 4, 4)
+		cache.Set("e", 5, 6)
+		Expect(evicted).Should(Equal([]interface{}{"a"}))
+		value, _ := cache.Get("b")
+		Expect(value).Should(Equal(20))
+	})
+
+	It("Given a priorityCache used with the GDSF policy, when the clock goes up, it should evict the cold entries first and age out the hot entries later.", func() {
+		frequencies := make(map[interface{}]float64)
+		access := func(key interface{}, cost float64) {
+			frequencies[key]++
+			cache.Set(key, key, cache.Clock()+frequencies[key]*cost)
+		}
+
+		access("hot", 1)
+		access("hot", 1)
+		access("hot", 1)
+		access("cold", 2)
+		access("x", 1)
+		for i := 0; i < 10; i++ {
+			access(i, 1)
+		}
+
+		Expect(evicted[:3]).Should(ContainElement("cold"))
+		Expect(evicted[:3]).ShouldNot(ContainElement("hot"))
+		Expect(evicted).Should(ContainElement("hot"))
+	})
+})
@@ -0,0 +1,162 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrChanFull is returned by TryInsert when a bounded PriorityChan is at its capacity.
+var ErrChanFull = errors.New("Channel is full ")
+
+// PriorityChan is a channel-like queue in which Receive always yields the pending value with the minimum key.
+// It is a concurrent FibHeap guarded by a mutex and condition variables, and can replace a buffered channel
+// when the values should be consumed by priority instead of in the order they were sent.
+// All methods of PriorityChan are concurrent safe.
+type PriorityChan struct {
+	mutex    sync.Mutex
+	cond     *sync.Cond
+	room     *sync.Cond
+	heap     *FibHeap
+	capacity uint
+	closed   bool
+}
+
+// NewPriorityChan creates an initialized empty PriorityChan.
+func NewPriorityChan() *PriorityChan {
+	ch := new(PriorityChan)
+	ch.cond = sync.NewCond(&ch.mutex)
+	ch.room = sync.NewCond(&ch.mutex)
+	ch.heap = NewFibHeap()
+
+	return ch
+}
+
+// NewBoundedPriorityChan creates an initialized empty PriorityChan holding at most the input number of pending values,
+// on which Send blocks while the channel is full, like a buffered channel.
+// A capacity of 0 will cause a panic.
+func NewBoundedPriorityChan(capacity uint) *PriorityChan {
+	if capacity == 0 {
+		panic("fibHeap: capacity of NewBoundedPriorityChan must be positive")
+	}
+
+	ch := NewPriorityChan()
+	ch.capacity = capacity
+
+	return ch
+}
+
+// Len returns the number of pending values.
+func (ch *PriorityChan) Len() uint {
+	ch.mutex.Lock()
+	defer ch.mutex.Unlock()
+
+	return ch.heap.Num()
+}
+
+// Cap returns the maximum number of pending values, or 0 if the channel is unbounded.
+func (ch *PriorityChan) Cap() uint {
+	return ch.capacity
+}
+
+// Send puts the input value into the channel. It never blocks on an unbounded channel,
+// and blocks while a bounded channel is full.
+// Try to send a nil value, a duplicate tag value or a -inf key value will cause an error return.
+// Try to send to a closed channel, or to a bounded channel closed while blocking, will cause an error return.
+func (ch *PriorityChan) Send(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	ch.mutex.Lock()
+	defer ch.mutex.Unlock()
+
+	for ch.full() && !ch.closed {
+		ch.room.Wait()
+	}
+
+	return ch.send(value)
+}
+
+// TryInsert puts the input value into the channel as Send does, but without blocking.
+// Try to insert into a full bounded channel will cause an ErrChanFull return, and the other errors are the same as Send.
+func (ch *PriorityChan) TryInsert(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	ch.mutex.Lock()
+	defer ch.mutex.Unlock()
+
+	if ch.full() && !ch.closed {
+		return ErrChanFull
+	}
+
+	return ch.send(value)
+}
+
+// Receive returns the pending value with the minimum key, blocking until there is one.
+// After the channel is closed, the pending values are still received, and then nil is returned immediately.
+func (ch *PriorityChan) Receive() Value {
+	ch.mutex.Lock()
+	defer ch.mutex.Unlock()
+
+	for ch.heap.Num() == 0 && !ch.closed {
+		ch.cond.Wait()
+	}
+
+	return ch.receive()
+}
+
+// TryExtractMin returns the pending value with the minimum key and true as Receive does, but without blocking,
+// or nil and false if there is no pending value.
+func (ch *PriorityChan) TryExtractMin() (Value, bool) {
+	ch.mutex.Lock()
+	defer ch.mutex.Unlock()
+
+	if ch.heap.Num() == 0 {
+		return nil, false
+	}
+
+	return ch.receive(), true
+}
+
+// Close closes the channel so that no more value can be sent, and wakes up all blocked senders and receivers.
+func (ch *PriorityChan) Close() {
+	ch.mutex.Lock()
+	defer ch.mutex.Unlock()
+
+	ch.closed = true
+	ch.cond.Broadcast()
+	ch.room.Broadcast()
+}
+
+// full reports whether the channel is bounded and at its capacity.
+func (ch *PriorityChan) full() bool {
+	return ch.capacity != 0 && ch.heap.Num() >= ch.capacity
+}
+
+// send inserts the value into the channel and wakes up a blocked receiver.
+func (ch *PriorityChan) send(value Value) error {
+	if ch.closed {
+		return errors.New("Channel is closed ")
+	}
+
+	if err := ch.heap.InsertValue(value); err != nil {
+		return err
+	}
+	ch.cond.Signal()
+
+	return nil
+}
+
+// receive extracts the minimum value from the channel and wakes up a blocked sender.
+func (ch *PriorityChan) receive() Value {
+	value := ch.heap.ExtractMinValue()
+	if value != nil {
+		ch.room.Signal()
+	}
+
+	return value
+}
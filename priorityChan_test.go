@@ -0,0 +1,160 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"sync"
+	"time"
+)
+
+var _ = Describe("Tests of priorityChan", func() {
+	var (
+		ch *PriorityChan
+	)
+
+	BeforeEach(func() {
+		ch = NewPriorityChan()
+	})
+
+	AfterEach(func() {
+		ch.Close()
+		ch = nil
+	})
+
+	It("Given a priorityChan, when call Send api with invalid inputs, it should return error.", func() {
+		Expect(ch.Send(nil)).Should(HaveOccurred())
+		Expect(ch.Send(&demoStruct{1, math.Inf(-1), ""})).Should(HaveOccurred())
+		Expect(ch.Send(&demoStruct{1, 1, ""})).ShouldNot(HaveOccurred())
+		Expect(ch.Send(&demoStruct{1, 1, ""})).Should(HaveOccurred())
+		Expect(ch.Len()).Should(BeEquivalentTo(1))
+
+		ch.Close()
+		Expect(ch.Send(&demoStruct{2, 2, ""})).Should(HaveOccurred())
+	})
+
+	It("Given a priorityChan with pending values, when call Receive api, it should return them in the order of keys.", func() {
+		for _, i := range []int{3, 1, 4, 0, 2} {
+			ch.Send(&demoStruct{i, float64(i), ""})
+		}
+
+		for i := 0; i < 5; i++ {
+			Expect(ch.Receive().Tag()).Should(Equal(i))
+		}
+	})
+
+	It("Given an empty priorityChan, when call Receive api, it should block until a value is sent.", func() {
+		received := make(chan Value, 1)
+		go func() {
+			received <- ch.Receive()
+		}()
+
+		Consistently(received, 20*time.Millisecond).ShouldNot(Receive())
+		ch.Send(&demoStruct{1, 1, ""})
+		var value Value
+		Eventually(received).Should(Receive(&value))
+		Expect(value.Tag()).Should(Equal(1))
+	})
+
+	It("Given a closed priorityChan, when call Receive api, it should drain the pending values and then return nil.", func() {
+		ch.Send(&demoStruct{1, 1, ""})
+		received := make(chan Value, 2)
+		for i := 0; i < 2; i++ {
+			go func() {
+				received <- ch.Receive()
+			}()
+		}
+		ch.Close()
+
+		values := []Value{<-received, <-received}
+		Expect(values).Should(ContainElement(BeNil()))
+		Expect(ch.Receive()).Should(BeNil())
+	})
+
+	It("Given a priorityChan shared by multiple senders and receivers, when they run concurrently, it should deliver every value once.", func() {
+		var wg sync.WaitGroup
+		for sender := 0; sender < 4; sender++ {
+			wg.Add(1)
+			go func(sender int) {
+				defer wg.Done()
+				for i := 0; i < 1000; i++ {
+					ch.Send(&demoStruct{sender*1000 + i, float64(i), ""})
+				}
+			}(sender)
+		}
+
+		var mutex sync.Mutex
+		seen := make(map[interface{}]bool)
+		var receivers sync.WaitGroup
+		for receiver := 0; receiver < 4; receiver++ {
+			receivers.Add(1)
+			go func() {
+				defer receivers.Done()
+				for value := ch.Receive(); value != nil; value = ch.Receive() {
+					mutex.Lock()
+					Expect(seen[value.Tag()]).Should(BeFalse())
+					seen[value.Tag()] = true
+					mutex.Unlock()
+				}
+			}()
+		}
+
+		wg.Wait()
+		ch.Close()
+		receivers.Wait()
+		Expect(seen).Should(HaveLen(4000))
+	})
+	It("Given a priorityChan, when call TryInsert and TryExtractMin apis, it should never block.", func() {
+		value, ok := ch.TryExtractMin()
+		Expect(ok).Should(BeFalse())
+		Expect(value).Should(BeNil())
+
+		Expect(ch.TryInsert(nil)).Should(Equal(ErrNilValue))
+		Expect(ch.TryInsert(&demoStruct{2, 2, ""})).ShouldNot(HaveOccurred())
+		Expect(ch.TryInsert(&demoStruct{1, 1, ""})).ShouldNot(HaveOccurred())
+		Expect(ch.TryInsert(&demoStruct{1, 1, ""})).Should(HaveOccurred())
+		Expect(ch.Cap()).Should(BeEquivalentTo(0))
+
+		value, ok = ch.TryExtractMin()
+		Expect(ok).Should(BeTrue())
+		Expect(value.Tag()).Should(Equal(1))
+
+		ch.Close()
+		Expect(ch.TryInsert(&demoStruct{3, 3, ""})).Should(HaveOccurred())
+		value, ok = ch.TryExtractMin()
+		Expect(ok).Should(BeTrue())
+		Expect(value.Tag()).Should(Equal(2))
+		_, ok = ch.TryExtractMin()
+		Expect(ok).Should(BeFalse())
+	})
+
+	It("Given a bounded priorityChan, when it is full, it should fail TryInsert and block Send until a value is received.", func() {
+		Expect(func() { NewBoundedPriorityChan(0) }).Should(Panic())
+		ch = NewBoundedPriorityChan(2)
+		Expect(ch.Cap()).Should(BeEquivalentTo(2))
+		Expect(ch.Send(&demoStruct{2, 2, ""})).ShouldNot(HaveOccurred())
+		Expect(ch.TryInsert(&demoStruct{3, 3, ""})).ShouldNot(HaveOccurred())
+		Expect(ch.TryInsert(&demoStruct{1, 1, ""})).Should(Equal(ErrChanFull))
+
+		sent := make(chan error, 1)
+		go func() {
+			sent <- ch.Send(&demoStruct{1, 1, ""})
+		}()
+		Consistently(sent, 20*time.Millisecond).ShouldNot(Receive())
+		Expect(ch.Receive().Tag()).Should(Equal(2))
+		Eventually(sent).Should(Receive(BeNil()))
+		Expect(ch.Len()).Should(BeEquivalentTo(2))
+		Expect(ch.Receive().Tag()).Should(Equal(1))
+
+		Expect(ch.Send(&demoStruct{4, 4, ""})).ShouldNot(HaveOccurred())
+		go func() {
+			sent <- ch.Send(&demoStruct{5, 5, ""})
+		}()
+		Consistently(sent, 20*time.Millisecond).ShouldNot(Receive())
+		ch.Close()
+		Eventually(sent).Should(Receive(HaveOccurred()))
+		Expect(ch.Len()).Should(BeEquivalentTo(2))
+	})
+})
@@ -0,0 +1,156 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// PriorityQueue is the common interface of FibHeap and all alternative implementations with a tag index,
+// so that applications can be written against the abstraction and pick an implementation by Kind.
+// SoftHeap and PersistentHeap do not implement PriorityQueue as they do not support DecreaseKey or in place updates.
+// PriorityQueue can only be implemented by the heaps of this package.
+type PriorityQueue interface {
+	// Num returns the total number of values in the heap.
+	Num() uint
+	// Insert pushes the input tag and key into the heap.
+	Insert(tag interface{}, key float64) error
+	// InsertValue pushes the input value into the heap.
+	InsertValue(value Value) error
+	// Minimum returns the current minimum tag and key in the heap.
+	Minimum() (interface{}, float64)
+	// MinimumValue returns the current minimum value in the heap.
+	MinimumValue() Value
+	// ExtractMin returns the current minimum tag and key in the heap and then extracts them from the heap.
+	ExtractMin() (interface{}, float64)
+	// ExtractMinValue returns the current minimum value in the heap and then extracts it from the heap.
+	ExtractMinValue() Value
+	// Union moves all values of the input heap into the heap and empties the input heap.
+	Union(anotherHeap PriorityQueue) error
+	// UnionInto merges copies of all values of the input heap into the heap and leaves the input heap untouched.
+	UnionInto(anotherHeap PriorityQueue) error
+	// DecreaseKey updates the tag in the heap by the input smaller key.
+	DecreaseKey(tag interface{}, key float64) error
+	// DecreaseKeyValue updates the value in the heap by the input value with a smaller key.
+	DecreaseKeyValue(value Value) error
+	// IncreaseKey updates the tag in the heap by the input larger key.
+	IncreaseKey(tag interface{}, key float64) error
+	// IncreaseKeyValue updates the value in the heap by the input value with a larger key.
+	IncreaseKeyValue(value Value) error
+	// Delete deletes the input tag in the heap.
+	Delete(tag interface{}) error
+	// DeleteValue deletes the value in the heap by the input value.
+	DeleteValue(value Value) error
+	// GetTag searches and returns the key in the heap by the input tag.
+	GetTag(tag interface{}) float64
+	// GetValue searches and returns the value in the heap by the input tag.
+	GetValue(tag interface{}) Value
+	// ExtractTag searches and extracts the tag/key in the heap by the input tag.
+	ExtractTag(tag interface{}) float64
+	// ExtractValue searches and extracts the value in the heap by the input tag.
+	ExtractValue(tag interface{}) Value
+	// String provides some basic debug information of the heap.
+	String() string
+
+	// reset empties the heap.
+	reset()
+	// each calls fn for every value in the heap in no particular order.
+	each(fn func(tag interface{}, key float64, value Value))
+}
+
+// Kind identifies an implementation of PriorityQueue.
+type Kind int
+
+// All kinds of PriorityQueue which can be created by New.
+const (
+	Fibonacci Kind = iota
+	Pairing
+	Dary
+	RankPairing
+	StrictFibonacci
+	MinMax
+	Interval
+	Leftist
+	Float32
+	Compact
+	Hybrid
+	Adaptive
+)
+
+// DefaultArity is the arity of the DaryHeap created by New.
+const DefaultArity = 4
+
+var kindNames = map[Kind]string{
+	Fibonacci:       "Fibonacci",
+	Pairing:         "Pairing",
+	Dary:            "Dary",
+	RankPairing:     "RankPairing",
+	StrictFibonacci: "StrictFibonacci",
+	MinMax:          "MinMax",
+	Interval:        "Interval",
+	Leftist:         "Leftist",
+	Float32:         "Float32",
+	Compact:         "Compact",
+	Hybrid:          "Hybrid",
+	Adaptive:        "Adaptive",
+}
+
+// String returns the name of the kind.
+func (kind Kind) String() string {
+	if name, exists := kindNames[kind]; exists {
+		return name
+	}
+
+	return fmt.Sprintf("Kind(%d)", int(kind))
+}
+
+// New creates an initialized PriorityQueue of the input kind.
+// A Dary kind creates a DaryHeap with DefaultArity, and a Hybrid kind creates a HybridHeap with DefaultHybridThreshold.
+// An unknown kind will cause a panic.
+func New(kind Kind) PriorityQueue {
+	switch kind {
+	case Fibonacci:
+		return NewFibHeap()
+	case Pairing:
+		return NewPairingHeap()
+	case Dary:
+		return NewDaryHeap(DefaultArity)
+	case RankPairing:
+		return NewRankPairingHeap()
+	case StrictFibonacci:
+		return NewStrictFibHeap()
+	case MinMax:
+		return NewMinMaxHeap()
+	case Interval:
+		return NewIntervalHeap()
+	case Leftist:
+		return NewLeftistHeap()
+	case Float32:
+		return NewFloat32Heap()
+	case Compact:
+		return NewCompactFibHeap()
+	case Hybrid:
+		return NewHybridHeap(DefaultHybridThreshold)
+	case Adaptive:
+		return NewAdaptiveHeap()
+	}
+
+	panic(fmt.Sprintf("fibHeap: unknown kind %v", kind))
+}
+
+// checkUnion returns an error if any tag of anotherHeap already exists in heap.
+func checkUnion(heap, anotherHeap PriorityQueue) error {
+	duplicate := false
+	anotherHeap.each(func(tag interface{}, key float64, value Value) {
+		if !math.IsInf(heap.GetTag(tag), -1) {
+			duplicate = true
+		}
+	})
+	if duplicate {
+		return errors.New("Duplicate tag is found in the target heap ")
+	}
+
+	return nil
+}
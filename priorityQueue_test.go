@@ -0,0 +1,148 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/starwander/GoFibonacciHeap/heaptest"
+)
+
+var _ = Describe("Tests of priorityQueue", func() {
+	kinds := []Kind{Fibonacci, Pairing, Dary, RankPairing, StrictFibonacci, MinMax, Interval, Leftist, Float32, Compact, Hybrid, Adaptive}
+
+	It("Given all kinds, when call New api, it should create an empty heap of the kind.", func() {
+		Expect(New(Fibonacci)).Should(BeAssignableToTypeOf(&FibHeap{}))
+		Expect(New(Dary).(*DaryHeap).Arity()).Should(Equal(DefaultArity))
+		Expect(New(Leftist)).Should(BeAssignableToTypeOf(&LeftistHeap{}))
+		Expect(New(Float32)).Should(BeAssignableToTypeOf(&Float32Heap{}))
+		for _, kind := range kinds {
+			Expect(New(kind).Num()).Should(BeEquivalentTo(0))
+			Expect(kind.String()).ShouldNot(HavePrefix("Kind("))
+		}
+	})
+
+	It("Given an unknown kind, when call New api, it should panic.", func() {
+		Expect(Kind(100).String()).Should(Equal("Kind(100)"))
+		Expect(func() { New(Kind(100)) }).Should(Panic())
+	})
+
+	It("Given heaps of all kinds, when call the value apis by an interface holding a nil pointer, it should return ErrNilValue.", func() {
+		var value *demoStruct
+		for _, kind := range kinds {
+			heap := New(kind)
+			Expect(heap.InsertValue(value)).Should(Equal(ErrNilValue), kind.String())
+			Expect(heap.DecreaseKeyValue(value)).Should(Equal(ErrNilValue), kind.String())
+			Expect(heap.IncreaseKeyValue(value)).Should(Equal(ErrNilValue), kind.String())
+			Expect(heap.DeleteValue(value)).Should(Equal(ErrNilValue), kind.String())
+			Expect(heap.InsertValue(nil)).Should(Equal(ErrNilValue), kind.String())
+		}
+
+		Expect(NewFibHeap().Txn(func(tx *HeapTxn) error {
+			Expect(tx.InsertValue(value)).Should(Equal(ErrNilValue))
+			Expect(tx.DecreaseKeyValue(value)).Should(Equal(ErrNilValue))
+			Expect(tx.IncreaseKeyValue(value)).Should(Equal(ErrNilValue))
+			Expect(tx.DeleteValue(value)).Should(Equal(ErrNilValue))
+			Expect(tx.InsertValue(nil)).Should(Equal(ErrNilValue))
+			return nil
+		})).Should(Succeed())
+
+		persistent := NewPersistentHeap()
+		next, err := persistent.InsertValue(value)
+		Expect(next).Should(BeNil())
+		Expect(err).Should(Equal(ErrNilValue))
+		next, err = persistent.InsertValue(nil)
+		Expect(next).Should(BeNil())
+		Expect(err).Should(Equal(ErrNilValue))
+	})
+
+	It("Given heaps of all kinds, when use []byte tags in the tag and value apis, it should find them by their contents.", func() {
+		for _, kind := range kinds {
+			heap := New(kind)
+			Expect(heap.Insert([]byte("a"), 1)).Should(Succeed(), kind.String())
+			Expect(heap.Insert([]byte("a"), 2)).Should(HaveOccurred(), kind.String())
+			Expect(heap.InsertValue(&bytesValue{[]byte("b"), 2})).Should(Succeed(), kind.String())
+			Expect(heap.Insert([]byte("c"), 3)).Should(Succeed(), kind.String())
+			Expect(heap.Insert([]byte("d"), 4)).Should(Succeed(), kind.String())
+
+			Expect(heap.GetTag([]byte("a"))).Should(BeEquivalentTo(1), kind.String())
+			Expect(heap.GetValue([]byte("b"))).Should(Equal(&bytesValue{[]byte("b"), 2}), kind.String())
+			Expect(heap.DecreaseKey([]byte("c"), 0)).Should(Succeed(), kind.String())
+			Expect(heap.IncreaseKey([]byte("a"), 5)).Should(Succeed(), kind.String())
+			Expect(heap.DecreaseKeyValue(&bytesValue{[]byte("b"), 1})).Should(Succeed(), kind.String())
+			Expect(heap.IncreaseKeyValue(&bytesValue{[]byte("b"), 1.5})).Should(Succeed(), kind.String())
+
+			tag, key := heap.ExtractMin()
+			Expect([]interface{}{tag, key}).Should(Equal([]interface{}{BytesTag("c"), 0.0}), kind.String())
+			Expect(heap.ExtractValue([]byte("b"))).Should(Equal(&bytesValue{[]byte("b"), 1.5}), kind.String())
+			Expect(heap.ExtractTag([]byte("d"))).Should(BeEquivalentTo(4), kind.String())
+			Expect(heap.InsertValue(&bytesValue{[]byte("e"), 6})).Should(Succeed(), kind.String())
+			Expect(heap.DeleteValue(&bytesValue{[]byte("e"), 6})).Should(Succeed(), kind.String())
+			Expect(heap.Delete([]byte("a"))).Should(Succeed(), kind.String())
+			Expect(heap.Delete([]byte("a"))).Should(HaveOccurred(), kind.String())
+			Expect(heap.Num()).Should(BeEquivalentTo(0), kind.String())
+		}
+	})
+
+	It("Given heaps of all kinds, when run the differential tester, it should never diverge from the reference model.", func() {
+		for _, kind := range kinds {
+			for seed := int64(0); seed < 5; seed++ {
+				Expect(heaptest.Run(New(kind), seed)).ShouldNot(HaveOccurred(), kind.String())
+			}
+		}
+	})
+
+	It("Given heaps of different kinds, when call Union api, it should move all values of the input heap in and empty it.", func() {
+		for _, kind := range kinds {
+			for _, anotherKind := range kinds {
+				heap, anotherHeap := New(kind), New(anotherKind)
+				heap.InsertValue(&demoStruct{1, 1, "1"})
+				heap.InsertValue(&demoStruct{2, 2, "2"})
+				anotherHeap.InsertValue(&demoStruct{3, 0, "3"})
+				anotherHeap.Insert(4, 4)
+
+				Expect(heap.Union(anotherHeap)).ShouldNot(HaveOccurred())
+				Expect(heap.Num()).Should(BeEquivalentTo(4))
+				Expect(heap.GetTag(4)).Should(BeEquivalentTo(4))
+				Expect(heap.MinimumValue().(*demoStruct).tag).Should(BeEquivalentTo(3))
+				Expect(anotherHeap.Num()).Should(BeEquivalentTo(0))
+				Expect(anotherHeap.GetValue(3)).Should(BeNil())
+				Expect(anotherHeap.MinimumValue()).Should(BeNil())
+
+				Expect(anotherHeap.Insert(1, 1)).ShouldNot(HaveOccurred())
+				Expect(heap.Union(anotherHeap)).Should(HaveOccurred())
+				Expect(heap.Num()).Should(BeEquivalentTo(4))
+				Expect(anotherHeap.Num()).Should(BeEquivalentTo(1))
+			}
+		}
+	})
+
+	It("Given heaps of different kinds, when call UnionInto api, it should merge copies of all values and leave the input heap untouched.", func() {
+		for _, kind := range kinds {
+			for _, anotherKind := range kinds {
+				heap, anotherHeap := New(kind), New(anotherKind)
+				heap.InsertValue(&demoStruct{1, 1, "1"})
+				anotherHeap.InsertValue(&demoStruct{3, 0, "3"})
+				anotherHeap.Insert(4, 4)
+
+				Expect(heap.UnionInto(anotherHeap)).ShouldNot(HaveOccurred())
+				Expect(heap.Num()).Should(BeEquivalentTo(3))
+				Expect(heap.GetTag(4)).Should(BeEquivalentTo(4))
+				Expect(heap.MinimumValue().(*demoStruct).tag).Should(BeEquivalentTo(3))
+				Expect(anotherHeap.Num()).Should(BeEquivalentTo(2))
+				Expect(anotherHeap.GetValue(3)).Should(BeIdenticalTo(heap.GetValue(3)))
+
+				Expect(heap.UnionInto(anotherHeap)).Should(HaveOccurred())
+				Expect(heap.Num()).Should(BeEquivalentTo(3))
+			}
+		}
+	})
+
+	It("Given a daryHeap emptied by Union, when reuse it, it should keep its arity.", func() {
+		heap, anotherHeap := NewDaryHeap(2), NewDaryHeap(8)
+		anotherHeap.Insert(1, 1)
+
+		Expect(heap.Union(anotherHeap)).ShouldNot(HaveOccurred())
+		Expect(anotherHeap.Arity()).Should(Equal(8))
+	})
+})
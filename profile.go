@@ -0,0 +1,60 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"context"
+	"runtime/pprof"
+	"time"
+)
+
+// profileLabel is the key of the pprof label set to the name of the internal phase of the heap, see SetProfileLabels.
+const profileLabel = "fibHeap"
+
+// SetProfileLabels turns on or off the pprof labels of the expensive internal phases of the heap, so that a CPU profile attributes
+// the time to the phases instead of to the methods calling them, e.g. with go tool pprof -tagfocus fibHeap=consolidate.
+// The label fibHeap is set to consolidate for the consolidation of the roots, including the incremental one, to cascadingCut for the chains
+// of cascading cuts, to union for the moving of the trees by Union, and to compact for the compaction of the lazy deletion mode.
+// The labels of the calling goroutine are not reachable without a context, so they are replaced by the label of the phase while it runs,
+// and restored afterwards. The labels are off by default, as setting them allocates.
+func (heap *FibHeap) SetProfileLabels(on bool) {
+	if debugMode {
+		defer heap.guard.enter("SetProfileLabels")()
+	}
+
+	heap.profiled = on
+}
+
+// ProfileLabels reports whether the pprof labels of the internal phases of the heap are on.
+func (heap *FibHeap) ProfileLabels() bool {
+	if debugMode {
+		defer heap.guard.enter("ProfileLabels")()
+	}
+
+	return heap.profiled
+}
+
+// observing reports whether the phase should be run by observe, i.e. the labels or the logger are on and no phase is running already,
+// so that a nested phase, e.g. the cascading cuts of a compaction, is attributed to the outermost one.
+func (heap *FibHeap) observing() bool {
+	return (heap.profiled || heap.logger != nil) && heap.phase == ""
+}
+
+// observe runs the phase with the pprof label of its name if the labels are on, and logs it if the logger is on, see SetLogger.
+func (heap *FibHeap) observe(phase string, fn func()) {
+	heap.phase = phase
+	defer func() { heap.phase = "" }()
+
+	if heap.logger != nil && heap.logger.enabled() {
+		roots, num, start := heap.roots.Len(), heap.num, time.Now()
+		defer func() { heap.logPhase(phase, roots, num, time.Since(start)) }()
+	}
+	if !heap.profiled {
+		fn()
+		return
+	}
+
+	pprof.Do(context.Background(), pprof.Labels(profileLabel, phase), func(context.Context) {
+		fn()
+	})
+}
@@ -0,0 +1,64 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math/rand"
+)
+
+var _ = Describe("Tests of profile labels", func() {
+	It("Given a fibHeap with the profile labels, when run a phase, it should attribute nested phases to the outermost one.", func() {
+		heap := NewFibHeap()
+		Expect(heap.ProfileLabels()).Should(BeFalse())
+		Expect(heap.observing()).Should(BeFalse())
+		heap.SetProfileLabels(true)
+		Expect(heap.ProfileLabels()).Should(BeTrue())
+		Expect(heap.observing()).Should(BeTrue())
+
+		var phases []string
+		heap.observe("consolidate", func() {
+			phases = append(phases, heap.phase)
+			Expect(heap.observing()).Should(BeFalse())
+		})
+		Expect(phases).Should(Equal([]string{"consolidate"}))
+		Expect(heap.phase).Should(BeEmpty())
+	})
+
+	It("Given a fibHeap with the profile labels under random operations, it should behave the same as a normal fibHeap.", func() {
+		heap := NewFibHeap()
+		heap.SetProfileLabels(true)
+		heap.SetLazyDelete(true)
+		reference := NewFibHeap()
+		random := rand.New(rand.NewSource(1910))
+		for i := 0; i < 20000; i++ {
+			tag := random.Intn(500)
+			key := float64(random.Intn(1000)*500 + tag)
+			switch random.Intn(6) {
+			case 0, 1:
+				Expect(heap.Insert(tag, key) == nil).Should(Equal(reference.Insert(tag, key) == nil))
+			case 2:
+				Expect(heap.Delete(tag) == nil).Should(Equal(reference.Delete(tag) == nil))
+			case 3:
+				Expect(heap.DecreaseKey(tag, key) == nil).Should(Equal(reference.DecreaseKey(tag, key) == nil))
+			case 4:
+				heap.SetConsolidationBudget(random.Intn(3))
+			case 5:
+				min, key := heap.ExtractMin()
+				referenceMin, referenceKey := reference.ExtractMin()
+				Expect([]interface{}{min, key}).Should(Equal([]interface{}{referenceMin, referenceKey}))
+			}
+			Expect(heap.phase).Should(BeEmpty())
+		}
+
+		another := NewFibHeap()
+		another.Insert("another", 0)
+		Expect(heap.Union(another)).ShouldNot(HaveOccurred())
+		Expect(heap.Union(another)).ShouldNot(HaveOccurred())
+		Expect(heap.ExtractTag("another")).Should(BeEquivalentTo(0))
+		Expect(heap.phase).Should(BeEmpty())
+		Expect(heap.ProfileLabels()).Should(BeTrue())
+		Expect(heap.Equal(reference)).Should(BeTrue())
+	})
+})
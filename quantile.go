@@ -0,0 +1,202 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"math"
+	"sort"
+)
+
+// keySketch is a streaming quantile sketch of the keys of a FibHeap, see SetQuantileSketch.
+// It counts the keys in buckets growing geometrically by gamma, so every bucket covers keys within the relative accuracy of its representative key.
+// Unlike most streaming sketches, a key can be removed from the sketch, as the sketch remembers the bucket of every tag.
+type keySketch struct {
+	accuracy  float64
+	gamma     float64
+	logGamma  float64
+	positives map[int]uint
+	negatives map[int]uint
+	zeros     uint
+	infs      uint
+	buckets   map[interface{}]sketchBucket
+}
+
+// sketchBucket is a bucket of a keySketch: the sign of its keys, and the index of the bucket for positive and negative keys.
+type sketchBucket struct {
+	sign  int
+	index int
+}
+
+// SetQuantileSketch turns on or off the quantile sketch of the heap, which is updated by every insert, extraction, deletion and key update in O(1),
+// so KeyQuantile can estimate the quantiles of the keys cheaply, e.g. the percentiles of the backlog latency of a queue.
+// The estimated quantiles are within the input relative accuracy of the exact ones, e.g. 0.01 for 1%.
+// The number of buckets of the sketch grows with the logarithm of the range of the keys divided by the accuracy.
+// The accuracy applies to the keys before AddToAllKeys, which shifts the keys but not the buckets.
+// An accuracy of 0 turns off the sketch, which is the default. An accuracy outside [0, 1) will cause a panic.
+// Turning on the sketch builds it from the current values in O(n).
+func (heap *FibHeap) SetQuantileSketch(accuracy float64) {
+	if debugMode {
+		defer heap.guard.enter("SetQuantileSketch")()
+	}
+
+	if !(accuracy >= 0 && accuracy < 1) {
+		panic("fibHeap: accuracy of SetQuantileSketch must be in [0, 1)")
+	}
+
+	if accuracy == 0 {
+		heap.sketch = nil
+		return
+	}
+
+	heap.sketch = newKeySketch(accuracy)
+	for _, n := range heap.index {
+		heap.sketch.put(n)
+	}
+}
+
+// KeyQuantile returns the q-quantile of the keys in the heap without extracting them, i.e. the key below which about q of the keys are,
+// e.g. KeyQuantile(0.99) for the 99th percentile. The rank of the quantile is rounded down, so KeyQuantile(0) is the minimum key.
+// With the quantile sketch, the key is estimated in O(b log b) where b is the number of buckets of the sketch.
+// Without it, the key is exact, and found in O(log n) with the ordered index, or in O(n log n) otherwise.
+// The ok result is false if the heap is empty or q is not in [0, 1].
+func (heap *FibHeap) KeyQuantile(q float64) (key float64, ok bool) {
+	if debugMode {
+		defer heap.guard.enter("KeyQuantile")()
+	}
+
+	if heap.num == 0 || !(q >= 0 && q <= 1) {
+		return math.Inf(-1), false
+	}
+
+	rank := int(q * float64(heap.num-1))
+	if heap.sketch != nil {
+		return heap.sketch.quantile(rank) + heap.offset, true
+	} else if heap.order != nil {
+		return heap.keyOf(heap.order.at(rank)), true
+	}
+
+	keys := make([]float64, 0, heap.num)
+	for _, n := range heap.index {
+		keys = append(keys, n.key)
+	}
+	sort.Float64s(keys)
+
+	return keys[rank] + heap.offset, true
+}
+
+func newKeySketch(accuracy float64) *keySketch {
+	sketch := new(keySketch)
+	sketch.accuracy = accuracy
+	sketch.gamma = (1 + accuracy) / (1 - accuracy)
+	sketch.logGamma = math.Log(sketch.gamma)
+	sketch.positives = make(map[int]uint)
+	sketch.negatives = make(map[int]uint)
+	sketch.buckets = make(map[interface{}]sketchBucket)
+
+	return sketch
+}
+
+// put counts the key of the node, or moves it to the bucket of its new key.
+func (sketch *keySketch) put(n *node) {
+	bucket := sketch.bucketOf(n.key)
+	if previous, exists := sketch.buckets[n.tag]; exists {
+		if previous == bucket {
+			return
+		}
+		sketch.remove(n.tag)
+	}
+
+	switch {
+	case bucket.sign > 0:
+		sketch.positives[bucket.index]++
+	case bucket.sign < 0:
+		sketch.negatives[bucket.index]++
+	case bucket.index == 0:
+		sketch.zeros++
+	default:
+		sketch.infs++
+	}
+	sketch.buckets[n.tag] = bucket
+}
+
+func (sketch *keySketch) remove(tag interface{}) {
+	bucket, exists := sketch.buckets[tag]
+	if !exists {
+		return
+	}
+	delete(sketch.buckets, tag)
+
+	switch {
+	case bucket.sign > 0:
+		if sketch.positives[bucket.index]--; sketch.positives[bucket.index] == 0 {
+			delete(sketch.positives, bucket.index)
+		}
+	case bucket.sign < 0:
+		if sketch.negatives[bucket.index]--; sketch.negatives[bucket.index] == 0 {
+			delete(sketch.negatives, bucket.index)
+		}
+	case bucket.index == 0:
+		sketch.zeros--
+	default:
+		sketch.infs--
+	}
+}
+
+func (sketch *keySketch) clear() {
+	*sketch = *newKeySketch(sketch.accuracy)
+}
+
+// bucketOf returns the bucket of the key. The zero key and the +inf key are in the buckets of sign 0 and index 0 and 1 respectively.
+func (sketch *keySketch) bucketOf(key float64) sketchBucket {
+	switch {
+	case math.IsInf(key, 1):
+		return sketchBucket{0, 1}
+	case key > 0:
+		return sketchBucket{1, int(math.Ceil(math.Log(key) / sketch.logGamma))}
+	case key < 0:
+		return sketchBucket{-1, int(math.Ceil(math.Log(-key) / sketch.logGamma))}
+	default:
+		return sketchBucket{0, 0}
+	}
+}
+
+// quantile returns the representative key of the bucket of the input rank, counting the buckets from the smallest keys.
+func (sketch *keySketch) quantile(rank int) float64 {
+	count := uint(rank)
+	indexes := make([]int, 0, len(sketch.negatives))
+	for index := range sketch.negatives {
+		indexes = append(indexes, index)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(indexes)))
+	for _, index := range indexes {
+		if count < sketch.negatives[index] {
+			return -sketch.representative(index)
+		}
+		count -= sketch.negatives[index]
+	}
+
+	if count < sketch.zeros {
+		return 0
+	}
+	count -= sketch.zeros
+
+	indexes = indexes[:0]
+	for index := range sketch.positives {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	for _, index := range indexes {
+		if count < sketch.positives[index] {
+			return sketch.representative(index)
+		}
+		count -= sketch.positives[index]
+	}
+
+	return math.Inf(1)
+}
+
+// representative returns the key in the middle of the bucket of the index in relative terms,
+// which is within the relative accuracy of every key of the bucket.
+func (sketch *keySketch) representative(index int) float64 {
+	return 2 * math.Pow(sketch.gamma, float64(index)) / (sketch.gamma + 1)
+}
@@ -0,0 +1,90 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"math/rand"
+)
+
+var _ = Describe("Tests of quantile sketch", func() {
+	var heap, reference *FibHeap
+
+	BeforeEach(func() {
+		heap = NewFibHeap()
+		heap.SetQuantileSketch(0.01)
+		reference = NewFibHeap()
+		reference.SetOrderedIndex(true)
+	})
+
+	AfterEach(func() {
+		heap = nil
+		reference = nil
+	})
+
+	It("Given an empty fibHeap, when call KeyQuantile api, it should report no quantile.", func() {
+		_, ok := heap.KeyQuantile(0.5)
+		Expect(ok).Should(BeFalse())
+		heap.Insert(1, 1)
+		_, ok = heap.KeyQuantile(1.5)
+		Expect(ok).Should(BeFalse())
+		_, ok = heap.KeyQuantile(math.NaN())
+		Expect(ok).Should(BeFalse())
+
+		Expect(func() { heap.SetQuantileSketch(1) }).Should(Panic())
+		Expect(func() { heap.SetQuantileSketch(-0.1) }).Should(Panic())
+		heap.SetQuantileSketch(0)
+		Expect(heap.sketch).Should(BeNil())
+	})
+
+	It("Given fibHeaps with and without the quantile sketch under random operations, it should estimate the quantiles within the accuracy.", func() {
+		random := rand.New(rand.NewSource(1892))
+		for i := 0; i < 20000; i++ {
+			tag := random.Intn(1000)
+			key := math.Floor(random.NormFloat64() * 1000)
+			switch random.Intn(5) {
+			case 0, 1:
+				heap.Insert(tag, key)
+				reference.Insert(tag, key)
+			case 2:
+				heap.Delete(tag)
+				reference.Delete(tag)
+			case 3:
+				heap.DecreaseKey(tag, key)
+				reference.DecreaseKey(tag, key)
+			case 4:
+				heap.IncreaseKey(tag, key)
+				reference.IncreaseKey(tag, key)
+			}
+		}
+		heap.Insert(-1, math.Inf(1))
+		reference.Insert(-1, math.Inf(1))
+		heap.AddToAllKeys(10000)
+		reference.AddToAllKeys(10000)
+
+		for _, q := range []float64{0, 0.01, 0.25, 0.5, 0.75, 0.99, 1} {
+			estimate, ok := heap.KeyQuantile(q)
+			Expect(ok).Should(BeTrue())
+			exact, _ := reference.KeyQuantile(q)
+			if math.IsInf(exact, 1) {
+				Expect(estimate).Should(Equal(exact))
+			} else {
+				Expect(math.Abs(estimate - exact)).Should(BeNumerically("<=", 0.01*math.Abs(exact-10000)+1e-9))
+			}
+		}
+
+		median, _ := reference.KeyQuantile(0.5)
+		reference.SetOrderedIndex(false)
+		exact, _ := reference.KeyQuantile(0.5)
+		Expect(median).Should(Equal(exact))
+
+		NewFibHeap().Union(heap)
+		_, ok := heap.KeyQuantile(0.5)
+		Expect(ok).Should(BeFalse())
+		heap.Insert(1, 5)
+		key, _ := heap.KeyQuantile(0.5)
+		Expect(key).Should(BeNumerically("~", 5, 0.05))
+	})
+})
@@ -0,0 +1,81 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"fmt"
+)
+
+// Quota limits the size of a heap, e.g. of a tenant in a HeapGroup, so that it cannot starve the others, see SetQuota.
+type Quota struct {
+	// MaxNum is the maximum number of values of the heap, or 0 for no limit.
+	MaxNum uint
+	// MaxBytes is the maximum memory of the heap estimated by SizeBytes, or 0 for no limit.
+	// The free nodes of the pool shared by all heaps of a HeapGroup are not counted, so that a namespace is not charged for the others.
+	MaxBytes uint64
+	// OnExceeded is called with the error before it is returned, if it is not nil.
+	OnExceeded func(err *QuotaError)
+}
+
+// QuotaError is returned by the methods inserting values into a heap when the values would exceed the quota of the heap.
+type QuotaError struct {
+	// Num and Bytes are the number of values and the estimated memory of the heap before the insert, as counted by Quota.
+	Num   uint
+	Bytes uint64
+	// Inserting is the number of values being inserted.
+	Inserting uint
+	// Quota is the exceeded quota.
+	Quota Quota
+}
+
+func (err *QuotaError) Error() string {
+	return fmt.Sprintf("Quota of %d values and %d bytes is exceeded by inserting %d values into %d values of %d bytes ",
+		err.Quota.MaxNum, err.Quota.MaxBytes, err.Inserting, err.Num, err.Bytes)
+}
+
+// valueBytes is the estimated memory of one more value of a heap, i.e. of its node and its entry of the index.
+var valueBytes = nodeBytes + mapSize(1, interfaceBytes, pointerBytes) - mapBytes
+
+// SetQuota sets the limits of the number of values and of the estimated memory of the heap.
+// Once an insert would exceed either limit, Insert, InsertValue and the other inserting methods return a *QuotaError instead,
+// and the bulk ones, e.g. Union and Import, insert nothing. The values already in the heap are kept even if they exceed a new quota.
+// The zero Quota turns off the limits, which is the default.
+func (heap *FibHeap) SetQuota(quota Quota) {
+	if debugMode {
+		defer heap.guard.enter("SetQuota")()
+	}
+
+	heap.quota = quota
+}
+
+// Quota returns the quota of the heap.
+func (heap *FibHeap) Quota() Quota {
+	if debugMode {
+		defer heap.guard.enter("Quota")()
+	}
+
+	return heap.quota
+}
+
+// checkQuota returns a *QuotaError if inserting the number of values would exceed the quota of the heap.
+func (heap *FibHeap) checkQuota(inserting uint) error {
+	quota := heap.quota
+	if quota.MaxNum == 0 && quota.MaxBytes == 0 {
+		return nil
+	}
+
+	var bytes uint64
+	if quota.MaxBytes != 0 {
+		bytes = heap.sizeBytes() - heap.poolBytes()
+	}
+	if (quota.MaxNum == 0 || heap.num+inserting <= quota.MaxNum) && (quota.MaxBytes == 0 || bytes+uint64(inserting)*valueBytes <= quota.MaxBytes) {
+		return nil
+	}
+
+	err := &QuotaError{Num: heap.num, Bytes: bytes, Inserting: inserting, Quota: quota}
+	if quota.OnExceeded != nil {
+		quota.OnExceeded(err)
+	}
+
+	return err
+}
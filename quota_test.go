@@ -0,0 +1,110 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tests of quota", func() {
+	var (
+		heap     *FibHeap
+		exceeded []*QuotaError
+	)
+
+	BeforeEach(func() {
+		heap = NewFibHeap()
+		exceeded = nil
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given a fibHeap with a quota of values, when insert beyond it, it should return a QuotaError and call the callback.", func() {
+		heap.SetQuota(Quota{MaxNum: 3, OnExceeded: func(err *QuotaError) { exceeded = append(exceeded, err) }})
+		Expect(heap.Quota().MaxNum).Should(BeEquivalentTo(3))
+		Expect(heap.Insert(1, 1)).ShouldNot(HaveOccurred())
+		Expect(heap.InsertValue(&demoStruct{2, 2, ""})).ShouldNot(HaveOccurred())
+		Expect(heap.InsertOrIncrement(3, 3)).ShouldNot(HaveOccurred())
+		Expect(heap.InsertOrIncrement(3, 3)).ShouldNot(HaveOccurred())
+
+		err := heap.Insert(4, 4)
+		Expect(err).Should(BeAssignableToTypeOf(&QuotaError{}))
+		Expect(err.Error()).Should(ContainSubstring("inserting 1 values into 3 values"))
+		Expect(heap.InsertValue(&demoStruct{4, 4, ""})).Should(HaveOccurred())
+		_, err = heap.InsertAuto(4, nil)
+		Expect(err).Should(HaveOccurred())
+		Expect(exceeded).Should(HaveLen(3))
+		Expect(exceeded[0].Num).Should(BeEquivalentTo(3))
+		Expect(exceeded[0].Inserting).Should(BeEquivalentTo(1))
+		Expect(heap.Num()).Should(BeEquivalentTo(3))
+
+		heap.ExtractMin()
+		Expect(heap.Insert(4, 4)).ShouldNot(HaveOccurred())
+		heap.SetQuota(Quota{})
+		Expect(heap.Insert(5, 5)).ShouldNot(HaveOccurred())
+	})
+
+	It("Given a fibHeap with a quota of values, when call the bulk apis beyond it, it should insert nothing.", func() {
+		heap.SetQuota(Quota{MaxNum: 3})
+		heap.Insert(0, 0)
+		another := NewFibHeap()
+		for i := 1; i < 4; i++ {
+			another.Insert(i, float64(i))
+		}
+
+		Expect(heap.Union(another)).Should(BeAssignableToTypeOf(&QuotaError{}))
+		Expect(heap.UnionInto(another)).Should(HaveOccurred())
+		entries, err := another.Export()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(heap.Import(entries)).Should(HaveOccurred())
+		Expect(heap.Num()).Should(BeEquivalentTo(1))
+		Expect(another.Num()).Should(BeEquivalentTo(3))
+
+		another.Delete(3)
+		Expect(heap.Union(another)).ShouldNot(HaveOccurred())
+		Expect(heap.Num()).Should(BeEquivalentTo(3))
+		Expect(another.Quota()).Should(Equal(Quota{}))
+	})
+
+	It("Given a fibHeap with a quota of bytes, when insert beyond it, it should return a QuotaError.", func() {
+		empty := heap.SizeBytes()
+		// The estimate of the index grows by the rounding of its slack, so the quota leaves a little room.
+		heap.SetQuota(Quota{MaxBytes: empty + 10*valueBytes + 64})
+		for i := 0; i < 10; i++ {
+			Expect(heap.Insert(i, float64(i))).ShouldNot(HaveOccurred())
+		}
+		err := heap.Insert(10, 10)
+		Expect(err).Should(BeAssignableToTypeOf(&QuotaError{}))
+		Expect(err.(*QuotaError).Bytes).Should(BeNumerically(">", empty))
+		Expect(heap.Num()).Should(BeEquivalentTo(10))
+	})
+
+	It("Given a fibHeap with a quota in a transaction, when the transaction is rolled back, it should restore the values regardless of the quota.", func() {
+		heap.SetQuota(Quota{MaxNum: 2})
+		heap.Insert(1, 1)
+		heap.Insert(2, 2)
+		Expect(heap.Txn(func(tx *HeapTxn) error {
+			tx.Delete(1)
+			Expect(tx.Insert(3, 3)).ShouldNot(HaveOccurred())
+			return tx.Insert(4, 4)
+		})).Should(HaveOccurred())
+		Expect(heap.Tags()).Should(ConsistOf(1, 2))
+		Expect(heap.Quota().MaxNum).Should(BeEquivalentTo(2))
+	})
+
+	It("Given a heapGroup with a quota, when a namespace is full, it should not affect the other namespaces.", func() {
+		group := NewHeapGroup()
+		group.Insert("a", 0, 0)
+		group.SetQuota(Quota{MaxNum: 2})
+		Expect(group.Insert("a", 1, 1)).ShouldNot(HaveOccurred())
+		Expect(group.Insert("a", 2, 2)).Should(BeAssignableToTypeOf(&QuotaError{}))
+		Expect(group.InsertValue("a", &demoStruct{2, 2, ""})).Should(HaveOccurred())
+		Expect(group.Insert("b", 2, 2)).ShouldNot(HaveOccurred())
+		Expect(group.Insert("b", 3, 3)).ShouldNot(HaveOccurred())
+		Expect(group.Insert("b", 4, 4)).Should(HaveOccurred())
+		Expect(group.Num()).Should(BeEquivalentTo(4))
+	})
+})
@@ -0,0 +1,247 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// MaxExactKey is the largest integer below which every integer can be represented exactly by a float64 key.
+// Integer priorities beyond it, e.g. large sequence numbers, may be rounded to the same float64 and silently reordered.
+const MaxExactKey = 1 << 53
+
+// Uint64Key converts the input integer priority to a float64 key.
+// An integer beyond MaxExactKey will cause an error return instead of a key which may reorder entries silently.
+func Uint64Key(x uint64) (float64, error) {
+	if x > MaxExactKey {
+		return 0, fmt.Errorf("Integer priority %d exceeds the exact range of float64 keys ", x)
+	}
+
+	return float64(x), nil
+}
+
+// RadixHeap is a monotone priority queue of tags with uint64 keys, e.g. sequence numbers or timestamps,
+// in which the inserted keys are never smaller than the last extracted minimum.
+// It compares keys as integers and thus never suffers from the float64 precision limit,
+// and all operations are O(1) except ExtractMin and Minimum which are O(log C) amortized, where C is the range of the keys.
+// Please note that all methods of RadixHeap are not concurrent safe.
+type RadixHeap struct {
+	buckets [65][]*radixNode
+	index   map[interface{}]*radixNode
+	last    uint64
+	num     uint
+}
+
+type radixNode struct {
+	tag      interface{}
+	key      uint64
+	bucket   int
+	position int
+}
+
+// NewRadixHeap creates an initialized empty RadixHeap.
+func NewRadixHeap() *RadixHeap {
+	heap := new(RadixHeap)
+	heap.index = make(map[interface{}]*radixNode)
+
+	return heap
+}
+
+// Num returns the total number of tags in the heap.
+func (heap *RadixHeap) Num() uint {
+	return heap.num
+}
+
+// Last returns the last extracted minimum key, below which no key can be inserted.
+func (heap *RadixHeap) Last() uint64 {
+	return heap.last
+}
+
+// Insert pushes the input tag and key into the heap.
+// Try to insert a nil tag, a duplicate tag or a key smaller than Last will cause an error return.
+func (heap *RadixHeap) Insert(tag interface{}, key uint64) error {
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if key < heap.last {
+		return errors.New("Key is smaller than the last extracted key ")
+	}
+
+	if _, exists := heap.index[tag]; exists {
+		return errors.New("Duplicate tag is not allowed ")
+	}
+
+	node := &radixNode{tag: tag, key: key}
+	heap.index[tag] = node
+	heap.num++
+	heap.push(node)
+
+	return nil
+}
+
+// Minimum returns the current minimum tag and key in the heap.
+// An empty heap will return nil and 0.
+func (heap *RadixHeap) Minimum() (interface{}, uint64) {
+	if heap.num == 0 {
+		return nil, 0
+	}
+
+	heap.redistribute()
+	min := heap.buckets[0][len(heap.buckets[0])-1]
+
+	return min.tag, min.key
+}
+
+// ExtractMin returns the current minimum tag and key in the heap and then extracts them from the heap.
+// An empty heap will return nil and 0 and extracts nothing.
+func (heap *RadixHeap) ExtractMin() (interface{}, uint64) {
+	if heap.num == 0 {
+		return nil, 0
+	}
+
+	heap.redistribute()
+	min := heap.buckets[0][len(heap.buckets[0])-1]
+	heap.remove(min)
+	delete(heap.index, min.tag)
+	heap.num--
+
+	return min.tag, min.key
+}
+
+// DecreaseKey updates the tag in the heap by the input smaller key, which must not be smaller than Last.
+// If the input key is not smaller than the current key or is smaller than Last, an error will be returned.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *RadixHeap) DecreaseKey(tag interface{}, key uint64) error {
+	node, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Value is not found ")
+	}
+
+	if key >= node.key {
+		return errors.New("New key is not smaller than current key ")
+	}
+
+	if key < heap.last {
+		return errors.New("Key is smaller than the last extracted key ")
+	}
+
+	heap.remove(node)
+	node.key = key
+	heap.push(node)
+
+	return nil
+}
+
+// IncreaseKey updates the tag in the heap by the input larger key.
+// If the input key is not larger than the current key, an error will be returned.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *RadixHeap) IncreaseKey(tag interface{}, key uint64) error {
+	node, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Value is not found ")
+	}
+
+	if key <= node.key {
+		return errors.New("New key is not larger than current key ")
+	}
+
+	heap.remove(node)
+	node.key = key
+	heap.push(node)
+
+	return nil
+}
+
+// Delete deletes the input tag in the heap.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *RadixHeap) Delete(tag interface{}) error {
+	node, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Tag is not found ")
+	}
+
+	heap.remove(node)
+	delete(heap.index, tag)
+	heap.num--
+
+	return nil
+}
+
+// GetTag searches and returns the key in the heap by the input tag.
+// If the input tag does not exist in the heap, 0 and false will be returned.
+func (heap *RadixHeap) GetTag(tag interface{}) (uint64, bool) {
+	if node, exists := heap.index[tag]; exists {
+		return node.key, true
+	}
+
+	return 0, false
+}
+
+// String provides some basic debug information of the heap.
+// It returns the total number, index size, the last extracted key and the sizes of the non-empty buckets.
+func (heap *RadixHeap) String() string {
+	var buffer bytes.Buffer
+
+	if heap.num != 0 {
+		buffer.WriteString(fmt.Sprintf("Total number: %d, Index size: %d, Last: %d,\n", heap.num, len(heap.index), heap.last))
+		buffer.WriteString(fmt.Sprintf("Buckets:"))
+		for i, bucket := range heap.buckets {
+			if len(bucket) != 0 {
+				buffer.WriteString(fmt.Sprintf(" %d(%d)", i, len(bucket)))
+			}
+		}
+		buffer.WriteString(fmt.Sprintf("\n"))
+	} else {
+		buffer.WriteString(fmt.Sprintf("Heap is empty.\n"))
+	}
+
+	return buffer.String()
+}
+
+// push puts the node into the bucket of the highest bit in which its key differs from last.
+func (heap *RadixHeap) push(n *radixNode) {
+	n.bucket = bits.Len64(n.key ^ heap.last)
+	n.position = len(heap.buckets[n.bucket])
+	heap.buckets[n.bucket] = append(heap.buckets[n.bucket], n)
+}
+
+// remove takes the node out of its bucket by swapping it with the last node of the bucket.
+func (heap *RadixHeap) remove(n *radixNode) {
+	bucket := heap.buckets[n.bucket]
+	moved := bucket[len(bucket)-1]
+	bucket[n.position] = moved
+	moved.position = n.position
+	bucket[len(bucket)-1] = nil
+	heap.buckets[n.bucket] = bucket[:len(bucket)-1]
+}
+
+// redistribute makes sure the bucket 0 holds the minimum keys of the non-empty heap.
+// If it is empty, last is advanced to the minimum key of the first non-empty bucket, whose nodes all move to smaller buckets.
+func (heap *RadixHeap) redistribute() {
+	if len(heap.buckets[0]) != 0 {
+		return
+	}
+
+	i := 1
+	for len(heap.buckets[i]) == 0 {
+		i++
+	}
+
+	bucket := heap.buckets[i]
+	heap.last = bucket[0].key
+	for _, n := range bucket[1:] {
+		if n.key < heap.last {
+			heap.last = n.key
+		}
+	}
+
+	heap.buckets[i] = bucket[:0]
+	for j, n := range bucket {
+		bucket[j] = nil
+		heap.push(n)
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+var _ = Describe("Tests of radixHeap", func() {
+	var (
+		heap *RadixHeap
+	)
+
+	BeforeEach(func() {
+		heap = NewRadixHeap()
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given integers around the float64 precision limit, when call Uint64Key api, it should reject the inexact ones.", func() {
+		key, err := Uint64Key(MaxExactKey)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(key).Should(BeEquivalentTo(1 << 53))
+		_, err = Uint64Key(MaxExactKey + 1)
+		Expect(err).Should(HaveOccurred())
+		Expect(float64(MaxExactKey + 1)).Should(Equal(float64(MaxExactKey)))
+	})
+
+	It("Given an empty radixHeap, when call Minimum and ExtractMin api, it should return nil.", func() {
+		tag, key := heap.Minimum()
+		Expect(tag).Should(BeNil())
+		Expect(key).Should(BeEquivalentTo(0))
+		tag, _ = heap.ExtractMin()
+		Expect(tag).Should(BeNil())
+		Expect(heap.String()).Should(Equal("Heap is empty.\n"))
+	})
+
+	It("Given a radixHeap, when call the apis with invalid inputs, it should return error.", func() {
+		Expect(heap.Insert(nil, 1)).Should(HaveOccurred())
+		Expect(heap.Insert(1, 10)).ShouldNot(HaveOccurred())
+		Expect(heap.Insert(1, 20)).Should(HaveOccurred())
+		Expect(heap.Insert(2, 20)).ShouldNot(HaveOccurred())
+		heap.ExtractMin()
+		Expect(heap.Last()).Should(BeEquivalentTo(10))
+		Expect(heap.Insert(3, 9)).Should(HaveOccurred())
+		Expect(heap.DecreaseKey(2, 9)).Should(HaveOccurred())
+		Expect(heap.DecreaseKey(2, 20)).Should(HaveOccurred())
+		Expect(heap.DecreaseKey(3, 15)).Should(HaveOccurred())
+		Expect(heap.IncreaseKey(2, 20)).Should(HaveOccurred())
+		Expect(heap.IncreaseKey(3, 30)).Should(HaveOccurred())
+		Expect(heap.Delete(3)).Should(HaveOccurred())
+		_, exists := heap.GetTag(3)
+		Expect(exists).Should(BeFalse())
+		Expect(heap.Num()).Should(BeEquivalentTo(1))
+	})
+
+	It("Given sequence numbers beyond the float64 precision limit, when extract them, it should keep their exact order.", func() {
+		for i := 0; i < 100; i++ {
+			Expect(heap.Insert(i, math.MaxUint64-uint64(i))).ShouldNot(HaveOccurred())
+		}
+
+		for i := 99; i >= 0; i-- {
+			tag, key := heap.ExtractMin()
+			Expect(tag).Should(Equal(i))
+			Expect(key).Should(Equal(uint64(math.MaxUint64) - uint64(i)))
+		}
+	})
+
+	It("Given a radixHeap under random monotone operations, when extract the minimums, it should return them in order.", func() {
+		random := rand.New(rand.NewSource(1))
+		keys := make(map[int]uint64)
+		var last uint64
+		for i := 0; i < 20000; i++ {
+			tag := random.Intn(1000)
+			key := last + uint64(random.Int63n(1<<40))
+			current, exists := keys[tag]
+			switch {
+			case !exists:
+				Expect(heap.Insert(tag, key)).ShouldNot(HaveOccurred())
+				keys[tag] = key
+			case random.Intn(4) == 0:
+				Expect(heap.Delete(tag)).ShouldNot(HaveOccurred())
+				delete(keys, tag)
+			case random.Intn(3) == 0:
+				var min uint64
+				for _, k := range keys {
+					if min == 0 || k < min {
+						min = k
+					}
+				}
+				minTag, minKey := heap.ExtractMin()
+				Expect(minKey).Should(Equal(min))
+				delete(keys, minTag.(int))
+				last = minKey
+			case key < current:
+				Expect(heap.DecreaseKey(tag, key)).ShouldNot(HaveOccurred())
+				keys[tag] = key
+			case key > current:
+				Expect(heap.IncreaseKey(tag, key)).ShouldNot(HaveOccurred())
+				keys[tag] = key
+			}
+			Expect(heap.Num()).Should(BeEquivalentTo(len(keys)))
+		}
+
+		var expected []uint64
+		for _, key := range keys {
+			expected = append(expected, key)
+		}
+		sort.Slice(expected, func(i, j int) bool { return expected[i] < expected[j] })
+		for _, key := range expected {
+			_, min := heap.Minimum()
+			Expect(min).Should(Equal(key))
+			_, min = heap.ExtractMin()
+			Expect(min).Should(Equal(key))
+		}
+	})
+})
@@ -0,0 +1,520 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// RankPairingHeap represents a Rank-Pairing Heap as described by Haeupler, Sen and Tarjan.
+// RankPairingHeap provides exactly the same methods as FibHeap so the two implementations can be swapped by changing the constructor only.
+// It has the same amortized bounds as FibHeap but a simpler structure: half-ordered binary half trees with ranks,
+// one-pass linking on ExtractMin and rank reduction without cascading cuts on DecreaseKey.
+// Please note that all methods of RankPairingHeap are not concurrent safe.
+type RankPairingHeap struct {
+	roots []*rankPairingNode
+	index map[interface{}]*rankPairingNode
+	min   *rankPairingNode
+	num   uint
+	// buckets is reused by the one-pass linking to group roots by rank.
+	buckets []*rankPairingNode
+}
+
+// rankPairingNode is a node of a half tree.
+// The left child holds the keys not smaller than the node, the right child is the next sibling in the heap ordered view.
+type rankPairingNode struct {
+	parent *rankPairingNode
+	left   *rankPairingNode
+	right  *rankPairingNode
+	rank   int
+	tag    interface{}
+	key    float64
+	value  Value
+}
+
+// NewRankPairingHeap creates an initialized Rank-Pairing Heap.
+func NewRankPairingHeap() *RankPairingHeap {
+	heap := new(RankPairingHeap)
+	heap.index = make(map[interface{}]*rankPairingNode)
+	heap.num = 0
+	heap.min = nil
+
+	return heap
+}
+
+// Num returns the total number of values in the heap.
+func (heap *RankPairingHeap) Num() uint {
+	return heap.num
+}
+
+// Insert pushes the input tag and key into the heap.
+// Try to insert a duplicate tag value will cause an error return.
+// The valid range of the key is (-inf, +inf].
+// Try to insert a -inf key value will cause an error return.
+func (heap *RankPairingHeap) Insert(tag interface{}, key float64) error {
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	return heap.insert(tag, key, nil)
+}
+
+// InsertValue pushes the input value into the heap.
+// The input value must implements the Value interface.
+// Try to insert a duplicate tag value will cause an error return.
+// The valid range of the value's key is (-inf, +inf].
+// Try to insert a -inf key value will cause an error return.
+func (heap *RankPairingHeap) InsertValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	return heap.insert(value.Tag(), value.Key(), value)
+}
+
+// Minimum returns the current minimum tag and key in the heap sorted by the key.
+// An empty heap will return nil and -inf.
+func (heap *RankPairingHeap) Minimum() (interface{}, float64) {
+	if heap.num == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	return heap.min.tag, heap.min.key
+}
+
+// MinimumValue returns the current minimum value in the heap sorted by the key.
+// An empty heap will return nil.
+func (heap *RankPairingHeap) MinimumValue() Value {
+	if heap.num == 0 {
+		return nil
+	}
+
+	return heap.min.value
+}
+
+// ExtractMin returns the current minimum tag and key in the heap and then extracts them from the heap.
+// An empty heap will return nil/-inf and extracts nothing.
+func (heap *RankPairingHeap) ExtractMin() (interface{}, float64) {
+	if heap.num == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	min := heap.extractMin()
+
+	return min.tag, min.key
+}
+
+// ExtractMinValue returns the current minimum value in the heap and then extracts it from the heap.
+// An empty heap will return nil and extracts nothing.
+func (heap *RankPairingHeap) ExtractMinValue() Value {
+	if heap.num == 0 {
+		return nil
+	}
+
+	min := heap.extractMin()
+
+	return min.value
+}
+
+// Union moves all values of the input heap into the heap.
+// The input heap can be any implementation of PriorityQueue, and it is emptied afterwards so that no value is reachable from both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned and both heaps are left untouched.
+func (heap *RankPairingHeap) Union(anotherHeap PriorityQueue) error {
+	if err := heap.UnionInto(anotherHeap); err != nil {
+		return err
+	}
+
+	anotherHeap.reset()
+
+	return nil
+}
+
+// UnionInto merges copies of all values of the input heap into the heap.
+// The input heap can be any implementation of PriorityQueue, and it is left untouched, so the values are shared by both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned.
+func (heap *RankPairingHeap) UnionInto(anotherHeap PriorityQueue) error {
+	if err := checkUnion(heap, anotherHeap); err != nil {
+		return err
+	}
+
+	anotherHeap.each(func(tag interface{}, key float64, value Value) {
+		heap.insert(tag, key, value)
+	})
+
+	return nil
+}
+
+// DecreaseKey updates the tag in the heap by the input key.
+// If the input key has a larger key or -inf key, an error will be returned.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *RankPairingHeap) DecreaseKey(tag interface{}, key float64) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tag]; exists {
+		return heap.decreaseKey(node, node.value, key)
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// DecreaseKeyValue updates the value in the heap by the input value.
+// If the input value has a larger key or -inf key, an error will be returned.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *RankPairingHeap) DecreaseKeyValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	if math.IsInf(value.Key(), -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tagKey(value.Tag())]; exists {
+		return heap.decreaseKey(node, value, value.Key())
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// IncreaseKey updates the tag in the heap by the input key.
+// If the input key has a smaller key or -inf key, an error will be returned.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *RankPairingHeap) IncreaseKey(tag interface{}, key float64) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tag]; exists {
+		return heap.increaseKey(node, node.value, key)
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// IncreaseKeyValue updates the value in the heap by the input value.
+// If the input value has a smaller key or -inf key, an error will be returned.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *RankPairingHeap) IncreaseKeyValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	if math.IsInf(value.Key(), -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tagKey(value.Tag())]; exists {
+		return heap.increaseKey(node, value, value.Key())
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// Delete deletes the input tag in the heap.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *RankPairingHeap) Delete(tag interface{}) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	node, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Tag is not found ")
+	}
+
+	heap.deleteNode(node)
+
+	return nil
+}
+
+// DeleteValue deletes the value in the heap by the input value.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *RankPairingHeap) DeleteValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	node, exists := heap.index[tagKey(value.Tag())]
+	if !exists {
+		return errors.New("Value is not found ")
+	}
+
+	heap.deleteNode(node)
+
+	return nil
+}
+
+// GetTag searches and returns the key in the heap by the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *RankPairingHeap) GetTag(tag interface{}) (key float64) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		return node.key
+	}
+
+	return math.Inf(-1)
+}
+
+// GetValue searches and returns the value in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *RankPairingHeap) GetValue(tag interface{}) (value Value) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		value = node.value
+	}
+
+	return
+}
+
+// ExtractTag searches and extracts the tag/key in the heap by the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *RankPairingHeap) ExtractTag(tag interface{}) (key float64) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		key = node.key
+		heap.deleteNode(node)
+		return
+	}
+
+	return math.Inf(-1)
+}
+
+// ExtractValue searches and extracts the value in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *RankPairingHeap) ExtractValue(tag interface{}) (value Value) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		value = node.value
+		heap.deleteNode(node)
+		return
+	}
+
+	return nil
+}
+
+// String provides some basic debug information of the heap.
+// It returns the total number, roots size, index size and current minimum value of the heap.
+// It also returns the topology of the half trees by dfs search.
+func (heap *RankPairingHeap) String() string {
+	var buffer bytes.Buffer
+
+	if heap.num != 0 {
+		buffer.WriteString(fmt.Sprintf("Total number: %d, Root Size: %d, Index size: %d,\n", heap.num, len(heap.roots), len(heap.index)))
+		buffer.WriteString(fmt.Sprintf("Current minimun: key(%f), tag(%v), value(%v),\n", heap.min.key, heap.min.tag, heap.min.value))
+		buffer.WriteString(fmt.Sprintf("Heap detail:\n"))
+		for _, root := range heap.roots {
+			probeRankPairingTree(&buffer, root)
+		}
+		buffer.WriteString(fmt.Sprintf("\n"))
+	} else {
+		buffer.WriteString(fmt.Sprintf("Heap is empty.\n"))
+	}
+
+	return buffer.String()
+}
+
+func probeRankPairingTree(buffer *bytes.Buffer, tree *rankPairingNode) {
+	buffer.WriteString(fmt.Sprintf("< "))
+	buffer.WriteString(fmt.Sprintf("%f ", tree.key))
+	for n := tree.left; n != nil; n = n.right {
+		probeRankPairingTree(buffer, n)
+	}
+	buffer.WriteString(fmt.Sprintf("> "))
+}
+
+func (heap *RankPairingHeap) reset() {
+	*heap = *NewRankPairingHeap()
+}
+
+func (heap *RankPairingHeap) each(fn func(tag interface{}, key float64, value Value)) {
+	for _, node := range heap.index {
+		fn(node.tag, node.key, node.value)
+	}
+}
+
+func (heap *RankPairingHeap) insert(tag interface{}, key float64, value Value) error {
+	tag = tagKey(tag)
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if _, exists := heap.index[tag]; exists {
+		return errors.New("Duplicate tag is not allowed ")
+	}
+
+	node := new(rankPairingNode)
+	node.tag = tag
+	node.key = key
+	node.value = value
+
+	heap.index[node.tag] = node
+	heap.num++
+	heap.addRoot(node)
+
+	return nil
+}
+
+func (heap *RankPairingHeap) addRoot(n *rankPairingNode) {
+	heap.roots = append(heap.roots, n)
+	if heap.min == nil || n.key < heap.min.key {
+		heap.min = n
+	}
+}
+
+func (heap *RankPairingHeap) extractMin() *rankPairingNode {
+	min := heap.min
+	delete(heap.index, min.tag)
+	heap.num--
+
+	candidates := heap.roots
+	heap.roots = nil
+	heap.min = nil
+
+	// The right spine of the left child of the extracted root falls apart into new half trees.
+	for n := min.left; n != nil; {
+		next := n.right
+		n.parent = nil
+		n.right = nil
+		n.rank = rankOfRankPairing(n.left) + 1
+		candidates = append(candidates, n)
+		n = next
+	}
+	min.left = nil
+
+	// One-pass linking: every root is linked at most once with another root of the same rank.
+	for _, n := range candidates {
+		if n == min {
+			continue
+		}
+		for len(heap.buckets) <= n.rank {
+			heap.buckets = append(heap.buckets, nil)
+		}
+		if another := heap.buckets[n.rank]; another != nil {
+			heap.buckets[n.rank] = nil
+			heap.addRoot(heap.link(n, another))
+		} else {
+			heap.buckets[n.rank] = n
+		}
+	}
+	for rank, n := range heap.buckets {
+		if n != nil {
+			heap.buckets[rank] = nil
+			heap.addRoot(n)
+		}
+	}
+
+	return min
+}
+
+func (heap *RankPairingHeap) deleteNode(n *rankPairingNode) {
+	heap.decreaseKey(n, n.value, math.Inf(-1))
+	heap.ExtractMinValue()
+}
+
+// link makes the root with the larger key the left child of the other root.
+func (heap *RankPairingHeap) link(x, y *rankPairingNode) *rankPairingNode {
+	if y.key < x.key {
+		x, y = y, x
+	}
+
+	y.right = x.left
+	if y.right != nil {
+		y.right.parent = y
+	}
+	y.parent = x
+	x.left = y
+	x.rank++
+
+	return x
+}
+
+func (heap *RankPairingHeap) decreaseKey(n *rankPairingNode, value Value, key float64) error {
+	if key >= n.key {
+		return errors.New("New key is not smaller than current key ")
+	}
+
+	n.key = key
+	n.value = value
+	if n.parent == nil {
+		if n.key < heap.min.key {
+			heap.min = n
+		}
+		return nil
+	}
+
+	// Detach the half tree of n, replacing it by its right child, and make it a new root.
+	parent := n.parent
+	right := n.right
+	if parent.left == n {
+		parent.left = right
+	} else {
+		parent.right = right
+	}
+	if right != nil {
+		right.parent = parent
+	}
+	n.parent = nil
+	n.right = nil
+	n.rank = rankOfRankPairing(n.left) + 1
+	heap.addRoot(n)
+
+	// Type-2 rank reduction walks up from the old parent as long as ranks decrease.
+	for u := parent; u != nil; u = u.parent {
+		var rank int
+		if u.parent == nil {
+			rank = rankOfRankPairing(u.left) + 1
+		} else {
+			left, right := rankOfRankPairing(u.left), rankOfRankPairing(u.right)
+			if left < right {
+				left, right = right, left
+			}
+			if left-right > 1 {
+				rank = left
+			} else {
+				rank = left + 1
+			}
+		}
+		if rank >= u.rank {
+			break
+		}
+		u.rank = rank
+	}
+
+	return nil
+}
+
+// increaseKey is done by deleting and inserting the item again since half trees can only decrease keys in place.
+func (heap *RankPairingHeap) increaseKey(n *rankPairingNode, value Value, key float64) error {
+	if key <= n.key {
+		return errors.New("New key is not larger than current key ")
+	}
+
+	tag := n.tag
+	heap.deleteNode(n)
+
+	return heap.insert(tag, key, value)
+}
+
+func rankOfRankPairing(n *rankPairingNode) int {
+	if n == nil {
+		return -1
+	}
+
+	return n.rank
+}
@@ -0,0 +1,165 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"fmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/starwander/GoFibonacciHeap/heaptest"
+	"math"
+	"math/rand"
+	"time"
+)
+
+var _ = Describe("Tests of rankPairingHeap", func() {
+	var (
+		heap        *RankPairingHeap
+		anotherHeap *RankPairingHeap
+	)
+
+	BeforeEach(func() {
+		heap = NewRankPairingHeap()
+		anotherHeap = NewRankPairingHeap()
+	})
+
+	AfterEach(func() {
+		heap = nil
+		anotherHeap = nil
+	})
+
+	It("Given an empty rankPairingHeap, when call Minimum and ExtractMin api, it should return nil.", func() {
+		tag, key := heap.Minimum()
+		Expect(tag).Should(BeNil())
+		Expect(key).Should(BeEquivalentTo(math.Inf(-1)))
+		Expect(heap.ExtractMinValue()).Should(BeNil())
+		Expect(heap.String()).Should(BeEquivalentTo("Heap is empty.\n"))
+	})
+
+	It("Given a empty rankPairingHeap, when call Insert api with invalid inputs, it should return error.", func() {
+		Expect(heap.Insert(nil, 0.0)).Should(HaveOccurred())
+		Expect(heap.InsertValue(nil)).Should(HaveOccurred())
+		Expect(heap.Insert(1000, math.Inf(-1))).Should(HaveOccurred())
+		Expect(heap.Insert(1000, 0.0)).ShouldNot(HaveOccurred())
+		Expect(heap.Insert(1000, 1.0)).Should(HaveOccurred())
+		Expect(heap.Num()).Should(BeEquivalentTo(1))
+	})
+
+	It("Given a rankPairingHeap inserted multiple values, when call ExtractMinValue api, it should extract the values sorted by key.", func() {
+		rand.Seed(time.Now().Unix())
+		for i := 0; i < 10000; i++ {
+			demo := new(demoStruct)
+			demo.tag = i
+			demo.key = rand.Float64()
+			demo.value = fmt.Sprint(demo.key)
+			Expect(heap.InsertValue(demo)).ShouldNot(HaveOccurred())
+		}
+
+		lastKey := heap.MinimumValue().(*demoStruct).key
+		for i := 0; i < 10000; i++ {
+			extracted := heap.ExtractMinValue().(*demoStruct)
+			Expect(extracted.key).Should(BeNumerically(">=", lastKey))
+			Expect(extracted.value).Should(Equal(fmt.Sprint(extracted.key)))
+			Expect(heap.Num()).Should(BeEquivalentTo(9999 - i))
+			lastKey = extracted.key
+		}
+	})
+
+	It("Given a rankPairingHeap inserted multiple values, when call DecreaseKey and IncreaseKey api, it should reorder the values.", func() {
+		for i := 0; i < 1000; i++ {
+			heap.Insert(i, float64(i+1000))
+		}
+
+		Expect(heap.DecreaseKey(500, 1500)).Should(HaveOccurred())
+		Expect(heap.IncreaseKey(500, 1000)).Should(HaveOccurred())
+		Expect(heap.DecreaseKey(500, -1)).ShouldNot(HaveOccurred())
+		Expect(heap.IncreaseKey(0, 5000)).ShouldNot(HaveOccurred())
+
+		tag, key := heap.ExtractMin()
+		Expect(tag).Should(BeEquivalentTo(500))
+		Expect(key).Should(BeEquivalentTo(-1))
+		tag, _ = heap.ExtractMin()
+		Expect(tag).Should(BeEquivalentTo(1))
+		Expect(heap.GetTag(0)).Should(BeEquivalentTo(5000))
+	})
+
+	It("Given a rankPairingHeap with a value, when call DecreaseKey api by tag, it should keep the stored value.", func() {
+		demo := &demoStruct{1, 10, "10"}
+		heap.InsertValue(demo)
+
+		Expect(heap.DecreaseKey(1, 5)).ShouldNot(HaveOccurred())
+		Expect(heap.GetValue(1)).Should(BeIdenticalTo(demo))
+	})
+
+	It("Given a rankPairingHeap inserted multiple values, when call Delete api, it should remove the value from the heap.", func() {
+		for i := 0; i < 1000; i++ {
+			heap.Insert(i, float64(i))
+		}
+
+		Expect(heap.Delete(nil)).Should(HaveOccurred())
+		Expect(heap.Delete(1000)).Should(HaveOccurred())
+		for i := 0; i < 1000; i += 2 {
+			Expect(heap.Delete(i)).ShouldNot(HaveOccurred())
+		}
+		Expect(heap.Num()).Should(BeEquivalentTo(500))
+		Expect(heap.ExtractTag(999)).Should(BeEquivalentTo(999))
+		Expect(heap.ExtractValue(999)).Should(BeNil())
+		for i := 1; i < 999; i += 2 {
+			tag, _ := heap.ExtractMin()
+			Expect(tag).Should(BeEquivalentTo(i))
+		}
+	})
+
+	It("Given two rankPairingHeaps, when call Union api, it should move all values of the input heap in and empty it.", func() {
+		heap.Insert(1, 1)
+		heap.InsertValue(&demoStruct{2, 2, "2"})
+		anotherHeap.Insert(3, 0)
+		anotherHeap.InsertValue(&demoStruct{4, 4, "4"})
+
+		Expect(heap.Union(anotherHeap)).ShouldNot(HaveOccurred())
+		Expect(heap.Num()).Should(BeEquivalentTo(4))
+		Expect(anotherHeap.Num()).Should(BeEquivalentTo(0))
+		Expect(heap.GetValue(4).(*demoStruct).value).Should(Equal("4"))
+		tag, _ := heap.Minimum()
+		Expect(tag).Should(BeEquivalentTo(3))
+
+		Expect(heap.Union(heap)).Should(HaveOccurred())
+	})
+
+	It("Given a rankPairingHeap under a decrease-key heavy workload, when checking the half trees, it should keep them half ordered with logarithmic ranks.", func() {
+		rand.Seed(time.Now().Unix())
+		for i := 0; i < 10000; i++ {
+			heap.Insert(i, float64(i))
+		}
+		heap.ExtractMin()
+		for i := 0; i < 10000; i++ {
+			tag := 1 + rand.Intn(9999)
+			heap.DecreaseKey(tag, heap.GetTag(tag)-rand.Float64()*100)
+			if i%100 == 0 {
+				heap.ExtractMin()
+			}
+		}
+
+		var check func(n *rankPairingNode) int
+		check = func(n *rankPairingNode) int {
+			count := 1
+			for child := n.left; child != nil; child = child.right {
+				Expect(child.key).Should(BeNumerically(">=", n.key))
+				count += check(child)
+			}
+			return count
+		}
+		total := 0
+		for _, root := range heap.roots {
+			Expect(root.rank).Should(BeNumerically("<=", 3*math.Log2(float64(heap.Num()))))
+			total += check(root)
+		}
+		Expect(total).Should(BeEquivalentTo(heap.Num()))
+	})
+
+	It("Given a rankPairingHeap, when run the differential tester, it should never diverge from the reference model.", func() {
+		for seed := int64(0); seed < 20; seed++ {
+			Expect(heaptest.Run(NewRankPairingHeap(), seed)).ShouldNot(HaveOccurred())
+		}
+	})
+})
@@ -0,0 +1,291 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Operations of the recorder records.
+const (
+	recInsert byte = iota + 1
+	recMinimum
+	recExtractMin
+	recDecreaseKey
+	recIncreaseKey
+	recDelete
+	recGetTag
+	recExtractTag
+	recNum
+)
+
+var recNames = map[byte]string{
+	recInsert:      "Insert",
+	recMinimum:     "Minimum",
+	recExtractMin:  "ExtractMin",
+	recDecreaseKey: "DecreaseKey",
+	recIncreaseKey: "IncreaseKey",
+	recDelete:      "Delete",
+	recGetTag:      "GetTag",
+	recExtractTag:  "ExtractTag",
+	recNum:         "Num",
+}
+
+// TagKeyHeap is the tag/key interfaces of a heap, which a Recorder records and ReplayRecording replays.
+// FibHeap, all kinds of PriorityQueue and the Recorder itself implement it.
+type TagKeyHeap interface {
+	Insert(tag interface{}, key float64) error
+	Minimum() (interface{}, float64)
+	ExtractMin() (interface{}, float64)
+	DecreaseKey(tag interface{}, key float64) error
+	IncreaseKey(tag interface{}, key float64) error
+	Delete(tag interface{}) error
+	GetTag(tag interface{}) float64
+	ExtractTag(tag interface{}) float64
+	Num() uint
+}
+
+// Recorder wraps a heap and appends a record of every call of the tag/key interfaces, with its arguments and results, to a writer.
+// A sequence captured in production, e.g. one which corrupted the ordering, can then be reproduced in a test by ReplayRecording.
+// Every record is written by a single Write call with a CRC-32 checksum like the write-ahead log.
+// The tags must be booleans, strings, byte slices, numbers of the builtin types, Handles or composite tags of them as Marshal requires,
+// and the first failure of encoding or writing stops the recording and is reported by Err.
+// Please note that all methods of Recorder are not concurrent safe.
+type Recorder struct {
+	heap TagKeyHeap
+	w    io.Writer
+	err  error
+}
+
+// NewRecorder creates a Recorder which calls the input heap and records the calls to the input writer.
+func NewRecorder(heap TagKeyHeap, w io.Writer) *Recorder {
+	return &Recorder{heap: heap, w: w}
+}
+
+// Err returns the first error of encoding or writing the records, or nil if the recording is healthy.
+func (recorder *Recorder) Err() error {
+	return recorder.err
+}
+
+// Insert calls Insert of the heap and records it.
+func (recorder *Recorder) Insert(tag interface{}, key float64) error {
+	err := recorder.heap.Insert(tag, key)
+	recorder.record(recInsert, tag, key, err)
+
+	return err
+}
+
+// Minimum calls Minimum of the heap and records it.
+func (recorder *Recorder) Minimum() (interface{}, float64) {
+	tag, key := recorder.heap.Minimum()
+	recorder.record(recMinimum, tag, key, nil)
+
+	return tag, key
+}
+
+// ExtractMin calls ExtractMin of the heap and records it.
+func (recorder *Recorder) ExtractMin() (interface{}, float64) {
+	tag, key := recorder.heap.ExtractMin()
+	recorder.record(recExtractMin, tag, key, nil)
+
+	return tag, key
+}
+
+// DecreaseKey calls DecreaseKey of the heap and records it.
+func (recorder *Recorder) DecreaseKey(tag interface{}, key float64) error {
+	err := recorder.heap.DecreaseKey(tag, key)
+	recorder.record(recDecreaseKey, tag, key, err)
+
+	return err
+}
+
+// IncreaseKey calls IncreaseKey of the heap and records it.
+func (recorder *Recorder) IncreaseKey(tag interface{}, key float64) error {
+	err := recorder.heap.IncreaseKey(tag, key)
+	recorder.record(recIncreaseKey, tag, key, err)
+
+	return err
+}
+
+// Delete calls Delete of the heap and records it.
+func (recorder *Recorder) Delete(tag interface{}) error {
+	err := recorder.heap.Delete(tag)
+	recorder.record(recDelete, tag, 0, err)
+
+	return err
+}
+
+// GetTag calls GetTag of the heap and records it.
+func (recorder *Recorder) GetTag(tag interface{}) float64 {
+	key := recorder.heap.GetTag(tag)
+	recorder.record(recGetTag, tag, key, nil)
+
+	return key
+}
+
+// ExtractTag calls ExtractTag of the heap and records it.
+func (recorder *Recorder) ExtractTag(tag interface{}) float64 {
+	key := recorder.heap.ExtractTag(tag)
+	recorder.record(recExtractTag, tag, key, nil)
+
+	return key
+}
+
+// Num calls Num of the heap and records it.
+func (recorder *Recorder) Num() uint {
+	num := recorder.heap.Num()
+	recorder.record(recNum, nil, float64(num), nil)
+
+	return num
+}
+
+// record encodes a call as the operation, the tag and key, which are the arguments or the results depending on the operation,
+// and whether an error was returned.
+func (recorder *Recorder) record(op byte, tag interface{}, key float64, err error) {
+	if recorder.err != nil {
+		return
+	}
+
+	var record bytes.Buffer
+	record.WriteByte(op)
+	if recorder.err = writeOptionalTag(&record, tag); recorder.err != nil {
+		return
+	}
+	writeFloat(&record, key)
+	if err != nil {
+		record.WriteByte(1)
+	} else {
+		record.WriteByte(0)
+	}
+
+	recorder.err = writeFrame(recorder.w, record.Bytes())
+}
+
+// ReplayRecording calls the input heap, typically a new empty heap, by all records read from the input reader,
+// and compares the results with the recorded ones.
+// It returns an error describing the first record whose results diverge, or nil if the replay reproduces the recording.
+// An incomplete record at the end of the recording is ignored, and a record failing the checksum will cause ErrCorrupted.
+func ReplayRecording(r io.Reader, heap TagKeyHeap) error {
+	for i := 0; ; i++ {
+		op, tag, key, failed, err := readRecord(r)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var (
+			gotTag interface{}
+			gotKey = key
+			gotErr error
+		)
+		switch op {
+		case recInsert:
+			gotErr = heap.Insert(tag, key)
+		case recMinimum:
+			gotTag, gotKey = heap.Minimum()
+		case recExtractMin:
+			gotTag, gotKey = heap.ExtractMin()
+		case recDecreaseKey:
+			gotErr = heap.DecreaseKey(tag, key)
+		case recIncreaseKey:
+			gotErr = heap.IncreaseKey(tag, key)
+		case recDelete:
+			gotErr = heap.Delete(tag)
+		case recGetTag:
+			gotKey = heap.GetTag(tag)
+		case recExtractTag:
+			gotKey = heap.ExtractTag(tag)
+		case recNum:
+			gotKey = float64(heap.Num())
+		default:
+			return ErrCorrupted
+		}
+		if op != recMinimum && op != recExtractMin {
+			gotTag = tag
+		}
+
+		if gotTag != tag || !sameKey(gotKey, key) || (gotErr != nil) != failed {
+			return fmt.Errorf("Record %d of %s diverged: recorded (%v, %v, failed %t), replayed (%v, %v, failed %t) ",
+				i, recNames[op], tag, key, failed, gotTag, gotKey, gotErr != nil)
+		}
+	}
+}
+
+// Record is a call of the tag/key interfaces read from a recording by ReadRecording.
+type Record struct {
+	// Op is the name of the called method, e.g. Insert or ExtractMin.
+	Op string
+	// Tag and Key are the arguments or the results of the call depending on the method, as ReplayRecording compares them.
+	Tag interface{}
+	Key float64
+	// Failed reports whether the call returned an error.
+	Failed bool
+}
+
+// ReadRecording reads all records of a recording written by a Recorder, e.g. to replay them against other heaps without comparing the results.
+// An incomplete record at the end of the recording is ignored, and a record failing the checksum or of an unknown method will cause ErrCorrupted.
+func ReadRecording(r io.Reader) ([]Record, error) {
+	var records []Record
+	for {
+		op, tag, key, failed, err := readRecord(r)
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		name, exists := recNames[op]
+		if !exists {
+			return nil, ErrCorrupted
+		}
+		records = append(records, Record{name, tag, key, failed})
+	}
+}
+
+// readRecord reads and decodes the next record, and returns io.EOF at the end of the recording.
+func readRecord(r io.Reader) (op byte, tag interface{}, key float64, failed bool, err error) {
+	record, err := readFrame(r)
+	if err != nil {
+		return 0, nil, 0, false, err
+	}
+
+	reader := &bodyReader{data: record}
+	op = reader.byte()
+	tag = reader.optionalTag()
+	key = reader.float()
+	failed = reader.byte() != 0
+	if reader.err != nil || len(reader.data) != 0 {
+		return 0, nil, 0, false, ErrCorrupted
+	}
+
+	return op, tag, key, failed, nil
+}
+
+// sameKey compares two keys, regarding NaN as equal to itself.
+func sameKey(a, b float64) bool {
+	return a == b || (math.IsNaN(a) && math.IsNaN(b))
+}
+
+// writeOptionalTag writes a flag of whether the tag is nil before the tag.
+func writeOptionalTag(buffer *bytes.Buffer, tag interface{}) error {
+	if tag == nil {
+		buffer.WriteByte(0)
+		return nil
+	}
+	buffer.WriteByte(1)
+
+	return writeTag(buffer, tag)
+}
+
+func (reader *bodyReader) optionalTag() interface{} {
+	if reader.byte() == 0 {
+		return nil
+	}
+
+	return reader.tag()
+}
@@ -0,0 +1,108 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math/rand"
+)
+
+var _ = Describe("Tests of recorder", func() {
+	var (
+		buffer   *bytes.Buffer
+		recorder *Recorder
+	)
+
+	BeforeEach(func() {
+		buffer = new(bytes.Buffer)
+		recorder = NewRecorder(NewFibHeap(), buffer)
+	})
+
+	AfterEach(func() {
+		buffer = nil
+		recorder = nil
+	})
+
+	It("Given a recorded random workload, when replay it on a new heap, it should reproduce all results.", func() {
+		random := rand.New(rand.NewSource(1))
+		for i := 0; i < 5000; i++ {
+			tag := random.Intn(200)
+			// The keys are unique so that a heap of another kind extracts the same tags.
+			key := float64(random.Intn(1000)*200 + tag)
+			switch random.Intn(8) {
+			case 0, 1:
+				recorder.Insert(tag, key)
+			case 2:
+				recorder.ExtractMin()
+			case 3:
+				recorder.DecreaseKey(tag, key)
+			case 4:
+				recorder.IncreaseKey(tag, key)
+			case 5:
+				recorder.Delete(tag)
+			case 6:
+				recorder.GetTag(tag)
+				recorder.Minimum()
+			case 7:
+				recorder.ExtractTag(tag)
+				recorder.Num()
+			}
+		}
+		recorder.Insert(nil, 1)
+		Expect(recorder.Err()).ShouldNot(HaveOccurred())
+
+		Expect(ReplayRecording(bytes.NewReader(buffer.Bytes()), NewFibHeap())).ShouldNot(HaveOccurred())
+		Expect(ReplayRecording(bytes.NewReader(buffer.Bytes()), New(Pairing))).ShouldNot(HaveOccurred())
+		Expect(ReplayRecording(bytes.NewReader(buffer.Bytes()[:buffer.Len()-3]), NewFibHeap())).ShouldNot(HaveOccurred())
+	})
+
+	It("Given a recording, when replay it on a heap behaving differently, it should report the first diverged record.", func() {
+		recorder.Insert("a", 10)
+		recorder.Insert("b", 5)
+		recorder.ExtractMin()
+		recorder.Minimum()
+
+		heap := NewFibHeap()
+		heap.Insert("c", 1)
+		err := ReplayRecording(bytes.NewReader(buffer.Bytes()), heap)
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).Should(ContainSubstring("Record 2 of ExtractMin diverged"))
+
+		data := append([]byte(nil), buffer.Bytes()...)
+		data[len(data)-1] ^= 0xff
+		Expect(ReplayRecording(bytes.NewReader(data), NewFibHeap())).Should(Equal(ErrCorrupted))
+	})
+
+	It("Given a recording, when call ReadRecording api, it should return all records in order.", func() {
+		recorder.Insert("a", 1)
+		recorder.Insert("a", 2)
+		recorder.Minimum()
+		recorder.ExtractMin()
+		recorder.Num()
+
+		records, err := ReadRecording(bytes.NewReader(buffer.Bytes()))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(records).Should(Equal([]Record{
+			{"Insert", "a", 1, false}, {"Insert", "a", 2, true}, {"Minimum", "a", 1, false}, {"ExtractMin", "a", 1, false}, {"Num", nil, 0, false}}))
+
+		records, err = ReadRecording(bytes.NewReader(buffer.Bytes()[:buffer.Len()-1]))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(records).Should(HaveLen(4))
+
+		data := buffer.Bytes()
+		data[len(data)-2] ^= 0xff
+		_, err = ReadRecording(bytes.NewReader(data))
+		Expect(err).Should(Equal(ErrCorrupted))
+	})
+
+	It("Given a tag which can not be encoded, when record it, it should stop the recording and report the error.", func() {
+		Expect(recorder.Insert(struct{}{}, 1)).ShouldNot(HaveOccurred())
+		Expect(recorder.Err()).Should(HaveOccurred())
+		length := buffer.Len()
+		recorder.Insert("a", 2)
+		Expect(buffer.Len()).Should(Equal(length))
+		Expect(recorder.Num()).Should(BeEquivalentTo(2))
+	})
+})
@@ -0,0 +1,108 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"errors"
+	"math"
+)
+
+// Refresh re-reads the key of the value of the input tag by its Key method, and moves the value up or down in the heap accordingly,
+// for the values whose keys have been changed in place after they were pushed, which the heap would not notice otherwise.
+// The key is compared with the key in the heap with the offset of AddToAllKeys applied, so a Key method which does not follow the offset
+// moves the value back by the offset. A key which has not changed is a no-op.
+// If the input tag is not existed in the heap or was inserted without a value, or the new key is -inf or NaN, an error will be returned.
+func (heap *FibHeap) Refresh(tag interface{}) error {
+	if debugMode {
+		defer heap.guard.enter("Refresh")()
+	}
+
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	node, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Value is not found ")
+	}
+
+	_, err := heap.refresh(node)
+
+	return err
+}
+
+// RefreshAll re-reads the keys of all values in the heap by their Key methods as Refresh does, and returns the number of changed keys.
+// If any new key is -inf or NaN, an error will be returned and the refresh stops there, leaving the keys refreshed so far changed.
+func (heap *FibHeap) RefreshAll() (int, error) {
+	if debugMode {
+		defer heap.guard.enter("RefreshAll")()
+	}
+
+	nodes := make([]*node, 0, heap.num)
+	for _, n := range heap.index {
+		if n.value != nil {
+			nodes = append(nodes, n)
+		}
+	}
+
+	refreshed := 0
+	for _, n := range nodes {
+		changed, err := heap.refresh(n)
+		if err != nil {
+			return refreshed, err
+		}
+		if changed {
+			refreshed++
+		}
+	}
+
+	return refreshed, nil
+}
+
+// StaleTags returns the tags whose values report keys by their Key methods different from their keys in the heap, in no particular order,
+// i.e. the values which Refresh would move. The values inserted without a value are never stale.
+// StaleTags scans all values in O(n) without changing anything, e.g. to detect the values changed in place by mistake.
+func (heap *FibHeap) StaleTags() []interface{} {
+	if debugMode {
+		defer heap.guard.enter("StaleTags")()
+	}
+
+	var tags []interface{}
+	for tag, n := range heap.index {
+		if heap.stale(n) {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// refresh moves the node to the key of its value, and reports whether the key has changed.
+func (heap *FibHeap) refresh(n *node) (bool, error) {
+	if n.value == nil {
+		return false, errors.New("Tag has no value to refresh ")
+	}
+
+	key, current := n.value.Key(), heap.keyOf(n)
+	if math.IsNaN(key) {
+		return false, errors.New("Key of the value is NaN ")
+	}
+	if math.IsInf(key, -1) {
+		return false, errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if key < current {
+		return true, heap.decreaseKey(n, n.value, key)
+	}
+	if key > current {
+		return true, heap.increaseKey(n, n.value, key)
+	}
+
+	return false, nil
+}
+
+// stale reports whether the key of the value of the node differs from its key in the heap.
+func (heap *FibHeap) stale(n *node) bool {
+	return n.value != nil && n.value.Key() != heap.keyOf(n)
+}
@@ -0,0 +1,75 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"math/rand"
+)
+
+var _ = Describe("Tests of refresh", func() {
+	var heap *FibHeap
+	var values []*demoStruct
+
+	BeforeEach(func() {
+		heap = NewFibHeap()
+		values = nil
+		for i := 0; i < 1000; i++ {
+			values = append(values, &demoStruct{i, float64(i), ""})
+			heap.InsertValue(values[i])
+		}
+		heap.Insert("tag", 0.5)
+		heap.ExtractMin()
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given a fibHeap whose values are changed in place, when call Refresh api, it should move the values to their new keys.", func() {
+		values[500].key = -1
+		values[1].key = 2000
+		Expect(heap.StaleTags()).Should(ConsistOf(500, 1))
+
+		Expect(heap.Refresh(500)).Should(Succeed())
+		Expect(heap.Refresh(1)).Should(Succeed())
+		Expect(heap.Refresh(2)).Should(Succeed())
+		Expect(heap.StaleTags()).Should(BeEmpty())
+		Expect(heap.GetTag(1)).Should(BeEquivalentTo(2000))
+		min, _ := heap.ExtractMin()
+		Expect(min).Should(BeEquivalentTo(500))
+
+		Expect(heap.Refresh(nil)).Should(HaveOccurred())
+		Expect(heap.Refresh(500)).Should(HaveOccurred())
+		Expect(heap.Refresh("tag")).Should(HaveOccurred())
+		values[3].key = math.Inf(-1)
+		Expect(heap.Refresh(3)).Should(HaveOccurred())
+		values[3].key = math.NaN()
+		Expect(heap.Refresh(3)).Should(HaveOccurred())
+		Expect(heap.GetTag(3)).Should(BeEquivalentTo(3))
+	})
+
+	It("Given a fibHeap whose values are changed in place at random, when call RefreshAll api, it should restore the heap order.", func() {
+		random := rand.New(rand.NewSource(1899))
+		changed := 0
+		for i := 1; i < 1000; i += 3 {
+			values[i].key = float64(random.Intn(1000000))
+			changed++
+		}
+
+		refreshed, err := heap.RefreshAll()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(refreshed).Should(Equal(changed))
+		Expect(heap.StaleTags()).Should(BeEmpty())
+		Expect(checkHeapOrder(heap.roots, nil)).Should(Equal(1000))
+
+		previous := math.Inf(-1)
+		for heap.Num() != 0 {
+			_, key := heap.ExtractMin()
+			Expect(key).Should(BeNumerically(">=", previous))
+			previous = key
+		}
+	})
+})
@@ -0,0 +1,174 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+// Package scheduler implements a priority job scheduler with a pool of workers on top of the FibHeap.
+// The pending jobs are kept in a FibHeap keyed by their priority, so the workers always pick the job with the smallest priority,
+// and a pending job can be reprioritized in place by DecreaseKey or IncreaseKey.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+
+	fibHeap "github.com/starwander/GoFibonacciHeap"
+)
+
+// JobID identifies a submitted job.
+type JobID uint64
+
+// Scheduler runs the submitted jobs by a fixed number of workers in the order of their priorities.
+// As the keys of FibHeap, a smaller priority runs first. Jobs with the same priority run in no particular order.
+// All methods of Scheduler are concurrent safe.
+type Scheduler struct {
+	mutex   sync.Mutex
+	cond    *sync.Cond
+	heap    *fibHeap.FibHeap
+	jobs    map[JobID]func()
+	seq     JobID
+	closed  bool
+	workers sync.WaitGroup
+}
+
+// New creates a Scheduler and starts the input number of workers.
+// A number of workers smaller than 1 will cause a panic.
+func New(workers int) *Scheduler {
+	if workers < 1 {
+		panic("scheduler: number of workers must be at least 1")
+	}
+
+	scheduler := new(Scheduler)
+	scheduler.cond = sync.NewCond(&scheduler.mutex)
+	scheduler.heap = fibHeap.NewFibHeap()
+	scheduler.jobs = make(map[JobID]func())
+
+	scheduler.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go scheduler.work()
+	}
+
+	return scheduler
+}
+
+// Pending returns the number of jobs waiting for a worker.
+func (scheduler *Scheduler) Pending() uint {
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+
+	return scheduler.heap.Num()
+}
+
+// Submit puts the job with the priority into the scheduler and returns the id of the job.
+// The valid range of the priority is (-inf, +inf].
+// If the job is nil or the priority is invalid, or the scheduler has been shut down, an error will be returned.
+func (scheduler *Scheduler) Submit(job func(), priority float64) (JobID, error) {
+	if job == nil {
+		return 0, errors.New("Input job is nil ")
+	}
+	if math.IsNaN(priority) {
+		return 0, errors.New("Input priority is NaN ")
+	}
+
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+
+	if scheduler.closed {
+		return 0, errors.New("Scheduler is shut down ")
+	}
+
+	scheduler.seq++
+	if err := scheduler.heap.Insert(scheduler.seq, priority); err != nil {
+		return 0, err
+	}
+	scheduler.jobs[scheduler.seq] = job
+	scheduler.cond.Signal()
+
+	return scheduler.seq, nil
+}
+
+// Reprioritize changes the priority of a pending job.
+// If the job is not pending any more, or the priority is invalid, an error will be returned.
+func (scheduler *Scheduler) Reprioritize(id JobID, priority float64) error {
+	if math.IsNaN(priority) {
+		return errors.New("Input priority is NaN ")
+	}
+
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+
+	current := scheduler.heap.GetTag(id)
+	if math.IsInf(current, -1) {
+		return errors.New("Job is not found ")
+	}
+
+	if priority < current {
+		return scheduler.heap.DecreaseKey(id, priority)
+	}
+	if priority > current {
+		return scheduler.heap.IncreaseKey(id, priority)
+	}
+
+	return nil
+}
+
+// Cancel removes a pending job so that it will never run.
+// If the job is not pending any more, an error will be returned.
+func (scheduler *Scheduler) Cancel(id JobID) error {
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+
+	if err := scheduler.heap.Delete(id); err != nil {
+		return errors.New("Job is not found ")
+	}
+	delete(scheduler.jobs, id)
+
+	return nil
+}
+
+// Shutdown stops accepting new jobs and waits for the workers to finish all pending and running jobs.
+// If the context is done before that, the pending jobs are dropped, the running jobs are left to finish in the background,
+// and the error of the context will be returned.
+func (scheduler *Scheduler) Shutdown(ctx context.Context) error {
+	scheduler.mutex.Lock()
+	scheduler.closed = true
+	scheduler.cond.Broadcast()
+	scheduler.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		scheduler.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		scheduler.mutex.Lock()
+		scheduler.heap = fibHeap.NewFibHeap()
+		scheduler.jobs = make(map[JobID]func())
+		scheduler.mutex.Unlock()
+		return ctx.Err()
+	}
+}
+
+func (scheduler *Scheduler) work() {
+	defer scheduler.workers.Done()
+
+	for {
+		scheduler.mutex.Lock()
+		for scheduler.heap.Num() == 0 && !scheduler.closed {
+			scheduler.cond.Wait()
+		}
+		if scheduler.heap.Num() == 0 {
+			scheduler.mutex.Unlock()
+			return
+		}
+		id, _ := scheduler.heap.ExtractMin()
+		job := scheduler.jobs[id.(JobID)]
+		delete(scheduler.jobs, id.(JobID))
+		scheduler.mutex.Unlock()
+
+		job()
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package scheduler
+
+import (
+	"context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var _ = Describe("Tests of scheduler", func() {
+	var (
+		mutex sync.Mutex
+		order []int
+	)
+
+	record := func(i int) func() {
+		return func() {
+			mutex.Lock()
+			defer mutex.Unlock()
+			order = append(order, i)
+		}
+	}
+
+	recorded := func() []int {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]int(nil), order...)
+	}
+
+	BeforeEach(func() {
+		order = nil
+	})
+
+	It("Given invalid inputs, when call New and Submit api, it should panic or return error.", func() {
+		Expect(func() { New(0) }).Should(Panic())
+
+		scheduler := New(1)
+		_, err := scheduler.Submit(nil, 1)
+		Expect(err).Should(HaveOccurred())
+		_, err = scheduler.Submit(func() {}, math.NaN())
+		Expect(err).Should(HaveOccurred())
+		_, err = scheduler.Submit(func() {}, math.Inf(-1))
+		Expect(err).Should(HaveOccurred())
+		Expect(scheduler.Reprioritize(JobID(100), 1)).Should(HaveOccurred())
+		Expect(scheduler.Cancel(JobID(100))).Should(HaveOccurred())
+
+		Expect(scheduler.Shutdown(context.Background())).ShouldNot(HaveOccurred())
+		_, err = scheduler.Submit(func() {}, 1)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("Given a scheduler with a busy worker, when submit and reprioritize jobs, it should run them in the order of priorities.", func() {
+		scheduler := New(1)
+		block := make(chan struct{})
+		scheduler.Submit(func() { <-block }, 0)
+		Eventually(scheduler.Pending).Should(BeEquivalentTo(0))
+
+		scheduler.Submit(record(3), 3)
+		second, _ := scheduler.Submit(record(2), 5)
+		scheduler.Submit(record(1), 1)
+		fourth, _ := scheduler.Submit(record(4), 0)
+		cancelled, _ := scheduler.Submit(record(5), 2)
+		Expect(scheduler.Pending()).Should(BeEquivalentTo(5))
+
+		Expect(scheduler.Reprioritize(second, 2)).ShouldNot(HaveOccurred())
+		Expect(scheduler.Reprioritize(fourth, 4)).ShouldNot(HaveOccurred())
+		Expect(scheduler.Reprioritize(fourth, 4)).ShouldNot(HaveOccurred())
+		Expect(scheduler.Reprioritize(fourth, math.NaN())).Should(HaveOccurred())
+		Expect(scheduler.Cancel(cancelled)).ShouldNot(HaveOccurred())
+		close(block)
+
+		Expect(scheduler.Shutdown(context.Background())).ShouldNot(HaveOccurred())
+		Expect(recorded()).Should(Equal([]int{1, 2, 3, 4}))
+		Expect(scheduler.Cancel(second)).Should(HaveOccurred())
+	})
+
+	It("Given a scheduler with multiple workers, when submit many jobs, it should run all of them before shutdown returns.", func() {
+		scheduler := New(8)
+		var count int32
+		for i := 0; i < 10000; i++ {
+			_, err := scheduler.Submit(func() { atomic.AddInt32(&count, 1) }, float64(i%100))
+			Expect(err).ShouldNot(HaveOccurred())
+		}
+
+		Expect(scheduler.Shutdown(context.Background())).ShouldNot(HaveOccurred())
+		Expect(atomic.LoadInt32(&count)).Should(BeEquivalentTo(10000))
+	})
+
+	It("Given a scheduler with slow jobs, when the shutdown context expires, it should drop the pending jobs and return the error.", func() {
+		scheduler := New(1)
+		var count int32
+		for i := 0; i < 10; i++ {
+			scheduler.Submit(func() {
+				time.Sleep(50 * time.Millisecond)
+				atomic.AddInt32(&count, 1)
+			}, float64(i))
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		Expect(scheduler.Shutdown(ctx)).Should(Equal(context.DeadlineExceeded))
+		Expect(scheduler.Pending()).Should(BeEquivalentTo(0))
+		Eventually(func() int32 { return atomic.LoadInt32(&count) }).Should(BeEquivalentTo(1))
+		Consistently(func() int32 { return atomic.LoadInt32(&count) }, 100*time.Millisecond).Should(BeEquivalentTo(1))
+	})
+})
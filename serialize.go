@@ -0,0 +1,445 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"math"
+)
+
+// FormatVersion is the version of the format written by Marshal.
+// Unmarshal also reads the version 1, which has no compressor in the header.
+const FormatVersion = 2
+
+var formatMagic = [4]byte{'F', 'I', 'B', 'H'}
+
+var (
+	// ErrCorrupted is returned by Unmarshal if the input is not a serialized heap, is truncated or fails the checksum.
+	ErrCorrupted = errors.New("Serialized heap is corrupted ")
+	// ErrVersion is returned by Unmarshal if the input is written in an incompatible format version.
+	ErrVersion = errors.New("Serialized heap has an incompatible format version ")
+)
+
+// Marshal writes all values in the heap to the input writer in a self-describing binary format without compression,
+// which starts with a format version and a CRC-32 checksum of the content so that Unmarshal can detect corruption.
+// The values are encoded as Export does, and the tags must be booleans, strings, byte slices, numbers of the builtin types, Handles or composite tags of them.
+// If any tag or value cannot be encoded, an error will be returned and nothing will be written.
+func (heap *FibHeap) Marshal(w io.Writer) error {
+	if debugMode {
+		defer heap.guard.enter("Marshal")()
+	}
+
+	return heap.MarshalCompressed(w, "")
+}
+
+// MarshalCompressed writes the heap as Marshal does, but compresses the content by the input compressor registered by RegisterCompressor.
+// The name of the compressor is recorded in the output, so Unmarshal decompresses it automatically.
+// An empty name writes the content uncompressed. An unregistered name will cause an error return.
+func (heap *FibHeap) MarshalCompressed(w io.Writer, compressor string) error {
+	if debugMode {
+		defer heap.guard.enter("MarshalCompressed")()
+	}
+
+	entries, err := heap.Export()
+	if err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+	writeUvarint(&body, uint64(len(entries)))
+	for _, entry := range entries {
+		if err := writeEntry(&body, entry); err != nil {
+			return err
+		}
+	}
+
+	stored, err := compress(compressor, body.Bytes())
+	if err != nil {
+		return err
+	}
+
+	var header bytes.Buffer
+	header.Write(formatMagic[:])
+	header.WriteByte(FormatVersion)
+	writeBytes(&header, []byte(compressor))
+	binary.Write(&header, binary.BigEndian, crc32.ChecksumIEEE(body.Bytes()))
+	writeUvarint(&header, uint64(len(stored)))
+
+	if _, err := w.Write(header.Bytes()); err != nil {
+		return err
+	}
+	_, err = w.Write(stored)
+
+	return err
+}
+
+// Unmarshal reads a heap written by Marshal or MarshalCompressed from the input reader.
+// It returns ErrCorrupted if the input is not a serialized heap, is truncated or fails the checksum,
+// and ErrVersion if the input is written in an unknown format version.
+// The types of the values and the compressor must be registered in advance.
+func Unmarshal(r io.Reader) (*FibHeap, error) {
+	entries, err := UnmarshalEntries(r)
+	if err != nil {
+		return nil, err
+	}
+
+	heap := NewFibHeap()
+	if err := heap.Import(entries); err != nil {
+		return nil, err
+	}
+
+	return heap, nil
+}
+
+// UnmarshalEntries reads the entries of a heap written by Marshal or MarshalCompressed from the input reader, without decoding the values,
+// e.g. for tools inspecting a heap whose value types are not registered. The entries can be pushed into a heap by Import.
+// It returns the same errors as Unmarshal, and the compressor must be registered in advance.
+func UnmarshalEntries(r io.Reader) ([]Entry, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, ErrCorrupted
+	}
+	if !bytes.Equal(header[:4], formatMagic[:]) {
+		return nil, ErrCorrupted
+	}
+	if header[4] != 1 && header[4] != FormatVersion {
+		return nil, ErrVersion
+	}
+
+	var compressor []byte
+	if header[4] != 1 {
+		var err error
+		if compressor, err = readBytes(r, 256); err != nil {
+			return nil, err
+		}
+	}
+	var checksum uint32
+	if err := binary.Read(r, binary.BigEndian, &checksum); err != nil {
+		return nil, ErrCorrupted
+	}
+	stored, err := readBytes(r, math.MaxInt64)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := decompress(string(compressor), stored)
+	if err != nil {
+		return nil, err
+	}
+	if crc32.ChecksumIEEE(body) != checksum {
+		return nil, ErrCorrupted
+	}
+
+	reader := &bodyReader{data: body}
+	count := reader.uvarint()
+	if count > uint64(len(body)) {
+		return nil, ErrCorrupted
+	}
+	entries := make([]Entry, 0, count)
+	for i := uint64(0); i < count; i++ {
+		entries = append(entries, reader.entry())
+	}
+	if reader.err != nil || len(reader.data) != 0 {
+		return nil, ErrCorrupted
+	}
+
+	return entries, nil
+}
+
+// readBytes reads what writeBytes writes from the input reader without reading ahead.
+// A length over the input limit or a truncated input will cause ErrCorrupted.
+func readBytes(r io.Reader, limit uint64) ([]byte, error) {
+	length, err := binary.ReadUvarint(byteReader{r})
+	if err != nil || length > limit {
+		return nil, ErrCorrupted
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(r, int64(length)))
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(data)) != length {
+		return nil, ErrCorrupted
+	}
+
+	return data, nil
+}
+
+// Kinds of the encoded tags.
+const (
+	tagBool byte = iota
+	tagString
+	tagInt
+	tagInt8
+	tagInt16
+	tagInt32
+	tagInt64
+	tagUint
+	tagUint8
+	tagUint16
+	tagUint32
+	tagUint64
+	tagFloat32
+	tagFloat64
+	tagHandle
+	tagComposite
+	tagBytes
+)
+
+func writeEntry(buffer *bytes.Buffer, entry Entry) error {
+	if err := writeTag(buffer, entry.Tag); err != nil {
+		return err
+	}
+	writeFloat(buffer, entry.Key)
+	writeBytes(buffer, []byte(entry.Type))
+	writeBytes(buffer, entry.Value)
+
+	return nil
+}
+
+func writeTag(buffer *bytes.Buffer, tag interface{}) error {
+	switch t := tag.(type) {
+	case bool:
+		buffer.WriteByte(tagBool)
+		if t {
+			buffer.WriteByte(1)
+		} else {
+			buffer.WriteByte(0)
+		}
+	case string:
+		buffer.WriteByte(tagString)
+		writeBytes(buffer, []byte(t))
+	case int:
+		buffer.WriteByte(tagInt)
+		writeVarint(buffer, int64(t))
+	case int8:
+		buffer.WriteByte(tagInt8)
+		writeVarint(buffer, int64(t))
+	case int16:
+		buffer.WriteByte(tagInt16)
+		writeVarint(buffer, int64(t))
+	case int32:
+		buffer.WriteByte(tagInt32)
+		writeVarint(buffer, int64(t))
+	case int64:
+		buffer.WriteByte(tagInt64)
+		writeVarint(buffer, t)
+	case uint:
+		buffer.WriteByte(tagUint)
+		writeUvarint(buffer, uint64(t))
+	case uint8:
+		buffer.WriteByte(tagUint8)
+		writeUvarint(buffer, uint64(t))
+	case uint16:
+		buffer.WriteByte(tagUint16)
+		writeUvarint(buffer, uint64(t))
+	case uint32:
+		buffer.WriteByte(tagUint32)
+		writeUvarint(buffer, uint64(t))
+	case uint64:
+		buffer.WriteByte(tagUint64)
+		writeUvarint(buffer, t)
+	case float32:
+		buffer.WriteByte(tagFloat32)
+		writeFloat(buffer, float64(t))
+	case float64:
+		buffer.WriteByte(tagFloat64)
+		writeFloat(buffer, t)
+	case Handle:
+		buffer.WriteByte(tagHandle)
+		writeUvarint(buffer, uint64(t))
+	case BytesTag:
+		buffer.WriteByte(tagBytes)
+		writeBytes(buffer, []byte(t))
+	case []byte:
+		buffer.WriteByte(tagBytes)
+		writeBytes(buffer, t)
+	case CompositeTag:
+		buffer.WriteByte(tagComposite)
+		parts := t.Parts()
+		writeUvarint(buffer, uint64(len(parts)))
+		for _, part := range parts {
+			if err := writeTag(buffer, part); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("Tag type %T is not supported ", tag)
+	}
+
+	return nil
+}
+
+func writeUvarint(buffer *bytes.Buffer, x uint64) {
+	var scratch [binary.MaxVarintLen64]byte
+	buffer.Write(scratch[:binary.PutUvarint(scratch[:], x)])
+}
+
+func writeVarint(buffer *bytes.Buffer, x int64) {
+	var scratch [binary.MaxVarintLen64]byte
+	buffer.Write(scratch[:binary.PutVarint(scratch[:], x)])
+}
+
+func writeFloat(buffer *bytes.Buffer, x float64) {
+	var scratch [8]byte
+	binary.BigEndian.PutUint64(scratch[:], math.Float64bits(x))
+	buffer.Write(scratch[:])
+}
+
+func writeBytes(buffer *bytes.Buffer, data []byte) {
+	writeUvarint(buffer, uint64(len(data)))
+	buffer.Write(data)
+}
+
+// bodyReader decodes what the write functions encode.
+// The first error is kept in err and all following reads return zero values.
+type bodyReader struct {
+	data []byte
+	err  error
+}
+
+func (reader *bodyReader) fail() {
+	reader.err = ErrCorrupted
+	reader.data = nil
+}
+
+func (reader *bodyReader) byte() byte {
+	if len(reader.data) < 1 {
+		reader.fail()
+		return 0
+	}
+	b := reader.data[0]
+	reader.data = reader.data[1:]
+
+	return b
+}
+
+func (reader *bodyReader) uvarint() uint64 {
+	x, n := binary.Uvarint(reader.data)
+	if n <= 0 {
+		reader.fail()
+		return 0
+	}
+	reader.data = reader.data[n:]
+
+	return x
+}
+
+func (reader *bodyReader) varint() int64 {
+	x, n := binary.Varint(reader.data)
+	if n <= 0 {
+		reader.fail()
+		return 0
+	}
+	reader.data = reader.data[n:]
+
+	return x
+}
+
+func (reader *bodyReader) float() float64 {
+	if len(reader.data) < 8 {
+		reader.fail()
+		return 0
+	}
+	x := math.Float64frombits(binary.BigEndian.Uint64(reader.data))
+	reader.data = reader.data[8:]
+
+	return x
+}
+
+func (reader *bodyReader) bytes() []byte {
+	length := reader.uvarint()
+	if length > uint64(len(reader.data)) {
+		reader.fail()
+		return nil
+	}
+	data := reader.data[:length:length]
+	reader.data = reader.data[length:]
+
+	return data
+}
+
+func (reader *bodyReader) tag() interface{} {
+	switch kind := reader.byte(); kind {
+	case tagBool:
+		return reader.byte() != 0
+	case tagString:
+		return string(reader.bytes())
+	case tagInt:
+		return int(reader.varint())
+	case tagInt8:
+		return int8(reader.varint())
+	case tagInt16:
+		return int16(reader.varint())
+	case tagInt32:
+		return int32(reader.varint())
+	case tagInt64:
+		return reader.varint()
+	case tagUint:
+		return uint(reader.uvarint())
+	case tagUint8:
+		return uint8(reader.uvarint())
+	case tagUint16:
+		return uint16(reader.uvarint())
+	case tagUint32:
+		return uint32(reader.uvarint())
+	case tagUint64:
+		return reader.uvarint()
+	case tagFloat32:
+		return float32(reader.float())
+	case tagFloat64:
+		return reader.float()
+	case tagHandle:
+		handle := Handle(reader.uvarint())
+		observeHandle(handle)
+		return handle
+	case tagBytes:
+		return BytesTag(reader.bytes())
+	case tagComposite:
+		// Every part takes at least one byte, so a larger size is corrupted rather than a reason to allocate.
+		size := reader.uvarint()
+		if size > uint64(len(reader.data)) {
+			break
+		}
+		parts := make([]interface{}, size)
+		for i := range parts {
+			parts[i] = reader.tag()
+		}
+		return TagOf(parts...)
+	}
+
+	reader.fail()
+
+	return nil
+}
+
+func (reader *bodyReader) entry() Entry {
+	var entry Entry
+	entry.Tag = reader.tag()
+	entry.Key = reader.float()
+	entry.Type = string(reader.bytes())
+	entry.Value = reader.bytes()
+	if len(entry.Value) == 0 && entry.Type == "" {
+		entry.Value = nil
+	}
+
+	return entry
+}
+
+// byteReader reads the input reader byte by byte, so that binary.ReadUvarint never reads ahead.
+type byteReader struct {
+	io.Reader
+}
+
+func (r byteReader) ReadByte() (byte, error) {
+	var b [1]byte
+	_, err := io.ReadFull(r.Reader, b[:])
+
+	return b[0], err
+}
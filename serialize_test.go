@@ -0,0 +1,198 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"compress/flate"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"io"
+	"io/ioutil"
+	"math"
+)
+
+var _ = Describe("Tests of serialize", func() {
+	var (
+		heap *FibHeap
+	)
+
+	BeforeEach(func() {
+		heap = NewFibHeap()
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given a fibHeap with tags of all supported types, when Marshal and Unmarshal, it should restore the same heap.", func() {
+		tags := []interface{}{true, "tag", int(-1), int8(-2), int16(-3), int32(-4), int64(-5),
+			uint(6), uint8(7), uint16(8), uint32(9), uint64(10), float32(1.5), float64(2.5)}
+		for i, tag := range tags {
+			Expect(heap.Insert(tag, float64(i))).ShouldNot(HaveOccurred())
+		}
+		heap.Insert(false, math.Inf(1))
+		for i := 100; i < 200; i++ {
+			heap.InsertValue(&payload{i, float64(-i), "payload"})
+		}
+
+		var buffer bytes.Buffer
+		Expect(heap.Marshal(&buffer)).ShouldNot(HaveOccurred())
+		restored, err := Unmarshal(&buffer)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(restored.Equal(heap)).Should(BeTrue())
+		Expect(restored.GetTag(uint16(8))).Should(BeEquivalentTo(9))
+		Expect(restored.GetTag(false)).Should(BeEquivalentTo(math.Inf(1)))
+		Expect(restored.GetValue(150)).Should(Equal(&payload{150, -150, "payload"}))
+		Expect(restored.GetValue("tag")).Should(BeNil())
+	})
+
+	It("Given an empty fibHeap, when Marshal and Unmarshal, it should restore an empty heap.", func() {
+		var buffer bytes.Buffer
+		Expect(heap.Marshal(&buffer)).ShouldNot(HaveOccurred())
+		restored, err := Unmarshal(&buffer)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(restored.Num()).Should(BeEquivalentTo(0))
+	})
+
+	It("Given a fibHeap with an unsupported tag or value, when call Marshal api, it should return error and write nothing.", func() {
+		var buffer bytes.Buffer
+		heap.Insert([2]int{1, 2}, 1)
+		Expect(heap.Marshal(&buffer)).Should(HaveOccurred())
+
+		heap = NewFibHeap()
+		heap.InsertValue(&demoStruct{1, 1, "1"})
+		Expect(heap.Marshal(&buffer)).Should(HaveOccurred())
+		Expect(buffer.Len()).Should(Equal(0))
+	})
+
+	It("Given a serialized fibHeap, when call UnmarshalEntries api, it should return the entries with the values undecoded.", func() {
+		heap.Insert("tag", 1)
+		heap.InsertValue(&payload{1, 0, "payload"})
+
+		var buffer bytes.Buffer
+		Expect(heap.Marshal(&buffer)).ShouldNot(HaveOccurred())
+		entries, err := UnmarshalEntries(bytes.NewReader(buffer.Bytes()))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(entries).Should(HaveLen(2))
+		Expect(entries[0].Tag).Should(Equal(1))
+		Expect(entries[0].Type).Should(Equal("fibHeap.payload"))
+		Expect(string(entries[0].Value)).Should(ContainSubstring("payload"))
+		Expect(entries[1]).Should(Equal(Entry{"tag", 1, "", nil}))
+
+		_, err = UnmarshalEntries(bytes.NewReader(buffer.Bytes()[:buffer.Len()-1]))
+		Expect(err).Should(Equal(ErrCorrupted))
+	})
+
+	It("Given corrupted data, when call Unmarshal api, it should return ErrCorrupted.", func() {
+		for i := 0; i < 10; i++ {
+			heap.InsertValue(&payload{i, float64(i), "payload"})
+		}
+		var buffer bytes.Buffer
+		heap.Marshal(&buffer)
+		data := buffer.Bytes()
+
+		for _, n := range []int{0, 3, 8, len(data) / 2, len(data) - 1} {
+			_, err := Unmarshal(bytes.NewReader(data[:n]))
+			Expect(err).Should(Equal(ErrCorrupted))
+		}
+
+		for _, i := range []int{0, 6, 12, len(data) - 1} {
+			corrupted := append([]byte(nil), data...)
+			corrupted[i] ^= 0x40
+			_, err := Unmarshal(bytes.NewReader(corrupted))
+			Expect(err).Should(Equal(ErrCorrupted))
+		}
+	})
+
+	It("Given data of another format version, when call Unmarshal api, it should return ErrVersion.", func() {
+		var buffer bytes.Buffer
+		heap.Marshal(&buffer)
+		data := buffer.Bytes()
+		data[4] = FormatVersion + 1
+
+		restored, err := Unmarshal(bytes.NewReader(data))
+		Expect(err).Should(Equal(ErrVersion))
+		Expect(restored).Should(BeNil())
+	})
+
+	It("Given data written in the format version 1, when call Unmarshal api, it should restore the heap.", func() {
+		// Written by Marshal of the version 1 from the tags "a" and 7 and a payload of ID 3.
+		data := []byte{
+			0x46, 0x49, 0x42, 0x48, 0x1, 0x5c, 0xc9, 0x4a, 0x94, 0x5c, 0x3, 0x2, 0xe, 0xc0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
+			0x0, 0x0, 0x0, 0x2, 0x6, 0x3f, 0xd0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0xf, 0x66, 0x69, 0x62, 0x48, 0x65, 0x61,
+			0x70, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x27, 0x7b, 0x22, 0x49, 0x44, 0x22, 0x3a, 0x33, 0x2c, 0x22, 0x50,
+			0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0x3a, 0x30, 0x2e, 0x32, 0x35, 0x2c, 0x22, 0x54, 0x65, 0x78, 0x74, 0x22,
+			0x3a, 0x22, 0x74, 0x68, 0x72, 0x65, 0x65, 0x22, 0x7d, 0x1, 0x1, 0x61, 0x3f, 0xf8, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
+			0x0, 0x0,
+		}
+
+		restored, err := Unmarshal(bytes.NewReader(data))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(restored.Num()).Should(BeEquivalentTo(3))
+		Expect(restored.GetTag("a")).Should(Equal(1.5))
+		Expect(restored.GetTag(7)).Should(Equal(-2.0))
+		Expect(restored.GetValue(3)).Should(Equal(&payload{ID: 3, Priority: 0.25, Text: "three"}))
+
+		data[len(data)-1] ^= 0x40
+		_, err = Unmarshal(bytes.NewReader(data))
+		Expect(err).Should(Equal(ErrCorrupted))
+	})
+
+	It("Given a fibHeap, when MarshalCompressed with gzip and Unmarshal, it should restore the same heap from a smaller output.", func() {
+		for i := 0; i < 1000; i++ {
+			heap.InsertValue(&payload{i, float64(i % 10), "a payload which compresses well"})
+		}
+
+		var plain, compressed bytes.Buffer
+		Expect(heap.Marshal(&plain)).ShouldNot(HaveOccurred())
+		Expect(heap.MarshalCompressed(&compressed, "gzip")).ShouldNot(HaveOccurred())
+		Expect(compressed.Len()).Should(BeNumerically("<", plain.Len()/4))
+
+		restored, err := Unmarshal(&compressed)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(restored.Equal(heap)).Should(BeTrue())
+		Expect(restored.GetValue(999)).Should(Equal(&payload{999, 9, "a payload which compresses well"}))
+	})
+
+	It("Given a registered compressor, when MarshalCompressed with it and Unmarshal, it should compress through the injected streams.", func() {
+		written := 0
+		RegisterCompressor("test-flate", Compressor{
+			NewWriter: func(w io.Writer) (io.WriteCloser, error) {
+				written++
+				return flate.NewWriter(w, flate.BestSpeed)
+			},
+			NewReader: func(r io.Reader) (io.ReadCloser, error) { return flate.NewReader(r), nil },
+		})
+		for i := 0; i < 100; i++ {
+			heap.Insert(i, float64(i))
+		}
+
+		var buffer bytes.Buffer
+		Expect(heap.MarshalCompressed(&buffer, "test-flate")).ShouldNot(HaveOccurred())
+		Expect(written).Should(Equal(1))
+		restored, err := Unmarshal(&buffer)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(restored.Equal(heap)).Should(BeTrue())
+
+		Expect(func() { RegisterCompressor("test-flate", Compressor{}) }).Should(Panic())
+		Expect(func() { RegisterCompressor("", Compressor{}) }).Should(Panic())
+		Expect(func() { RegisterCompressor("incomplete", Compressor{NewWriter: nil}) }).Should(Panic())
+	})
+
+	It("Given an unknown compressor or corrupted compressed data, when MarshalCompressed or Unmarshal, it should return error.", func() {
+		heap.Insert(1, 1)
+		var buffer bytes.Buffer
+		Expect(heap.MarshalCompressed(&buffer, "unknown")).Should(HaveOccurred())
+		Expect(buffer.Len()).Should(Equal(0))
+
+		Expect(heap.MarshalCompressed(&buffer, "gzip")).ShouldNot(HaveOccurred())
+		data, _ := ioutil.ReadAll(&buffer)
+		corrupted := append([]byte(nil), data...)
+		corrupted[len(corrupted)-5] ^= 0xff
+		_, err := Unmarshal(bytes.NewReader(corrupted))
+		Expect(err).Should(Equal(ErrCorrupted))
+		_, err = Unmarshal(bytes.NewReader(data[:len(data)-1]))
+		Expect(err).Should(Equal(ErrCorrupted))
+	})
+})
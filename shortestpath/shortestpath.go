@@ -0,0 +1,153 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+// Package shortestpath implements the single source shortest path algorithms on top of the FibHeap.
+// Both Dijkstra and A* rely on DecreaseKey to update the tentative distance of a vertex in place,
+// which is the canonical use case of the Fibonacci Heap.
+package shortestpath
+
+import (
+	"errors"
+	"math"
+
+	fibHeap "github.com/starwander/GoFibonacciHeap"
+)
+
+// Edge is a directed edge to the vertex To with a non-negative Weight.
+type Edge struct {
+	To     interface{}
+	Weight float64
+}
+
+// Graph is the interface of a directed graph which the algorithms search on.
+// The vertices are used as tags of the heap, so they must be comparable and not nil.
+type Graph interface {
+	// Edges returns all edges going out from the vertex.
+	Edges(vertex interface{}) []Edge
+}
+
+// AdjacencyList is a Graph backed by a map from every vertex to its outgoing edges.
+type AdjacencyList map[interface{}][]Edge
+
+// Edges returns all edges going out from the vertex.
+func (graph AdjacencyList) Edges(vertex interface{}) []Edge {
+	return graph[vertex]
+}
+
+// AddEdge adds a directed edge from the vertex from to the vertex to.
+func (graph AdjacencyList) AddEdge(from, to interface{}, weight float64) {
+	graph[from] = append(graph[from], Edge{to, weight})
+}
+
+// Heuristic estimates the cost from the vertex to the target of A*.
+// It must never overestimate the real cost, otherwise the path found may not be the shortest one.
+type Heuristic func(vertex interface{}) float64
+
+// Dijkstra calculates the shortest distances from the source to all reachable vertices.
+// It returns the distance and the previous vertex on the shortest path of every reachable vertex.
+// The previous vertex of the source is nil. Use Path to rebuild the path to a vertex.
+// If an edge with a negative weight is met, an error will be returned.
+func Dijkstra(graph Graph, source interface{}) (distances map[interface{}]float64, previous map[interface{}]interface{}, err error) {
+	if source == nil {
+		return nil, nil, errors.New("Input source is nil ")
+	}
+
+	distances = make(map[interface{}]float64)
+	previous = map[interface{}]interface{}{source: nil}
+	done := make(map[interface{}]bool)
+
+	heap := fibHeap.NewFibHeap()
+	heap.Insert(source, 0)
+	for heap.Num() > 0 {
+		vertex, distance := heap.ExtractMin()
+		distances[vertex] = distance
+		done[vertex] = true
+
+		for _, edge := range graph.Edges(vertex) {
+			if edge.Weight < 0 {
+				return nil, nil, errors.New("Negative edge weight is not allowed ")
+			}
+			if done[edge.To] {
+				continue
+			}
+
+			if relax(heap, edge.To, distance+edge.Weight) {
+				previous[edge.To] = vertex
+			}
+		}
+	}
+
+	return distances, previous, nil
+}
+
+// AStar searches the shortest path from the source to the target guided by the heuristic.
+// It returns all vertices on the path, from the source to the target, and the total cost of the path.
+// A nil heuristic makes AStar behave like Dijkstra stopped at the target.
+// If the heuristic is admissible but not consistent, vertices are reopened so the returned path is still the shortest one.
+// If the target is not reachable, a nil path and +inf will be returned.
+// If an edge with a negative weight is met, an error will be returned.
+func AStar(graph Graph, source, target interface{}, heuristic Heuristic) (path []interface{}, cost float64, err error) {
+	if source == nil || target == nil {
+		return nil, math.Inf(1), errors.New("Input source or target is nil ")
+	}
+	if heuristic == nil {
+		heuristic = func(interface{}) float64 { return 0 }
+	}
+
+	costs := map[interface{}]float64{source: 0}
+	previous := map[interface{}]interface{}{source: nil}
+
+	heap := fibHeap.NewFibHeap()
+	heap.Insert(source, heuristic(source))
+	for heap.Num() > 0 {
+		vertex, _ := heap.ExtractMin()
+		if vertex == target {
+			return Path(previous, target), costs[target], nil
+		}
+
+		for _, edge := range graph.Edges(vertex) {
+			if edge.Weight < 0 {
+				return nil, math.Inf(1), errors.New("Negative edge weight is not allowed ")
+			}
+
+			newCost := costs[vertex] + edge.Weight
+			if oldCost, visited := costs[edge.To]; visited && newCost >= oldCost {
+				continue
+			}
+
+			costs[edge.To] = newCost
+			previous[edge.To] = vertex
+			relax(heap, edge.To, newCost+heuristic(edge.To))
+		}
+	}
+
+	return nil, math.Inf(1), nil
+}
+
+// Path rebuilds the path from the source to the target by the previous vertices returned by Dijkstra.
+// If the target is not reachable, nil will be returned.
+func Path(previous map[interface{}]interface{}, target interface{}) []interface{} {
+	if _, reachable := previous[target]; !reachable {
+		return nil
+	}
+
+	var path []interface{}
+	for vertex := target; vertex != nil; vertex = previous[vertex] {
+		path = append(path, vertex)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path
+}
+
+// relax inserts the vertex with the key or decreases its key in the heap.
+// It returns false if the vertex is already in the heap with a key not larger than the input key.
+func relax(heap *fibHeap.FibHeap, vertex interface{}, key float64) bool {
+	if math.IsInf(heap.GetTag(vertex), -1) {
+		heap.Insert(vertex, key)
+		return true
+	}
+
+	return heap.DecreaseKey(vertex, key) == nil
+}
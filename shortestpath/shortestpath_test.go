@@ -0,0 +1,158 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package shortestpath
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"math/rand"
+	"time"
+)
+
+type cell struct {
+	x, y int
+}
+
+// newGrid creates a size*size 4-connected grid with random blocked cells and random weights not less than 1.
+func newGrid(random *rand.Rand, size int) AdjacencyList {
+	graph := make(AdjacencyList)
+	blocked := make(map[cell]bool)
+	for i := 0; i < size*size/5; i++ {
+		blocked[cell{random.Intn(size), random.Intn(size)}] = true
+	}
+	delete(blocked, cell{0, 0})
+	delete(blocked, cell{size - 1, size - 1})
+
+	for x := 0; x < size; x++ {
+		for y := 0; y < size; y++ {
+			from := cell{x, y}
+			if blocked[from] {
+				continue
+			}
+			for _, to := range []cell{{x + 1, y}, {x - 1, y}, {x, y + 1}, {x, y - 1}} {
+				if to.x >= 0 && to.x < size && to.y >= 0 && to.y < size && !blocked[to] {
+					graph.AddEdge(from, to, float64(1+random.Intn(5)))
+				}
+			}
+		}
+	}
+
+	return graph
+}
+
+func manhattan(target cell) Heuristic {
+	return func(vertex interface{}) float64 {
+		return math.Abs(float64(vertex.(cell).x-target.x)) + math.Abs(float64(vertex.(cell).y-target.y))
+	}
+}
+
+func pathCost(graph AdjacencyList, path []interface{}) float64 {
+	cost := 0.0
+	for i := 1; i < len(path); i++ {
+		found := false
+		for _, edge := range graph.Edges(path[i-1]) {
+			if edge.To == path[i] {
+				cost += edge.Weight
+				found = true
+				break
+			}
+		}
+		Expect(found).Should(BeTrue())
+	}
+
+	return cost
+}
+
+var _ = Describe("Tests of shortestpath", func() {
+	var (
+		graph AdjacencyList
+	)
+
+	BeforeEach(func() {
+		graph = make(AdjacencyList)
+		graph.AddEdge("a", "b", 7)
+		graph.AddEdge("a", "c", 9)
+		graph.AddEdge("a", "f", 14)
+		graph.AddEdge("b", "c", 10)
+		graph.AddEdge("b", "d", 15)
+		graph.AddEdge("c", "d", 11)
+		graph.AddEdge("c", "f", 2)
+		graph.AddEdge("d", "e", 6)
+		graph.AddEdge("f", "e", 9)
+	})
+
+	AfterEach(func() {
+		graph = nil
+	})
+
+	It("Given a graph, when call Dijkstra api, it should return the shortest distances and paths to all reachable vertices.", func() {
+		distances, previous, err := Dijkstra(graph, "a")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(distances).Should(Equal(map[interface{}]float64{"a": 0, "b": 7, "c": 9, "d": 20, "e": 20, "f": 11}))
+		Expect(Path(previous, "e")).Should(Equal([]interface{}{"a", "c", "f", "e"}))
+		Expect(Path(previous, "a")).Should(Equal([]interface{}{"a"}))
+		Expect(Path(previous, "z")).Should(BeNil())
+	})
+
+	It("Given a graph, when call AStar api, it should return the shortest path to the target.", func() {
+		path, cost, err := AStar(graph, "a", "e", nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(path).Should(Equal([]interface{}{"a", "c", "f", "e"}))
+		Expect(cost).Should(BeEquivalentTo(20))
+
+		path, cost, err = AStar(graph, "e", "a", nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(path).Should(BeNil())
+		Expect(math.IsInf(cost, 1)).Should(BeTrue())
+	})
+
+	It("Given invalid inputs, when call Dijkstra and AStar api, it should return error.", func() {
+		_, _, err := Dijkstra(graph, nil)
+		Expect(err).Should(HaveOccurred())
+		_, _, err = AStar(graph, "a", nil, nil)
+		Expect(err).Should(HaveOccurred())
+
+		graph.AddEdge("e", "a", -1)
+		_, _, err = Dijkstra(graph, "a")
+		Expect(err).Should(HaveOccurred())
+		_, _, err = AStar(graph, "a", "z", nil)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("Given an admissible but inconsistent heuristic, when call AStar api, it should still return the shortest path.", func() {
+		graph = make(AdjacencyList)
+		graph.AddEdge("s", "a", 1)
+		graph.AddEdge("s", "b", 4)
+		graph.AddEdge("a", "b", 1)
+		graph.AddEdge("b", "t", 5)
+		estimates := map[interface{}]float64{"s": 0, "a": 5, "b": 0, "t": 0}
+
+		path, cost, err := AStar(graph, "s", "t", func(vertex interface{}) float64 { return estimates[vertex] })
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(path).Should(Equal([]interface{}{"s", "a", "b", "t"}))
+		Expect(cost).Should(BeEquivalentTo(7))
+	})
+
+	It("Given random grids, when call AStar api with the manhattan heuristic, it should return paths as short as Dijkstra.", func() {
+		random := rand.New(rand.NewSource(time.Now().Unix()))
+		for round := 0; round < 20; round++ {
+			grid := newGrid(random, 30)
+			source, target := cell{0, 0}, cell{29, 29}
+
+			distances, _, err := Dijkstra(grid, source)
+			Expect(err).ShouldNot(HaveOccurred())
+			path, cost, err := AStar(grid, source, target, manhattan(target))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			if distance, reachable := distances[target]; reachable {
+				Expect(cost).Should(BeEquivalentTo(distance))
+				Expect(path[0]).Should(Equal(source))
+				Expect(path[len(path)-1]).Should(Equal(target))
+				Expect(pathCost(grid, path)).Should(BeEquivalentTo(cost))
+			} else {
+				Expect(path).Should(BeNil())
+			}
+		}
+	})
+})
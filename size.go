@@ -0,0 +1,92 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"container/list"
+	"unsafe"
+)
+
+const (
+	// pointerBytes and interfaceBytes are the sizes of a pointer and of an interface, e.g. a tag.
+	pointerBytes   = uint64(unsafe.Sizeof(uintptr(0)))
+	interfaceBytes = uint64(unsafe.Sizeof(interface{}(nil)))
+	// mapBytes is the size of the header of a map, and chanBytes is the size of the header of a channel.
+	mapBytes  = 48
+	chanBytes = 96
+)
+
+// SizeBytes estimates the memory used by the heap in bytes, for capacity planning and quotas, e.g. per tenant.
+// It counts the nodes of all values, including the dead nodes of the lazy deletion mode, their list elements,
+// the index and the other maps by tags, the degree table, the free nodes of the pool of a HeapGroup,
+// the ordered index, the quantile sketch and the channels of Watch.
+// The values and the tags themselves are not counted beyond their interfaces, as the heap only refers to them,
+// and the maps and the levels of the ordered index are estimated by the numbers of their entries,
+// so the actual usage may differ by the slack of the runtime. It runs in O(1) apart from a pass over the watched tags.
+func (heap *FibHeap) SizeBytes() uint64 {
+	if debugMode {
+		defer heap.guard.enter("SizeBytes")()
+	}
+
+	return heap.sizeBytes()
+}
+
+func (heap *FibHeap) sizeBytes() uint64 {
+	size := uint64(unsafe.Sizeof(*heap)) + uint64(unsafe.Sizeof(list.List{}))
+	size += uint64(heap.num+heap.dead) * nodeBytes
+	size += mapSize(len(heap.index), interfaceBytes, pointerBytes)
+	if heap.counts != nil {
+		size += mapSize(len(heap.counts), interfaceBytes, uint64(unsafe.Sizeof(uint(0))))
+	}
+	if heap.weights != nil {
+		size += mapSize(len(heap.weights), interfaceBytes, uint64(unsafe.Sizeof(float64(0))))
+	}
+	size += uint64(cap(heap.treeDegrees)) * pointerBytes
+	size += heap.poolBytes()
+	if heap.order != nil {
+		size += heap.order.sizeBytes()
+	}
+	if heap.sketch != nil {
+		size += heap.sketch.sizeBytes()
+	}
+	if heap.watchers != nil {
+		size += mapSize(len(heap.watchers), interfaceBytes, uint64(unsafe.Sizeof([]chan Event{})))
+		for _, channels := range heap.watchers {
+			size += uint64(cap(channels))*pointerBytes + uint64(len(channels))*(chanBytes+uint64(unsafe.Sizeof(Event{})))
+		}
+	}
+
+	return size
+}
+
+// poolBytes estimates the size of the free nodes of the pool of the heap, which is shared by all heaps of a HeapGroup.
+func (heap *FibHeap) poolBytes() uint64 {
+	if heap.pool == nil {
+		return 0
+	}
+
+	return uint64(cap(heap.pool.free))*pointerBytes + uint64(len(heap.pool.free))*nodeBytes
+}
+
+// nodeBytes is the size of a node together with its list element and the list of its children.
+var nodeBytes = uint64(unsafe.Sizeof(node{}) + unsafe.Sizeof(list.Element{}) + unsafe.Sizeof(list.List{}))
+
+// mapSize estimates the size of a map of the entries of the key and value sizes:
+// every slot of the runtime has a control byte, and the slots are kept at most 7/8 full.
+func mapSize(entries int, key, value uint64) uint64 {
+	return mapBytes + uint64(entries)*(key+value+1)*8/7
+}
+
+// sizeBytes estimates the size of the skip list, including the entries and their levels.
+// An entry has 4/3 levels on average, as every level is added with a probability of 1/4.
+func (order *orderedIndex) sizeBytes() uint64 {
+	entry := uint64(unsafe.Sizeof(skipEntry{})) + (pointerBytes+uint64(unsafe.Sizeof(0)))*4/3
+	return uint64(unsafe.Sizeof(*order)) + mapSize(len(order.entries), interfaceBytes, pointerBytes) + uint64(len(order.entries))*entry
+}
+
+// sizeBytes estimates the size of the sketch, including the buckets of the tags.
+func (sketch *keySketch) sizeBytes() uint64 {
+	counter := uint64(unsafe.Sizeof(0) + unsafe.Sizeof(uint(0)))
+	return uint64(unsafe.Sizeof(*sketch)) + mapSize(len(sketch.positives), counter, 0) + mapSize(len(sketch.negatives), counter, 0) +
+		mapSize(len(sketch.buckets), interfaceBytes, uint64(unsafe.Sizeof(sketchBucket{})))
+}
@@ -0,0 +1,59 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tests of SizeBytes", func() {
+	It("Given a fibHeap, when insert and extract values, it should estimate the memory by the number of nodes.", func() {
+		heap := NewFibHeap()
+		empty := heap.SizeBytes()
+		Expect(empty).Should(BeNumerically(">", 0))
+
+		for i := 0; i < 1000; i++ {
+			heap.InsertValue(&demoStruct{i, float64(i), ""})
+		}
+		full := heap.SizeBytes()
+		Expect(full - empty).Should(BeNumerically(">", 1000*(nodeBytes+interfaceBytes+pointerBytes)))
+		Expect(full - empty).Should(BeNumerically("<", 1000*(nodeBytes+2*(interfaceBytes+pointerBytes))))
+
+		heap.SetLazyDelete(true)
+		for i := 1; i < 10; i++ {
+			heap.Delete(i)
+		}
+		Expect(heap.SizeBytes()).Should(BeNumerically("<", full))
+		Expect(heap.SizeBytes()).Should(BeNumerically(">", full-9*(nodeBytes+2*(interfaceBytes+pointerBytes))))
+
+		heap.SetOrderedIndex(true)
+		heap.SetQuantileSketch(0.01)
+		heap.Watch(0)
+		heap.InsertOrIncrement(0, 0)
+		heap.SetWeight(0, 2)
+		Expect(heap.SizeBytes()).Should(BeNumerically(">", full+991*(interfaceBytes+pointerBytes)))
+
+		heap.SetOrderedIndex(false)
+		heap.SetQuantileSketch(0)
+		for heap.Num() != 0 {
+			heap.ExtractMin()
+		}
+		Expect(heap.SizeBytes()).Should(BeNumerically("<", empty+512))
+	})
+
+	It("Given a fibHeap with a pool of a heapGroup, when values are extracted, it should count the free nodes of the pool.", func() {
+		heap := NewFibHeap()
+		heap.pool = new(nodePool)
+		empty := heap.SizeBytes()
+		for i := 0; i < 100; i++ {
+			heap.Insert(i, float64(i))
+		}
+		full := heap.SizeBytes()
+		for i := 0; i < 100; i++ {
+			heap.ExtractMin()
+		}
+		Expect(heap.SizeBytes()).Should(BeNumerically(">", empty+100*nodeBytes))
+		Expect(heap.SizeBytes()).Should(BeNumerically("<", full))
+	})
+})
@@ -0,0 +1,41 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+//go:build go1.21
+// +build go1.21
+
+package fibHeap
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SetLogger sets the logger to which the heap logs its structural events at the debug level, to diagnose pathological workloads:
+// the consolidations and the compactions with the numbers of roots before and after, the unions with the numbers of moved values,
+// and the cascading cuts of at least minLoggedCascade cuts, all of them with their durations.
+// Nothing is measured unless the logger is enabled for the debug level. A nil logger turns it off, which is the default.
+func (heap *FibHeap) SetLogger(logger *slog.Logger) {
+	if debugMode {
+		defer heap.guard.enter("SetLogger")()
+	}
+
+	if logger == nil {
+		heap.logger = nil
+		return
+	}
+
+	heap.logger = slogLogger{logger}
+}
+
+// slogLogger is the phaseLogger of a *slog.Logger.
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+func (l slogLogger) enabled() bool {
+	return l.logger.Enabled(context.Background(), slog.LevelDebug)
+}
+
+func (l slogLogger) log(msg string, args ...interface{}) {
+	l.logger.Debug(msg, args...)
+}
@@ -0,0 +1,81 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+//go:build go1.21
+// +build go1.21
+
+package fibHeap
+
+import (
+	"bytes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"log/slog"
+)
+
+var _ = Describe("Tests of slog", func() {
+	var (
+		heap   *FibHeap
+		buffer *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		heap = NewFibHeap()
+		buffer = new(bytes.Buffer)
+	})
+
+	AfterEach(func() {
+		heap = nil
+		buffer = nil
+	})
+
+	It("Given a fibHeap with a debug logger, when consolidate, compact and union, it should log the phases with their sizes.", func() {
+		heap.SetLogger(slog.New(slog.NewTextHandler(buffer, &slog.HandlerOptions{Level: slog.LevelDebug})))
+		for i := 0; i < 100; i++ {
+			heap.Insert(i, float64(i))
+		}
+		heap.ExtractMin()
+		Expect(buffer.String()).Should(ContainSubstring("msg=\"fibHeap consolidate\" roots=99 trees=4 values=99 duration="))
+		Expect(heap.phase).Should(BeEmpty())
+
+		buffer.Reset()
+		another := NewFibHeap()
+		another.Insert("another", 0)
+		Expect(heap.Union(another)).ShouldNot(HaveOccurred())
+		Expect(buffer.String()).Should(ContainSubstring("msg=\"fibHeap union\" moved=1 values=100 duration="))
+
+		buffer.Reset()
+		heap.SetLazyDelete(true)
+		for i := 1; i < 100; i += 2 {
+			heap.Delete(i)
+		}
+		heap.SetLazyDelete(false)
+		Expect(buffer.String()).Should(ContainSubstring("msg=\"fibHeap compact\""))
+		Expect(heap.Num()).Should(BeEquivalentTo(50))
+
+		buffer.Reset()
+		heap.SetLogger(nil)
+		heap.ExtractMin()
+		Expect(buffer.String()).Should(BeEmpty())
+	})
+
+	It("Given a fibHeap with a logger above the debug level, when consolidate, it should log nothing.", func() {
+		heap.SetLogger(slog.New(slog.NewTextHandler(buffer, &slog.HandlerOptions{Level: slog.LevelInfo})))
+		for i := 0; i < 100; i++ {
+			heap.Insert(i, float64(i))
+		}
+		heap.ExtractMin()
+		Expect(buffer.String()).Should(BeEmpty())
+		Expect(heap.Num()).Should(BeEquivalentTo(99))
+	})
+
+	It("Given a fibHeap with a debug logger, when a long cascade of cuts happens, it should log the cascade.", func() {
+		heap.SetLogger(slog.New(slog.NewTextHandler(buffer, &slog.HandlerOptions{Level: slog.LevelDebug})))
+		heap.logPhase("cascadingCut", heap.roots.Len()-minLoggedCascade+1, 0, 0)
+		Expect(buffer.String()).Should(BeEmpty())
+		for i := 0; i < minLoggedCascade; i++ {
+			heap.Insert(i, float64(i))
+		}
+		heap.logPhase("cascadingCut", 0, 0, 0)
+		Expect(buffer.String()).Should(ContainSubstring("msg=\"fibHeap cascadingCut\" cuts=8 values=8"))
+	})
+})
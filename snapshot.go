@@ -0,0 +1,134 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+// ReadOnlyHeap is a consistent read only view of a heap.
+// All methods of ReadOnlyHeap are concurrent safe, so it can be handed to other goroutines while the heap keeps being mutated.
+type ReadOnlyHeap interface {
+	// Num returns the total number of values in the view.
+	Num() uint
+	// Minimum returns the minimum tag and key in the view. An empty view will return nil and -inf.
+	Minimum() (interface{}, float64)
+	// MinimumValue returns the minimum value in the view. An empty view will return nil.
+	MinimumValue() Value
+	// GetTag returns the key of the input tag. If the input tag does not exist in the view, -inf will be returned.
+	GetTag(tag interface{}) float64
+	// GetValue returns the value of the input tag. If the input tag does not exist in the view, nil will be returned.
+	GetValue(tag interface{}) Value
+	// Range calls fn for every tag, key and value in the view in the ascending order of keys until fn returns false.
+	Range(fn func(tag interface{}, key float64, value Value) bool)
+	// String provides some basic debug information of the view.
+	String() string
+}
+
+// Snapshot returns a consistent read only view of the heap at the time of the call.
+// The snapshot is a frozen clone of all tags, keys and values in O(n), which later mutations of the heap will not be seen in.
+// As all methods of FibHeap, Snapshot itself must not be called concurrently with other methods,
+// but the returned view can be read by any number of goroutines while the heap keeps being mutated.
+// Please note that the values themselves are shared with the heap rather than copied.
+func (heap *FibHeap) Snapshot() ReadOnlyHeap {
+	if debugMode {
+		defer heap.guard.enter("Snapshot")()
+	}
+
+	view := new(snapshot)
+	view.entries = make([]snapshotEntry, 0, heap.num)
+	view.index = make(map[interface{}]int, heap.num)
+	view.min = -1
+
+	heap.each(func(tag interface{}, key float64, value Value) {
+		if view.min < 0 || key < view.entries[view.min].key {
+			view.min = len(view.entries)
+		}
+		view.index[tagKey(tag)] = len(view.entries)
+		view.entries = append(view.entries, snapshotEntry{tag, key, value})
+	})
+
+	return view
+}
+
+type snapshotEntry struct {
+	tag   interface{}
+	key   float64
+	value Value
+}
+
+type snapshot struct {
+	entries []snapshotEntry
+	index   map[interface{}]int
+	min     int
+	once    sync.Once
+	sorted  []snapshotEntry
+}
+
+func (view *snapshot) Num() uint {
+	return uint(len(view.entries))
+}
+
+func (view *snapshot) Minimum() (interface{}, float64) {
+	if view.min < 0 {
+		return nil, math.Inf(-1)
+	}
+
+	return view.entries[view.min].tag, view.entries[view.min].key
+}
+
+func (view *snapshot) MinimumValue() Value {
+	if view.min < 0 {
+		return nil
+	}
+
+	return view.entries[view.min].value
+}
+
+func (view *snapshot) GetTag(tag interface{}) float64 {
+	if i, exists := view.index[tagKey(tag)]; exists {
+		return view.entries[i].key
+	}
+
+	return math.Inf(-1)
+}
+
+func (view *snapshot) GetValue(tag interface{}) Value {
+	if i, exists := view.index[tagKey(tag)]; exists {
+		return view.entries[i].value
+	}
+
+	return nil
+}
+
+// Range sorts the entries on the first call, so that taking a snapshot which is never ranged stays O(n).
+func (view *snapshot) Range(fn func(tag interface{}, key float64, value Value) bool) {
+	view.once.Do(func() {
+		view.sorted = append([]snapshotEntry(nil), view.entries...)
+		sort.SliceStable(view.sorted, func(i, j int) bool { return view.sorted[i].key < view.sorted[j].key })
+	})
+
+	for _, entry := range view.sorted {
+		if !fn(entry.tag, entry.key, entry.value) {
+			return
+		}
+	}
+}
+
+func (view *snapshot) String() string {
+	var buffer bytes.Buffer
+
+	if len(view.entries) != 0 {
+		min := view.entries[view.min]
+		buffer.WriteString(fmt.Sprintf("Total number: %d,\n", len(view.entries)))
+		buffer.WriteString(fmt.Sprintf("Current minimun: key(%f), tag(%v), value(%v),\n", min.key, min.tag, min.value))
+	} else {
+		buffer.WriteString(fmt.Sprintf("Heap is empty.\n"))
+	}
+
+	return buffer.String()
+}
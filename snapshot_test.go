@@ -0,0 +1,92 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"sync"
+)
+
+var _ = Describe("Tests of snapshot", func() {
+	var (
+		heap *FibHeap
+	)
+
+	BeforeEach(func() {
+		heap = NewFibHeap()
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given an empty fibHeap, when call Snapshot api, it should return an empty view.", func() {
+		view := heap.Snapshot()
+		Expect(view.Num()).Should(BeEquivalentTo(0))
+		tag, key := view.Minimum()
+		Expect(tag).Should(BeNil())
+		Expect(key).Should(BeEquivalentTo(math.Inf(-1)))
+		Expect(view.MinimumValue()).Should(BeNil())
+		Expect(view.String()).Should(Equal("Heap is empty.\n"))
+		view.Range(func(tag interface{}, key float64, value Value) bool {
+			Fail("an empty view should not be ranged")
+			return true
+		})
+	})
+
+	It("Given a snapshot of a fibHeap, when mutate the heap, it should keep the view at the time of the snapshot.", func() {
+		for i := 0; i < 100; i++ {
+			heap.InsertValue(&demoStruct{i, float64(100 - i), ""})
+		}
+		view := heap.Snapshot()
+
+		heap.ExtractMin()
+		heap.DecreaseKey(50, -1)
+		heap.Delete(0)
+		heap.Insert(1000, -100)
+
+		Expect(view.Num()).Should(BeEquivalentTo(100))
+		tag, key := view.Minimum()
+		Expect(tag).Should(Equal(99))
+		Expect(key).Should(BeEquivalentTo(1))
+		Expect(view.MinimumValue().Tag()).Should(Equal(99))
+		Expect(view.GetTag(50)).Should(BeEquivalentTo(50))
+		Expect(view.GetTag(1000)).Should(BeEquivalentTo(math.Inf(-1)))
+		Expect(view.GetValue(0).Tag()).Should(Equal(0))
+		Expect(view.GetValue(1000)).Should(BeNil())
+
+		var keys []float64
+		view.Range(func(tag interface{}, key float64, value Value) bool {
+			keys = append(keys, key)
+			return len(keys) < 10
+		})
+		Expect(keys).Should(Equal([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}))
+	})
+
+	It("Given a snapshot of a fibHeap, when readers walk it while the writer mutates the heap, it should not race.", func() {
+		for i := 0; i < 1000; i++ {
+			heap.Insert(i, float64(i))
+		}
+		view := heap.Snapshot()
+
+		var wg sync.WaitGroup
+		for reader := 0; reader < 4; reader++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				count := 0
+				view.Range(func(tag interface{}, key float64, value Value) bool {
+					count++
+					return true
+				})
+				Expect(count).Should(Equal(1000))
+			}()
+		}
+		for i := 0; i < 1000; i++ {
+			heap.ExtractMin()
+		}
+		wg.Wait()
+	})
+})
@@ -0,0 +1,454 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// SoftHeap represents a Soft Heap as introduced by Chazelle, following the simplified binary tree design of Kaplan and Zwick.
+// A soft heap is allowed to corrupt keys: some keys are raised to a larger "corrupted key" and values are extracted by their corrupted keys.
+// In exchange for this inaccuracy, Insert is O(1) and ExtractMin is O(log(1/ε)) amortized, independent of the heap size.
+// Among n inserted values at most ε*n values are corrupted at any time, where ε is the error rate given to NewSoftHeap.
+// Soft heaps are the building block of approximate selection and of the fastest deterministic MST algorithms.
+// SoftHeap does not support DecreaseKey or IncreaseKey. Delete is done lazily and the deleted values are skipped on extraction.
+// Please note that all methods of SoftHeap are not concurrent safe.
+type SoftHeap struct {
+	epsilon   float64
+	threshold int
+	first     *softNode
+	index     map[interface{}]*softItem
+	num       uint
+}
+
+// softNode is a node of a binary tree in the root list.
+// Every value in the items of the node has the node's ckey as its corrupted key.
+type softNode struct {
+	left, right *softNode
+	// next, prev and suffixMin are only used by roots.
+	// suffixMin points to the root with the minimum ckey among this root and all roots after it.
+	next, prev *softNode
+	suffixMin  *softNode
+	rank       int
+	size       int
+	ckey       float64
+	items      []*softItem
+}
+
+type softItem struct {
+	tag     interface{}
+	key     float64
+	value   Value
+	deleted bool
+}
+
+// NewSoftHeap creates an initialized Soft Heap with the error rate epsilon.
+// The valid range of epsilon is (0, 1). An invalid epsilon will cause a panic.
+func NewSoftHeap(epsilon float64) *SoftHeap {
+	if !(epsilon > 0 && epsilon < 1) {
+		panic("fibHeap: epsilon of SoftHeap must be in (0, 1)")
+	}
+
+	heap := new(SoftHeap)
+	heap.epsilon = epsilon
+	heap.threshold = int(math.Ceil(math.Log2(1/epsilon))) + 5
+	heap.index = make(map[interface{}]*softItem)
+
+	return heap
+}
+
+// Epsilon returns the error rate of the heap.
+func (heap *SoftHeap) Epsilon() float64 {
+	return heap.epsilon
+}
+
+// Num returns the total number of values in the heap.
+func (heap *SoftHeap) Num() uint {
+	return heap.num
+}
+
+// Insert pushes the input tag and key into the heap.
+// Try to insert a duplicate tag value will cause an error return.
+// The valid range of the key is (-inf, +inf].
+// Try to insert a -inf key value will cause an error return.
+func (heap *SoftHeap) Insert(tag interface{}, key float64) error {
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	return heap.insert(tag, key, nil)
+}
+
+// InsertValue pushes the input value into the heap.
+// The input value must implements the Value interface.
+// Try to insert a duplicate tag value will cause an error return.
+// The valid range of the value's key is (-inf, +inf].
+// Try to insert a -inf key value will cause an error return.
+func (heap *SoftHeap) InsertValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	return heap.insert(value.Tag(), value.Key(), value)
+}
+
+// Minimum returns the tag and the original key of the value which will be extracted next.
+// The key is not necessarily the minimum key in the heap since keys may be corrupted.
+// An empty heap will return nil and -inf.
+func (heap *SoftHeap) Minimum() (interface{}, float64) {
+	if heap.num == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	item := heap.peek()
+
+	return item.tag, item.key
+}
+
+// MinimumValue returns the value which will be extracted next.
+// An empty heap will return nil.
+func (heap *SoftHeap) MinimumValue() Value {
+	if heap.num == 0 {
+		return nil
+	}
+
+	return heap.peek().value
+}
+
+// ExtractMin returns the tag and the original key of the value with the minimum corrupted key and then extracts them from the heap.
+// An empty heap will return nil/-inf and extracts nothing.
+func (heap *SoftHeap) ExtractMin() (interface{}, float64) {
+	if heap.num == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	item, _ := heap.extractMin()
+
+	return item.tag, item.key
+}
+
+// ExtractMinCKey is the same as ExtractMin but also returns the corrupted key by which the value was extracted.
+// The value was corrupted if and only if the corrupted key is larger than the original key.
+// An empty heap will return nil/-inf/-inf and extracts nothing.
+func (heap *SoftHeap) ExtractMinCKey() (interface{}, float64, float64) {
+	if heap.num == 0 {
+		return nil, math.Inf(-1), math.Inf(-1)
+	}
+
+	item, ckey := heap.extractMin()
+
+	return item.tag, item.key, ckey
+}
+
+// ExtractMinValue returns the value with the minimum corrupted key and then extracts it from the heap.
+// An empty heap will return nil and extracts nothing.
+func (heap *SoftHeap) ExtractMinValue() Value {
+	if heap.num == 0 {
+		return nil
+	}
+
+	item, _ := heap.extractMin()
+
+	return item.value
+}
+
+// Union moves all values of the input heap into the heap.
+// The input heap is emptied afterwards so that no value is reachable from both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned and both heaps are left untouched.
+func (heap *SoftHeap) Union(anotherHeap *SoftHeap) error {
+	if err := heap.UnionInto(anotherHeap); err != nil {
+		return err
+	}
+
+	*anotherHeap = *NewSoftHeap(anotherHeap.epsilon)
+
+	return nil
+}
+
+// UnionInto merges copies of all values of the input heap into the heap.
+// The input heap is left untouched, so the values are shared by both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned.
+func (heap *SoftHeap) UnionInto(anotherHeap *SoftHeap) error {
+	for tag := range anotherHeap.index {
+		if _, exists := heap.index[tag]; exists {
+			return errors.New("Duplicate tag is found in the target heap ")
+		}
+	}
+
+	for _, item := range anotherHeap.index {
+		heap.insert(item.tag, item.key, item.value)
+	}
+
+	return nil
+}
+
+// Delete deletes the input tag in the heap.
+// The value is only marked as deleted and will be dropped when it reaches the top of the heap.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *SoftHeap) Delete(tag interface{}) error {
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	item, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Tag is not found ")
+	}
+
+	heap.deleteItem(item)
+
+	return nil
+}
+
+// DeleteValue deletes the value in the heap by the input value.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *SoftHeap) DeleteValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	item, exists := heap.index[value.Tag()]
+	if !exists {
+		return errors.New("Value is not found ")
+	}
+
+	heap.deleteItem(item)
+
+	return nil
+}
+
+// GetTag searches and returns the original key in the heap by the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *SoftHeap) GetTag(tag interface{}) (key float64) {
+	if item, exists := heap.index[tag]; exists {
+		return item.key
+	}
+
+	return math.Inf(-1)
+}
+
+// GetValue searches and returns the value in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *SoftHeap) GetValue(tag interface{}) (value Value) {
+	if item, exists := heap.index[tag]; exists {
+		value = item.value
+	}
+
+	return
+}
+
+// ExtractTag searches and extracts the tag/key in the heap by the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *SoftHeap) ExtractTag(tag interface{}) (key float64) {
+	if item, exists := heap.index[tag]; exists {
+		key = item.key
+		heap.deleteItem(item)
+		return
+	}
+
+	return math.Inf(-1)
+}
+
+// ExtractValue searches and extracts the value in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *SoftHeap) ExtractValue(tag interface{}) (value Value) {
+	if item, exists := heap.index[tag]; exists {
+		value = item.value
+		heap.deleteItem(item)
+		return
+	}
+
+	return nil
+}
+
+// String provides some basic debug information of the heap.
+// It returns the total number, roots size, error rate and the value which will be extracted next.
+// It also returns the corrupted keys and list sizes of the trees by dfs search.
+func (heap *SoftHeap) String() string {
+	var buffer bytes.Buffer
+
+	if heap.num != 0 {
+		roots := 0
+		for root := heap.first; root != nil; root = root.next {
+			roots++
+		}
+		item := heap.peek()
+		buffer.WriteString(fmt.Sprintf("Total number: %d, Root Size: %d, Epsilon: %f,\n", heap.num, roots, heap.epsilon))
+		buffer.WriteString(fmt.Sprintf("Current minimun: key(%f), ckey(%f), tag(%v), value(%v),\n", item.key, heap.first.suffixMin.ckey, item.tag, item.value))
+		buffer.WriteString(fmt.Sprintf("Heap detail:\n"))
+		for root := heap.first; root != nil; root = root.next {
+			probeSoftTree(&buffer, root)
+		}
+		buffer.WriteString(fmt.Sprintf("\n"))
+	} else {
+		buffer.WriteString(fmt.Sprintf("Heap is empty.\n"))
+	}
+
+	return buffer.String()
+}
+
+func probeSoftTree(buffer *bytes.Buffer, tree *softNode) {
+	buffer.WriteString(fmt.Sprintf("< %f(%d) ", tree.ckey, len(tree.items)))
+	if tree.left != nil {
+		probeSoftTree(buffer, tree.left)
+	}
+	if tree.right != nil {
+		probeSoftTree(buffer, tree.right)
+	}
+	buffer.WriteString(fmt.Sprintf("> "))
+}
+
+func (heap *SoftHeap) insert(tag interface{}, key float64, value Value) error {
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if _, exists := heap.index[tag]; exists {
+		return errors.New("Duplicate tag is not allowed ")
+	}
+
+	item := &softItem{tag: tag, key: key, value: value}
+	heap.index[tag] = item
+	heap.num++
+
+	// The roots are kept in increasing rank order, so inserting a rank 0 tree is a binary counter increment.
+	carry := &softNode{size: 1, ckey: key, items: []*softItem{item}}
+	for heap.first != nil && heap.first.rank == carry.rank {
+		root := heap.first
+		heap.removeRoot(root)
+		carry = heap.combine(carry, root)
+	}
+
+	carry.prev = nil
+	carry.next = heap.first
+	if heap.first != nil {
+		heap.first.prev = carry
+	}
+	heap.first = carry
+	heap.updateSuffixMin(carry)
+
+	return nil
+}
+
+// peek drops the deleted values from the top of the heap and returns the next value to extract without extracting it.
+func (heap *SoftHeap) peek() *softItem {
+	for {
+		root := heap.first.suffixMin
+		item := root.items[len(root.items)-1]
+		if !item.deleted {
+			return item
+		}
+		heap.pop(root)
+	}
+}
+
+func (heap *SoftHeap) extractMin() (*softItem, float64) {
+	item := heap.peek()
+	root := heap.first.suffixMin
+	ckey := root.ckey
+	heap.pop(root)
+
+	delete(heap.index, item.tag)
+	heap.num--
+	if heap.num == 0 {
+		heap.first = nil
+	}
+
+	return item, ckey
+}
+
+// pop removes the last value of the root and refills the root from its children once it is less than half full.
+func (heap *SoftHeap) pop(root *softNode) {
+	root.items[len(root.items)-1] = nil
+	root.items = root.items[:len(root.items)-1]
+
+	if 2*len(root.items) > root.size {
+		return
+	}
+
+	if root.left != nil || root.right != nil {
+		heap.sift(root)
+	}
+	if len(root.items) == 0 {
+		prev := root.prev
+		heap.removeRoot(root)
+		if prev != nil {
+			heap.updateSuffixMin(prev)
+		}
+		return
+	}
+	heap.updateSuffixMin(root)
+}
+
+func (heap *SoftHeap) deleteItem(item *softItem) {
+	item.deleted = true
+	delete(heap.index, item.tag)
+	heap.num--
+	if heap.num == 0 {
+		heap.first = nil
+	}
+}
+
+// combine links two trees of the same rank under a new node and fills the new node from its children.
+func (heap *SoftHeap) combine(x, y *softNode) *softNode {
+	x.next, x.prev, x.suffixMin = nil, nil, nil
+	y.next, y.prev, y.suffixMin = nil, nil, nil
+
+	node := &softNode{left: x, right: y, rank: x.rank + 1}
+	if node.rank <= heap.threshold {
+		node.size = 1
+	} else {
+		node.size = (3*x.size + 1) / 2
+	}
+	heap.sift(node)
+
+	return node
+}
+
+// sift moves the values of the child with the smaller ckey up until the node holds enough values.
+// This is where keys are corrupted: the moved values take the ckey of the node.
+func (heap *SoftHeap) sift(node *softNode) {
+	for len(node.items) < node.size && (node.left != nil || node.right != nil) {
+		if node.left == nil || (node.right != nil && node.left.ckey > node.right.ckey) {
+			node.left, node.right = node.right, node.left
+		}
+
+		child := node.left
+		node.items = append(node.items, child.items...)
+		node.ckey = child.ckey
+		child.items = nil
+		if child.left != nil || child.right != nil {
+			heap.sift(child)
+		}
+		if len(child.items) == 0 {
+			node.left = nil
+		}
+	}
+}
+
+func (heap *SoftHeap) removeRoot(root *softNode) {
+	if root.prev != nil {
+		root.prev.next = root.next
+	} else {
+		heap.first = root.next
+	}
+	if root.next != nil {
+		root.next.prev = root.prev
+	}
+	root.prev = nil
+	root.next = nil
+}
+
+// updateSuffixMin recomputes the suffix minimums from the root back to the first root.
+func (heap *SoftHeap) updateSuffixMin(root *softNode) {
+	for ; root != nil; root = root.prev {
+		if root.next == nil || root.ckey <= root.next.suffixMin.ckey {
+			root.suffixMin = root
+		} else {
+			root.suffixMin = root.next.suffixMin
+		}
+	}
+}
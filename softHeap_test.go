@@ -0,0 +1,147 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"math/rand"
+	"time"
+)
+
+var _ = Describe("Tests of softHeap", func() {
+	var heap *SoftHeap
+
+	BeforeEach(func() {
+		heap = NewSoftHeap(0.1)
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given an invalid error rate, when call NewSoftHeap, it should panic.", func() {
+		Expect(func() { NewSoftHeap(0) }).Should(Panic())
+		Expect(func() { NewSoftHeap(1) }).Should(Panic())
+		Expect(func() { NewSoftHeap(math.NaN()) }).Should(Panic())
+		Expect(NewSoftHeap(0.25).Epsilon()).Should(BeEquivalentTo(0.25))
+	})
+
+	It("Given an empty softHeap, when call Minimum and ExtractMin api, it should return nil.", func() {
+		tag, key := heap.Minimum()
+		Expect(tag).Should(BeNil())
+		Expect(key).Should(BeEquivalentTo(math.Inf(-1)))
+		Expect(heap.ExtractMinValue()).Should(BeNil())
+		Expect(heap.String()).Should(BeEquivalentTo("Heap is empty.\n"))
+	})
+
+	It("Given a empty softHeap, when call Insert api with invalid inputs, it should return error.", func() {
+		Expect(heap.Insert(nil, 0.0)).Should(HaveOccurred())
+		Expect(heap.InsertValue(nil)).Should(HaveOccurred())
+		Expect(heap.Insert(1000, math.Inf(-1))).Should(HaveOccurred())
+		Expect(heap.Insert(1000, 0.0)).ShouldNot(HaveOccurred())
+		Expect(heap.Insert(1000, 1.0)).Should(HaveOccurred())
+		Expect(heap.Num()).Should(BeEquivalentTo(1))
+	})
+
+	It("Given a softHeap inserted multiple values, when extract all of them, it should keep at most epsilon*n corrupted keys and never decrease the ckeys.", func() {
+		rand.Seed(time.Now().Unix())
+		n := 20000
+		for i := 0; i < n; i++ {
+			Expect(heap.Insert(i, rand.Float64())).ShouldNot(HaveOccurred())
+			if i%1000 == 0 {
+				Expect(countSoftCorrupted(heap)).Should(BeNumerically("<=", 0.1*float64(i+1)))
+			}
+		}
+
+		lastCKey := math.Inf(-1)
+		for i := 0; i < n; i++ {
+			tag, key, ckey := heap.ExtractMinCKey()
+			Expect(tag).ShouldNot(BeNil())
+			Expect(ckey).Should(BeNumerically(">=", key))
+			Expect(ckey).Should(BeNumerically(">=", lastCKey))
+			lastCKey = ckey
+			if i%1000 == 0 {
+				Expect(countSoftCorrupted(heap)).Should(BeNumerically("<=", 0.1*float64(n)))
+			}
+		}
+		Expect(heap.Num()).Should(BeEquivalentTo(0))
+	})
+
+	It("Given a softHeap with a tiny error rate, when extract all values, it should behave as an exact heap.", func() {
+		heap = NewSoftHeap(1e-9)
+		rand.Seed(time.Now().Unix())
+		for i := 0; i < 5000; i++ {
+			heap.InsertValue(&demoStruct{i, rand.Float64(), ""})
+		}
+
+		lastKey := math.Inf(-1)
+		for i := 0; i < 5000; i++ {
+			key := heap.ExtractMinValue().Key()
+			Expect(key).Should(BeNumerically(">=", lastKey))
+			lastKey = key
+		}
+	})
+
+	It("Given a softHeap inserted multiple values, when call Delete and ExtractTag api, it should skip the deleted values.", func() {
+		for i := 0; i < 1000; i++ {
+			heap.Insert(i, float64(i))
+		}
+
+		Expect(heap.Delete(nil)).Should(HaveOccurred())
+		Expect(heap.Delete(1000)).Should(HaveOccurred())
+		for i := 0; i < 1000; i += 2 {
+			Expect(heap.Delete(i)).ShouldNot(HaveOccurred())
+		}
+		Expect(heap.ExtractTag(1)).Should(BeEquivalentTo(1))
+		Expect(heap.ExtractTag(1)).Should(BeEquivalentTo(math.Inf(-1)))
+		Expect(heap.Num()).Should(BeEquivalentTo(499))
+
+		seen := make(map[interface{}]bool)
+		for heap.Num() != 0 {
+			tag, key := heap.ExtractMin()
+			Expect(tag.(int) % 2).Should(Equal(1))
+			Expect(key).Should(BeEquivalentTo(tag))
+			seen[tag] = true
+		}
+		Expect(seen).Should(HaveLen(499))
+	})
+
+	It("Given two softHeaps, when call Union api, it should move all values of the input heap in and empty it.", func() {
+		anotherHeap := NewSoftHeap(0.1)
+		heap.Insert(1, 1)
+		anotherHeap.InsertValue(&demoStruct{2, 0, "2"})
+
+		Expect(heap.Union(anotherHeap)).ShouldNot(HaveOccurred())
+		Expect(heap.Num()).Should(BeEquivalentTo(2))
+		Expect(anotherHeap.Num()).Should(BeEquivalentTo(0))
+		Expect(heap.GetValue(2).(*demoStruct).value).Should(Equal("2"))
+		Expect(heap.GetTag(1)).Should(BeEquivalentTo(1))
+		Expect(heap.Union(heap)).Should(HaveOccurred())
+	})
+})
+
+// countSoftCorrupted counts the values whose key is currently smaller than their corrupted key.
+func countSoftCorrupted(heap *SoftHeap) int {
+	var count func(node *softNode) int
+	count = func(node *softNode) int {
+		if node == nil {
+			return 0
+		}
+		corrupted := count(node.left) + count(node.right)
+		for _, item := range node.items {
+			if !item.deleted && item.key < node.ckey {
+				corrupted++
+			}
+		}
+		return corrupted
+	}
+
+	corrupted := 0
+	for root := heap.first; root != nil; root = root.next {
+		corrupted += count(root)
+	}
+
+	return corrupted
+}
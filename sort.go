@@ -0,0 +1,53 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"math"
+)
+
+// SortValues sorts the values in place by their keys in ascending order, by a heapsort on a FibHeap in O(n log n),
+// so the order is exactly the one in which a FibHeap would extract the values.
+// The values of -inf keys, which a FibHeap does not accept, are moved to the front.
+// The values of equal keys are in no particular order, and the tags of the values do not need to be unique.
+// A nil value will cause a panic.
+func SortValues(values []Value) {
+	heap := NewFibHeap()
+	sorted := make([]Value, 0, len(values))
+	for i, value := range values {
+		if isNilValue(value) {
+			panic("fibHeap: value of SortValues must not be nil")
+		}
+		if math.IsInf(value.Key(), -1) {
+			sorted = append(sorted, value)
+			continue
+		}
+		heap.insert(i, value.Key(), nil)
+	}
+
+	for heap.num != 0 {
+		sorted = append(sorted, values[heap.extractMin().tag.(int)])
+	}
+	copy(values, sorted)
+}
+
+// SortTags sorts the pairs of tags and keys in place by their keys in ascending order, by a heapsort on a FibHeap in O(n log n),
+// so the order is exactly the one in which a FibHeap would extract the tags.
+// The pairs of -inf keys, which a FibHeap does not accept, are moved to the front.
+// The pairs of equal keys are in no particular order, and the tags do not need to be unique.
+func SortTags(pairs []TagKey) {
+	heap := NewFibHeap()
+	sorted := make([]TagKey, 0, len(pairs))
+	for i, pair := range pairs {
+		if math.IsInf(pair.Key, -1) {
+			sorted = append(sorted, pair)
+			continue
+		}
+		heap.insert(i, pair.Key, nil)
+	}
+
+	for heap.num != 0 {
+		sorted = append(sorted, pairs[heap.extractMin().tag.(int)])
+	}
+	copy(pairs, sorted)
+}
@@ -0,0 +1,51 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+var _ = Describe("Tests of sort", func() {
+	It("Given random values with duplicate tags and -inf keys, when call SortValues api, it should sort them by the keys.", func() {
+		random := rand.New(rand.NewSource(1888))
+		var values []Value
+		for i := 0; i < 1000; i++ {
+			values = append(values, &demoStruct{i % 10, random.Float64(), ""})
+		}
+		values = append(values, &demoStruct{1, math.Inf(-1), ""}, &demoStruct{2, math.Inf(1), ""})
+
+		SortValues(values)
+		Expect(values).Should(HaveLen(1002))
+		Expect(values[0].Key()).Should(Equal(math.Inf(-1)))
+		Expect(values[1001].Key()).Should(Equal(math.Inf(1)))
+		Expect(sort.SliceIsSorted(values, func(i, j int) bool { return values[i].Key() < values[j].Key() })).Should(BeTrue())
+
+		Expect(func() { SortValues([]Value{nil}) }).Should(Panic())
+		SortValues(nil)
+	})
+
+	It("Given random pairs of tags and keys, when call SortTags api, it should sort them by the keys and keep the pairs.", func() {
+		random := rand.New(rand.NewSource(1888))
+		var pairs []TagKey
+		for i := 0; i < 1000; i++ {
+			pairs = append(pairs, TagKey{i, float64(random.Intn(100))})
+		}
+		keys := make(map[interface{}]float64)
+		for _, pair := range pairs {
+			keys[pair.Tag] = pair.Key
+		}
+
+		SortTags(pairs)
+		Expect(sort.SliceIsSorted(pairs, func(i, j int) bool { return pairs[i].Key < pairs[j].Key })).Should(BeTrue())
+		for _, pair := range pairs {
+			Expect(keys[pair.Tag]).Should(Equal(pair.Key))
+			delete(keys, pair.Tag)
+		}
+		Expect(keys).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,227 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// DefaultMaxLogSize is the size of the write-ahead log in bytes beyond which a Store takes a checkpoint automatically.
+const DefaultMaxLogSize = 64 << 20
+
+const (
+	storeSnapshotFile = "heap.snapshot"
+	storeLogFile      = "heap.wal"
+)
+
+// Store persists a FibHeap in a directory as a snapshot of the full heap written by Marshal plus a write-ahead log of the later changes.
+// A checkpoint writes a new snapshot and truncates the log, which happens automatically when the log grows beyond the limit.
+// The records of the log assign the state of a tag rather than describe a relative change,
+// so replaying a log on top of a newer snapshot is harmless and a crash at any point of a checkpoint loses nothing.
+// All methods of Store are concurrent safe, so Checkpoint can be called by a ticker in another goroutine.
+type Store struct {
+	mutex      sync.Mutex
+	dir        string
+	heap       *FibHeap
+	log        *os.File
+	logSize    int64
+	maxLogSize int64
+}
+
+// Open opens the Store in the input directory, creating the directory if it does not exist.
+// The heap is restored from the snapshot and the log in the directory, and then a checkpoint is taken so that the log starts empty.
+// The types of the values must be registered in advance.
+// A snapshot or a log failing the checksum will cause ErrCorrupted.
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	store := new(Store)
+	store.dir = dir
+	store.maxLogSize = DefaultMaxLogSize
+
+	heap, err := store.restore()
+	if err != nil {
+		return nil, err
+	}
+	store.heap = heap
+
+	if err := store.checkpoint(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// SetMaxLogSize sets the size of the log in bytes beyond which a checkpoint is taken automatically by Update.
+// A non-positive size turns off automatic checkpoints.
+func (store *Store) SetMaxLogSize(size int64) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.maxLogSize = size
+}
+
+// Update calls fn with the heap to change it, and every change is appended to the log.
+// The heap must not be retained or used outside of fn.
+// If the log fails, a checkpoint is tried to recover it, and its error is returned if it fails too.
+func (store *Store) Update(fn func(heap *FibHeap)) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.log == nil {
+		return errors.New("Store is closed ")
+	}
+
+	fn(store.heap)
+
+	if store.heap.WALErr() != nil || (store.maxLogSize > 0 && store.logSize > store.maxLogSize) {
+		return store.checkpoint()
+	}
+
+	return nil
+}
+
+// View calls fn with the heap to read it. The heap must not be changed, retained or used outside of fn.
+func (store *Store) View(fn func(heap *FibHeap)) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	fn(store.heap)
+}
+
+// Checkpoint writes a snapshot of the full heap and truncates the log.
+func (store *Store) Checkpoint() error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.log == nil {
+		return errors.New("Store is closed ")
+	}
+
+	return store.checkpoint()
+}
+
+// Sync commits the log to the stable storage.
+// Without Sync, the changes survive a crash of the process but not a crash of the operating system.
+func (store *Store) Sync() error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.log == nil {
+		return errors.New("Store is closed ")
+	}
+
+	return store.log.Sync()
+}
+
+// Close syncs and closes the log. The Store can no longer be updated after Close.
+func (store *Store) Close() error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.log == nil {
+		return nil
+	}
+
+	store.heap.SetWAL(nil)
+	err := store.log.Sync()
+	if closeErr := store.log.Close(); err == nil {
+		err = closeErr
+	}
+	store.log = nil
+
+	return err
+}
+
+// restore reads the snapshot and replays the log on top of it, either of which may not exist.
+func (store *Store) restore() (*FibHeap, error) {
+	heap := NewFibHeap()
+
+	snapshot, err := os.Open(filepath.Join(store.dir, storeSnapshotFile))
+	if err == nil {
+		heap, err = Unmarshal(snapshot)
+		snapshot.Close()
+		if err != nil {
+			return nil, err
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	log, err := os.Open(filepath.Join(store.dir, storeLogFile))
+	if err == nil {
+		err = heap.Replay(log)
+		log.Close()
+		if err != nil {
+			return nil, err
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	return heap, nil
+}
+
+// checkpoint replaces the snapshot atomically by renaming a temporary file, and then starts a new empty log.
+func (store *Store) checkpoint() error {
+	path := filepath.Join(store.dir, storeSnapshotFile)
+	temp, err := os.Create(path + ".tmp")
+	if err != nil {
+		return err
+	}
+	if err := store.heap.Marshal(temp); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Sync(); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return err
+	}
+	syncDir(store.dir)
+
+	if store.log != nil {
+		store.log.Close()
+	}
+	log, err := os.Create(filepath.Join(store.dir, storeLogFile))
+	if err != nil {
+		store.log = nil
+		store.heap.wal = nil
+		return err
+	}
+	store.log = log
+	store.logSize = 0
+	store.heap.wal = &writeAheadLog{w: storeLogWriter{store}}
+
+	return nil
+}
+
+// syncDir commits a rename in the directory. It is best effort as some platforms cannot sync a directory.
+func syncDir(dir string) {
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+}
+
+// storeLogWriter writes to the log of the Store and counts its size.
+type storeLogWriter struct {
+	store *Store
+}
+
+func (w storeLogWriter) Write(p []byte) (int, error) {
+	n, err := w.store.log.Write(p)
+	w.store.logSize += int64(n)
+
+	return n, err
+}
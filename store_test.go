@@ -0,0 +1,138 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+var _ = Describe("Tests of store", func() {
+	var (
+		dir   string
+		store *Store
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "fibheap-store")
+		Expect(err).ShouldNot(HaveOccurred())
+		store, err = Open(dir)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		store.Close()
+		os.RemoveAll(dir)
+		store = nil
+	})
+
+	logSize := func() int64 {
+		info, err := os.Stat(filepath.Join(dir, storeLogFile))
+		Expect(err).ShouldNot(HaveOccurred())
+		return info.Size()
+	}
+
+	It("Given an updated store, when reopen the directory, it should restore the heap from the snapshot and the log.", func() {
+		Expect(store.Update(func(heap *FibHeap) {
+			for i := 0; i < 100; i++ {
+				heap.InsertValue(&payload{i, float64(i), "payload"})
+			}
+		})).ShouldNot(HaveOccurred())
+		Expect(store.Checkpoint()).ShouldNot(HaveOccurred())
+		Expect(logSize()).Should(BeEquivalentTo(0))
+
+		Expect(store.Update(func(heap *FibHeap) {
+			heap.ExtractMin()
+			heap.DecreaseKeyValue(&payload{50, -1, "decreased"})
+			heap.Insert("tag", 1000)
+		})).ShouldNot(HaveOccurred())
+		Expect(logSize()).Should(BeNumerically(">", 0))
+		Expect(store.Sync()).ShouldNot(HaveOccurred())
+		Expect(store.Close()).ShouldNot(HaveOccurred())
+		Expect(store.Update(func(heap *FibHeap) {})).Should(HaveOccurred())
+
+		var err error
+		store, err = Open(dir)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(logSize()).Should(BeEquivalentTo(0))
+		store.View(func(heap *FibHeap) {
+			Expect(heap.Num()).Should(BeEquivalentTo(100))
+			Expect(heap.GetTag(0)).Should(BeEquivalentTo(math.Inf(-1)))
+			Expect(heap.MinimumValue()).Should(Equal(&payload{50, -1, "decreased"}))
+			Expect(heap.GetTag("tag")).Should(BeEquivalentTo(1000))
+		})
+	})
+
+	It("Given a store with a small log limit, when update it, it should take checkpoints to keep the log small.", func() {
+		store.SetMaxLogSize(1024)
+		for i := 0; i < 1000; i++ {
+			Expect(store.Update(func(heap *FibHeap) {
+				heap.Insert(i, float64(i))
+			})).ShouldNot(HaveOccurred())
+			Expect(logSize()).Should(BeNumerically("<=", 1024+64))
+		}
+
+		store.Close()
+		var err error
+		store, err = Open(dir)
+		Expect(err).ShouldNot(HaveOccurred())
+		store.View(func(heap *FibHeap) {
+			Expect(heap.Num()).Should(BeEquivalentTo(1000))
+		})
+	})
+
+	It("Given a store crashed with a torn log record or in the middle of a checkpoint, when reopen it, it should restore the heap.", func() {
+		store.Update(func(heap *FibHeap) {
+			heap.Insert(1, 1)
+			heap.Insert(2, 2)
+		})
+		store.Close()
+		log, _ := ioutil.ReadFile(filepath.Join(dir, storeLogFile))
+		Expect(ioutil.WriteFile(filepath.Join(dir, storeLogFile), log[:len(log)-1], 0644)).ShouldNot(HaveOccurred())
+
+		var err error
+		store, err = Open(dir)
+		Expect(err).ShouldNot(HaveOccurred())
+		store.View(func(heap *FibHeap) {
+			Expect(heap.Num()).Should(BeEquivalentTo(1))
+		})
+
+		store.Update(func(heap *FibHeap) {
+			heap.Insert(3, 3)
+			heap.Delete(1)
+		})
+		store.Close()
+		log, _ = ioutil.ReadFile(filepath.Join(dir, storeLogFile))
+		store, err = Open(dir)
+		Expect(err).ShouldNot(HaveOccurred())
+		store.Close()
+		Expect(ioutil.WriteFile(filepath.Join(dir, storeLogFile), log, 0644)).ShouldNot(HaveOccurred())
+
+		store, err = Open(dir)
+		Expect(err).ShouldNot(HaveOccurred())
+		store.View(func(heap *FibHeap) {
+			Expect(heap.Num()).Should(BeEquivalentTo(1))
+			Expect(heap.GetTag(3)).Should(BeEquivalentTo(3))
+		})
+	})
+
+	It("Given a corrupted snapshot, when call Open api, it should return ErrCorrupted.", func() {
+		store.Update(func(heap *FibHeap) {
+			heap.Insert(1, 1)
+		})
+		store.Checkpoint()
+		store.Close()
+		snapshot, _ := ioutil.ReadFile(filepath.Join(dir, storeSnapshotFile))
+		snapshot[len(snapshot)-1] ^= 0xff
+		Expect(ioutil.WriteFile(filepath.Join(dir, storeSnapshotFile), snapshot, 0644)).ShouldNot(HaveOccurred())
+
+		reopened, err := Open(dir)
+		Expect(err).Should(Equal(ErrCorrupted))
+		Expect(reopened).Should(BeNil())
+	})
+})
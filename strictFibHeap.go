@@ -0,0 +1,869 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// StrictFibHeap represents a Strict Fibonacci Heap as described by Brodal, Lagogiannis and Tarjan.
+// StrictFibHeap provides exactly the same methods as FibHeap so the two implementations can be swapped by changing the constructor only.
+// Unlike FibHeap, the time bounds of StrictFibHeap are worst-case rather than amortized:
+// Insert, Minimum and DecreaseKey are O(1) and ExtractMin, Delete and IncreaseKey are O(log n) for every single call.
+// This makes it suitable for real-time use cases where an occasional long consolidation is not acceptable.
+// The constants are noticeably larger than the ones of FibHeap, so it is usually slower in throughput.
+// Please note that all methods of StrictFibHeap are not concurrent safe.
+type StrictFibHeap struct {
+	root  *strictNode
+	index map[interface{}]*strictNode
+	num   uint
+	seq   uint64
+	// queue is the circular list of all non-root nodes which is used to bound the degrees round-robin.
+	queue *strictNode
+	// ranks holds the active roots and the nodes with loss 1 grouped by rank.
+	ranks []*strictRank
+	// rootPairs lists the ranks which have at least two active roots.
+	rootPairs *strictRank
+	// loserPairs lists the ranks which have at least two nodes with loss 1.
+	loserPairs *strictRank
+	// bigLosers lists the nodes with loss of 2 or more.
+	bigLosers *strictNode
+}
+
+// strictNode is a node of the single tree of the strict fibonacci heap.
+// The item fields(tag, key, value and seq) may be swapped between nodes, so the index always points to the node holding the item.
+type strictNode struct {
+	parent *strictNode
+	// child points to the leftmost child. Active children are kept to the left of passive children.
+	child       *strictNode
+	left, right *strictNode
+	queuePrev   *strictNode
+	queueNext   *strictNode
+	fixPrev     *strictNode
+	fixNext     *strictNode
+	fixed       int
+	active      bool
+	rank        int
+	loss        int
+	tag         interface{}
+	key         float64
+	value       Value
+	seq         uint64
+}
+
+type strictRank struct {
+	rank                int
+	roots, losers       *strictNode
+	numRoots, numLosers int
+	rootPairPrev        *strictRank
+	rootPairNext        *strictRank
+	loserPairPrev       *strictRank
+	loserPairNext       *strictRank
+	inRootPairs         bool
+	inLoserPairs        bool
+}
+
+const (
+	strictFixNone = iota
+	strictFixRoot
+	strictFixLoser
+	strictFixBigLoser
+)
+
+// NewStrictFibHeap creates an initialized Strict Fibonacci Heap.
+func NewStrictFibHeap() *StrictFibHeap {
+	heap := new(StrictFibHeap)
+	heap.index = make(map[interface{}]*strictNode)
+	heap.num = 0
+	heap.root = nil
+
+	return heap
+}
+
+// Num returns the total number of values in the heap.
+func (heap *StrictFibHeap) Num() uint {
+	return heap.num
+}
+
+// Insert pushes the input tag and key into the heap.
+// Try to insert a duplicate tag value will cause an error return.
+// The valid range of the key is (-inf, +inf].
+// Try to insert a -inf key value will cause an error return.
+func (heap *StrictFibHeap) Insert(tag interface{}, key float64) error {
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	return heap.insert(tag, key, nil)
+}
+
+// InsertValue pushes the input value into the heap.
+// The input value must implements the Value interface.
+// Try to insert a duplicate tag value will cause an error return.
+// The valid range of the value's key is (-inf, +inf].
+// Try to insert a -inf key value will cause an error return.
+func (heap *StrictFibHeap) InsertValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	return heap.insert(value.Tag(), value.Key(), value)
+}
+
+// Minimum returns the current minimum tag and key in the heap sorted by the key.
+// An empty heap will return nil and -inf.
+func (heap *StrictFibHeap) Minimum() (interface{}, float64) {
+	if heap.num == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	return heap.root.tag, heap.root.key
+}
+
+// MinimumValue returns the current minimum value in the heap sorted by the key.
+// An empty heap will return nil.
+func (heap *StrictFibHeap) MinimumValue() Value {
+	if heap.num == 0 {
+		return nil
+	}
+
+	return heap.root.value
+}
+
+// ExtractMin returns the current minimum tag and key in the heap and then extracts them from the heap.
+// An empty heap will return nil/-inf and extracts nothing.
+func (heap *StrictFibHeap) ExtractMin() (interface{}, float64) {
+	if heap.num == 0 {
+		return nil, math.Inf(-1)
+	}
+
+	tag, key, _ := heap.extractMin()
+
+	return tag, key
+}
+
+// ExtractMinValue returns the current minimum value in the heap and then extracts it from the heap.
+// An empty heap will return nil and extracts nothing.
+func (heap *StrictFibHeap) ExtractMinValue() Value {
+	if heap.num == 0 {
+		return nil
+	}
+
+	_, _, value := heap.extractMin()
+
+	return value
+}
+
+// Union moves all values of the input heap into the heap.
+// The input heap can be any implementation of PriorityQueue, and it is emptied afterwards so that no value is reachable from both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned and both heaps are left untouched.
+func (heap *StrictFibHeap) Union(anotherHeap PriorityQueue) error {
+	if err := heap.UnionInto(anotherHeap); err != nil {
+		return err
+	}
+
+	anotherHeap.reset()
+
+	return nil
+}
+
+// UnionInto merges copies of all values of the input heap into the heap.
+// The input heap can be any implementation of PriorityQueue, and it is left untouched, so the values are shared by both heaps.
+// All values of the input heap must not have duplicate tags. Otherwise an error will be returned.
+func (heap *StrictFibHeap) UnionInto(anotherHeap PriorityQueue) error {
+	if err := checkUnion(heap, anotherHeap); err != nil {
+		return err
+	}
+
+	anotherHeap.each(func(tag interface{}, key float64, value Value) {
+		heap.insert(tag, key, value)
+	})
+
+	return nil
+}
+
+// DecreaseKey updates the tag in the heap by the input key.
+// If the input key has a larger key or -inf key, an error will be returned.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *StrictFibHeap) DecreaseKey(tag interface{}, key float64) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tag]; exists {
+		return heap.decreaseKey(node, node.value, key)
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// DecreaseKeyValue updates the value in the heap by the input value.
+// If the input value has a larger key or -inf key, an error will be returned.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *StrictFibHeap) DecreaseKeyValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	if math.IsInf(value.Key(), -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tagKey(value.Tag())]; exists {
+		return heap.decreaseKey(node, value, value.Key())
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// IncreaseKey updates the tag in the heap by the input key.
+// If the input key has a smaller key or -inf key, an error will be returned.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *StrictFibHeap) IncreaseKey(tag interface{}, key float64) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tag]; exists {
+		return heap.increaseKey(node, node.value, key)
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// IncreaseKeyValue updates the value in the heap by the input value.
+// If the input value has a smaller key or -inf key, an error will be returned.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *StrictFibHeap) IncreaseKeyValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	if math.IsInf(value.Key(), -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if node, exists := heap.index[tagKey(value.Tag())]; exists {
+		return heap.increaseKey(node, value, value.Key())
+	}
+
+	return errors.New("Value is not found ")
+}
+
+// Delete deletes the input tag in the heap.
+// If the input tag is not existed in the heap, an error will be returned.
+func (heap *StrictFibHeap) Delete(tag interface{}) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	node, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Tag is not found ")
+	}
+
+	heap.deleteNode(node)
+
+	return nil
+}
+
+// DeleteValue deletes the value in the heap by the input value.
+// If the tag of the input value is not existed in the heap, an error will be returned.
+func (heap *StrictFibHeap) DeleteValue(value Value) error {
+	if isNilValue(value) {
+		return ErrNilValue
+	}
+
+	node, exists := heap.index[tagKey(value.Tag())]
+	if !exists {
+		return errors.New("Value is not found ")
+	}
+
+	heap.deleteNode(node)
+
+	return nil
+}
+
+// GetTag searches and returns the key in the heap by the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *StrictFibHeap) GetTag(tag interface{}) (key float64) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		return node.key
+	}
+
+	return math.Inf(-1)
+}
+
+// GetValue searches and returns the value in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *StrictFibHeap) GetValue(tag interface{}) (value Value) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		value = node.value
+	}
+
+	return
+}
+
+// ExtractTag searches and extracts the tag/key in the heap by the input tag.
+// If the input tag does not exist in the heap, -inf will be returned.
+func (heap *StrictFibHeap) ExtractTag(tag interface{}) (key float64) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		key = node.key
+		heap.deleteNode(node)
+		return
+	}
+
+	return math.Inf(-1)
+}
+
+// ExtractValue searches and extracts the value in the heap by the input tag.
+// If the input tag does not exist in the heap, nil will be returned.
+func (heap *StrictFibHeap) ExtractValue(tag interface{}) (value Value) {
+	tag = tagKey(tag)
+	if node, exists := heap.index[tag]; exists {
+		value = node.value
+		heap.deleteNode(node)
+		return
+	}
+
+	return nil
+}
+
+// String provides some basic debug information of the heap.
+// It returns the total number, index size and current minimum value of the heap.
+// It also returns the topology of the tree by dfs search.
+func (heap *StrictFibHeap) String() string {
+	var buffer bytes.Buffer
+
+	if heap.num != 0 {
+		buffer.WriteString(fmt.Sprintf("Total number: %d, Index size: %d,\n", heap.num, len(heap.index)))
+		buffer.WriteString(fmt.Sprintf("Current minimun: key(%f), tag(%v), value(%v),\n", heap.root.key, heap.root.tag, heap.root.value))
+		buffer.WriteString(fmt.Sprintf("Heap detail:\n"))
+		probeStrictTree(&buffer, heap.root)
+		buffer.WriteString(fmt.Sprintf("\n"))
+	} else {
+		buffer.WriteString(fmt.Sprintf("Heap is empty.\n"))
+	}
+
+	return buffer.String()
+}
+
+func probeStrictTree(buffer *bytes.Buffer, tree *strictNode) {
+	buffer.WriteString(fmt.Sprintf("< "))
+	n := tree
+	for {
+		buffer.WriteString(fmt.Sprintf("%f ", n.key))
+		if n.child != nil {
+			probeStrictTree(buffer, n.child)
+		}
+		n = n.right
+		if n == nil || n == tree {
+			break
+		}
+	}
+	buffer.WriteString(fmt.Sprintf("> "))
+}
+
+func (heap *StrictFibHeap) reset() {
+	*heap = *NewStrictFibHeap()
+}
+
+func (heap *StrictFibHeap) each(fn func(tag interface{}, key float64, value Value)) {
+	for _, node := range heap.index {
+		fn(node.tag, node.key, node.value)
+	}
+}
+
+func (heap *StrictFibHeap) insert(tag interface{}, key float64, value Value) error {
+	tag = tagKey(tag)
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+
+	if _, exists := heap.index[tag]; exists {
+		return errors.New("Duplicate tag is not allowed ")
+	}
+
+	node := new(strictNode)
+	node.tag = tag
+	node.key = key
+	node.value = value
+	node.seq = heap.seq
+	heap.seq++
+
+	heap.index[node.tag] = node
+	heap.num++
+
+	if heap.root == nil {
+		heap.root = node
+		return nil
+	}
+
+	// Melding with a singleton heap: the new node is linked below the root as a passive leaf
+	// and the items are swapped if the new one is smaller, so the root never changes.
+	heap.addChild(heap.root, node)
+	heap.enqueue(node)
+	if node.less(heap.root) {
+		heap.swapItems(node, heap.root)
+	}
+
+	heap.activeRootReduction()
+	heap.rootDegreeReduction()
+
+	return nil
+}
+
+func (heap *StrictFibHeap) extractMin() (interface{}, float64, Value) {
+	old := heap.root
+	tag, key, value := old.tag, old.key, old.value
+	delete(heap.index, old.tag)
+	heap.num--
+
+	if old.child == nil {
+		heap.root = nil
+		heap.queue = nil
+		heap.ranks = nil
+		heap.rootPairs = nil
+		heap.loserPairs = nil
+		heap.bigLosers = nil
+		return tag, key, value
+	}
+
+	// The new root is the smallest child of the old root.
+	min := old.child
+	for n := old.child.right; n != old.child; n = n.right {
+		if n.less(min) {
+			min = n
+		}
+	}
+
+	heap.removeChild(min)
+	heap.dequeue(min)
+	heap.unfile(min)
+	min.active = false
+	min.loss = 0
+	heap.root = min
+
+	// The active children of the new root become active roots.
+	if min.child != nil {
+		n := min.child
+		for n.active {
+			heap.unfile(n)
+			n.loss = 0
+			heap.file(n)
+			n = n.right
+			if n == min.child {
+				break
+			}
+		}
+	}
+
+	// All other children of the old root are linked to the new root.
+	for old.child != nil {
+		n := old.child
+		heap.removeChild(n)
+		heap.addChild(min, n)
+	}
+
+	// Move passive children of the first two nodes of the queue to the root, which bounds the degrees of non-root nodes.
+	for i := 0; i < 2 && heap.queue != nil; i++ {
+		n := heap.queue
+		heap.queue = n.queueNext
+		for j := 0; j < 2 && n.child != nil; j++ {
+			rightmost := n.child.left
+			if rightmost.active {
+				break
+			}
+			heap.removeChild(rightmost)
+			heap.addChild(heap.root, rightmost)
+		}
+	}
+
+	for heap.lossReduction() {
+	}
+	for heap.activeRootReduction() || heap.rootDegreeReduction() {
+	}
+
+	return tag, key, value
+}
+
+func (heap *StrictFibHeap) deleteNode(n *strictNode) {
+	heap.decreaseKey(n, n.value, math.Inf(-1))
+	heap.ExtractMinValue()
+}
+
+func (heap *StrictFibHeap) decreaseKey(n *strictNode, value Value, key float64) error {
+	if key >= n.key {
+		return errors.New("New key is not smaller than current key ")
+	}
+
+	n.key = key
+	n.value = value
+	if n == heap.root {
+		return nil
+	}
+
+	if n.less(heap.root) {
+		heap.swapItems(n, heap.root)
+	}
+
+	parent := n.parent
+	if parent != heap.root && n.less(parent) {
+		heap.cut(n)
+	}
+
+	heap.lossReduction()
+	for i := 0; i < 6; i++ {
+		heap.activeRootReduction()
+	}
+	for i := 0; i < 4; i++ {
+		heap.rootDegreeReduction()
+	}
+
+	return nil
+}
+
+// increaseKey is done by deleting and inserting the item again since the heap can only decrease keys in place.
+func (heap *StrictFibHeap) increaseKey(n *strictNode, value Value, key float64) error {
+	if key <= n.key {
+		return errors.New("New key is not larger than current key ")
+	}
+
+	tag := n.tag
+	heap.deleteNode(n)
+
+	return heap.insert(tag, key, value)
+}
+
+// cut moves the non-root node n with its subtree to the root.
+func (heap *StrictFibHeap) cut(n *strictNode) {
+	parent := n.parent
+	heap.unfile(n)
+	n.loss = 0
+	heap.removeChild(n)
+	heap.addChild(heap.root, n)
+	heap.file(n)
+
+	if n.active {
+		heap.lose(parent)
+	}
+}
+
+// lose updates the active node n which lost one of its active children.
+func (heap *StrictFibHeap) lose(n *strictNode) {
+	heap.unfile(n)
+	n.rank--
+	if n.parent != heap.root {
+		n.loss++
+	}
+	heap.file(n)
+}
+
+// activeRootReduction links two active roots of the same rank if there are any.
+// The rightmost passive child of the winner is moved to the root to keep its degree unchanged.
+func (heap *StrictFibHeap) activeRootReduction() bool {
+	if heap.rootPairs == nil {
+		return false
+	}
+
+	x := heap.rootPairs.roots
+	y := x.fixNext
+	if y.less(x) {
+		x, y = y, x
+	}
+
+	heap.unfile(x)
+	heap.unfile(y)
+	heap.removeChild(y)
+	heap.addChild(x, y)
+	x.rank++
+	heap.file(x)
+
+	if rightmost := x.child.left; !rightmost.active {
+		heap.removeChild(rightmost)
+		heap.addChild(heap.root, rightmost)
+	}
+
+	return true
+}
+
+// rootDegreeReduction turns the three rightmost passive children of the root into a new active root of rank 1.
+func (heap *StrictFibHeap) rootDegreeReduction() bool {
+	if heap.root.child == nil {
+		return false
+	}
+
+	x := heap.root.child.left
+	y := x.left
+	z := y.left
+	if x.active || y.active || z.active || x == y || y == z || x == z {
+		return false
+	}
+
+	if y.less(x) {
+		x, y = y, x
+	}
+	if z.less(y) {
+		y, z = z, y
+	}
+	if y.less(x) {
+		x, y = y, x
+	}
+
+	heap.removeChild(x)
+	heap.removeChild(y)
+	heap.removeChild(z)
+
+	y.active = true
+	y.rank = 0
+	y.loss = 0
+	heap.addChild(y, z)
+
+	x.active = true
+	x.rank = 1
+	x.loss = 0
+	heap.addChild(x, y)
+	heap.addChild(heap.root, x)
+	heap.file(x)
+
+	return true
+}
+
+// lossReduction removes one node with loss of at least 2, or links two nodes with loss 1 and the same rank.
+func (heap *StrictFibHeap) lossReduction() bool {
+	if heap.bigLosers != nil {
+		heap.cut(heap.bigLosers)
+		return true
+	}
+
+	if heap.loserPairs == nil {
+		return false
+	}
+
+	x := heap.loserPairs.losers
+	y := x.fixNext
+	if y.less(x) {
+		x, y = y, x
+	}
+
+	parent := y.parent
+	heap.unfile(x)
+	heap.unfile(y)
+	x.loss = 0
+	y.loss = 0
+	if parent != x {
+		heap.removeChild(y)
+		heap.addChild(x, y)
+		x.rank++
+		heap.lose(parent)
+	}
+	heap.file(x)
+
+	return true
+}
+
+func (n *strictNode) less(another *strictNode) bool {
+	return n.key < another.key || (n.key == another.key && n.seq < another.seq)
+}
+
+func (heap *StrictFibHeap) swapItems(a, b *strictNode) {
+	a.tag, b.tag = b.tag, a.tag
+	a.key, b.key = b.key, a.key
+	a.value, b.value = b.value, a.value
+	a.seq, b.seq = b.seq, a.seq
+	heap.index[a.tag] = a
+	heap.index[b.tag] = b
+}
+
+// addChild links n as a child of parent. Active children are placed leftmost and passive children rightmost.
+func (heap *StrictFibHeap) addChild(parent, n *strictNode) {
+	n.parent = parent
+	if parent.child == nil {
+		n.left = n
+		n.right = n
+		parent.child = n
+		return
+	}
+
+	first := parent.child
+	n.right = first
+	n.left = first.left
+	first.left.right = n
+	first.left = n
+	if n.active {
+		parent.child = n
+	}
+}
+
+func (heap *StrictFibHeap) removeChild(n *strictNode) {
+	parent := n.parent
+	if n.right == n {
+		parent.child = nil
+	} else {
+		if parent.child == n {
+			parent.child = n.right
+		}
+		n.left.right = n.right
+		n.right.left = n.left
+	}
+	n.parent = nil
+	n.left = nil
+	n.right = nil
+}
+
+func (heap *StrictFibHeap) enqueue(n *strictNode) {
+	if heap.queue == nil {
+		n.queuePrev = n
+		n.queueNext = n
+		heap.queue = n
+		return
+	}
+
+	n.queueNext = heap.queue
+	n.queuePrev = heap.queue.queuePrev
+	heap.queue.queuePrev.queueNext = n
+	heap.queue.queuePrev = n
+}
+
+func (heap *StrictFibHeap) dequeue(n *strictNode) {
+	if n.queueNext == n {
+		heap.queue = nil
+	} else {
+		if heap.queue == n {
+			heap.queue = n.queueNext
+		}
+		n.queuePrev.queueNext = n.queueNext
+		n.queueNext.queuePrev = n.queuePrev
+	}
+	n.queuePrev = nil
+	n.queueNext = nil
+}
+
+func (heap *StrictFibHeap) rankOf(rank int) *strictRank {
+	for len(heap.ranks) <= rank {
+		heap.ranks = append(heap.ranks, &strictRank{rank: len(heap.ranks)})
+	}
+
+	return heap.ranks[rank]
+}
+
+// file puts the node into the fix lists according to its current state.
+func (heap *StrictFibHeap) file(n *strictNode) {
+	switch {
+	case !n.active:
+		n.fixed = strictFixNone
+	case n.parent == heap.root:
+		rank := heap.rankOf(n.rank)
+		pushFix(&rank.roots, n)
+		rank.numRoots++
+		if rank.numRoots == 2 {
+			rank.inRootPairs = true
+			rank.rootPairNext = heap.rootPairs
+			if heap.rootPairs != nil {
+				heap.rootPairs.rootPairPrev = rank
+			}
+			heap.rootPairs = rank
+		}
+		n.fixed = strictFixRoot
+	case n.loss == 1:
+		rank := heap.rankOf(n.rank)
+		pushFix(&rank.losers, n)
+		rank.numLosers++
+		if rank.numLosers == 2 {
+			rank.inLoserPairs = true
+			rank.loserPairNext = heap.loserPairs
+			if heap.loserPairs != nil {
+				heap.loserPairs.loserPairPrev = rank
+			}
+			heap.loserPairs = rank
+		}
+		n.fixed = strictFixLoser
+	case n.loss > 1:
+		pushFix(&heap.bigLosers, n)
+		n.fixed = strictFixBigLoser
+	default:
+		n.fixed = strictFixNone
+	}
+}
+
+// unfile removes the node from the fix lists.
+func (heap *StrictFibHeap) unfile(n *strictNode) {
+	switch n.fixed {
+	case strictFixRoot:
+		rank := heap.ranks[n.rank]
+		removeFix(&rank.roots, n)
+		rank.numRoots--
+		if rank.numRoots == 1 && rank.inRootPairs {
+			rank.inRootPairs = false
+			if rank.rootPairPrev != nil {
+				rank.rootPairPrev.rootPairNext = rank.rootPairNext
+			} else {
+				heap.rootPairs = rank.rootPairNext
+			}
+			if rank.rootPairNext != nil {
+				rank.rootPairNext.rootPairPrev = rank.rootPairPrev
+			}
+			rank.rootPairPrev = nil
+			rank.rootPairNext = nil
+		}
+	case strictFixLoser:
+		rank := heap.ranks[n.rank]
+		removeFix(&rank.losers, n)
+		rank.numLosers--
+		if rank.numLosers == 1 && rank.inLoserPairs {
+			rank.inLoserPairs = false
+			if rank.loserPairPrev != nil {
+				rank.loserPairPrev.loserPairNext = rank.loserPairNext
+			} else {
+				heap.loserPairs = rank.loserPairNext
+			}
+			if rank.loserPairNext != nil {
+				rank.loserPairNext.loserPairPrev = rank.loserPairPrev
+			}
+			rank.loserPairPrev = nil
+			rank.loserPairNext = nil
+		}
+	case strictFixBigLoser:
+		removeFix(&heap.bigLosers, n)
+	}
+	n.fixed = strictFixNone
+}
+
+func pushFix(head **strictNode, n *strictNode) {
+	n.fixPrev = nil
+	n.fixNext = *head
+	if *head != nil {
+		(*head).fixPrev = n
+	}
+	*head = n
+}
+
+func removeFix(head **strictNode, n *strictNode) {
+	if n.fixPrev != nil {
+		n.fixPrev.fixNext = n.fixNext
+	} else {
+		*head = n.fixNext
+	}
+	if n.fixNext != nil {
+		n.fixNext.fixPrev = n.fixPrev
+	}
+	n.fixPrev = nil
+	n.fixNext = nil
+}
@@ -0,0 +1,227 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"fmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/starwander/GoFibonacciHeap/heaptest"
+	"math"
+	"math/rand"
+	"time"
+)
+
+var _ = Describe("Tests of strictFibHeap", func() {
+	var (
+		heap        *StrictFibHeap
+		anotherHeap *StrictFibHeap
+	)
+
+	BeforeEach(func() {
+		heap = NewStrictFibHeap()
+		anotherHeap = NewStrictFibHeap()
+	})
+
+	AfterEach(func() {
+		heap = nil
+		anotherHeap = nil
+	})
+
+	It("Given an empty strictFibHeap, when call Minimum and ExtractMin api, it should return nil.", func() {
+		tag, key := heap.Minimum()
+		Expect(tag).Should(BeNil())
+		Expect(key).Should(BeEquivalentTo(math.Inf(-1)))
+		Expect(heap.ExtractMinValue()).Should(BeNil())
+		Expect(heap.String()).Should(BeEquivalentTo("Heap is empty.\n"))
+	})
+
+	It("Given a empty strictFibHeap, when call Insert api with invalid inputs, it should return error.", func() {
+		Expect(heap.Insert(nil, 0.0)).Should(HaveOccurred())
+		Expect(heap.InsertValue(nil)).Should(HaveOccurred())
+		Expect(heap.Insert(1000, math.Inf(-1))).Should(HaveOccurred())
+		Expect(heap.Insert(1000, 0.0)).ShouldNot(HaveOccurred())
+		Expect(heap.Insert(1000, 1.0)).Should(HaveOccurred())
+		Expect(heap.Num()).Should(BeEquivalentTo(1))
+	})
+
+	It("Given a strictFibHeap inserted multiple values, when call ExtractMinValue api, it should extract the values sorted by key.", func() {
+		rand.Seed(time.Now().Unix())
+		for i := 0; i < 10000; i++ {
+			demo := new(demoStruct)
+			demo.tag = i
+			demo.key = rand.Float64()
+			demo.value = fmt.Sprint(demo.key)
+			Expect(heap.InsertValue(demo)).ShouldNot(HaveOccurred())
+		}
+		Expect(checkStrictFibHeap(heap)).ShouldNot(HaveOccurred())
+
+		lastKey := heap.MinimumValue().(*demoStruct).key
+		for i := 0; i < 10000; i++ {
+			extracted := heap.ExtractMinValue().(*demoStruct)
+			Expect(extracted.key).Should(BeNumerically(">=", lastKey))
+			Expect(extracted.value).Should(Equal(fmt.Sprint(extracted.key)))
+			Expect(heap.Num()).Should(BeEquivalentTo(9999 - i))
+			lastKey = extracted.key
+			if i%1000 == 0 {
+				Expect(checkStrictFibHeap(heap)).ShouldNot(HaveOccurred())
+			}
+		}
+	})
+
+	It("Given a strictFibHeap inserted multiple values, when call DecreaseKey, IncreaseKey and Delete api, it should reorder the values.", func() {
+		for i := 0; i < 1000; i++ {
+			heap.Insert(i, float64(i+1000))
+		}
+		heap.ExtractMin()
+		for i := 999; i >= 1; i-- {
+			Expect(heap.DecreaseKey(i, float64(i))).ShouldNot(HaveOccurred())
+		}
+		Expect(checkStrictFibHeap(heap)).ShouldNot(HaveOccurred())
+
+		Expect(heap.DecreaseKey(500, 1500)).Should(HaveOccurred())
+		Expect(heap.IncreaseKey(500, 100)).Should(HaveOccurred())
+		Expect(heap.IncreaseKey(1, 5000)).ShouldNot(HaveOccurred())
+		Expect(heap.Delete(2)).ShouldNot(HaveOccurred())
+		Expect(heap.Delete(2)).Should(HaveOccurred())
+		Expect(checkStrictFibHeap(heap)).ShouldNot(HaveOccurred())
+
+		for i := 3; i < 1000; i++ {
+			tag, key := heap.ExtractMin()
+			Expect(tag).Should(BeEquivalentTo(i))
+			Expect(key).Should(BeEquivalentTo(i))
+		}
+		Expect(heap.ExtractTag(1)).Should(BeEquivalentTo(5000))
+		Expect(heap.Num()).Should(BeEquivalentTo(0))
+	})
+
+	It("Given a strictFibHeap with a value, when call DecreaseKey api by tag, it should keep the stored value.", func() {
+		demo := &demoStruct{1, 10, "10"}
+		heap.Insert(0, 0)
+		heap.InsertValue(demo)
+
+		Expect(heap.DecreaseKey(1, -5)).ShouldNot(HaveOccurred())
+		Expect(heap.GetValue(1)).Should(BeIdenticalTo(demo))
+		Expect(heap.MinimumValue()).Should(BeIdenticalTo(demo))
+	})
+
+	It("Given two strictFibHeaps, when call Union api, it should move all values of the input heap in and empty it.", func() {
+		for i := 0; i < 100; i++ {
+			heap.Insert(i, float64(100-i))
+			anotherHeap.InsertValue(&demoStruct{i + 100, float64(i), fmt.Sprint(i)})
+		}
+
+		Expect(heap.Union(anotherHeap)).ShouldNot(HaveOccurred())
+		Expect(heap.Num()).Should(BeEquivalentTo(200))
+		Expect(anotherHeap.Num()).Should(BeEquivalentTo(0))
+		Expect(heap.MinimumValue().(*demoStruct).tag).Should(Equal(100))
+		Expect(heap.Union(heap)).Should(HaveOccurred())
+	})
+
+	It("Given a strictFibHeap, when run the differential tester, it should never diverge from the reference model.", func() {
+		for seed := int64(0); seed < 20; seed++ {
+			Expect(heaptest.Run(NewStrictFibHeap(), seed)).ShouldNot(HaveOccurred())
+		}
+	})
+
+	It("Given a strictFibHeap under a decrease-key heavy workload, when checking the structure, it should keep the root degree logarithmic.", func() {
+		rand.Seed(time.Now().Unix())
+		for i := 0; i < 20000; i++ {
+			heap.Insert(i, float64(i))
+		}
+		heap.ExtractMin()
+		for i := 0; i < 20000; i++ {
+			tag := 1 + rand.Intn(19999)
+			heap.DecreaseKey(tag, heap.GetTag(tag)-rand.Float64()*100)
+			if i%100 == 0 {
+				heap.ExtractMin()
+			}
+		}
+
+		Expect(checkStrictFibHeap(heap)).ShouldNot(HaveOccurred())
+		degree := 0
+		if heap.root.child != nil {
+			degree = 1
+			for n := heap.root.child.right; n != heap.root.child; n = n.right {
+				degree++
+			}
+		}
+		Expect(degree).Should(BeNumerically("<", 4*math.Log2(float64(heap.Num()))+8))
+	})
+})
+
+// checkStrictFibHeap validates the structural invariants of a strict fibonacci heap.
+func checkStrictFibHeap(heap *StrictFibHeap) error {
+	if heap.root == nil {
+		return nil
+	}
+	if heap.root.active {
+		return fmt.Errorf("root is active")
+	}
+
+	var count uint
+	var walk func(n *strictNode) error
+	walk = func(n *strictNode) error {
+		count++
+		if heap.index[n.tag] != n {
+			return fmt.Errorf("index of tag %v is broken", n.tag)
+		}
+		if n.child == nil {
+			return nil
+		}
+
+		rank := 0
+		seenPassive := false
+		c := n.child
+		for {
+			if c.parent != n {
+				return fmt.Errorf("parent of %v is broken", c.tag)
+			}
+			if c.less(n) {
+				return fmt.Errorf("heap order between %v and %v is broken", n.tag, c.tag)
+			}
+			if c.active {
+				if seenPassive {
+					return fmt.Errorf("active child %v is right of a passive child", c.tag)
+				}
+				rank++
+			} else {
+				seenPassive = true
+			}
+			if n != heap.root && !n.active && c.active {
+				return fmt.Errorf("passive non-root %v has an active child", n.tag)
+			}
+			if err := walk(c); err != nil {
+				return err
+			}
+			c = c.right
+			if c == n.child {
+				break
+			}
+		}
+		if n.active && rank != n.rank {
+			return fmt.Errorf("rank of %v is %d but has %d active children", n.tag, n.rank, rank)
+		}
+
+		return nil
+	}
+	if err := walk(heap.root); err != nil {
+		return err
+	}
+	if count != heap.num {
+		return fmt.Errorf("tree has %d nodes but num is %d", count, heap.num)
+	}
+
+	var queued uint
+	if heap.queue != nil {
+		queued = 1
+		for n := heap.queue.queueNext; n != heap.queue; n = n.queueNext {
+			queued++
+		}
+	}
+	if queued != heap.num-1 {
+		return fmt.Errorf("queue has %d nodes but %d non-root nodes exist", queued, heap.num-1)
+	}
+
+	return nil
+}
@@ -0,0 +1,352 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// TagFibHeap is a Fibonacci Heap of tags and keys only, for the huge heaps whose memory is the binding constraint, e.g. routing tables.
+// It has the same tag methods as KeyHeap, with the O(1) amortized Insert and DecreaseKey of a Fibonacci Heap instead of O(log n).
+// Its nodes are slimmed down from the nodes of FibHeap: there is no value, no cached list element and no child list,
+// as the siblings are linked to each other directly and a node only points to its first child once it has one,
+// so a node takes 64 bytes, about a third of a FibHeap node with its list elements.
+// Please note that all methods of TagFibHeap are not concurrent safe.
+type TagFibHeap struct {
+	index   map[interface{}]*tagNode
+	min     *tagNode
+	degrees []*tagNode
+}
+
+// tagNode is a node of TagFibHeap. The children of a node and the roots are kept in circular doubly linked lists by left and right.
+type tagNode struct {
+	parent, child, left, right *tagNode
+	key                        float64
+	tag                        interface{}
+	degree                     int32
+	marked                     bool
+}
+
+// NewTagFibHeap creates an initialized empty TagFibHeap.
+func NewTagFibHeap() *TagFibHeap {
+	heap := new(TagFibHeap)
+	heap.index = make(map[interface{}]*tagNode)
+
+	return heap
+}
+
+// Num returns the total number of tags in the heap.
+func (heap *TagFibHeap) Num() uint {
+	return uint(len(heap.index))
+}
+
+// Insert pushes the input tag and key into the heap.
+// Try to insert a nil tag, a duplicate tag or a -inf key will cause an error return.
+func (heap *TagFibHeap) Insert(tag interface{}, key float64) error {
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+	if _, exists := heap.index[tag]; exists {
+		return errors.New("Duplicate tag is not allowed ")
+	}
+
+	n := &tagNode{key: key, tag: tag}
+	heap.index[tag] = n
+	heap.addRoot(n)
+
+	return nil
+}
+
+// Minimum returns the current minimum tag and key in the heap.
+// An empty heap will return nil and -inf.
+func (heap *TagFibHeap) Minimum() (interface{}, float64) {
+	if heap.min == nil {
+		return nil, math.Inf(-1)
+	}
+
+	return heap.min.tag, heap.min.key
+}
+
+// ExtractMin returns the current minimum tag and key in the heap and then extracts them.
+// An empty heap will return nil/-inf and extracts nothing.
+func (heap *TagFibHeap) ExtractMin() (interface{}, float64) {
+	if heap.min == nil {
+		return nil, math.Inf(-1)
+	}
+
+	min := heap.extractMin()
+
+	return min.tag, min.key
+}
+
+// DecreaseKey updates the tag in the heap by the input smaller key.
+// If the tag does not exist, or the key is -inf or not smaller, an error will be returned.
+func (heap *TagFibHeap) DecreaseKey(tag interface{}, key float64) error {
+	tag = tagKey(tag)
+	n, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Tag is not found ")
+	}
+	if math.IsInf(key, -1) {
+		return errors.New("Negative infinity key is reserved for internal usage ")
+	}
+	if key >= n.key {
+		return errors.New("New key is not smaller than current key ")
+	}
+
+	n.key = key
+	if parent := n.parent; parent != nil && key < parent.key {
+		heap.cut(n)
+		heap.cascadingCut(parent)
+	}
+	if key < heap.min.key {
+		heap.min = n
+	}
+
+	return nil
+}
+
+// IncreaseKey updates the tag in the heap by the input larger key.
+// If the tag does not exist, or the key is not larger, an error will be returned.
+func (heap *TagFibHeap) IncreaseKey(tag interface{}, key float64) error {
+	tag = tagKey(tag)
+	n, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Tag is not found ")
+	}
+	if key <= n.key {
+		return errors.New("New key is not larger than current key ")
+	}
+
+	// The children may be smaller than the new key, so they are moved to the roots before the key changes.
+	for n.child != nil {
+		heap.cut(n.child)
+	}
+	if parent := n.parent; parent != nil {
+		heap.cut(n)
+		heap.cascadingCut(parent)
+	}
+	n.key = key
+	if heap.min == n {
+		heap.resetMin()
+	}
+
+	return nil
+}
+
+// Delete deletes the input tag in the heap.
+// If the tag does not exist, an error will be returned.
+func (heap *TagFibHeap) Delete(tag interface{}) error {
+	tag = tagKey(tag)
+	n, exists := heap.index[tag]
+	if !exists {
+		return errors.New("Tag is not found ")
+	}
+	heap.remove(n)
+
+	return nil
+}
+
+// GetTag returns the key of the input tag.
+// If the tag does not exist, -inf will be returned.
+func (heap *TagFibHeap) GetTag(tag interface{}) float64 {
+	tag = tagKey(tag)
+	if n, exists := heap.index[tag]; exists {
+		return n.key
+	}
+
+	return math.Inf(-1)
+}
+
+// ExtractTag extracts the input tag and returns its key.
+// If the tag does not exist, -inf will be returned.
+func (heap *TagFibHeap) ExtractTag(tag interface{}) float64 {
+	tag = tagKey(tag)
+	n, exists := heap.index[tag]
+	if !exists {
+		return math.Inf(-1)
+	}
+
+	return heap.remove(n).key
+}
+
+// String provides some basic debug information of the heap.
+// It returns the total number and the current minimum of the heap.
+func (heap *TagFibHeap) String() string {
+	var buffer bytes.Buffer
+
+	if heap.min != nil {
+		buffer.WriteString(fmt.Sprintf("Total number: %d,\n", len(heap.index)))
+		buffer.WriteString(fmt.Sprintf("Current minimun: key(%f), tag(%v),\n", heap.min.key, heap.min.tag))
+	} else {
+		buffer.WriteString(fmt.Sprintf("Heap is empty.\n"))
+	}
+
+	return buffer.String()
+}
+
+// extractMin extracts the minimum node and returns it.
+func (heap *TagFibHeap) extractMin() *tagNode {
+	min := heap.min
+	for min.child != nil {
+		child := min.child
+		heap.removeChild(min, child)
+		child.parent = nil
+		child.marked = false
+		heap.splice(min, child)
+	}
+
+	if min.right == min {
+		heap.min = nil
+	} else {
+		min.left.right = min.right
+		min.right.left = min.left
+		heap.min = min.right
+		heap.consolidate()
+	}
+
+	delete(heap.index, min.tag)
+	min.left, min.right = nil, nil
+
+	return min
+}
+
+// remove extracts the node by making it the minimum.
+func (heap *TagFibHeap) remove(n *tagNode) *tagNode {
+	if parent := n.parent; parent != nil {
+		heap.cut(n)
+		heap.cascadingCut(parent)
+	}
+	heap.min = n
+
+	return heap.extractMin()
+}
+
+// consolidate links the roots of the same degree until all roots have different degrees, and finds the new minimum.
+func (heap *TagFibHeap) consolidate() {
+	degrees := heap.degrees[:0]
+
+	start := heap.min
+	start.left.right = nil
+	for next := start; next != nil; {
+		n := next
+		next = n.right
+		n.left, n.right = n, n
+		for {
+			degree := int(n.degree)
+			for len(degrees) <= degree {
+				degrees = append(degrees, nil)
+			}
+			other := degrees[degree]
+			if other == nil {
+				break
+			}
+			degrees[degree] = nil
+			if other.key < n.key {
+				n, other = other, n
+			}
+			heap.link(n, other)
+		}
+		degrees[n.degree] = n
+	}
+
+	heap.min = nil
+	for i, n := range degrees {
+		if n != nil {
+			heap.addRoot(n)
+			degrees[i] = nil
+		}
+	}
+	heap.degrees = degrees
+}
+
+// addRoot adds the single node to the roots and updates the minimum.
+func (heap *TagFibHeap) addRoot(n *tagNode) {
+	n.parent = nil
+	n.marked = false
+	if heap.min == nil {
+		n.left, n.right = n, n
+		heap.min = n
+		return
+	}
+
+	min := heap.min
+	n.left, n.right = min, min.right
+	min.right.left = n
+	min.right = n
+	if n.key < min.key {
+		heap.min = n
+	}
+}
+
+// link makes the child a child of the parent.
+func (heap *TagFibHeap) link(parent, child *tagNode) {
+	child.parent = parent
+	child.marked = false
+	if first := parent.child; first == nil {
+		child.left, child.right = child, child
+		parent.child = child
+	} else {
+		child.left, child.right = first, first.right
+		first.right.left = child
+		first.right = child
+	}
+	parent.degree++
+}
+
+// removeChild unlinks the child from the children of the parent.
+func (heap *TagFibHeap) removeChild(parent, child *tagNode) {
+	if child.right == child {
+		parent.child = nil
+	} else {
+		if parent.child == child {
+			parent.child = child.right
+		}
+		child.left.right = child.right
+		child.right.left = child.left
+	}
+	child.left, child.right = child, child
+	parent.degree--
+}
+
+// cut moves the node from its parent to the roots.
+func (heap *TagFibHeap) cut(n *tagNode) {
+	heap.removeChild(n.parent, n)
+	heap.addRoot(n)
+}
+
+func (heap *TagFibHeap) cascadingCut(n *tagNode) {
+	for n.parent != nil {
+		if !n.marked {
+			n.marked = true
+			return
+		}
+		parent := n.parent
+		heap.cut(n)
+		n = parent
+	}
+}
+
+// resetMin scans the roots for the minimum.
+func (heap *TagFibHeap) resetMin() {
+	start := heap.min
+	for n := start.right; n != start; n = n.right {
+		if n.key < heap.min.key {
+			heap.min = n
+		}
+	}
+}
+
+// splice joins the circular lists of a and b.
+func (heap *TagFibHeap) splice(a, b *tagNode) {
+	aRight, bLeft := a.right, b.left
+	a.right, b.left = b, a
+	aRight.left, bLeft.right = bLeft, aRight
+}
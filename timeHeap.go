@@ -0,0 +1,111 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// TimeHeap is a deadline queue keyed by time.Time, so the common case of scheduling tags at points in time
+// does not require manual conversions between times and float64 keys.
+// Internally, the keys are the nanoseconds since the creation of the TimeHeap on the monotonic clock,
+// which are exact in float64 for about 104 days around the creation.
+// Please note that all methods of TimeHeap are not concurrent safe.
+type TimeHeap struct {
+	heap *FibHeap
+	base time.Time
+}
+
+// NewTimeHeap creates an initialized empty TimeHeap.
+func NewTimeHeap() *TimeHeap {
+	heap := new(TimeHeap)
+	heap.heap = NewFibHeap()
+	heap.base = time.Now()
+
+	return heap
+}
+
+// Num returns the total number of tags in the heap.
+func (heap *TimeHeap) Num() uint {
+	return heap.heap.Num()
+}
+
+// InsertAt pushes the input tag with the deadline t into the heap.
+// Try to insert a nil or duplicate tag will cause an error return.
+func (heap *TimeHeap) InsertAt(tag interface{}, t time.Time) error {
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	return heap.heap.insert(tag, heap.key(t), nil)
+}
+
+// Reschedule moves the deadline of the input tag to t, either earlier or later.
+// If the input tag does not exist in the heap, an error will be returned.
+func (heap *TimeHeap) Reschedule(tag interface{}, t time.Time) error {
+	current := heap.heap.GetTag(tag)
+	if math.IsInf(current, -1) {
+		return errors.New("Tag is not found ")
+	}
+
+	key := heap.key(t)
+	if key < current {
+		return heap.heap.DecreaseKey(tag, key)
+	}
+	if key > current {
+		return heap.heap.IncreaseKey(tag, key)
+	}
+
+	return nil
+}
+
+// Deadline returns the deadline of the input tag.
+// If the input tag does not exist in the heap, a zero time will be returned.
+func (heap *TimeHeap) Deadline(tag interface{}) time.Time {
+	key := heap.heap.GetTag(tag)
+	if math.IsInf(key, -1) {
+		return time.Time{}
+	}
+
+	return heap.time(key)
+}
+
+// Remove removes the input tag from the heap.
+// If the input tag does not exist in the heap, an error will be returned.
+func (heap *TimeHeap) Remove(tag interface{}) error {
+	return heap.heap.Delete(tag)
+}
+
+// NextDeadline returns the tag with the earliest deadline and the deadline without removing it.
+// An empty heap will return nil and a zero time.
+func (heap *TimeHeap) NextDeadline() (interface{}, time.Time) {
+	tag, key := heap.heap.Minimum()
+	if tag == nil {
+		return nil, time.Time{}
+	}
+
+	return tag, heap.time(key)
+}
+
+// PopDue removes and returns all tags whose deadlines are not after now, in the order of their deadlines.
+// It returns nil if no tag is due.
+func (heap *TimeHeap) PopDue(now time.Time) []interface{} {
+	var due []interface{}
+	limit := heap.key(now)
+	for heap.heap.Num() != 0 && heap.heap.keyOf(heap.heap.min) <= limit {
+		tag, _ := heap.heap.ExtractMin()
+		due = append(due, tag)
+	}
+
+	return due
+}
+
+func (heap *TimeHeap) key(t time.Time) float64 {
+	return float64(t.Sub(heap.base))
+}
+
+func (heap *TimeHeap) time(key float64) time.Time {
+	return heap.base.Add(time.Duration(key))
+}
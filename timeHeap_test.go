@@ -0,0 +1,65 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"time"
+)
+
+var _ = Describe("Tests of timeHeap", func() {
+	var (
+		heap *TimeHeap
+		now  time.Time
+	)
+
+	BeforeEach(func() {
+		heap = NewTimeHeap()
+		now = time.Now()
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given an empty timeHeap, when call NextDeadline and PopDue api, it should return nothing.", func() {
+		tag, deadline := heap.NextDeadline()
+		Expect(tag).Should(BeNil())
+		Expect(deadline.IsZero()).Should(BeTrue())
+		Expect(heap.PopDue(now)).Should(BeNil())
+		Expect(heap.Deadline(1).IsZero()).Should(BeTrue())
+	})
+
+	It("Given a timeHeap with deadlines, when call PopDue api, it should pop the due tags in the order of deadlines.", func() {
+		for i := 0; i < 10; i++ {
+			Expect(heap.InsertAt(i, now.Add(time.Duration(10-i)*time.Second))).ShouldNot(HaveOccurred())
+		}
+		Expect(heap.InsertAt(1, now)).Should(HaveOccurred())
+		Expect(heap.InsertAt(nil, now)).Should(HaveOccurred())
+
+		tag, deadline := heap.NextDeadline()
+		Expect(tag).Should(Equal(9))
+		Expect(deadline.Equal(now.Add(time.Second))).Should(BeTrue())
+		Expect(heap.Deadline(0).Equal(now.Add(10 * time.Second))).Should(BeTrue())
+
+		Expect(heap.PopDue(now)).Should(BeNil())
+		Expect(heap.PopDue(now.Add(3 * time.Second))).Should(Equal([]interface{}{9, 8, 7}))
+		Expect(heap.Num()).Should(BeEquivalentTo(7))
+	})
+
+	It("Given a timeHeap with deadlines, when call Reschedule and Remove api, it should move and remove the deadlines.", func() {
+		heap.InsertAt(1, now.Add(time.Second))
+		heap.InsertAt(2, now.Add(2*time.Second))
+		heap.InsertAt(3, now.Add(3*time.Second))
+
+		Expect(heap.Reschedule(1, now.Add(time.Minute))).ShouldNot(HaveOccurred())
+		Expect(heap.Reschedule(3, now.Add(-time.Minute))).ShouldNot(HaveOccurred())
+		Expect(heap.Reschedule(2, now.Add(2*time.Second))).ShouldNot(HaveOccurred())
+		Expect(heap.Reschedule(4, now)).Should(HaveOccurred())
+		Expect(heap.Remove(2)).ShouldNot(HaveOccurred())
+		Expect(heap.Remove(2)).Should(HaveOccurred())
+
+		Expect(heap.PopDue(now.Add(time.Hour))).Should(Equal([]interface{}{3, 1}))
+	})
+})
@@ -0,0 +1,181 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// TimerID identifies a timer of a TimerManager.
+type TimerID uint64
+
+// TimerManager manages a large number of timers with a FibHeap and a single goroutine owning a single runtime timer.
+// It is designed for services managing hundreds of thousands of timeouts which are mostly reset or stopped before they fire,
+// where the contention on the runtime timer heap hurts.
+// Reset and Stop are O(1) amortized when moving a timer earlier and O(log n) amortized otherwise.
+// All methods of TimerManager are concurrent safe.
+type TimerManager struct {
+	mutex  sync.Mutex
+	heap   *FibHeap
+	fns    map[TimerID]func()
+	base   time.Time
+	seq    TimerID
+	wakeup chan struct{}
+	done   chan struct{}
+	closed bool
+}
+
+// NewTimerManager creates an initialized TimerManager and starts its goroutine.
+// Close must be called to stop the goroutine when the manager is no longer used.
+func NewTimerManager() *TimerManager {
+	manager := new(TimerManager)
+	manager.heap = NewFibHeap()
+	manager.fns = make(map[TimerID]func())
+	manager.base = time.Now()
+	manager.wakeup = make(chan struct{}, 1)
+	manager.done = make(chan struct{})
+
+	go manager.run()
+
+	return manager
+}
+
+// Num returns the number of active timers.
+func (manager *TimerManager) Num() uint {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	return manager.heap.Num()
+}
+
+// AfterFunc starts a timer which calls fn in its own goroutine after the duration d, and returns the id of the timer.
+// Timers started on a closed manager never fire.
+func (manager *TimerManager) AfterFunc(d time.Duration, fn func()) TimerID {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	manager.seq++
+	if !manager.closed {
+		manager.fns[manager.seq] = fn
+		manager.heap.Insert(manager.seq, manager.deadline(d))
+		manager.notify()
+	}
+
+	return manager.seq
+}
+
+// Reset changes the timer to fire after the duration d.
+// It returns true if the timer had been active, false if the timer had expired or been stopped, in which case nothing is changed.
+func (manager *TimerManager) Reset(id TimerID, d time.Duration) bool {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	key := manager.heap.GetTag(id)
+	if math.IsInf(key, -1) {
+		return false
+	}
+
+	deadline := manager.deadline(d)
+	if deadline < key {
+		manager.heap.DecreaseKey(id, deadline)
+		manager.notify()
+	} else if deadline > key {
+		manager.heap.IncreaseKey(id, deadline)
+	}
+
+	return true
+}
+
+// Stop prevents the timer from firing.
+// It returns true if the call stops the timer, false if the timer has already expired or been stopped.
+func (manager *TimerManager) Stop(id TimerID) bool {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	if manager.heap.Delete(id) != nil {
+		return false
+	}
+	delete(manager.fns, id)
+
+	return true
+}
+
+// Close stops the goroutine of the manager. All active timers will never fire.
+func (manager *TimerManager) Close() {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	if !manager.closed {
+		manager.closed = true
+		close(manager.done)
+	}
+}
+
+func (manager *TimerManager) deadline(d time.Duration) float64 {
+	return float64(time.Since(manager.base) + d)
+}
+
+func (manager *TimerManager) notify() {
+	select {
+	case manager.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+func (manager *TimerManager) run() {
+	timer := time.NewTimer(time.Hour)
+	if !timer.Stop() {
+		<-timer.C
+	}
+
+	for {
+		manager.mutex.Lock()
+		fns, wait := manager.expire()
+		manager.mutex.Unlock()
+
+		for _, fn := range fns {
+			go fn()
+		}
+
+		if wait < 0 {
+			select {
+			case <-manager.wakeup:
+			case <-manager.done:
+				return
+			}
+			continue
+		}
+
+		timer.Reset(wait)
+		select {
+		case <-timer.C:
+		case <-manager.wakeup:
+			if !timer.Stop() {
+				<-timer.C
+			}
+		case <-manager.done:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// expire extracts all expired timers and returns their functions,
+// and the duration to wait for the next timer, which is negative if there is no active timer.
+func (manager *TimerManager) expire() (fns []func(), wait time.Duration) {
+	now := float64(time.Since(manager.base))
+	for manager.heap.Num() > 0 {
+		id, key := manager.heap.Minimum()
+		if key > now {
+			return fns, time.Duration(key - now)
+		}
+
+		manager.heap.ExtractMin()
+		fns = append(fns, manager.fns[id.(TimerID)])
+		delete(manager.fns, id.(TimerID))
+	}
+
+	return fns, -1
+}
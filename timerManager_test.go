@@ -0,0 +1,96 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"sync/atomic"
+	"time"
+)
+
+var _ = Describe("Tests of timerManager", func() {
+	var (
+		manager *TimerManager
+	)
+
+	BeforeEach(func() {
+		manager = NewTimerManager()
+	})
+
+	AfterEach(func() {
+		manager.Close()
+		manager = nil
+	})
+
+	It("Given a timerManager, when call AfterFunc api, it should call the functions in the order of their durations.", func() {
+		fired := make(chan int, 3)
+		start := time.Now()
+		manager.AfterFunc(60*time.Millisecond, func() { fired <- 3 })
+		manager.AfterFunc(20*time.Millisecond, func() { fired <- 1 })
+		manager.AfterFunc(40*time.Millisecond, func() { fired <- 2 })
+		Expect(manager.Num()).Should(BeEquivalentTo(3))
+
+		for i := 1; i <= 3; i++ {
+			Eventually(fired).Should(Receive(Equal(i)))
+		}
+		Expect(time.Since(start)).Should(BeNumerically(">=", 60*time.Millisecond))
+		Eventually(manager.Num).Should(BeEquivalentTo(0))
+	})
+
+	It("Given a timerManager with active timers, when call Stop api, it should prevent the timers from firing.", func() {
+		var count int32
+		first := manager.AfterFunc(20*time.Millisecond, func() { atomic.AddInt32(&count, 1) })
+		manager.AfterFunc(20*time.Millisecond, func() { atomic.AddInt32(&count, 1) })
+
+		Expect(manager.Stop(first)).Should(BeTrue())
+		Expect(manager.Stop(first)).Should(BeFalse())
+		Eventually(func() int32 { return atomic.LoadInt32(&count) }).Should(BeEquivalentTo(1))
+		Consistently(func() int32 { return atomic.LoadInt32(&count) }, 50*time.Millisecond).Should(BeEquivalentTo(1))
+	})
+
+	It("Given a timerManager with active timers, when call Reset api, it should move the timers earlier or later.", func() {
+		fired := make(chan int, 2)
+		start := time.Now()
+		early := manager.AfterFunc(20*time.Millisecond, func() { fired <- 1 })
+		late := manager.AfterFunc(time.Hour, func() { fired <- 2 })
+
+		Expect(manager.Reset(late, 10*time.Millisecond)).Should(BeTrue())
+		Expect(manager.Reset(early, 60*time.Millisecond)).Should(BeTrue())
+		Eventually(fired).Should(Receive(Equal(2)))
+		Eventually(fired).Should(Receive(Equal(1)))
+		Expect(time.Since(start)).Should(BeNumerically(">=", 60*time.Millisecond))
+
+		Expect(manager.Reset(early, time.Millisecond)).Should(BeFalse())
+		Expect(manager.Reset(TimerID(100), time.Millisecond)).Should(BeFalse())
+	})
+
+	It("Given a timerManager with many timers, when most of them are stopped, it should only fire the rest.", func() {
+		var count int32
+		var ids []TimerID
+		// The timers to stop never reach their deadlines, however slow the test runs.
+		for i := 0; i < 10000; i++ {
+			d := time.Hour
+			if i%10 == 0 {
+				d = time.Duration(200+i%50) * time.Millisecond
+			}
+			ids = append(ids, manager.AfterFunc(d, func() { atomic.AddInt32(&count, 1) }))
+		}
+		for i, id := range ids {
+			if i%10 != 0 {
+				Expect(manager.Stop(id)).Should(BeTrue())
+			}
+		}
+
+		Eventually(func() int32 { return atomic.LoadInt32(&count) }, 2*time.Second).Should(BeEquivalentTo(1000))
+		Expect(manager.Num()).Should(BeEquivalentTo(0))
+	})
+
+	It("Given a closed timerManager, when call AfterFunc api, it should never fire.", func() {
+		var count int32
+		manager.AfterFunc(10*time.Millisecond, func() { atomic.AddInt32(&count, 1) })
+		manager.Close()
+		manager.AfterFunc(time.Millisecond, func() { atomic.AddInt32(&count, 1) })
+		Consistently(func() int32 { return atomic.LoadInt32(&count) }, 50*time.Millisecond).Should(BeEquivalentTo(0))
+	})
+})
@@ -0,0 +1,120 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"container/list"
+)
+
+// minCompaction is the minimum number of dead nodes before a heap in the lazy deletion mode is compacted.
+const minCompaction = 64
+
+// SetLazyDelete turns on or off the lazy deletion mode of the heap, for the workloads which delete most values before they reach the minimum,
+// e.g. timers which are mostly cancelled before they fire.
+// In the lazy deletion mode, Delete, ExtractTag and the other methods deleting a value other than the minimum only mark its node dead in O(1),
+// instead of decreasing its key to -inf and extracting it in O(log n) amortized.
+// The dead nodes stay in the trees, and are purged when they become roots, i.e. by the consolidation of ExtractMin.
+// Once the dead nodes outnumber the values, the heap is rebuilt from the values in O(n), so the memory is at most about doubled.
+// Turning off the lazy deletion mode purges all dead nodes.
+func (heap *FibHeap) SetLazyDelete(lazy bool) {
+	if debugMode {
+		defer heap.guard.enter("SetLazyDelete")()
+	}
+
+	heap.lazy = lazy
+	if !lazy && heap.dead != 0 {
+		heap.compact()
+	}
+}
+
+// LazyDelete reports whether the lazy deletion mode of the heap is on.
+func (heap *FibHeap) LazyDelete() bool {
+	if debugMode {
+		defer heap.guard.enter("LazyDelete")()
+	}
+
+	return heap.lazy
+}
+
+// bury marks the node dead and removes it from the index, leaving it in the trees.
+func (heap *FibHeap) bury(n *node) {
+	n.dead = true
+	delete(heap.index, n.tag)
+	delete(heap.counts, n.tag)
+	delete(heap.weights, n.tag)
+	heap.num--
+	heap.dead++
+	heap.logRemove(n.tag)
+
+	if heap.dead >= minCompaction && heap.dead > heap.num {
+		heap.compact()
+	}
+}
+
+// purgeRoots removes the dead roots and promotes their children to roots, until no root is dead.
+func (heap *FibHeap) purgeRoots() {
+	if heap.dead == 0 {
+		return
+	}
+
+	for e := heap.roots.Front(); e != nil; {
+		if e.Value.(*node).dead {
+			e = heap.purge(e)
+		} else {
+			e = e.Next()
+		}
+	}
+}
+
+// purge removes the dead root and promotes its children to roots, and returns the next root.
+func (heap *FibHeap) purge(e *list.Element) *list.Element {
+	n := e.Value.(*node)
+	for child := n.children.Front(); child != nil; child = child.Next() {
+		child.Value.(*node).parent = nil
+		child.Value.(*node).self = heap.roots.PushBack(child.Value.(*node))
+	}
+
+	next := e.Next()
+	heap.removeRoot(e)
+	heap.dead--
+	heap.recycle(n)
+
+	return next
+}
+
+// compact drops all dead nodes by rebuilding the heap from the nodes of the index.
+func (heap *FibHeap) compact() {
+	if heap.observing() {
+		heap.observe("compact", heap.compact)
+		return
+	}
+
+	nodes := make([]*node, 0, heap.num)
+	for _, n := range heap.index {
+		nodes = append(nodes, n)
+	}
+
+	heap.rebuild(nodes)
+}
+
+// rebuild makes every input node a root and consolidates them, dropping all other nodes of the heap.
+func (heap *FibHeap) rebuild(nodes []*node) {
+	heap.roots = list.New()
+	heap.treeDegrees = nil
+	heap.done = nil
+	heap.dead = 0
+	for _, n := range nodes {
+		n.parent = nil
+		n.children = list.New()
+		n.marked = false
+		n.degree = 0
+		n.position = 0
+		n.self = heap.roots.PushBack(n)
+	}
+
+	if len(nodes) == 0 {
+		heap.min = nil
+	} else {
+		heap.consolidate()
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"math/rand"
+)
+
+var _ = Describe("Tests of lazy deletion mode", func() {
+	var heap, reference *FibHeap
+
+	BeforeEach(func() {
+		heap = NewFibHeap()
+		heap.SetLazyDelete(true)
+		reference = NewFibHeap()
+	})
+
+	AfterEach(func() {
+		heap = nil
+		reference = nil
+	})
+
+	It("Given a fibHeap in the lazy deletion mode, when delete values, it should mark them dead and skip them by ExtractMin.", func() {
+		Expect(heap.LazyDelete()).Should(BeTrue())
+		for i := 0; i < 10; i++ {
+			heap.Insert(i, float64(i))
+		}
+		heap.ExtractMin()
+
+		Expect(heap.Delete(3)).Should(BeNil())
+		Expect(heap.ExtractTag(5)).Should(BeEquivalentTo(5))
+		Expect(heap.Delete(3)).ShouldNot(BeNil())
+		Expect(heap.Num()).Should(BeEquivalentTo(7))
+		Expect(heap.dead).Should(BeEquivalentTo(2))
+		Expect(heap.GetTag(3)).Should(BeEquivalentTo(math.Inf(-1)))
+		Expect(heap.String()).Should(ContainSubstring("(3.000000)"))
+
+		Expect(heap.Insert(3, 30)).Should(BeNil())
+		for _, tag := range []int{1, 2, 4, 6, 7, 8, 9, 3} {
+			min, _ := heap.ExtractMin()
+			Expect(min).Should(BeEquivalentTo(tag))
+		}
+		Expect(heap.Num()).Should(BeEquivalentTo(0))
+	})
+
+	It("Given a fibHeap in the lazy deletion mode under random operations, it should behave the same as a normal fibHeap.", func() {
+		random := rand.New(rand.NewSource(1879))
+		for i := 0; i < 20000; i++ {
+			tag := random.Intn(500)
+			// The keys are unique so that both heaps extract the same tags.
+			key := float64(random.Intn(1000)*500 + tag)
+			switch random.Intn(6) {
+			case 0, 1:
+				Expect(heap.Insert(tag, key) == nil).Should(Equal(reference.Insert(tag, key) == nil))
+			case 2:
+				Expect(heap.Delete(tag) == nil).Should(Equal(reference.Delete(tag) == nil))
+			case 3:
+				Expect(heap.DecreaseKey(tag, key) == nil).Should(Equal(reference.DecreaseKey(tag, key) == nil))
+			case 4:
+				Expect(heap.IncreaseKey(tag, key) == nil).Should(Equal(reference.IncreaseKey(tag, key) == nil))
+			case 5:
+				min, _ := heap.ExtractMin()
+				referenceMin, _ := reference.ExtractMin()
+				Expect(min).Should(Equal(referenceMin))
+			}
+			Expect(heap.Num()).Should(Equal(reference.Num()))
+			Expect(heap.dead).Should(BeNumerically("<=", heap.Num()+minCompaction))
+		}
+
+		Expect(heap.Equal(reference)).Should(BeTrue())
+		heap.SetLazyDelete(false)
+		Expect(heap.dead).Should(BeEquivalentTo(0))
+		for reference.Num() != 0 {
+			_, key := heap.ExtractMin()
+			_, referenceKey := reference.ExtractMin()
+			Expect(key).Should(Equal(referenceKey))
+		}
+		Expect(heap.Num()).Should(BeEquivalentTo(0))
+	})
+
+	It("Given a fibHeap with dead nodes, when transform the keys out of order, it should keep the minimum right.", func() {
+		for i := 0; i < 100; i++ {
+			heap.Insert(i, float64(i))
+		}
+		heap.ExtractMin()
+		for i := 1; i < 100; i += 2 {
+			heap.Delete(i)
+		}
+
+		Expect(heap.TransformKeys(func(old float64) float64 { return -old })).Should(BeNil())
+		Expect(heap.dead).Should(BeEquivalentTo(0))
+		min, _ := heap.ExtractMin()
+		Expect(min).Should(BeEquivalentTo(98))
+		Expect(NewFibHeap().Union(heap)).Should(BeNil())
+		Expect(heap.Num()).Should(BeEquivalentTo(0))
+		Expect(heap.LazyDelete()).Should(BeTrue())
+	})
+})
@@ -0,0 +1,69 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"container/list"
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// topologyNode is a node of the trees in the output of TopologyJSON.
+type topologyNode struct {
+	Tag      interface{}    `json:"tag"`
+	Key      topologyKey    `json:"key"`
+	Marked   bool           `json:"marked"`
+	Dead     bool           `json:"dead,omitempty"`
+	Children []topologyNode `json:"children"`
+}
+
+// topologyKey is a key which is encoded as a JSON number, or as the string "+Inf" or "-Inf" which JSON has no number for.
+type topologyKey float64
+
+func (key topologyKey) MarshalJSON() ([]byte, error) {
+	if math.IsInf(float64(key), 0) {
+		return []byte(strconv.Quote(strconv.FormatFloat(float64(key), 'g', -1, 64))), nil
+	}
+
+	return json.Marshal(float64(key))
+}
+
+// TopologyJSON returns the trees of the heap as a JSON array of the roots, in which every node is an object of its tag, key,
+// whether it is marked by a cut of one of its children, and the array of its children,
+// e.g. [{"tag":1,"key":1,"marked":false,"children":[{"tag":2,"key":2,"marked":true,"children":[]}]}], for visualization frontends.
+// The dead nodes of the lazy deletion mode have "dead":true in addition. An infinite key is encoded as the string "+Inf".
+// The tags are encoded by encoding/json, and a CompositeTag is encoded as the array of its parts.
+// A tag which encoding/json fails to encode will cause an error return.
+func (heap *FibHeap) TopologyJSON() ([]byte, error) {
+	if debugMode {
+		defer heap.guard.enter("TopologyJSON")()
+	}
+
+	return json.Marshal(heap.topology(heap.roots))
+}
+
+func (heap *FibHeap) topology(trees *list.List) []topologyNode {
+	nodes := make([]topologyNode, 0, trees.Len())
+	for e := trees.Front(); e != nil; e = e.Next() {
+		n := e.Value.(*node)
+		nodes = append(nodes, topologyNode{topologyTag(n.tag), topologyKey(heap.keyOf(n)), n.marked, n.dead, heap.topology(n.children)})
+	}
+
+	return nodes
+}
+
+// topologyTag returns the tag to be encoded by encoding/json, i.e. the parts of a CompositeTag, whose fields are unexported.
+func topologyTag(tag interface{}) interface{} {
+	composite, ok := tag.(CompositeTag)
+	if !ok {
+		return tag
+	}
+
+	parts := composite.Parts()
+	for i, part := range parts {
+		parts[i] = topologyTag(part)
+	}
+
+	return parts
+}
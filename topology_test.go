@@ -0,0 +1,72 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"encoding/json"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+)
+
+var _ = Describe("Tests of TopologyJSON", func() {
+	var heap *FibHeap
+
+	BeforeEach(func() {
+		heap = NewFibHeap()
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given an empty fibHeap, when call TopologyJSON api, it should return an empty array.", func() {
+		data, err := heap.TopologyJSON()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(data)).Should(Equal("[]"))
+	})
+
+	It("Given a consolidated fibHeap, when call TopologyJSON api, it should return the nested trees.", func() {
+		for i := 0; i < 5; i++ {
+			heap.Insert(i, float64(i))
+		}
+		heap.ExtractMin()
+		heap.DecreaseKey(4, 3.5)
+
+		data, err := heap.TopologyJSON()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(data)).Should(MatchJSON(`[{"tag":1,"key":1,"marked":false,"children":[
+			{"tag":2,"key":2,"marked":false,"children":[]},
+			{"tag":3,"key":3,"marked":false,"children":[{"tag":4,"key":3.5,"marked":false,"children":[]}]}]}]`))
+
+		heap.DecreaseKey(4, 0.5)
+		data, _ = heap.TopologyJSON()
+		Expect(string(data)).Should(MatchJSON(`[{"tag":1,"key":1,"marked":false,"children":[
+			{"tag":2,"key":2,"marked":false,"children":[]},
+			{"tag":3,"key":3,"marked":true,"children":[]}]},
+			{"tag":4,"key":0.5,"marked":false,"children":[]}]`))
+	})
+
+	It("Given a fibHeap with special tags, keys and dead nodes, when call TopologyJSON api, it should encode them all.", func() {
+		heap.SetLazyDelete(true)
+		heap.Insert(TagOf("a", TagOf(1, 2)), math.Inf(1))
+		heap.Insert([]byte("b"), 1)
+		heap.Insert("c", 0)
+		heap.Delete([]byte("b"))
+
+		data, err := heap.TopologyJSON()
+		Expect(err).ShouldNot(HaveOccurred())
+		var trees []map[string]interface{}
+		Expect(json.Unmarshal(data, &trees)).Should(Succeed())
+		Expect(trees).Should(HaveLen(3))
+		Expect(trees[0]["tag"]).Should(Equal([]interface{}{"a", []interface{}{1.0, 2.0}}))
+		Expect(trees[0]["key"]).Should(Equal("+Inf"))
+		Expect(trees[1]["tag"]).Should(Equal("b"))
+		Expect(trees[1]["dead"]).Should(BeTrue())
+		Expect(trees[2]).ShouldNot(HaveKey("dead"))
+
+		heap.Insert(struct{ C chan int }{make(chan int)}, 2)
+		_, err = heap.TopologyJSON()
+		Expect(err).Should(HaveOccurred())
+	})
+})
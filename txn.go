@@ -0,0 +1,235 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+// HeapTxn is the handle of a transaction started by Txn.
+// It provides the same methods as FibHeap, and every mutation through it can be rolled back.
+type HeapTxn struct {
+	heap  *FibHeap
+	saved map[interface{}]bool
+	undo  []txnUndo
+}
+
+// txnUndo is the state of a tag before its first mutation in a transaction.
+type txnUndo struct {
+	tag      interface{}
+	existed  bool
+	key      float64
+	value    Value
+	count    uint
+	weight   float64
+	weighted bool
+}
+
+// Txn calls fn with a transaction of the heap. If fn returns an error or panics, all mutations made through the transaction are rolled back,
+// so the heap can be kept consistent with an external store updated in the same closure.
+// The error of fn is returned, and a panic of fn goes on after the rollback.
+// The heap must only be accessed through the transaction inside fn, and the transaction must not be used after fn returns.
+// A rollback is applied as further changes of the heap, so the hooks and the write-ahead log see it as well.
+// The values are restored by reference, so a value modified in place inside fn is not restored.
+func (heap *FibHeap) Txn(fn func(tx *HeapTxn) error) (err error) {
+	if debugMode {
+		defer heap.guard.enter("Txn")()
+	}
+
+	tx := &HeapTxn{heap: heap, saved: make(map[interface{}]bool)}
+
+	committed := false
+	defer func() {
+		if !committed {
+			tx.rollback()
+		}
+	}()
+
+	if err = fn(tx); err == nil {
+		committed = true
+	}
+
+	return err
+}
+
+// Num returns the total number of values in the heap.
+func (tx *HeapTxn) Num() uint {
+	return tx.heap.Num()
+}
+
+// Insert pushes the input tag and key into the heap.
+func (tx *HeapTxn) Insert(tag interface{}, key float64) error {
+	tx.save(tag)
+
+	return tx.heap.Insert(tag, key)
+}
+
+// InsertValue pushes the input value into the heap.
+func (tx *HeapTxn) InsertValue(value Value) error {
+	if !isNilValue(value) {
+		tx.save(value.Tag())
+	}
+
+	return tx.heap.InsertValue(value)
+}
+
+// Minimum returns the current minimum tag and key in the heap.
+func (tx *HeapTxn) Minimum() (interface{}, float64) {
+	return tx.heap.Minimum()
+}
+
+// MinimumValue returns the current minimum value in the heap.
+func (tx *HeapTxn) MinimumValue() Value {
+	return tx.heap.MinimumValue()
+}
+
+// ExtractMin returns the current minimum tag and key in the heap and then extracts them from the heap.
+func (tx *HeapTxn) ExtractMin() (interface{}, float64) {
+	if tx.heap.num != 0 {
+		tx.save(tx.heap.min.tag)
+	}
+
+	return tx.heap.ExtractMin()
+}
+
+// ExtractMinValue returns the current minimum value in the heap and then extracts it from the heap.
+func (tx *HeapTxn) ExtractMinValue() Value {
+	if tx.heap.num != 0 {
+		tx.save(tx.heap.min.tag)
+	}
+
+	return tx.heap.ExtractMinValue()
+}
+
+// DecreaseKey updates the tag in the heap by the input smaller key.
+func (tx *HeapTxn) DecreaseKey(tag interface{}, key float64) error {
+	tx.save(tag)
+
+	return tx.heap.DecreaseKey(tag, key)
+}
+
+// DecreaseKeyValue updates the value in the heap by the input value with a smaller key.
+func (tx *HeapTxn) DecreaseKeyValue(value Value) error {
+	if !isNilValue(value) {
+		tx.save(value.Tag())
+	}
+
+	return tx.heap.DecreaseKeyValue(value)
+}
+
+// IncreaseKey updates the tag in the heap by the input larger key.
+func (tx *HeapTxn) IncreaseKey(tag interface{}, key float64) error {
+	tx.save(tag)
+
+	return tx.heap.IncreaseKey(tag, key)
+}
+
+// IncreaseKeyValue updates the value in the heap by the input value with a larger key.
+func (tx *HeapTxn) IncreaseKeyValue(value Value) error {
+	if !isNilValue(value) {
+		tx.save(value.Tag())
+	}
+
+	return tx.heap.IncreaseKeyValue(value)
+}
+
+// Delete deletes the input tag in the heap.
+func (tx *HeapTxn) Delete(tag interface{}) error {
+	tx.save(tag)
+
+	return tx.heap.Delete(tag)
+}
+
+// DeleteValue deletes the value in the heap by the input value.
+func (tx *HeapTxn) DeleteValue(value Value) error {
+	if !isNilValue(value) {
+		tx.save(value.Tag())
+	}
+
+	return tx.heap.DeleteValue(value)
+}
+
+// GetTag searches and returns the key in the heap by the input tag.
+func (tx *HeapTxn) GetTag(tag interface{}) float64 {
+	return tx.heap.GetTag(tag)
+}
+
+// GetValue searches and returns the value in the heap by the input tag.
+func (tx *HeapTxn) GetValue(tag interface{}) Value {
+	return tx.heap.GetValue(tag)
+}
+
+// ExtractTag searches and extracts the tag/key in the heap by the input tag.
+func (tx *HeapTxn) ExtractTag(tag interface{}) float64 {
+	tx.save(tag)
+
+	return tx.heap.ExtractTag(tag)
+}
+
+// ExtractValue searches and extracts the value in the heap by the input tag.
+func (tx *HeapTxn) ExtractValue(tag interface{}) Value {
+	tx.save(tag)
+
+	return tx.heap.ExtractValue(tag)
+}
+
+// save keeps the state of the tag before its first mutation in the transaction.
+func (tx *HeapTxn) save(tag interface{}) {
+	tag = tagKey(tag)
+	if tag == nil || tx.saved[tag] {
+		return
+	}
+	tx.saved[tag] = true
+
+	undo := txnUndo{tag: tag, count: tx.heap.counts[tag]}
+	undo.weight, undo.weighted = tx.heap.weights[tag]
+	if n, exists := tx.heap.index[tag]; exists {
+		undo.existed, undo.key, undo.value = true, tx.heap.keyOf(n), n.value
+	}
+	tx.undo = append(tx.undo, undo)
+}
+
+// rollback restores every mutated tag to its saved state, in the reverse order of the mutations.
+func (tx *HeapTxn) rollback() {
+	heap := tx.heap
+	// The saved states fitted into the quota already, so they are restored regardless of it.
+	quota := heap.quota
+	heap.quota = Quota{}
+	defer func() { heap.quota = quota }()
+
+	for i := len(tx.undo) - 1; i >= 0; i-- {
+		undo := tx.undo[i]
+		if n, exists := heap.index[undo.tag]; exists {
+			if undo.existed && heap.keyOf(n) == undo.key {
+				n.value = undo.value
+				heap.logPut(n)
+				heap.restoreCount(undo)
+				continue
+			}
+			heap.remove(n)
+		}
+		if undo.existed {
+			heap.insert(undo.tag, undo.key, undo.value)
+			heap.restoreCount(undo)
+		}
+	}
+	tx.saved, tx.undo = nil, nil
+}
+
+// restoreCount restores the count of the tag inserted by InsertOrIncrement, and the weight of the tag set by SetWeight.
+func (heap *FibHeap) restoreCount(undo txnUndo) {
+	if !undo.weighted {
+		delete(heap.weights, undo.tag)
+	} else {
+		if heap.weights == nil {
+			heap.weights = make(map[interface{}]float64)
+		}
+		heap.weights[undo.tag] = undo.weight
+	}
+
+	if undo.count == 0 {
+		delete(heap.counts, undo.tag)
+		return
+	}
+
+	if heap.counts == nil {
+		heap.counts = make(map[interface{}]uint)
+	}
+	heap.counts[undo.tag] = undo.count
+}
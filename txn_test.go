@@ -0,0 +1,88 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"errors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math/rand"
+)
+
+var _ = Describe("Tests of txn", func() {
+	var (
+		heap *FibHeap
+	)
+
+	BeforeEach(func() {
+		heap = NewFibHeap()
+		for i := 0; i < 100; i++ {
+			heap.InsertValue(&demoStruct{i, float64(i), ""})
+		}
+		heap.InsertOrIncrement("counted", 50.5)
+		heap.InsertOrIncrement("counted", 50.5)
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given a transaction returning nil, when it returns, it should keep all mutations.", func() {
+		Expect(heap.Txn(func(tx *HeapTxn) error {
+			Expect(tx.Insert("a", 0.5)).ShouldNot(HaveOccurred())
+			Expect(tx.Delete(1)).ShouldNot(HaveOccurred())
+			Expect(tx.DecreaseKey(2, -1)).ShouldNot(HaveOccurred())
+			tag, _ := tx.ExtractMin()
+			Expect(tag).Should(Equal(2))
+			return nil
+		})).ShouldNot(HaveOccurred())
+
+		Expect(heap.Num()).Should(BeEquivalentTo(100))
+		Expect(heap.GetTag("a")).Should(Equal(0.5))
+		Expect(heap.GetValue(1)).Should(BeNil())
+		Expect(heap.GetValue(2)).Should(BeNil())
+	})
+
+	It("Given a transaction returning an error or panicking, when it returns, it should roll back all mutations.", func() {
+		expected := NewFibHeap()
+		expected.UnionInto(heap)
+		failure := errors.New("store failure")
+
+		random := rand.New(rand.NewSource(1))
+		Expect(heap.Txn(func(tx *HeapTxn) error {
+			for i := 0; i < 1000; i++ {
+				tag := random.Intn(120)
+				switch random.Intn(6) {
+				case 0:
+					tx.Insert(tag, random.Float64()*100)
+				case 1:
+					tx.InsertValue(&demoStruct{tag, random.Float64() * 100, "new"})
+				case 2:
+					tx.ExtractMin()
+				case 3:
+					tx.DecreaseKey(tag, tx.GetTag(tag)-random.Float64())
+				case 4:
+					tx.IncreaseKeyValue(&demoStruct{tag, tx.GetTag(tag) + random.Float64(), "changed"})
+				case 5:
+					tx.ExtractTag(tag)
+				}
+			}
+			tx.ExtractTag("counted")
+			tx.ExtractTag("counted")
+			return failure
+		})).Should(Equal(failure))
+		Expect(heap.Equal(expected)).Should(BeTrue())
+		Expect(heap.GetValue(3).(*demoStruct).value).Should(BeEmpty())
+		Expect(heap.Count("counted")).Should(BeEquivalentTo(2))
+
+		Expect(func() {
+			heap.Txn(func(tx *HeapTxn) error {
+				tx.DeleteValue(&demoStruct{5, 5, ""})
+				tx.Insert("b", 1)
+				panic("boom")
+			})
+		}).Should(Panic())
+		Expect(heap.Equal(expected)).Should(BeTrue())
+		Expect(heap.GetValue("b")).Should(BeNil())
+	})
+})
@@ -0,0 +1,397 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+// Package fibHeap implements the Fibonacci Heap priority queue with generic tags and values.
+// It is the v2 of github.com/starwander/GoFibonacciHeap with a cleaned-up API:
+// the tag and value types are type parameters, the sentinel returns like nil/-inf are replaced by (..., ok) pairs,
+// and GetTag/ExtractTag are renamed to Lookup/Remove. The v1 package is kept intact for the existing users.
+package fibHeap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	// ErrDuplicate is returned by Insert if the tag already exists in the heap.
+	ErrDuplicate = errors.New("Duplicate tag is not allowed ")
+	// ErrNotFound is returned if the tag does not exist in the heap.
+	ErrNotFound = errors.New("Tag is not found ")
+	// ErrInvalidKey is returned if the key is NaN.
+	ErrInvalidKey = errors.New("Key is NaN ")
+	// ErrNotSmaller is returned by DecreaseKey if the new key is not smaller than the current key.
+	ErrNotSmaller = errors.New("New key is not smaller than current key ")
+	// ErrNotLarger is returned by IncreaseKey if the new key is not larger than the current key.
+	ErrNotLarger = errors.New("New key is not larger than current key ")
+)
+
+// FibHeap represents a Fibonacci Heap of tags of type T with keys and values of type V.
+// Every tag is unique in the heap, and any key but NaN is valid, including -inf and +inf.
+// The zero value is not usable, create a heap by NewFibHeap.
+// Please note that all methods of FibHeap are not concurrent safe.
+type FibHeap[T comparable, V any] struct {
+	min   *node[T, V]
+	index map[T]*node[T, V]
+}
+
+// node is a node of the heap. The children of a node and the roots are kept in circular doubly linked lists by left and right.
+type node[T comparable, V any] struct {
+	parent, child, left, right *node[T, V]
+	degree                     int
+	marked                     bool
+	tag                        T
+	key                        float64
+	value                      V
+}
+
+// NewFibHeap creates an initialized empty Fibonacci Heap.
+func NewFibHeap[T comparable, V any]() *FibHeap[T, V] {
+	heap := new(FibHeap[T, V])
+	heap.index = make(map[T]*node[T, V])
+
+	return heap
+}
+
+// Len returns the total number of tags in the heap.
+func (heap *FibHeap[T, V]) Len() int {
+	return len(heap.index)
+}
+
+// Contains reports whether the tag exists in the heap.
+func (heap *FibHeap[T, V]) Contains(tag T) bool {
+	_, exists := heap.index[tag]
+
+	return exists
+}
+
+// Insert pushes the input tag with its key and value into the heap.
+// Try to insert a duplicate tag will cause ErrDuplicate, and a NaN key will cause ErrInvalidKey.
+func (heap *FibHeap[T, V]) Insert(tag T, key float64, value V) error {
+	if math.IsNaN(key) {
+		return ErrInvalidKey
+	}
+	if _, exists := heap.index[tag]; exists {
+		return ErrDuplicate
+	}
+
+	n := &node[T, V]{tag: tag, key: key, value: value}
+	n.left, n.right = n, n
+	heap.index[tag] = n
+	heap.addRoot(n)
+
+	return nil
+}
+
+// Min returns the tag, key and value of the current minimum in the heap without extracting it.
+// ok is false if the heap is empty.
+func (heap *FibHeap[T, V]) Min() (tag T, key float64, value V, ok bool) {
+	if heap.min == nil {
+		return
+	}
+
+	return heap.min.tag, heap.min.key, heap.min.value, true
+}
+
+// ExtractMin returns the tag, key and value of the current minimum in the heap and then extracts it.
+// ok is false if the heap is empty.
+func (heap *FibHeap[T, V]) ExtractMin() (tag T, key float64, value V, ok bool) {
+	if heap.min == nil {
+		return
+	}
+
+	min := heap.extractMin()
+
+	return min.tag, min.key, min.value, true
+}
+
+// Lookup returns the key and value of the input tag without extracting it.
+// ok is false if the tag does not exist in the heap.
+func (heap *FibHeap[T, V]) Lookup(tag T) (key float64, value V, ok bool) {
+	n, exists := heap.index[tag]
+	if !exists {
+		return
+	}
+
+	return n.key, n.value, true
+}
+
+// Remove extracts the input tag from the heap and returns its key and value.
+// ok is false if the tag does not exist in the heap.
+func (heap *FibHeap[T, V]) Remove(tag T) (key float64, value V, ok bool) {
+	n, exists := heap.index[tag]
+	if !exists {
+		return
+	}
+
+	if n.parent != nil {
+		parent := n.parent
+		heap.cut(n)
+		heap.cascadingCut(parent)
+	}
+	heap.min = n
+	heap.extractMin()
+
+	return n.key, n.value, true
+}
+
+// DecreaseKey updates the key of the input tag by the input smaller key.
+// It returns ErrNotFound if the tag does not exist, ErrInvalidKey for a NaN key and ErrNotSmaller if the key is not smaller.
+func (heap *FibHeap[T, V]) DecreaseKey(tag T, key float64) error {
+	if math.IsNaN(key) {
+		return ErrInvalidKey
+	}
+	n, exists := heap.index[tag]
+	if !exists {
+		return ErrNotFound
+	}
+	if key >= n.key {
+		return ErrNotSmaller
+	}
+
+	n.key = key
+	if parent := n.parent; parent != nil && n.key < parent.key {
+		heap.cut(n)
+		heap.cascadingCut(parent)
+	}
+	if n.key < heap.min.key {
+		heap.min = n
+	}
+
+	return nil
+}
+
+// IncreaseKey updates the key of the input tag by the input larger key.
+// It returns ErrNotFound if the tag does not exist, ErrInvalidKey for a NaN key and ErrNotLarger if the key is not larger.
+func (heap *FibHeap[T, V]) IncreaseKey(tag T, key float64) error {
+	if math.IsNaN(key) {
+		return ErrInvalidKey
+	}
+	n, exists := heap.index[tag]
+	if !exists {
+		return ErrNotFound
+	}
+	if key <= n.key {
+		return ErrNotLarger
+	}
+
+	// Move the children of the node to the roots, since they may be smaller than the new key.
+	for n.child != nil {
+		heap.cut(n.child)
+	}
+	if parent := n.parent; parent != nil {
+		heap.cut(n)
+		heap.cascadingCut(parent)
+	}
+	n.key = key
+	if heap.min == n {
+		heap.resetMin()
+	}
+
+	return nil
+}
+
+// SetValue replaces the value of the input tag and keeps its key.
+// It returns ErrNotFound if the tag does not exist.
+func (heap *FibHeap[T, V]) SetValue(tag T, value V) error {
+	n, exists := heap.index[tag]
+	if !exists {
+		return ErrNotFound
+	}
+	n.value = value
+
+	return nil
+}
+
+// Union moves all tags of the input heap into the heap and empties the input heap in O(1) amortized plus the merge of the indexes.
+// If any tag exists in both heaps, ErrDuplicate is returned and both heaps are untouched.
+func (heap *FibHeap[T, V]) Union(anotherHeap *FibHeap[T, V]) error {
+	if heap == anotherHeap {
+		return nil
+	}
+	for tag := range anotherHeap.index {
+		if _, exists := heap.index[tag]; exists {
+			return ErrDuplicate
+		}
+	}
+
+	for tag, n := range anotherHeap.index {
+		heap.index[tag] = n
+	}
+	if anotherHeap.min != nil {
+		if heap.min == nil {
+			heap.min = anotherHeap.min
+		} else {
+			splice(heap.min, anotherHeap.min)
+			if anotherHeap.min.key < heap.min.key {
+				heap.min = anotherHeap.min
+			}
+		}
+	}
+	anotherHeap.min = nil
+	anotherHeap.index = make(map[T]*node[T, V])
+
+	return nil
+}
+
+// String provides some basic debug information of the heap.
+// It returns the total number and the current minimum of the heap.
+func (heap *FibHeap[T, V]) String() string {
+	var buffer bytes.Buffer
+
+	if heap.min != nil {
+		buffer.WriteString(fmt.Sprintf("Total number: %d,\n", len(heap.index)))
+		buffer.WriteString(fmt.Sprintf("Current minimun: key(%f), tag(%v), value(%v),\n", heap.min.key, heap.min.tag, heap.min.value))
+	} else {
+		buffer.WriteString("Heap is empty.\n")
+	}
+
+	return buffer.String()
+}
+
+// addRoot adds the single node to the roots and updates the minimum.
+func (heap *FibHeap[T, V]) addRoot(n *node[T, V]) {
+	n.parent = nil
+	n.marked = false
+	if heap.min == nil {
+		n.left, n.right = n, n
+		heap.min = n
+		return
+	}
+
+	n.left, n.right = heap.min, heap.min.right
+	heap.min.right.left = n
+	heap.min.right = n
+	if n.key < heap.min.key {
+		heap.min = n
+	}
+}
+
+func (heap *FibHeap[T, V]) extractMin() *node[T, V] {
+	min := heap.min
+	delete(heap.index, min.tag)
+
+	for min.child != nil {
+		child := min.child
+		heap.removeChild(min, child)
+		child.parent = nil
+		child.marked = false
+		splice(min, child)
+	}
+
+	if min.right == min {
+		heap.min = nil
+	} else {
+		min.left.right = min.right
+		min.right.left = min.left
+		heap.min = min.right
+		heap.consolidate()
+	}
+	min.left, min.right, min.parent = min, min, nil
+
+	return min
+}
+
+// consolidate links the roots of the same degree until all roots have different degrees, and finds the new minimum.
+func (heap *FibHeap[T, V]) consolidate() {
+	var roots []*node[T, V]
+	for n := heap.min; ; {
+		roots = append(roots, n)
+		n = n.right
+		if n == heap.min {
+			break
+		}
+	}
+
+	var degrees []*node[T, V]
+	for _, n := range roots {
+		n.left, n.right = n, n
+		for {
+			for len(degrees) <= n.degree {
+				degrees = append(degrees, nil)
+			}
+			other := degrees[n.degree]
+			if other == nil {
+				break
+			}
+			degrees[n.degree] = nil
+			if other.key < n.key {
+				n, other = other, n
+			}
+			heap.link(n, other)
+		}
+		degrees[n.degree] = n
+	}
+
+	heap.min = nil
+	for _, n := range degrees {
+		if n != nil {
+			heap.addRoot(n)
+		}
+	}
+}
+
+// link makes the child a child of the parent.
+func (heap *FibHeap[T, V]) link(parent, child *node[T, V]) {
+	child.parent = parent
+	child.marked = false
+	if parent.child == nil {
+		child.left, child.right = child, child
+		parent.child = child
+	} else {
+		child.left, child.right = parent.child, parent.child.right
+		parent.child.right.left = child
+		parent.child.right = child
+	}
+	parent.degree++
+}
+
+// removeChild unlinks the child from the children of the parent.
+func (heap *FibHeap[T, V]) removeChild(parent, child *node[T, V]) {
+	if child.right == child {
+		parent.child = nil
+	} else {
+		if parent.child == child {
+			parent.child = child.right
+		}
+		child.left.right = child.right
+		child.right.left = child.left
+	}
+	child.left, child.right = child, child
+	parent.degree--
+}
+
+// cut moves the node from its parent to the roots.
+func (heap *FibHeap[T, V]) cut(n *node[T, V]) {
+	heap.removeChild(n.parent, n)
+	heap.addRoot(n)
+}
+
+func (heap *FibHeap[T, V]) cascadingCut(n *node[T, V]) {
+	for n.parent != nil {
+		if !n.marked {
+			n.marked = true
+			return
+		}
+		parent := n.parent
+		heap.cut(n)
+		n = parent
+	}
+}
+
+// resetMin scans the roots for the minimum.
+func (heap *FibHeap[T, V]) resetMin() {
+	start := heap.min
+	for n := start.right; n != start; n = n.right {
+		if n.key < heap.min.key {
+			heap.min = n
+		}
+	}
+}
+
+// splice joins the circular lists of a and b.
+func splice[T comparable, V any](a, b *node[T, V]) {
+	aRight, bLeft := a.right, b.left
+	a.right, b.left = b, a
+	aRight.left, bLeft.right = bLeft, aRight
+}
@@ -0,0 +1,138 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+var _ = Describe("Tests of fibHeap v2", func() {
+	var (
+		heap *FibHeap[int, string]
+	)
+
+	BeforeEach(func() {
+		heap = NewFibHeap[int, string]()
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given an empty fibHeap, when call Min and ExtractMin api, it should return not ok.", func() {
+		_, _, _, ok := heap.Min()
+		Expect(ok).Should(BeFalse())
+		_, _, _, ok = heap.ExtractMin()
+		Expect(ok).Should(BeFalse())
+		_, _, ok = heap.Lookup(1)
+		Expect(ok).Should(BeFalse())
+		_, _, ok = heap.Remove(1)
+		Expect(ok).Should(BeFalse())
+		Expect(heap.DecreaseKey(1, 0)).Should(Equal(ErrNotFound))
+		Expect(heap.IncreaseKey(1, 0)).Should(Equal(ErrNotFound))
+		Expect(heap.SetValue(1, "")).Should(Equal(ErrNotFound))
+		Expect(heap.Len()).Should(BeZero())
+		Expect(heap.String()).Should(Equal("Heap is empty.\n"))
+	})
+
+	It("Given a fibHeap, when call the apis with invalid input, it should return the errors.", func() {
+		Expect(heap.Insert(1, math.NaN(), "")).Should(Equal(ErrInvalidKey))
+		Expect(heap.Insert(1, math.Inf(-1), "-inf")).ShouldNot(HaveOccurred())
+		Expect(heap.Insert(1, 1, "")).Should(Equal(ErrDuplicate))
+		Expect(heap.DecreaseKey(1, math.NaN())).Should(Equal(ErrInvalidKey))
+		Expect(heap.DecreaseKey(1, math.Inf(-1))).Should(Equal(ErrNotSmaller))
+		Expect(heap.IncreaseKey(1, math.Inf(-1))).Should(Equal(ErrNotLarger))
+
+		tag, key, value, ok := heap.Min()
+		Expect([]interface{}{tag, key, value, ok}).Should(Equal([]interface{}{1, math.Inf(-1), "-inf", true}))
+		Expect(heap.Contains(1)).Should(BeTrue())
+		Expect(heap.SetValue(1, "changed")).ShouldNot(HaveOccurred())
+		key, value, ok = heap.Remove(1)
+		Expect([]interface{}{key, value, ok}).Should(Equal([]interface{}{math.Inf(-1), "changed", true}))
+		Expect(heap.Contains(1)).Should(BeFalse())
+	})
+
+	It("Given a fibHeap under random operations, when extract all tags, it should return them in order.", func() {
+		random := rand.New(rand.NewSource(1))
+		keys := make(map[int]float64)
+		for i := 0; i < 20000; i++ {
+			tag := random.Intn(1000)
+			key := random.Float64() * 1000
+			current, exists := keys[tag]
+			switch random.Intn(5) {
+			case 0, 1:
+				if exists {
+					Expect(heap.Insert(tag, key, "")).Should(Equal(ErrDuplicate))
+				} else {
+					Expect(heap.Insert(tag, key, "")).ShouldNot(HaveOccurred())
+					keys[tag] = key
+				}
+			case 2:
+				if tag, key, _, ok := heap.ExtractMin(); ok {
+					for _, other := range keys {
+						Expect(key).Should(BeNumerically("<=", other))
+					}
+					delete(keys, tag)
+				}
+			case 3:
+				if exists && key < current {
+					Expect(heap.DecreaseKey(tag, key)).ShouldNot(HaveOccurred())
+					keys[tag] = key
+				} else if exists && key > current {
+					Expect(heap.IncreaseKey(tag, key)).ShouldNot(HaveOccurred())
+					keys[tag] = key
+				}
+			case 4:
+				removed, _, ok := heap.Remove(tag)
+				Expect(ok).Should(Equal(exists))
+				Expect(removed).Should(Equal(current))
+				delete(keys, tag)
+			}
+		}
+
+		var expected []float64
+		for tag, key := range keys {
+			found, _, ok := heap.Lookup(tag)
+			Expect(ok).Should(BeTrue())
+			Expect(found).Should(Equal(key))
+			expected = append(expected, key)
+		}
+		sort.Float64s(expected)
+		Expect(heap.Len()).Should(Equal(len(expected)))
+		for _, key := range expected {
+			_, min, _, _ := heap.ExtractMin()
+			Expect(min).Should(Equal(key))
+		}
+	})
+
+	It("Given two fibHeaps, when call Union api, it should move all tags of the input heap in.", func() {
+		anotherHeap := NewFibHeap[int, string]()
+		for i := 0; i < 100; i++ {
+			heap.Insert(i, float64(i), "")
+			anotherHeap.Insert(i+100, float64(i)-50, "another")
+		}
+		heap.ExtractMin()
+		anotherHeap.ExtractMin()
+
+		heap.Insert(200, 0, "")
+		anotherHeap.Insert(200, 0, "")
+		Expect(heap.Union(anotherHeap)).Should(Equal(ErrDuplicate))
+		anotherHeap.Remove(200)
+
+		Expect(heap.Union(anotherHeap)).ShouldNot(HaveOccurred())
+		Expect(anotherHeap.Len()).Should(BeZero())
+		Expect(heap.Len()).Should(Equal(199))
+		tag, key, value, _ := heap.ExtractMin()
+		Expect([]interface{}{tag, key, value}).Should(Equal([]interface{}{101, -49.0, "another"}))
+		last := key
+		for heap.Len() != 0 {
+			_, key, _, _ = heap.ExtractMin()
+			Expect(key).Should(BeNumerically(">=", last))
+			last = key
+		}
+	})
+})
@@ -0,0 +1,97 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+)
+
+// Validate checks the invariants of the heap in O(n) and returns an error describing the first violation found, or nil if there is none,
+// e.g. in the tests of the applications or after a suspected memory corruption.
+// The trees must be heap ordered with consistent links and degrees, the index and the number of values must match the live nodes,
+// and the minimum must be the live root of the smallest key.
+// If staleKeys is true, the key of every value is also compared with its key in the heap as StaleTags does,
+// so a value changed in place without a Refresh is reported with both keys.
+func (heap *FibHeap) Validate(staleKeys bool) error {
+	if debugMode {
+		defer heap.guard.enter("Validate")()
+	}
+
+	live, dead, err := heap.validateTrees(heap.roots, nil)
+	if err != nil {
+		return err
+	}
+	if live != heap.num || uint(len(heap.index)) != heap.num {
+		return fmt.Errorf("Number of values is %v but the trees have %v and the index has %v ", heap.num, live, len(heap.index))
+	}
+	if dead != heap.dead {
+		return fmt.Errorf("Number of dead nodes is %v but the trees have %v ", heap.dead, dead)
+	}
+
+	if heap.num == 0 {
+		if heap.min != nil {
+			return errors.New("Minimum is set in an empty heap ")
+		}
+	} else {
+		if heap.min == nil || heap.min.parent != nil || heap.min.dead {
+			return errors.New("Minimum is not a live root ")
+		}
+		for e := heap.roots.Front(); e != nil; e = e.Next() {
+			if n := e.Value.(*node); !n.dead && n.key < heap.min.key {
+				return fmt.Errorf("Root %v is smaller than the minimum %v ", n.tag, heap.min.tag)
+			}
+		}
+	}
+
+	for tag := range heap.counts {
+		if _, exists := heap.index[tag]; !exists {
+			return fmt.Errorf("Count of tag %v is left after it is removed ", tag)
+		}
+	}
+
+	if staleKeys {
+		for tag, n := range heap.index {
+			if heap.stale(n) {
+				return fmt.Errorf("Key of tag %v is %v in the heap but %v by its value ", tag, heap.keyOf(n), n.value.Key())
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateTrees checks the links, degrees and heap order of the trees under the parent, and returns the numbers of live and dead nodes in them.
+func (heap *FibHeap) validateTrees(trees *list.List, parent *node) (uint, uint, error) {
+	var live, dead uint
+	for e := trees.Front(); e != nil; e = e.Next() {
+		n := e.Value.(*node)
+		if n.self != e || n.parent != parent {
+			return 0, 0, fmt.Errorf("Node of tag %v is linked to a wrong position ", n.tag)
+		}
+		if parent != nil && n.key < parent.key {
+			return 0, 0, fmt.Errorf("Node of tag %v is smaller than its parent of tag %v ", n.tag, parent.tag)
+		}
+		if uint(n.children.Len()) != n.degree {
+			return 0, 0, fmt.Errorf("Node of tag %v has %v children but a degree of %v ", n.tag, n.children.Len(), n.degree)
+		}
+
+		if n.dead {
+			dead++
+		} else if heap.index[n.tag] != n {
+			return 0, 0, fmt.Errorf("Node of tag %v is not in the index ", n.tag)
+		} else {
+			live++
+		}
+
+		childrenLive, childrenDead, err := heap.validateTrees(n.children, n)
+		if err != nil {
+			return 0, 0, err
+		}
+		live += childrenLive
+		dead += childrenDead
+	}
+
+	return live, dead, nil
+}
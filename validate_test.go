@@ -0,0 +1,78 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math/rand"
+)
+
+var _ = Describe("Tests of validate", func() {
+	var heap *FibHeap
+
+	BeforeEach(func() {
+		heap = NewFibHeap()
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given a fibHeap under random operations in all modes, when call Validate api, it should find no violation.", func() {
+		Expect(heap.Validate(true)).Should(Succeed())
+		heap.SetLazyDelete(true)
+		heap.SetConsolidationBudget(4)
+		random := rand.New(rand.NewSource(1900))
+		for i := 0; i < 20000; i++ {
+			tag := random.Intn(500)
+			key := float64(random.Intn(1000))
+			switch random.Intn(6) {
+			case 0, 1:
+				heap.InsertValue(&demoStruct{tag, key, ""})
+			case 2:
+				heap.Delete(tag)
+			case 3:
+				heap.DecreaseKeyValue(&demoStruct{tag, key, ""})
+			case 4:
+				heap.IncreaseKeyValue(&demoStruct{tag, key, ""})
+			case 5:
+				heap.ExtractMin()
+			}
+			if i%1000 == 0 {
+				Expect(heap.Validate(true)).Should(Succeed())
+			}
+		}
+		Expect(heap.Validate(true)).Should(Succeed())
+	})
+
+	It("Given a fibHeap with a value changed in place, when call Validate api, it should report the drift only if asked to.", func() {
+		value := &demoStruct{1, 1, ""}
+		heap.InsertValue(value)
+		heap.Insert(2, 2)
+		value.key = 3
+
+		Expect(heap.Validate(false)).Should(Succeed())
+		Expect(heap.Validate(true)).Should(MatchError("Key of tag 1 is 1 in the heap but 3 by its value "))
+	})
+
+	It("Given a corrupted fibHeap, when call Validate api, it should report the violation.", func() {
+		for i := 0; i < 10; i++ {
+			heap.Insert(i, float64(i))
+		}
+		heap.ExtractMin()
+		Expect(heap.Validate(false)).Should(Succeed())
+
+		child := heap.min.children.Front().Value.(*node)
+		child.key = -1
+		Expect(heap.Validate(false)).Should(HaveOccurred())
+		child.key = 100
+		Expect(heap.Validate(false)).Should(Succeed())
+
+		heap.num++
+		Expect(heap.Validate(false)).Should(HaveOccurred())
+		heap.num--
+		heap.min = child
+		Expect(heap.Validate(false)).Should(HaveOccurred())
+	})
+})
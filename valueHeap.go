@@ -0,0 +1,90 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// ValueHeap is a Fibonacci Heap of values only, for the workloads which always use the value interfaces.
+// It has only one family of methods, e.g. Insert(value), instead of the parallel tag and value families of FibHeap,
+// so the tag and key of every value are always taken from the value itself.
+// See KeyHeap for the tag and key counterpart.
+// Please note that all methods of ValueHeap are not concurrent safe.
+type ValueHeap struct {
+	heap *FibHeap
+}
+
+// NewValueHeap creates an initialized empty ValueHeap.
+func NewValueHeap() *ValueHeap {
+	return &ValueHeap{heap: NewFibHeap()}
+}
+
+// Num returns the total number of values in the heap.
+func (heap *ValueHeap) Num() uint {
+	return heap.heap.Num()
+}
+
+// Insert pushes the input value into the heap.
+// Try to insert a nil value, a value with a duplicate tag or a -inf key will cause an error return.
+func (heap *ValueHeap) Insert(value Value) error {
+	return heap.heap.InsertValue(value)
+}
+
+// Minimum returns the current minimum value in the heap.
+// An empty heap will return nil.
+func (heap *ValueHeap) Minimum() Value {
+	return heap.heap.MinimumValue()
+}
+
+// ExtractMin returns the current minimum value in the heap and then extracts it.
+// An empty heap will return nil and extracts nothing.
+func (heap *ValueHeap) ExtractMin() Value {
+	return heap.heap.ExtractMinValue()
+}
+
+// DecreaseKey replaces the value of the same tag in the heap by the input value with a smaller key.
+// If the tag does not exist, or the key is not smaller, an error will be returned.
+func (heap *ValueHeap) DecreaseKey(value Value) error {
+	return heap.heap.DecreaseKeyValue(value)
+}
+
+// IncreaseKey replaces the value of the same tag in the heap by the input value with a larger key.
+// If the tag does not exist, or the key is not larger, an error will be returned.
+func (heap *ValueHeap) IncreaseKey(value Value) error {
+	return heap.heap.IncreaseKeyValue(value)
+}
+
+// Delete deletes the value of the same tag as the input value in the heap.
+// If the tag does not exist, an error will be returned.
+func (heap *ValueHeap) Delete(value Value) error {
+	return heap.heap.DeleteValue(value)
+}
+
+// Get returns the value of the input tag.
+// If the tag does not exist, nil will be returned.
+func (heap *ValueHeap) Get(tag interface{}) Value {
+	return heap.heap.GetValue(tag)
+}
+
+// Extract extracts the value of the input tag and returns it.
+// If the tag does not exist, nil will be returned.
+func (heap *ValueHeap) Extract(tag interface{}) Value {
+	return heap.heap.ExtractValue(tag)
+}
+
+// String provides some basic debug information of the heap.
+// It returns the total number and the current minimum value of the heap.
+func (heap *ValueHeap) String() string {
+	var buffer bytes.Buffer
+
+	if min := heap.heap.MinimumValue(); min != nil {
+		buffer.WriteString(fmt.Sprintf("Total number: %d,\n", heap.heap.Num()))
+		buffer.WriteString(fmt.Sprintf("Current minimun: value(%v),\n", min))
+	} else {
+		buffer.WriteString(fmt.Sprintf("Heap is empty.\n"))
+	}
+
+	return buffer.String()
+}
@@ -0,0 +1,54 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tests of valueHeap", func() {
+	var (
+		heap *ValueHeap
+	)
+
+	BeforeEach(func() {
+		heap = NewValueHeap()
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given an empty valueHeap, when call Minimum and ExtractMin api, it should return nil.", func() {
+		Expect(heap.Minimum()).Should(BeNil())
+		Expect(heap.ExtractMin()).Should(BeNil())
+		Expect(heap.Get(1)).Should(BeNil())
+		Expect(heap.Extract(1)).Should(BeNil())
+		Expect(heap.String()).Should(Equal("Heap is empty.\n"))
+	})
+
+	It("Given a valueHeap, when call the apis, it should keep the values in order.", func() {
+		var nilValue *demoStruct
+		Expect(heap.Insert(nilValue)).Should(Equal(ErrNilValue))
+		for i := 0; i < 10; i++ {
+			Expect(heap.Insert(&demoStruct{i, float64(i), "origin"})).ShouldNot(HaveOccurred())
+		}
+		Expect(heap.Insert(&demoStruct{1, 1, ""})).Should(HaveOccurred())
+		Expect(heap.DecreaseKey(&demoStruct{5, -1, "decreased"})).ShouldNot(HaveOccurred())
+		Expect(heap.IncreaseKey(&demoStruct{0, 100, "increased"})).ShouldNot(HaveOccurred())
+		Expect(heap.DecreaseKey(&demoStruct{20, 0, ""})).Should(HaveOccurred())
+		Expect(heap.Delete(&demoStruct{2, 2, ""})).ShouldNot(HaveOccurred())
+		Expect(heap.Num()).Should(BeEquivalentTo(9))
+		Expect(heap.String()).Should(ContainSubstring("Total number: 9"))
+
+		Expect(heap.Get(0).(*demoStruct).value).Should(Equal("increased"))
+		Expect(heap.Extract(3).(*demoStruct).value).Should(Equal("origin"))
+		Expect(heap.Minimum().(*demoStruct).value).Should(Equal("decreased"))
+		var tags []interface{}
+		for heap.Num() != 0 {
+			tags = append(tags, heap.ExtractMin().Tag())
+		}
+		Expect(tags).Should(Equal([]interface{}{5, 1, 4, 6, 7, 8, 9, 0}))
+	})
+})
@@ -0,0 +1,226 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+)
+
+// Operations of the write-ahead log records.
+const (
+	walPut byte = iota + 1
+	walRemove
+	walClear
+)
+
+// writeAheadLog appends a record of every change of a FibHeap to a writer.
+// The first error is kept in err and no record is written after it.
+type writeAheadLog struct {
+	w   io.Writer
+	err error
+}
+
+// SetWAL turns on the write-ahead log mode of the heap: every change of the heap, e.g. by Insert, ExtractMin, DecreaseKey or Delete,
+// appends a record to the input writer, so that the heap can be reconstructed by Replay after a crash.
+// The log starts with the records of all current values, so it can rebuild the heap alone and can be appended to a log which has been replayed.
+// A nil writer turns off the write-ahead log mode.
+// Every record is written by a single Write call with a CRC-32 checksum. Buffering and syncing are up to the input writer.
+// The tags and values must be encodable as Marshal requires, and the first failure of encoding or writing stops the log and is reported by WALErr.
+func (heap *FibHeap) SetWAL(w io.Writer) error {
+	if debugMode {
+		defer heap.guard.enter("SetWAL")()
+	}
+
+	if w == nil {
+		heap.wal = nil
+		return nil
+	}
+
+	heap.wal = &writeAheadLog{w: w}
+	heap.logClear()
+	for _, node := range heap.index {
+		heap.logPut(node)
+	}
+
+	return heap.wal.err
+}
+
+// WALErr returns the first error of encoding or writing the write-ahead log, or nil if the log is healthy or turned off.
+// Once an error happens, no more record is written and the log can no longer reconstruct the heap.
+func (heap *FibHeap) WALErr() error {
+	if debugMode {
+		defer heap.guard.enter("WALErr")()
+	}
+
+	if heap.wal == nil {
+		return nil
+	}
+
+	return heap.wal.err
+}
+
+// Replay applies all records of a write-ahead log read from the input reader to the heap, typically a new empty heap after a crash.
+// An incomplete record at the end of the log, which is left by a crash in the middle of a write, is ignored.
+// A record failing the checksum will cause ErrCorrupted, and the records before it are still applied.
+// The types of the values must be registered in advance.
+// If the write-ahead log mode of the heap is on, the replayed changes are logged as well.
+func (heap *FibHeap) Replay(r io.Reader) error {
+	if debugMode {
+		defer heap.guard.enter("Replay")()
+	}
+
+	for {
+		record, err := readFrame(r)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := heap.apply(record); err != nil {
+			return err
+		}
+	}
+}
+
+func (heap *FibHeap) apply(record []byte) error {
+	reader := &bodyReader{data: record}
+	switch reader.byte() {
+	case walPut:
+		entry := reader.entry()
+		if reader.err != nil {
+			return ErrCorrupted
+		}
+		value, err := decodeValue(entry.Type, entry.Value)
+		if err != nil {
+			return err
+		}
+		if node, exists := heap.index[entry.Tag]; exists {
+			heap.remove(node)
+		}
+		return heap.insert(entry.Tag, entry.Key, value)
+	case walRemove:
+		tag := reader.tag()
+		if reader.err != nil {
+			return ErrCorrupted
+		}
+		if node, exists := heap.index[tag]; exists {
+			heap.remove(node)
+		}
+		return nil
+	case walClear:
+		heap.reset()
+		return nil
+	}
+
+	return ErrCorrupted
+}
+
+// logPut records the current key and value of the node in the ordered index, the quantile sketch and the write-ahead log.
+func (heap *FibHeap) logPut(n *node) {
+	if heap.order != nil {
+		heap.order.put(n)
+	}
+	if heap.sketch != nil {
+		heap.sketch.put(n)
+	}
+	if heap.wal == nil || heap.wal.err != nil {
+		return
+	}
+
+	name, data, err := encodeValue(n.value)
+	if err != nil {
+		heap.wal.err = err
+		return
+	}
+
+	var record bytes.Buffer
+	record.WriteByte(walPut)
+	heap.wal.err = writeEntry(&record, Entry{n.tag, heap.keyOf(n), name, data})
+	heap.wal.write(record.Bytes())
+}
+
+// logRemove records the removal of the tag in the ordered index, the quantile sketch, the dependencies and the write-ahead log.
+func (heap *FibHeap) logRemove(tag interface{}) {
+	heap.unblock(tag)
+	if heap.order != nil {
+		heap.order.remove(tag)
+	}
+	if heap.sketch != nil {
+		heap.sketch.remove(tag)
+	}
+	if heap.wal == nil || heap.wal.err != nil {
+		return
+	}
+
+	var record bytes.Buffer
+	record.WriteByte(walRemove)
+	heap.wal.err = writeTag(&record, tag)
+	heap.wal.write(record.Bytes())
+}
+
+// logClear records the removal of all values in the ordered index, the quantile sketch and the write-ahead log.
+func (heap *FibHeap) logClear() {
+	if heap.order != nil {
+		heap.order.clear()
+	}
+	if heap.sketch != nil {
+		heap.sketch.clear()
+	}
+	if heap.wal == nil || heap.wal.err != nil {
+		return
+	}
+
+	heap.wal.write([]byte{walClear})
+}
+
+// write frames the input record with its length and checksum, and writes it unless an error has happened.
+func (wal *writeAheadLog) write(record []byte) {
+	if wal.err != nil {
+		return
+	}
+
+	wal.err = writeFrame(wal.w, record)
+}
+
+// writeFrame writes the input record framed with its length and checksum by a single Write call.
+func writeFrame(w io.Writer, record []byte) error {
+	var frame bytes.Buffer
+	writeUvarint(&frame, uint64(len(record)))
+	binary.Write(&frame, binary.BigEndian, crc32.ChecksumIEEE(record))
+	frame.Write(record)
+
+	_, err := w.Write(frame.Bytes())
+
+	return err
+}
+
+// readFrame reads a record framed by writeFrame.
+// It returns io.EOF at the end of the input or at an incomplete frame, and ErrCorrupted if the checksum fails.
+func readFrame(r io.Reader) ([]byte, error) {
+	length, err := binary.ReadUvarint(byteReader{r})
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	frame, err := ioutil.ReadAll(io.LimitReader(r, int64(4+length)))
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(frame)) != 4+length {
+		return nil, io.EOF
+	}
+	if crc32.ChecksumIEEE(frame[4:]) != binary.BigEndian.Uint32(frame) {
+		return nil, ErrCorrupted
+	}
+
+	return frame[4:], nil
+}
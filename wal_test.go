@@ -0,0 +1,172 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"bytes"
+	"errors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math/rand"
+)
+
+// failingWriter fails all writes after the first n ones.
+type failingWriter struct {
+	n int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.n == 0 {
+		return 0, errors.New("disk is full")
+	}
+	w.n--
+
+	return len(p), nil
+}
+
+var _ = Describe("Tests of wal", func() {
+	var (
+		heap *FibHeap
+		log  *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		heap = NewFibHeap()
+		log = new(bytes.Buffer)
+	})
+
+	AfterEach(func() {
+		heap = nil
+		log = nil
+	})
+
+	It("Given a fibHeap in the WAL mode under random operations, when Replay the log, it should reconstruct the same heap.", func() {
+		Expect(heap.SetWAL(log)).ShouldNot(HaveOccurred())
+		random := rand.New(rand.NewSource(1))
+		for i := 0; i < 5000; i++ {
+			tag := random.Intn(500)
+			switch random.Intn(7) {
+			case 0, 1:
+				heap.InsertValue(&payload{tag, random.Float64() * 100, "payload"})
+			case 2:
+				heap.Insert(tag, random.Float64()*100)
+			case 3:
+				heap.ExtractMin()
+			case 4:
+				heap.DecreaseKey(tag, heap.GetTag(tag)-random.Float64())
+			case 5:
+				heap.IncreaseKeyValue(&payload{tag, heap.GetTag(tag) + random.Float64(), "increased"})
+			case 6:
+				heap.Delete(tag)
+			}
+		}
+		heap.ExtractMax()
+		Expect(heap.WALErr()).ShouldNot(HaveOccurred())
+
+		replayed := NewFibHeap()
+		Expect(replayed.Replay(bytes.NewReader(log.Bytes()))).ShouldNot(HaveOccurred())
+		Expect(replayed.Equal(heap)).Should(BeTrue())
+		for tag, node := range heap.index {
+			if node.value == nil {
+				Expect(replayed.GetValue(tag)).Should(BeNil())
+			} else {
+				Expect(replayed.GetValue(tag)).Should(Equal(node.value))
+			}
+		}
+	})
+
+	It("Given a fibHeap in the WAL mode, when call AddToAllKeys api, it should log the shifted keys.", func() {
+		heap.SetWAL(log)
+		for i := 0; i < 10; i++ {
+			heap.Insert(i, float64(i))
+		}
+		heap.AddToAllKeys(100)
+		heap.DecreaseKey(5, 50)
+
+		replayed := NewFibHeap()
+		Expect(replayed.Replay(log)).ShouldNot(HaveOccurred())
+		Expect(replayed.Equal(heap)).Should(BeTrue())
+		Expect(replayed.GetTag(9)).Should(BeEquivalentTo(109))
+	})
+
+	It("Given a non-empty fibHeap, when call SetWAL api, it should log the current values first.", func() {
+		heap.Insert(1, 1)
+		heap.InsertValue(&payload{2, 2, "2"})
+
+		Expect(heap.SetWAL(log)).ShouldNot(HaveOccurred())
+		heap.Insert(3, 3)
+		replayed := NewFibHeap()
+		Expect(replayed.Replay(log)).ShouldNot(HaveOccurred())
+		Expect(replayed.Equal(heap)).Should(BeTrue())
+		Expect(replayed.GetValue(2)).Should(Equal(&payload{2, 2, "2"}))
+
+		Expect(heap.SetWAL(nil)).ShouldNot(HaveOccurred())
+		heap.Insert(4, 4)
+		Expect(log.Len()).Should(Equal(0))
+	})
+
+	It("Given a replayed fibHeap, when append to the same log and Replay it again, it should reconstruct the latest heap.", func() {
+		heap.SetWAL(log)
+		for i := 0; i < 10; i++ {
+			heap.Insert(i, float64(i))
+		}
+
+		recovered := NewFibHeap()
+		Expect(recovered.Replay(bytes.NewReader(log.Bytes()))).ShouldNot(HaveOccurred())
+		Expect(recovered.SetWAL(log)).ShouldNot(HaveOccurred())
+		recovered.ExtractMin()
+		recovered.Insert(10, 10)
+
+		replayed := NewFibHeap()
+		Expect(replayed.Replay(log)).ShouldNot(HaveOccurred())
+		Expect(replayed.Equal(recovered)).Should(BeTrue())
+		Expect(replayed.Num()).Should(BeEquivalentTo(10))
+	})
+
+	It("Given a fibHeap emptied by Union in the WAL mode, when Replay the log, it should reconstruct an empty heap.", func() {
+		heap.SetWAL(log)
+		heap.Insert(1, 1)
+		another := NewFibHeap()
+		Expect(another.Union(heap)).ShouldNot(HaveOccurred())
+
+		replayed := NewFibHeap()
+		Expect(replayed.Replay(log)).ShouldNot(HaveOccurred())
+		Expect(replayed.Num()).Should(BeEquivalentTo(0))
+	})
+
+	It("Given a log with a torn or corrupted record, when call Replay api, it should ignore the torn tail and report the corruption.", func() {
+		heap.SetWAL(log)
+		heap.Insert(1, 1)
+		heap.Insert(2, 2)
+		data := log.Bytes()
+
+		replayed := NewFibHeap()
+		Expect(replayed.Replay(bytes.NewReader(data[:len(data)-1]))).ShouldNot(HaveOccurred())
+		Expect(replayed.Num()).Should(BeEquivalentTo(1))
+
+		corrupted := append([]byte(nil), data...)
+		corrupted[len(corrupted)-1] ^= 0xff
+		replayed = NewFibHeap()
+		Expect(replayed.Replay(bytes.NewReader(corrupted))).Should(Equal(ErrCorrupted))
+		Expect(replayed.Num()).Should(BeEquivalentTo(1))
+	})
+
+	It("Given a fibHeap in the WAL mode, when a tag cannot be encoded or the writer fails, it should stop the log and report the error.", func() {
+		heap.SetWAL(log)
+		heap.Insert(1, 1)
+		heap.Insert([2]int{1, 2}, 2)
+		Expect(heap.WALErr()).Should(HaveOccurred())
+		size := log.Len()
+		heap.Insert(3, 3)
+		Expect(log.Len()).Should(Equal(size))
+		Expect(heap.Num()).Should(BeEquivalentTo(3))
+
+		heap = NewFibHeap()
+		Expect(heap.WALErr()).ShouldNot(HaveOccurred())
+		Expect(heap.SetWAL(&failingWriter{2})).ShouldNot(HaveOccurred())
+		heap.Insert(1, 1)
+		Expect(heap.WALErr()).ShouldNot(HaveOccurred())
+		heap.ExtractMin()
+		Expect(heap.WALErr()).Should(MatchError("disk is full"))
+	})
+})
@@ -0,0 +1,59 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+// EventKind is the kind of mutation of a tag reported by an Event.
+type EventKind int
+
+const (
+	// EventInsert is the insertion of a tag, see Hooks.OnInsert.
+	EventInsert EventKind = iota
+	// EventExtract is the extraction of a tag, see Hooks.OnExtract.
+	EventExtract
+	// EventKeyChange is the change of the key of a tag, see Hooks.OnKeyChange.
+	EventKeyChange
+	// EventDelete is the deletion of a tag, see Hooks.OnDelete.
+	EventDelete
+)
+
+// Event is a mutation of a tag delivered by Watch, with the key and value involved as they are passed to the matching hook.
+type Event struct {
+	Kind  EventKind
+	Tag   interface{}
+	Key   float64
+	Value Value
+}
+
+// Watch returns a channel which delivers a single Event when the input tag is extracted or deleted, or its key is changed,
+// and is closed right after, so the waiters on a particular tag do not have to poll GetValue.
+// The channel is buffered so the heap never blocks on it, and the waiters do not need to receive the event at all.
+// A tag can be watched any number of times, and every channel receives the event.
+// If the tag does not exist in the heap, the returned channel is already closed without any event.
+func (heap *FibHeap) Watch(tag interface{}) <-chan Event {
+	if debugMode {
+		defer heap.guard.enter("Watch")()
+	}
+
+	tag = tagKey(tag)
+	events := make(chan Event, 1)
+	if _, exists := heap.index[tag]; !exists {
+		close(events)
+		return events
+	}
+
+	if heap.watchers == nil {
+		heap.watchers = make(map[interface{}][]chan Event)
+	}
+	heap.watchers[tag] = append(heap.watchers[tag], events)
+
+	return events
+}
+
+// notify delivers the event to all channels watching its tag and closes them.
+func (heap *FibHeap) notify(event Event) {
+	for _, events := range heap.watchers[event.Tag] {
+		events <- event
+		close(events)
+	}
+	delete(heap.watchers, event.Tag)
+}
@@ -0,0 +1,60 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tests of watch", func() {
+	var heap *FibHeap
+
+	BeforeEach(func() {
+		heap = NewFibHeap()
+		for i := 0; i < 10; i++ {
+			heap.Insert(i, float64(i))
+		}
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given a fibHeap, when watch tags which leave the heap or change their keys, it should deliver a single event for each of them.", func() {
+		extracted := heap.Watch(0)
+		deleted := heap.Watch(5)
+		changed := heap.Watch(7)
+		another := heap.Watch(7)
+		untouched := heap.Watch(9)
+
+		heap.ExtractMin()
+		heap.Delete(5)
+		heap.IncreaseKeyValue(&demoStruct{7, 70, "70"})
+
+		Expect(<-extracted).Should(Equal(Event{EventExtract, 0, 0, nil}))
+		Expect(<-deleted).Should(Equal(Event{EventDelete, 5, 5, nil}))
+		Expect(<-changed).Should(Equal(Event{EventKeyChange, 7, 70, &demoStruct{7, 70, "70"}}))
+		Expect(<-another).Should(Equal(Event{EventKeyChange, 7, 70, &demoStruct{7, 70, "70"}}))
+		for _, events := range []<-chan Event{extracted, deleted, changed, another} {
+			_, ok := <-events
+			Expect(ok).Should(BeFalse())
+		}
+		Expect(untouched).Should(BeEmpty())
+
+		heap.AddToAllKeys(1)
+		Expect(<-untouched).Should(Equal(Event{EventKeyChange, 9, 10, nil}))
+		Expect(heap.watchers).Should(BeEmpty())
+	})
+
+	It("Given a fibHeap, when watch a missing tag or empty the heap, it should close the channels.", func() {
+		_, ok := <-heap.Watch(10)
+		Expect(ok).Should(BeFalse())
+
+		events := heap.Watch(3)
+		NewFibHeap().Union(heap)
+		Expect(<-events).Should(Equal(Event{EventDelete, 3, 3, nil}))
+		_, ok = <-events
+		Expect(ok).Should(BeFalse())
+	})
+})
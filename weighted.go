@@ -0,0 +1,122 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"container/list"
+	"errors"
+	"math"
+	"math/rand"
+)
+
+// SetWeight sets the weight of the input tag for ExtractWeightedRandom. The weight of every tag is 1 until it is set.
+// The weight is kept along with the key until the tag is extracted or deleted, and is not changed by the key updates.
+// The weights are not kept by Union, Export, Marshal or the write-ahead log.
+// If the input tag is not existed in the heap, or the weight is negative, inf or NaN, an error will be returned.
+func (heap *FibHeap) SetWeight(tag interface{}, weight float64) error {
+	if debugMode {
+		defer heap.guard.enter("SetWeight")()
+	}
+
+	tag = tagKey(tag)
+	if tag == nil {
+		return errors.New("Input tag is nil ")
+	}
+
+	if weight < 0 || math.IsInf(weight, 1) || math.IsNaN(weight) {
+		return errors.New("Weight must be finite and non-negative ")
+	}
+
+	if _, exists := heap.index[tag]; !exists {
+		return errors.New("Value is not found ")
+	}
+
+	if weight == 1 {
+		delete(heap.weights, tag)
+		return nil
+	}
+	if heap.weights == nil {
+		heap.weights = make(map[interface{}]float64)
+	}
+	heap.weights[tag] = weight
+
+	return nil
+}
+
+// Weight returns the weight of the input tag for ExtractWeightedRandom.
+// If the input tag does not exist in the heap, 0 will be returned.
+func (heap *FibHeap) Weight(tag interface{}) float64 {
+	if debugMode {
+		defer heap.guard.enter("Weight")()
+	}
+
+	tag = tagKey(tag)
+	if _, exists := heap.index[tag]; !exists {
+		return 0
+	}
+
+	return heap.weightOf(tag)
+}
+
+// ExtractWeightedRandom picks a value at random with a probability proportional to its weight set by SetWeight, and then extracts it from the heap,
+// e.g. for the load balancers which prefer the better entries without all of them herding to the strict minimum.
+// The key does not affect the probability. The values are visited in the order of the trees, so the same seed of rng picks the same values
+// for the same sequence of operations. The pick scans all values in O(n).
+// The values of the tags inserted by Insert are returned as nil.
+// An empty heap or a heap of zero total weight will return nil and extracts nothing.
+func (heap *FibHeap) ExtractWeightedRandom(rng *rand.Rand) Value {
+	if debugMode {
+		defer heap.guard.enter("ExtractWeightedRandom")()
+	}
+
+	total := 0.0
+	heap.walk(heap.roots, func(n *node) {
+		total += heap.weightOf(n.tag)
+	})
+	if total == 0 {
+		return nil
+	}
+
+	var picked *node
+	target := rng.Float64() * total
+	heap.walk(heap.roots, func(n *node) {
+		if weight := heap.weightOf(n.tag); picked == nil && weight > 0 {
+			if target < weight {
+				picked = n
+			}
+			target -= weight
+		}
+	})
+	if picked == nil {
+		// The rounding of the sum can leave the target just above the last weight.
+		heap.walk(heap.roots, func(n *node) {
+			if heap.weightOf(n.tag) > 0 {
+				picked = n
+			}
+		})
+	}
+
+	if !heap.decrement(picked) {
+		heap.extractNode(picked)
+	}
+
+	return picked.value
+}
+
+func (heap *FibHeap) weightOf(tag interface{}) float64 {
+	if weight, exists := heap.weights[tag]; exists {
+		return weight
+	}
+
+	return 1
+}
+
+// walk calls fn for every live node of the trees in the depth first order.
+func (heap *FibHeap) walk(trees *list.List, fn func(n *node)) {
+	for e := trees.Front(); e != nil; e = e.Next() {
+		if !e.Value.(*node).dead {
+			fn(e.Value.(*node))
+		}
+		heap.walk(e.Value.(*node).children, fn)
+	}
+}
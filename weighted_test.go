@@ -0,0 +1,83 @@
+// Copyright(c) 2016 Ethan Zhuang <zhuangwj@gmail.com>.
+
+package fibHeap
+
+import (
+	"errors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"math"
+	"math/rand"
+)
+
+var _ = Describe("Tests of weighted random extraction", func() {
+	var heap *FibHeap
+
+	BeforeEach(func() {
+		heap = NewFibHeap()
+	})
+
+	AfterEach(func() {
+		heap = nil
+	})
+
+	It("Given a fibHeap, when call SetWeight api with invalid inputs, it should return errors.", func() {
+		heap.Insert(1, 1)
+
+		Expect(heap.Weight(1)).Should(BeEquivalentTo(1))
+		Expect(heap.Weight(2)).Should(BeEquivalentTo(0))
+		Expect(heap.SetWeight(nil, 1)).Should(HaveOccurred())
+		Expect(heap.SetWeight(2, 1)).Should(HaveOccurred())
+		Expect(heap.SetWeight(1, -1)).Should(HaveOccurred())
+		Expect(heap.SetWeight(1, math.Inf(1))).Should(HaveOccurred())
+		Expect(heap.SetWeight(1, math.NaN())).Should(HaveOccurred())
+		Expect(heap.SetWeight(1, 2.5)).ShouldNot(HaveOccurred())
+		Expect(heap.Weight(1)).Should(BeEquivalentTo(2.5))
+
+		Expect(heap.SetWeight(1, 0)).ShouldNot(HaveOccurred())
+		Expect(heap.ExtractWeightedRandom(rand.New(rand.NewSource(1)))).Should(BeNil())
+		Expect(heap.Num()).Should(BeEquivalentTo(1))
+
+		heap.Delete(1)
+		heap.Insert(1, 1)
+		Expect(heap.Weight(1)).Should(BeEquivalentTo(1))
+		Expect(NewFibHeap().ExtractWeightedRandom(rand.New(rand.NewSource(1)))).Should(BeNil())
+	})
+
+	It("Given a fibHeap with weighted values, when call ExtractWeightedRandom api, it should pick the values in proportion to the weights.", func() {
+		random := rand.New(rand.NewSource(1889))
+		picks := make(map[int]int)
+		for i := 0; i < 4000; i++ {
+			heap = NewFibHeap()
+			for tag := 0; tag < 4; tag++ {
+				heap.InsertValue(&demoStruct{tag, float64(tag), ""})
+			}
+			heap.SetWeight(0, 0)
+			heap.SetWeight(3, 2)
+
+			picked := heap.ExtractWeightedRandom(random)
+			picks[picked.Tag().(int)]++
+			Expect(heap.Num()).Should(BeEquivalentTo(3))
+			Expect(heap.GetValue(picked.Tag())).Should(BeNil())
+		}
+
+		Expect(picks[0]).Should(Equal(0))
+		Expect(picks[1]).Should(BeNumerically("~", 1000, 150))
+		Expect(picks[2]).Should(BeNumerically("~", 1000, 150))
+		Expect(picks[3]).Should(BeNumerically("~", 2000, 150))
+	})
+
+	It("Given a fibHeap with weighted values, when a transaction deleting them is rolled back, it should restore the weights.", func() {
+		heap.Insert(1, 1)
+		heap.Insert(2, 2)
+		heap.SetWeight(1, 5)
+
+		Expect(heap.Txn(func(tx *HeapTxn) error {
+			tx.Delete(1)
+			tx.Delete(2)
+			return errors.New("abort")
+		})).Should(HaveOccurred())
+		Expect(heap.Weight(1)).Should(BeEquivalentTo(5))
+		Expect(heap.Weight(2)).Should(BeEquivalentTo(1))
+	})
+})